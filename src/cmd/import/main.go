@@ -0,0 +1,88 @@
+// Command import CSVまたはJSONのファイルから投稿を一括登録する。
+//
+//	go run ./cmd/import -user 1 -file phrases.csv [-format csv] [-dry-run] [-atomic] [-allow-duplicate]
+//
+// データベースの接続先はAPIサーバーと同じ環境変数で指定する。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/infrastructure/persistence/datastore"
+	"github.com/k-kazuya0926/power-phrase2-api/ui/importer"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/k-kazuya0926/power-phrase2-api/validator"
+)
+
+// pollInterval 進捗を表示する間隔
+const pollInterval = 500 * time.Millisecond
+
+func main() {
+	userID := flag.Int("user", 0, "投稿者のユーザーID")
+	fileName := flag.String("file", "", "読み込むファイル")
+	format := flag.String("format", "", "ファイル形式(csv、json)。省略した場合は拡張子から判定する")
+	dryRun := flag.Bool("dry-run", false, "登録せずにチェック結果のみ表示する")
+	atomic := flag.Bool("atomic", false, "1行でもエラーがあれば何も登録しない")
+	allowDuplicate := flag.Bool("allow-duplicate", false, "類似した投稿があっても登録する")
+	flag.Parse()
+
+	if *userID < 1 || *fileName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = importer.FormatFromFileName(*fileName)
+	}
+
+	file, err := os.Open(*fileName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	rows, err := importer.Parse(*format, file, validator.NewValidator())
+	file.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	importUseCase := usecase.NewImportUseCase(datastore.NewPostRepository(), datastore.NewImportJobRepository(), datastore.NewProhibitedWordRepository(), datastore.NewReportRepository(), usecase.NewAutocompleteIndexCache(), usecase.RunInBackground)
+	job, err := importUseCase.ImportPosts(*userID, rows, *dryRun, *atomic, *allowDuplicate)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for !*dryRun && job.FinishedAt == nil {
+		time.Sleep(pollInterval)
+		if job, err = importUseCase.GetImportJob(job.ID, *userID); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "%d/%d\n", job.ProcessedCount, job.TotalCount)
+	}
+
+	printResults(job)
+	if job.Status == model.ImportJobStatusFailed || job.FailedCount > 0 {
+		os.Exit(1)
+	}
+}
+
+// printResults 行ごとの結果と件数を表示する。
+func printResults(job *model.ImportJob) {
+	for _, result := range job.Results {
+		for _, message := range result.Errors {
+			fmt.Printf("%d行目：エラー：%s\n", result.Row, message)
+		}
+		for _, message := range result.Warnings {
+			fmt.Printf("%d行目：注意：%s\n", result.Row, message)
+		}
+	}
+	if job.Error != "" {
+		fmt.Println(job.Error)
+	}
+	fmt.Printf("成功：%d件、失敗：%d件\n", job.SucceededCount, job.FailedCount)
+}
@@ -0,0 +1,37 @@
+// Command purge 保持期間を過ぎた削除済みの投稿、コメント、ユーザーを完全に削除する。
+// ダウンロード期限を過ぎた個人データのエクスポートも削除する。
+//
+//	go run ./cmd/purge
+//
+// cronなどで1日1回実行することを想定している。
+// 保持期間は環境変数TRASH_RETENTION_DAYSで、データベースの接続先とエクスポートの保存先はAPIサーバーと同じ環境変数で指定する。
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/k-kazuya0926/power-phrase2-api/infrastructure/persistence/datastore"
+	"github.com/k-kazuya0926/power-phrase2-api/infrastructure/storage"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+)
+
+func main() {
+	trashUseCase := usecase.NewTrashUseCase(datastore.NewTrashRepository(), datastore.NewUserRepository(), usecase.NewAutocompleteIndexCache())
+	result, err := trashUseCase.PurgeExpired()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("投稿：%d件、コメント：%d件、ユーザー：%d件を完全に削除しました。\n", result.Posts, result.Comments, result.Users)
+
+	exportUseCase := usecase.NewExportUseCase(datastore.NewPostRepository(), datastore.NewUserRepository(), datastore.NewExportJobRepository(), storage.NewFileArchiveStorage(os.Getenv("EXPORT_ARCHIVE_DIR")), usecase.RunInBackground)
+	exports, err := exportUseCase.PurgeExpiredExports()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("エクスポート：%d件を削除しました。\n", exports)
+}
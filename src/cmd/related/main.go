@@ -0,0 +1,26 @@
+// Command related 全投稿の関連する投稿を計算し、置き換える。
+//
+//	go run ./cmd/related
+//
+// cronなどで1日1回実行することを想定している。
+// データベースの接続先はAPIサーバーと同じ環境変数で指定する。
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/k-kazuya0926/power-phrase2-api/infrastructure/persistence/datastore"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+)
+
+func main() {
+	relatedPostUseCase := usecase.NewRelatedPostUseCase(datastore.NewPostRepository(), datastore.NewRelatedPostRepository())
+	count, err := relatedPostUseCase.ComputeRelatedPosts()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("関連する投稿：%d件を登録しました。\n", count)
+}
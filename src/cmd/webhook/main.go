@@ -0,0 +1,27 @@
+// Command webhook 送信に失敗したWebhookの配信のうち、再送する日時を過ぎたものを再送する。
+//
+//	go run ./cmd/webhook
+//
+// cronなどで1分ごとに実行することを想定している。
+// 送信前に配信を確保するため、前回の実行やAPIサーバーの送信と重なっても同じ配信を同時に送信しない。
+// データベースの接続先はAPIサーバーと同じ環境変数で指定する。
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/k-kazuya0926/power-phrase2-api/infrastructure/persistence/datastore"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+)
+
+func main() {
+	webhookUseCase := usecase.NewWebhookUseCase(datastore.NewWebhookRepository(), usecase.RunInBackground)
+	count, err := webhookUseCase.RetryWebhookDeliveries()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Webhookの配信：%d件を再送しました。\n", count)
+}
@@ -3,15 +3,26 @@ package conf
 import (
 	"fmt"
 	"os"
+	"sync"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jinzhu/gorm"
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
 )
 
-// NewDBConnection 新規データベースコネクションを取得する。
-func NewDBConnection() *gorm.DB {
-	return getMysqlConnection()
+var (
+	// sharedDB 全リポジトリで共有するデータベースコネクション(プール)
+	sharedDB     *gorm.DB
+	sharedDBOnce sync.Once
+)
+
+// DBConnection データベースコネクションを取得する。
+// コネクションプールを全リポジトリで共有するため、呼び出し元でCloseしない。
+func DBConnection() *gorm.DB {
+	sharedDBOnce.Do(func() {
+		sharedDB = getMysqlConnection()
+	})
+	return sharedDB
 }
 
 // getMysqlConnection MySQLへのコネクションを取得する。
@@ -38,13 +49,29 @@ func getMysqlConnection() *gorm.DB {
 	db.DB().SetMaxIdleConns(10)
 	db.DB().SetMaxOpenConns(20)
 
-	db.Set("gorm:table_options", "ENGINE=InnoDB")
+	return db
+}
+
+// Migrate テーブルを作成、更新する。APIサーバーの起動時に1回だけ実行する。
+func Migrate(db *gorm.DB) {
+	db = db.Set("gorm:table_options", "ENGINE=InnoDB")
 
-	// マイグレーション
 	db.AutoMigrate(&model.User{})
 	db.AutoMigrate(&model.Post{}).
 		AddForeignKey("user_id", "users(id)", "RESTRICT", "RESTRICT").
-		AddIndex("idx_posts_user_id", "user_id")
+		AddIndex("idx_posts_user_id", "user_id").
+		AddIndex("idx_posts_verification_status", "verification_status").
+		AddIndex("idx_posts_normalized_speaker", "normalized_speaker").
+		AddIndex("idx_posts_language", "language")
+	db.AutoMigrate(&model.PostTag{}).
+		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT").
+		AddUniqueIndex("idx_post_tags_post_id_tag", "post_id", "tag").
+		AddIndex("idx_post_tags_tag", "tag")
+	db.AutoMigrate(&model.SeenPost{}).
+		AddForeignKey("user_id", "users(id)", "RESTRICT", "RESTRICT").
+		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT").
+		AddUniqueIndex("idx_seen_posts_user_id_post_id", "user_id", "post_id").
+		AddIndex("idx_seen_posts_post_id", "post_id")
 	db.AutoMigrate(&model.Comment{}).
 		AddForeignKey("user_id", "users(id)", "RESTRICT", "RESTRICT").
 		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT").
@@ -53,7 +80,85 @@ func getMysqlConnection() *gorm.DB {
 		AddForeignKey("user_id", "users(id)", "RESTRICT", "RESTRICT").
 		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT").
 		AddUniqueIndex("idx_favorites_user_id_post_id", "user_id", "post_id").
-		AddIndex("idx_favorites_post_id", "post_id")
-
-	return db
+		AddIndex("idx_favorites_post_id", "post_id").
+		AddIndex("idx_favorites_user_id_tag", "user_id", "tag")
+	db.AutoMigrate(&model.DailyPost{}).
+		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT")
+	db.AutoMigrate(&model.AttributionClaim{}).
+		AddForeignKey("user_id", "users(id)", "RESTRICT", "RESTRICT").
+		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT").
+		AddIndex("idx_attribution_claims_post_id", "post_id")
+	db.AutoMigrate(&model.PostTranslation{}).
+		AddForeignKey("translator_id", "users(id)", "RESTRICT", "RESTRICT").
+		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT").
+		AddUniqueIndex("idx_post_translations_post_id_language", "post_id", "language").
+		AddIndex("idx_post_translations_language", "language")
+	db.AutoMigrate(&model.Collection{}).
+		AddForeignKey("user_id", "users(id)", "RESTRICT", "RESTRICT").
+		AddIndex("idx_collections_user_id", "user_id")
+	db.AutoMigrate(&model.CollectionItem{}).
+		AddForeignKey("collection_id", "collections(id)", "RESTRICT", "RESTRICT").
+		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT").
+		AddUniqueIndex("idx_collection_items_collection_id_post_id", "collection_id", "post_id").
+		AddIndex("idx_collection_items_post_id", "post_id")
+	// システムによる通報はreporter_idが0のため外部キーは設定しない
+	db.AutoMigrate(&model.Report{}).
+		AddUniqueIndex("idx_reports_reporter_id_target", "reporter_id", "target_type", "target_id").
+		AddIndex("idx_reports_status_target", "status", "target_type", "target_id")
+	db.AutoMigrate(&model.ModerationAction{}).
+		AddIndex("idx_moderation_actions_target", "target_type", "target_id")
+	db.AutoMigrate(&model.ProhibitedWord{}).
+		AddUniqueIndex("idx_prohibited_words_normalized_word", "normalized_word")
+	// 閲覧の記録は重複を除外する期間を過ぎると削除するため、外部キーは設定しない
+	db.AutoMigrate(&model.PostView{}).
+		AddUniqueIndex("idx_post_views_post_id_viewer_window_start", "post_id", "viewer", "window_start").
+		AddIndex("idx_post_views_window_start", "window_start")
+	db.AutoMigrate(&model.PostDailyView{}).
+		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT").
+		AddUniqueIndex("idx_post_daily_views_post_id_date", "post_id", "date")
+	db.AutoMigrate(&model.Reaction{}).
+		AddForeignKey("user_id", "users(id)", "RESTRICT", "RESTRICT").
+		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT").
+		AddUniqueIndex("idx_reactions_user_id_post_id_type", "user_id", "post_id", "type").
+		AddIndex("idx_reactions_post_id_type", "post_id", "type")
+	db.AutoMigrate(&model.RelatedPost{}).
+		AddForeignKey("post_id", "posts(id)", "RESTRICT", "RESTRICT").
+		AddForeignKey("related_post_id", "posts(id)", "RESTRICT", "RESTRICT").
+		AddIndex("idx_related_posts_post_id_score", "post_id", "score").
+		AddIndex("idx_related_posts_related_post_id", "related_post_id")
+	db.AutoMigrate(&model.Follow{}).
+		AddForeignKey("follower_id", "users(id)", "RESTRICT", "RESTRICT").
+		AddForeignKey("followee_id", "users(id)", "RESTRICT", "RESTRICT").
+		AddUniqueIndex("idx_follows_follower_id_followee_id", "follower_id", "followee_id").
+		AddIndex("idx_follows_followee_id", "followee_id")
+	// フォローの通知はpost_idが0のため、post_idには外部キーを設定しない
+	db.AutoMigrate(&model.Notification{}).
+		AddForeignKey("user_id", "users(id)", "RESTRICT", "RESTRICT").
+		AddIndex("idx_notifications_user_id_read_at", "user_id", "read_at").
+		AddIndex("idx_notifications_user_id_type_post_id", "user_id", "type", "post_id")
+	db.AutoMigrate(&model.NotificationActor{}).
+		AddForeignKey("notification_id", "notifications(id)", "RESTRICT", "RESTRICT").
+		AddUniqueIndex("idx_notification_actors_notification_id_actor_id", "notification_id", "actor_id").
+		AddIndex("idx_notification_actors_actor_id", "actor_id")
+	db.AutoMigrate(&model.NotificationSetting{}).
+		AddForeignKey("user_id", "users(id)", "RESTRICT", "RESTRICT").
+		AddUniqueIndex("idx_notification_settings_user_id_type", "user_id", "type")
+	db.AutoMigrate(&model.UsedStreamTicket{}).
+		AddUniqueIndex("idx_used_stream_tickets_ticket_id", "ticket_id").
+		AddIndex("idx_used_stream_tickets_expires_at", "expires_at")
+	db.AutoMigrate(&model.Webhook{})
+	db.AutoMigrate(&model.WebhookDelivery{}).
+		AddForeignKey("webhook_id", "webhooks(id)", "RESTRICT", "RESTRICT").
+		AddIndex("idx_webhook_deliveries_webhook_id", "webhook_id").
+		AddIndex("idx_webhook_deliveries_status_next_attempt_at", "status", "next_attempt_at")
+	// ジョブは保持期間が過ぎた後に削除するため、ユーザーの完全削除を妨げないよう外部キーは設定しない
+	db.AutoMigrate(&model.ImportJob{}).
+		AddIndex("idx_import_jobs_created_at", "created_at")
+	// ジョブはダウンロード期限が過ぎた後にファイルとともに削除するため、ユーザーの完全削除を妨げないよう外部キーは設定しない
+	db.AutoMigrate(&model.ExportJob{}).
+		AddUniqueIndex("idx_export_jobs_token", "token").
+		AddIndex("idx_export_jobs_user_id_created_at", "user_id", "created_at").
+		AddIndex("idx_export_jobs_expires_at", "expires_at")
+	db.AutoMigrate(&model.OutboxEvent{}).
+		AddIndex("idx_outbox_events_status_next_attempt_at", "status", "next_attempt_at")
 }
@@ -0,0 +1,51 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// 出典の検証状態
+const (
+	VerificationStatusUnverified    = "unverified"
+	VerificationStatusDisputed      = "disputed"
+	VerificationStatusVerified      = "verified"
+	VerificationStatusMisattributed = "misattributed"
+)
+
+// 証拠・異議の種類
+const (
+	ClaimTypeEvidence = "evidence"
+	ClaimTypeDispute  = "dispute"
+)
+
+// 証拠・異議の審査状態
+const (
+	ClaimStatusPending  = "pending"
+	ClaimStatusAccepted = "accepted"
+	ClaimStatusRejected = "rejected"
+)
+
+// AttributionClaim attribution_claimsテーブルに対応する構造体。投稿の出典に対する証拠または異議。
+type AttributionClaim struct {
+	ID             int        `json:"id" gorm:"primary_key"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	PostID         int        `json:"post_id" gorm:"not null;default:0"`
+	UserID         int        `json:"user_id" gorm:"not null;default:0"`
+	ClaimType      string     `json:"claim_type" gorm:"type:varchar(16);not null;default:''"`
+	ProposedStatus string     `json:"proposed_status" gorm:"type:varchar(16);not null;default:''"`
+	Body           string     `json:"body" gorm:"type:varchar(1024);not null;default:''"`
+	SourceURL      string     `json:"source_url" gorm:"type:varchar(256);not null;default:''"`
+	Status         string     `json:"status" gorm:"type:varchar(16);not null;default:'pending'"`
+	RuledBy        int        `json:"ruled_by" gorm:"not null;default:0"`
+	RulingComment  string     `json:"ruling_comment" gorm:"type:varchar(512);not null;default:''"`
+	RuledAt        *time.Time `json:"ruled_at"`
+}
+
+// GetAttributionClaimResult 証拠・異議一覧取得の戻り値として使用される構造体。
+type GetAttributionClaimResult struct {
+	AttributionClaim
+	UserName          string `json:"user_name"`
+	UserImageFilePath string `json:"user_image_file_path"`
+}
@@ -0,0 +1,8 @@
+// Package model Domain Model
+package model
+
+// AutocompleteCandidate 入力補完候補。
+type AutocompleteCandidate struct {
+	Text  string `json:"text"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,46 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// Collection collectionsテーブルに対応する構造体。ユーザーが作成する投稿のまとめ。
+type Collection struct {
+	ID          int        `json:"id" gorm:"primary_key"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+	UserID      int        `json:"user_id" gorm:"not null;default:0"`
+	Name        string     `json:"name" gorm:"type:varchar(64);not null;default:''"`
+	Description string     `json:"description" gorm:"type:varchar(512);not null;default:''"`
+	// 表紙とする投稿。0の場合は先頭の投稿を表紙とする。
+	CoverPostID int `json:"cover_post_id" gorm:"not null;default:0"`
+	// 公開する場合はtrue。非公開のまとめは作成したユーザーのみ参照できる。
+	IsPublic bool `json:"is_public" gorm:"not null;default:false"`
+}
+
+// CollectionItem collection_itemsテーブルに対応する構造体。まとめに含まれる投稿。
+type CollectionItem struct {
+	ID           int       `json:"id" gorm:"primary_key"`
+	CreatedAt    time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	CollectionID int       `json:"collection_id" gorm:"not null;default:0"`
+	PostID       int       `json:"post_id" gorm:"not null;default:0"`
+	// 並び順(昇順)
+	Position int `json:"position" gorm:"not null;default:0"`
+}
+
+// GetCollectionResult まとめ取得時に使用される構造体。
+type GetCollectionResult struct {
+	Collection
+	UserName          string `json:"user_name"`
+	UserImageFilePath string `json:"user_image_file_path"`
+	PostCount         int    `json:"post_count"`
+	// 並び順が先頭の投稿のID(表紙の決定用)
+	FirstPostID int `json:"-"`
+	// 表紙の投稿。投稿がない場合はnil。
+	CoverPost *GetPostResult `json:"cover_post" gorm:"-"`
+	// 共有用URL
+	ShareURL string `json:"share_url" gorm:"-"`
+}
@@ -14,6 +14,8 @@ type Comment struct {
 	PostID    int        `json:"post_id" gorm:"not null;default:0"`
 	UserID    int        `json:"user_id" gorm:"not null;default:0"`
 	Body      string     `json:"body" gorm:"type:varchar(256);not null;default:''"`
+	// 通報またはモデレーターにより非表示にされたコメント
+	IsHidden bool `json:"is_hidden" gorm:"not null;default:false"`
 }
 
 // GetCommentResult GetCommentの戻り値として使用される構造体。
@@ -0,0 +1,24 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// DailyPost daily_postsテーブルに対応する構造体。日付ごとの「今日の言葉」。
+type DailyPost struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	// 日付(例：2006-01-02)
+	Date   string `json:"date" gorm:"type:varchar(10);not null;default:'';unique"`
+	PostID int    `json:"post_id" gorm:"not null;default:0"`
+	// 管理者が指定した場合はtrue
+	Pinned bool `json:"pinned" gorm:"not null;default:false"`
+}
+
+// DailyPostCandidate 「今日の言葉」の候補。
+type DailyPostCandidate struct {
+	PostID        int `json:"post_id"`
+	FavoriteCount int `json:"favorite_count"`
+}
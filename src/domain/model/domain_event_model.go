@@ -0,0 +1,99 @@
+// Package model Domain Model
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ドメインイベントの種類
+const (
+	DomainEventPostCreated     = "post.created"
+	DomainEventPostUpdated     = "post.updated"
+	DomainEventPostDeleted     = "post.deleted"
+	DomainEventPostRestored    = "post.restored"
+	DomainEventPostPurged      = "post.purged"
+	DomainEventCommentCreated  = "comment.created"
+	DomainEventCommentDeleted  = "comment.deleted"
+	DomainEventCommentRestored = "comment.restored"
+	DomainEventCommentPurged   = "comment.purged"
+	DomainEventFavoriteCreated = "favorite.created"
+	DomainEventFavoriteDeleted = "favorite.deleted"
+	DomainEventUserCreated     = "user.created"
+	DomainEventUserUpdated     = "user.updated"
+	DomainEventUserDeleted     = "user.deleted"
+	DomainEventUserPurged      = "user.purged"
+	DomainEventFollowCreated   = "follow.created"
+)
+
+// 送信箱(outbox)のイベントの処理状況
+const (
+	// OutboxEventStatusPending 未処理(再処理待ちを含む)
+	OutboxEventStatusPending = "pending"
+	// OutboxEventStatusDispatched 処理済み
+	OutboxEventStatusDispatched = "dispatched"
+	// OutboxEventStatusFailed 再処理の上限に達し、処理できなかった
+	OutboxEventStatusFailed = "failed"
+)
+
+// DomainEvent ユースケースが発行するドメインイベント。
+// 登録・更新と同じトランザクションで送信箱に保存する。Dataは保存時にJSONにするため、登録で採番されたIDも含まれる。
+type DomainEvent struct {
+	Type string
+	Data interface{}
+}
+
+// NewDomainEvent DomainEventを生成する。
+func NewDomainEvent(eventType string, data interface{}) *DomainEvent {
+	return &DomainEvent{Type: eventType, Data: data}
+}
+
+// DomainEventTarget 更新、削除のイベントの内容。対象のIDのみを表す。
+type DomainEventTarget struct {
+	ID int `json:"id"`
+}
+
+// UserEventPayload ユーザーのイベントの内容。パスワード、メールアドレスは含めない。
+type UserEventPayload User
+
+// MarshalJSON 公開してよい項目のみをJSONにする。
+func (payload *UserEventPayload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"id":              payload.ID,
+		"created_at":      payload.CreatedAt,
+		"name":            payload.Name,
+		"image_file_path": payload.ImageFilePath,
+	})
+}
+
+// FavoriteEventPayload お気に入りのイベントの内容。メモ、理由の分類は本人のみ参照できるため含めない。
+type FavoriteEventPayload Favorite
+
+// MarshalJSON 公開してよい項目のみをJSONにする。
+func (payload *FavoriteEventPayload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"id":         payload.ID,
+		"created_at": payload.CreatedAt,
+		"user_id":    payload.UserID,
+		"post_id":    payload.PostID,
+	})
+}
+
+// OutboxEvent outbox_eventsテーブルに対応する構造体。送信箱に保存したドメインイベント。
+// 登録・更新と同じトランザクションで保存し、ディスパッチャーがサブスクライバーに少なくとも1回届ける。
+type OutboxEvent struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	EventType string    `json:"event_type" gorm:"type:varchar(32);not null;default:''"`
+	// イベントの内容(JSON)
+	Payload string `json:"payload" gorm:"type:text;not null"`
+	Status  string `json:"status" gorm:"type:varchar(16);not null;default:'pending'"`
+	// 処理した回数
+	Attempts int `json:"attempts" gorm:"not null;default:0"`
+	// 最後に処理した際のエラー
+	Error string `json:"error" gorm:"type:varchar(256);not null;default:''"`
+	// 次に処理する日時。処理中は他のAPIサーバーが処理しないよう、処理の期限を設定する
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	DispatchedAt  *time.Time `json:"dispatched_at"`
+}
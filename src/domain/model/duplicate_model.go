@@ -0,0 +1,17 @@
+// Package model Domain Model
+package model
+
+// DuplicateCandidate 重複の可能性がある投稿。
+type DuplicateCandidate struct {
+	PostID     int     `json:"post_id"`
+	UserID     int     `json:"user_id"`
+	Title      string  `json:"title"`
+	Speaker    string  `json:"speaker"`
+	Similarity float64 `json:"similarity"`
+}
+
+// DuplicateCluster 互いに重複の可能性がある投稿のまとまり。
+type DuplicateCluster struct {
+	Speaker string                `json:"speaker"`
+	Posts   []*DuplicateCandidate `json:"posts"`
+}
@@ -0,0 +1,33 @@
+// Package model Domain Model
+package model
+
+// OEmbed oEmbedのレスポンス(type=rich)。
+type OEmbed struct {
+	Type            string `json:"type"`
+	Version         string `json:"version"`
+	Title           string `json:"title"`
+	AuthorName      string `json:"author_name"`
+	AuthorURL       string `json:"author_url"`
+	ProviderName    string `json:"provider_name"`
+	ProviderURL     string `json:"provider_url"`
+	CacheAge        int    `json:"cache_age"`
+	ThumbnailURL    string `json:"thumbnail_url"`
+	ThumbnailWidth  int    `json:"thumbnail_width"`
+	ThumbnailHeight int    `json:"thumbnail_height"`
+	HTML            string `json:"html"`
+	Width           int    `json:"width"`
+	Height          int    `json:"height"`
+}
+
+// EmbedWidget 埋め込みウィジェットの表示内容。
+type EmbedWidget struct {
+	Title   string
+	Speaker string
+	// フロントエンドの投稿詳細ページのURL
+	PostURL string
+	// 配色(#rrggbb)
+	Background string
+	Text       string
+	Sub        string
+	Accent     string
+}
@@ -0,0 +1,34 @@
+// Package model Domain Model
+package model
+
+import "time"
+
+// 個人データのエクスポートジョブの状態
+const (
+	// ExportJobStatusPending 実行待ち
+	ExportJobStatusPending = "pending"
+	// ExportJobStatusRunning 実行中
+	ExportJobStatusRunning = "running"
+	// ExportJobStatusCompleted 完了。ダウンロードできる。
+	ExportJobStatusCompleted = "completed"
+	// ExportJobStatusFailed 失敗
+	ExportJobStatusFailed = "failed"
+)
+
+// ExportJob export_jobsテーブルに対応する構造体。個人データのエクスポートジョブ。
+// 複数のAPIサーバーで共有できるよう、ジョブはDBに、作成したファイルはExportArchiveStorageに保存する。
+type ExportJob struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UserID    int       `json:"user_id" gorm:"not null"`
+	Status    string    `json:"status" gorm:"type:varchar(16);not null;default:'pending'"`
+	Error     string    `json:"error,omitempty" gorm:"type:varchar(256);not null;default:''"`
+	// ダウンロードURLに含める推測できないトークン。作成したファイルの保存先のキーにも使う
+	Token string `json:"-" gorm:"type:varchar(64);not null"`
+	// 完了後に設定する。ログインせずにダウンロードできるため、本人にのみ返す。
+	DownloadURL string     `json:"download_url,omitempty" gorm:"-"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	// 最後に状態を更新した日時。実行待ち、実行中のまま一定時間更新されないジョブは、サーバーの停止などで中断したものとして扱う
+	HeartbeatAt time.Time `json:"-" gorm:"not null;default:current_timestamp"`
+}
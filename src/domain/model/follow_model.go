@@ -0,0 +1,33 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// Follow followsテーブルに対応する構造体。ユーザー間のフォロー。
+type Follow struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	// フォローしたユーザー
+	FollowerID int `json:"follower_id" gorm:"not null;default:0"`
+	// フォローされたユーザー
+	FolloweeID int `json:"followee_id" gorm:"not null;default:0"`
+}
+
+// GetFollowUserResult フォロワー、フォロー中のユーザー一覧の戻り値として使用される構造体。
+type GetFollowUserResult struct {
+	UserID            int       `json:"user_id"`
+	UserName          string    `json:"user_name"`
+	UserImageFilePath string    `json:"user_image_file_path"`
+	FollowedAt        time.Time `json:"followed_at"`
+}
+
+// FollowCounts ユーザーのフォロワー数、フォロー数。
+type FollowCounts struct {
+	FollowerCount  int `json:"follower_count"`
+	FollowingCount int `json:"following_count"`
+	// ログインユーザーがフォローしている場合はtrue
+	IsFollowing bool `json:"is_following"`
+}
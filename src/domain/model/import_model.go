@@ -0,0 +1,58 @@
+// Package model Domain Model
+package model
+
+import "time"
+
+// 一括登録ジョブの状態
+const (
+	// ImportJobStatusPending 実行待ち
+	ImportJobStatusPending = "pending"
+	// ImportJobStatusRunning 実行中
+	ImportJobStatusRunning = "running"
+	// ImportJobStatusCompleted 完了。行ごとに登録する場合は、一部の行が失敗していても完了とする。
+	ImportJobStatusCompleted = "completed"
+	// ImportJobStatusFailed 失敗。一括で登録する場合は、1行でも失敗すれば何も登録しない。
+	ImportJobStatusFailed = "failed"
+)
+
+// ImportRow 一括登録する1行分の投稿。
+type ImportRow struct {
+	// 行番号。CSVの場合はヘッダー行を1行目とし、JSONの場合は配列の先頭を1行目とする。
+	Row  int
+	Post Post
+	// 入力チェックのエラーメッセージ
+	Errors []string
+	// 登録には影響しない注意事項
+	Warnings []string
+}
+
+// ImportRowResult 一括登録の1行分の結果。
+type ImportRowResult struct {
+	Row      int      `json:"row"`
+	PostID   int      `json:"post_id,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ImportJob import_jobsテーブルに対応する構造体。投稿の一括登録ジョブ。
+// 複数のAPIサーバーで共有できるよう、ジョブはDBに保存する。ドライランのジョブは保存しない。
+type ImportJob struct {
+	ID             int       `json:"id" gorm:"primary_key"`
+	CreatedAt      time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UserID         int       `json:"user_id" gorm:"not null"`
+	Status         string    `json:"status" gorm:"type:varchar(16);not null;default:'pending'"`
+	DryRun         bool      `json:"dry_run" gorm:"not null;default:false"`
+	Atomic         bool      `json:"atomic" gorm:"not null;default:false"`
+	AllowDuplicate bool      `json:"allow_duplicate" gorm:"not null;default:false"`
+	TotalCount     int       `json:"total_count" gorm:"not null;default:0"`
+	ProcessedCount int       `json:"processed_count" gorm:"not null;default:0"`
+	SucceededCount int       `json:"succeeded_count" gorm:"not null;default:0"`
+	FailedCount    int       `json:"failed_count" gorm:"not null;default:0"`
+	Error          string    `json:"error,omitempty" gorm:"type:varchar(256);not null;default:''"`
+	// 行ごとの結果。保存時はEncodedResultsにJSONにして保存する
+	Results        []*ImportRowResult `json:"results" gorm:"-"`
+	EncodedResults string             `json:"-" gorm:"column:results;type:mediumtext"`
+	FinishedAt     *time.Time         `json:"finished_at,omitempty"`
+	// 最後に進捗を反映した日時。実行待ち、実行中のまま一定時間更新されないジョブは、サーバーの停止などで中断したものとして扱う
+	HeartbeatAt time.Time `json:"-" gorm:"not null;default:current_timestamp"`
+}
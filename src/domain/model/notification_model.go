@@ -0,0 +1,63 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// 通知の種類
+const (
+	NotificationTypeComment  = "comment"
+	NotificationTypeFavorite = "favorite"
+	NotificationTypeFollow   = "follow"
+)
+
+// NotificationTypes 通知の種類一覧
+var NotificationTypes = []string{NotificationTypeComment, NotificationTypeFavorite, NotificationTypeFollow}
+
+// Notification notificationsテーブルに対応する構造体。
+// 未読の間は同じ種類、同じ投稿への通知を1件にまとめ、行ったユーザーの人数を数える。
+type Notification struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	// 通知を受け取るユーザー
+	UserID int    `json:"user_id" gorm:"not null;default:0"`
+	Type   string `json:"type" gorm:"type:varchar(16);not null;default:''"`
+	// 対象の投稿。フォローの場合は0
+	PostID int `json:"post_id" gorm:"not null;default:0"`
+	// 最後に行ったユーザー
+	ActorID int `json:"actor_id" gorm:"not null;default:0"`
+	// 行ったユーザーの人数
+	ActorCount int        `json:"actor_count" gorm:"not null;default:0"`
+	ReadAt     *time.Time `json:"read_at"`
+}
+
+// NotificationActor notification_actorsテーブルに対応する構造体。通知をまとめる際に同じユーザーを重複して数えないために使用する。
+type NotificationActor struct {
+	ID             int       `json:"id" gorm:"primary_key"`
+	CreatedAt      time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	NotificationID int       `json:"notification_id" gorm:"not null;default:0"`
+	ActorID        int       `json:"actor_id" gorm:"not null;default:0"`
+}
+
+// NotificationSetting notification_settingsテーブルに対応する構造体。通知の種類ごとの受け取り設定。
+// 行がない種類は受け取る。
+type NotificationSetting struct {
+	ID        int       `json:"-" gorm:"primary_key"`
+	CreatedAt time.Time `json:"-" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"-" gorm:"not null;default:current_timestamp"`
+	UserID    int       `json:"-" gorm:"not null;default:0"`
+	Type      string    `json:"type" gorm:"type:varchar(16);not null;default:''"`
+	Enabled   bool      `json:"enabled" gorm:"not null;default:false"`
+}
+
+// GetNotificationResult 通知一覧の戻り値として使用される構造体。
+type GetNotificationResult struct {
+	Notification
+	ActorName          string `json:"actor_name"`
+	ActorImageFilePath string `json:"actor_image_file_path"`
+	PostTitle          string `json:"post_title"`
+	// 表示用のメッセージ(例：3人があなたの投稿「…」をお気に入りしました。)
+	Message string `json:"message" gorm:"-"`
+}
@@ -16,17 +16,89 @@ type Post struct {
 	Speaker   string     `json:"speaker" gorm:"type:varchar(256);not null;default:''"`
 	Detail    string     `json:"detail" gorm:"type:varchar(512);not null;default:''"`
 	MovieURL  string     `json:"movie_url" gorm:"type:varchar(256);not null;default:''"`
+	License   string     `json:"license" gorm:"type:varchar(32);not null;default:''"`
+	// 投稿の言語(BCP 47の言語タグ)
+	Language string `json:"language" gorm:"type:varchar(16);not null;default:'ja'"`
+	PostSource
+	VerificationStatus string `json:"verification_status" gorm:"type:varchar(16);not null;default:'unverified'"`
+	// 重複検出用に正規化したタイトル、発言者
+	NormalizedTitle   string `json:"-" gorm:"type:varchar(256);not null;default:''"`
+	NormalizedSpeaker string `json:"-" gorm:"type:varchar(256);not null;default:''"`
+	// 通報またはモデレーターにより非表示にされた投稿
+	IsHidden bool `json:"is_hidden" gorm:"not null;default:false"`
+	// 投稿の分類(例：励まし、仕事)。post_tagsテーブルに保存する。
+	Tags []string `json:"tags,omitempty" gorm:"-"`
+}
+
+// PostTag post_tagsテーブルに対応する構造体。
+type PostTag struct {
+	ID     int    `json:"id" gorm:"primary_key"`
+	PostID int    `json:"post_id" gorm:"not null;default:0"`
+	Tag    string `json:"tag" gorm:"type:varchar(32);not null;default:''"`
+}
+
+// SeenPost seen_postsテーブルに対応する構造体。ログインユーザーが閲覧した投稿。
+type SeenPost struct {
+	ID     int       `json:"id" gorm:"primary_key"`
+	UserID int       `json:"user_id" gorm:"not null;default:0"`
+	PostID int       `json:"post_id" gorm:"not null;default:0"`
+	SeenAt time.Time `json:"seen_at" gorm:"not null;default:current_timestamp"`
+}
+
+// DefaultLanguage 投稿の言語の既定値
+const DefaultLanguage = "ja"
+
+// 出典の種類
+const (
+	SourceTypeBook      = "book"
+	SourceTypeSpeech    = "speech"
+	SourceTypeInterview = "interview"
+	SourceTypeWeb       = "web"
+	SourceTypeVideo     = "video"
+)
+
+// ライセンス
+const (
+	LicenseAllRightsReserved = "all_rights_reserved"
+	LicensePublicDomain      = "public_domain"
+	LicenseCC0               = "cc0"
+	LicenseCCBY              = "cc_by"
+	LicenseCCBYSA            = "cc_by_sa"
+	LicenseCCBYNC            = "cc_by_nc"
+)
+
+// PostSource 投稿の出典情報。postsテーブルに埋め込まれる。
+type PostSource struct {
+	SourceType      string `json:"source_type" gorm:"type:varchar(16);not null;default:''"`
+	SourceTitle     string `json:"source_title" gorm:"type:varchar(256);not null;default:''"`
+	SourceLocator   string `json:"source_locator" gorm:"type:varchar(64);not null;default:''"`
+	PublicationYear int    `json:"publication_year" gorm:"not null;default:0"`
+	SourceURL       string `json:"source_url" gorm:"type:varchar(256);not null;default:''"`
 }
 
 // GetPostResult GetPostの戻り値として使用される構造体。
 type GetPostResult struct {
 	Post
 	EmbedMovieURL     string `json:"embed_movie_url"`
+	Citation          string `json:"citation"`
 	UserName          string `json:"user_name"`
 	UserImageFilePath string `json:"user_image_file_path"`
 	CommentCount      int    `json:"comment_count"`
 	IsFavorite        bool   `json:"is_favorite"`
 	FavoriteCount     int    `json:"favorite_count"`
+	// 閲覧数。同じユーザー(未ログインの場合はIPアドレス)による一定時間内の閲覧は1回と数える。
+	ViewCount int `json:"view_count"`
+	// リアクションの種類ごとの件数。件数が0の種類も含む。
+	Reactions []*ReactionCount `json:"reactions,omitempty" gorm:"-"`
+	// ログインユーザーがリアクションしている種類
+	MyReactions []string `json:"my_reactions,omitempty" gorm:"-"`
+	// 出典検証の証拠・異議の履歴。投稿詳細取得時のみ設定される。
+	AttributionClaims []*GetAttributionClaimResult `json:"attribution_claims,omitempty" gorm:"-"`
+	// 希望言語に最も合う翻訳。原文が希望言語に合う場合や翻訳がない場合はnil。
+	Translation *GetPostTranslationResult `json:"translation,omitempty" gorm:"-"`
+	// お気に入りのメモ、理由の分類。本人のお気に入り一覧取得時のみ設定される。
+	FavoriteNote string `json:"favorite_note,omitempty"`
+	FavoriteTag  string `json:"favorite_tag,omitempty"`
 }
 
 // Favorite favoritesテーブルに対応する構造体。
@@ -36,4 +108,8 @@ type Favorite struct {
 	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
 	UserID    int       `json:"user_id" gorm:"not null;default:0"`
 	PostID    int       `json:"post_id" gorm:"not null;default:0"`
+	// お気に入りにした理由などのメモ。本人のみ参照できる。
+	Note string `json:"note" gorm:"type:varchar(1024);not null;default:''"`
+	// お気に入りにした理由の分類(例：励まし、仕事)。本人のみ参照できる。
+	Tag string `json:"tag" gorm:"type:varchar(32);not null;default:''"`
 }
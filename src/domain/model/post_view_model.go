@@ -0,0 +1,57 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// PostView post_viewsテーブルに対応する構造体。閲覧者による投稿の閲覧を、重複を除外する期間ごとに1件記録する。
+type PostView struct {
+	ID int `json:"id" gorm:"primary_key"`
+	// 閲覧日時
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	PostID    int       `json:"post_id" gorm:"not null;default:0"`
+	// 閲覧者(例：user:1、ip:192.0.2.1)
+	Viewer string `json:"viewer" gorm:"type:varchar(64);not null;default:''"`
+	// 重複を除外する期間の開始日時
+	WindowStart time.Time `json:"window_start" gorm:"not null;default:current_timestamp"`
+	// 閲覧したログインユーザーのID。ログインしていない場合は0
+	UserID int `json:"-" gorm:"-"`
+	// 閲覧数を加算する日付(例：2006-01-02)
+	Date string `json:"-" gorm:"-"`
+}
+
+// PostDailyView post_daily_viewsテーブルに対応する構造体。投稿の日付ごとの閲覧数。
+type PostDailyView struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	PostID    int       `json:"post_id" gorm:"not null;default:0"`
+	// 日付(例：2006-01-02)
+	Date  string `json:"date" gorm:"type:varchar(10);not null;default:''"`
+	Views int    `json:"views" gorm:"not null;default:0"`
+}
+
+// PostDailyStat 投稿の日付ごとの閲覧数、お気に入り数、コメント数。
+type PostDailyStat struct {
+	// 日付(例：2006-01-02)
+	Date      string `json:"date"`
+	Views     int    `json:"views"`
+	Favorites int    `json:"favorites"`
+	Comments  int    `json:"comments"`
+}
+
+// PostStats 投稿者向けの投稿の統計。
+type PostStats struct {
+	// 対象の投稿ID。投稿者の全ての投稿の合計の場合は0。
+	PostID int `json:"post_id"`
+	// 集計期間(例：2006-01-02)
+	From string `json:"from"`
+	To   string `json:"to"`
+	// 集計期間の合計
+	Views     int `json:"views"`
+	Favorites int `json:"favorites"`
+	Comments  int `json:"comments"`
+	// 日付ごとの件数。件数が0の日も含めて日付の古い順に並べる。
+	Daily []*PostDailyStat `json:"daily"`
+}
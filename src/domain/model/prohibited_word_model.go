@@ -0,0 +1,27 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// 禁止語を含む場合の扱い
+const (
+	// ProhibitedWordActionBlock 登録を拒否する
+	ProhibitedWordActionBlock = "block"
+	// ProhibitedWordActionMask 伏せ字にして登録する
+	ProhibitedWordActionMask = "mask"
+	// ProhibitedWordActionReview 非表示で登録し、モデレーターの確認待ちにする
+	ProhibitedWordActionReview = "review"
+)
+
+// ProhibitedWord prohibited_wordsテーブルに対応する構造体。投稿、コメントに使用できない語句。
+type ProhibitedWord struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	Word      string    `json:"word" gorm:"type:varchar(100);not null;default:''"`
+	// 検出用に正規化した語句
+	NormalizedWord string `json:"normalized_word" gorm:"type:varchar(100);not null;default:''"`
+	Action         string `json:"action" gorm:"type:varchar(16);not null;default:'block'"`
+}
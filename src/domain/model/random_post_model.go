@@ -0,0 +1,20 @@
+// Package model Domain Model
+package model
+
+// RandomPostCondition ランダム投稿取得の絞り込み条件。
+type RandomPostCondition struct {
+	// 発言者(完全一致)。絞り込まない場合は空文字。
+	Speaker string
+	// タグ。絞り込まない場合は空文字。
+	Tag string
+	// 出典の種類。絞り込まない場合は空文字。
+	SourceType string
+	// お気に入り数の下限
+	MinFavoriteCount int
+	// ログインユーザー。ExcludeSeen、ExcludeFavoritedがtrueの場合に使用する。
+	LoginUserID int
+	// trueの場合はログインユーザーが閲覧済みの投稿を除く
+	ExcludeSeen bool
+	// trueの場合はログインユーザーがお気に入り登録済みの投稿を除く
+	ExcludeFavorited bool
+}
@@ -0,0 +1,47 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// Reaction reactionsテーブルに対応する構造体。投稿へのリアクション(例：感動、励まされた)。
+type Reaction struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	UserID    int       `json:"user_id" gorm:"not null;default:0"`
+	PostID    int       `json:"post_id" gorm:"not null;default:0"`
+	// リアクションの種類(例：moved)
+	Type string `json:"type" gorm:"type:varchar(32);not null;default:''"`
+}
+
+// ReactionType リアクションの種類。
+type ReactionType struct {
+	Type string `json:"type"`
+	// 表示名(例：感動)
+	Label string `json:"label"`
+}
+
+// ReactionCount 投稿のリアクションの種類ごとの件数。
+type ReactionCount struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// PostReactionCount 投稿、リアクションの種類ごとの件数。
+type PostReactionCount struct {
+	PostID int
+	Type   string
+	Count  int
+	// ログインユーザーがリアクションしている場合はtrue
+	Reacted bool
+}
+
+// GetReactionResult リアクションしたユーザー一覧の戻り値として使用される構造体。
+type GetReactionResult struct {
+	Reaction
+	UserName          string `json:"user_name"`
+	UserImageFilePath string `json:"user_image_file_path"`
+}
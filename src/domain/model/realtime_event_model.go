@@ -0,0 +1,50 @@
+// Package model Domain Model
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// リアルタイムに配信するイベントの種類
+const (
+	// RealtimeEventComment 投稿へのコメント
+	RealtimeEventComment = "comment"
+	// RealtimeEventNotification 通知
+	RealtimeEventNotification = "notification"
+	// RealtimeEventFavoriteCount 投稿のお気に入り数の変化
+	RealtimeEventFavoriteCount = "favorite_count"
+)
+
+// RealtimeEvent リアルタイムに配信するイベント。
+// 複数のAPIサーバー間でブローカーを通して受け渡せるよう、内容はJSONにしておく。
+type RealtimeEvent struct {
+	Type string `json:"type"`
+	// 対象の投稿。投稿を購読している接続に配信する
+	PostID int `json:"post_id,omitempty"`
+	// 対象のユーザー。そのユーザーの接続にのみ配信する
+	UserID int             `json:"user_id,omitempty"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// FavoriteCount 投稿のお気に入り数。
+type FavoriteCount struct {
+	PostID        int `json:"post_id"`
+	FavoriteCount int `json:"favorite_count"`
+}
+
+// StreamTicket リアルタイム配信に接続するためのチケット。
+// EventSourceはヘッダーを指定できずクエリパラメータで渡すため、アクセスログに残っても悪用されにくいよう有効期限を短くする。
+type StreamTicket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// UsedStreamTicket used_stream_ticketsテーブルに対応する構造体。使用済みのリアルタイム配信のチケット。
+// 同じチケットで再度接続できないよう、有効期限まで記録しておく。
+type UsedStreamTicket struct {
+	ID int `json:"id" gorm:"primary_key"`
+	// チケットのID(jtiクレーム)
+	TicketID  string    `json:"ticket_id" gorm:"type:varchar(64);not null;default:''"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;default:current_timestamp"`
+}
@@ -0,0 +1,16 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// RelatedPost related_postsテーブルに対応する構造体。事前に計算した関連する投稿と関連度。
+type RelatedPost struct {
+	ID            int       `json:"id" gorm:"primary_key"`
+	CreatedAt     time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	PostID        int       `json:"post_id" gorm:"not null;default:0"`
+	RelatedPostID int       `json:"related_post_id" gorm:"not null;default:0"`
+	// 関連度。大きいほど関連が強い。
+	Score float64 `json:"score" gorm:"not null;default:0"`
+}
@@ -0,0 +1,120 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// 通報の対象の種類
+const (
+	ReportTargetPost    = "post"
+	ReportTargetComment = "comment"
+	ReportTargetUser    = "user"
+)
+
+// 通報の理由
+const (
+	ReportReasonSpam           = "spam"
+	ReportReasonHarassment     = "harassment"
+	ReportReasonHateSpeech     = "hate_speech"
+	ReportReasonMisinformation = "misinformation"
+	ReportReasonCopyright      = "copyright"
+	ReportReasonOther          = "other"
+	// ReportReasonProhibitedWord 禁止語を含む(システムによる通報)
+	ReportReasonProhibitedWord = "prohibited_word"
+)
+
+// 通報の状態
+const (
+	ReportStatusOpen     = "open"
+	ReportStatusResolved = "resolved"
+)
+
+// モデレーターの対応
+const (
+	// ModerationActionDismiss 通報を却下する。非表示の場合は再表示する。
+	ModerationActionDismiss = "dismiss"
+	// ModerationActionHide 非表示にする
+	ModerationActionHide = "hide"
+	// ModerationActionDelete 削除する
+	ModerationActionDelete = "delete"
+	// ModerationActionWarn ユーザー(投稿、コメントの場合は投稿者)に警告する
+	ModerationActionWarn = "warn"
+	// ModerationActionSuspend ユーザー(投稿、コメントの場合は投稿者)を利用停止にする
+	ModerationActionSuspend = "suspend"
+	// ModerationActionAutoHide 通報の件数がしきい値に達したため自動で非表示にした
+	ModerationActionAutoHide = "auto_hide"
+)
+
+// Report reportsテーブルに対応する構造体。投稿、コメント、ユーザーに対する通報。
+// 同じユーザーは同じ対象を1件のみ通報できる。
+type Report struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	// システムによる通報の場合は0
+	ReporterID int        `json:"reporter_id" gorm:"not null;default:0"`
+	TargetType string     `json:"target_type" gorm:"type:varchar(16);not null;default:''"`
+	TargetID   int        `json:"target_id" gorm:"not null;default:0"`
+	ReasonCode string     `json:"reason_code" gorm:"type:varchar(32);not null;default:''"`
+	Detail     string     `json:"detail" gorm:"type:varchar(512);not null;default:''"`
+	Status     string     `json:"status" gorm:"type:varchar(16);not null;default:'open'"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+}
+
+// GetReportResult 通報一覧取得の戻り値として使用される構造体。
+type GetReportResult struct {
+	Report
+	ReporterName string `json:"reporter_name"`
+}
+
+// ModerationAction moderation_actionsテーブルに対応する構造体。モデレーターの対応の記録。
+type ModerationAction struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	// 自動で非表示にした場合は0
+	ModeratorID int    `json:"moderator_id" gorm:"not null;default:0"`
+	TargetType  string `json:"target_type" gorm:"type:varchar(16);not null;default:''"`
+	TargetID    int    `json:"target_id" gorm:"not null;default:0"`
+	// 対象のユーザー。投稿、コメントの場合は投稿者。
+	TargetUserID int    `json:"target_user_id" gorm:"not null;default:0"`
+	Action       string `json:"action" gorm:"type:varchar(16);not null;default:''"`
+	Reason       string `json:"reason" gorm:"type:varchar(512);not null;default:''"`
+}
+
+// ModerationEffect モデレーターの対応として対象に適用する変更。対応の記録と同一トランザクションで適用する。
+type ModerationEffect struct {
+	// 投稿、コメントの表示、非表示を切り替える場合に指定する
+	Hidden *bool
+	// 投稿、コメントを削除する場合はtrue
+	Delete bool
+	// 対象のユーザーを利用停止にする場合に指定する
+	SuspendedAt *time.Time
+	// 変更に伴って送信箱に保存するドメインイベント
+	Events []*DomainEvent
+}
+
+// ReportQueueItem モデレーター向けの未対応の通報の対象。
+type ReportQueueItem struct {
+	TargetType       string    `json:"target_type"`
+	TargetID         int       `json:"target_id"`
+	ReportCount      int       `json:"report_count"`
+	LatestReportedAt time.Time `json:"latest_reported_at"`
+	// 通報の理由をカンマ区切りで連結したもの
+	ConcatenatedReasonCodes string   `json:"-"`
+	ReasonCodes             []string `json:"reason_codes" gorm:"-"`
+}
+
+// ReportTarget 通報の対象と、通報、対応の履歴。
+type ReportTarget struct {
+	TargetType string `json:"target_type"`
+	TargetID   int    `json:"target_id"`
+	// 対象のユーザー。投稿、コメントの場合は投稿者。
+	TargetUserID int                 `json:"target_user_id"`
+	IsHidden     bool                `json:"is_hidden"`
+	Post         *Post               `json:"post,omitempty"`
+	Comment      *Comment            `json:"comment,omitempty"`
+	User         *User               `json:"user,omitempty"`
+	Reports      []*GetReportResult  `json:"reports"`
+	Actions      []*ModerationAction `json:"actions"`
+}
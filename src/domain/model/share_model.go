@@ -0,0 +1,22 @@
+// Package model Domain Model
+package model
+
+// リンクプレビューの種類
+const (
+	TwitterCardSummary           = "summary"
+	TwitterCardSummaryLargeImage = "summary_large_image"
+)
+
+// SharePage リンクプレビュー(Open Graph、Twitterカード)用のページ情報。
+type SharePage struct {
+	Title       string
+	Description string
+	// 画像の絶対URL。画像がない場合は空文字。
+	ImageURL string
+	// フロントエンドのページの絶対URL
+	CanonicalURL string
+	// og:type
+	Type string
+	// twitter:card
+	TwitterCard string
+}
@@ -0,0 +1,25 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// PostTranslation post_translationsテーブルに対応する構造体。投稿の言語ごとの翻訳。
+type PostTranslation struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	PostID    int       `json:"post_id" gorm:"not null;default:0"`
+	// 翻訳の言語(BCP 47の言語タグ)
+	Language     string `json:"language" gorm:"type:varchar(16);not null;default:''"`
+	Title        string `json:"title" gorm:"type:varchar(256);not null;default:''"`
+	Detail       string `json:"detail" gorm:"type:varchar(512);not null;default:''"`
+	TranslatorID int    `json:"translator_id" gorm:"not null;default:0"`
+}
+
+// GetPostTranslationResult 翻訳取得時に使用される構造体。
+type GetPostTranslationResult struct {
+	PostTranslation
+	TranslatorName string `json:"translator_name"`
+}
@@ -0,0 +1,24 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// TrashItem ゴミ箱の中の削除済みの投稿またはコメント。
+type TrashItem struct {
+	TargetType string    `json:"target_type"`
+	TargetID   int       `json:"target_id"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	// 復元できる期限。期限を過ぎると完全に削除される。
+	RestorableUntil time.Time `json:"restorable_until"`
+	Post            *Post     `json:"post,omitempty"`
+	Comment         *Comment  `json:"comment,omitempty"`
+}
+
+// PurgeResult 完全に削除した件数。
+type PurgeResult struct {
+	Posts    int `json:"posts"`
+	Comments int `json:"comments"`
+	Users    int `json:"users"`
+}
@@ -15,4 +15,15 @@ type User struct {
 	Email         string     `json:"email" gorm:"type:varchar(256);not null;default:'';unique"`
 	Password      string     `json:"password" gorm:"type:varchar(256);not null;default:''"`
 	ImageFilePath string     `json:"image_file_path" gorm:"type:varchar(256);not null;default:''"`
+	Role          string     `json:"role" gorm:"type:varchar(16);not null;default:'user'"`
+	TimeZone      string     `json:"time_zone" gorm:"type:varchar(64);not null;default:''"`
+	// モデレーターにより利用停止された日時。利用停止中はログインできない。
+	SuspendedAt *time.Time `json:"suspended_at"`
 }
+
+// ユーザーの権限
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
@@ -0,0 +1,68 @@
+// Package model Domain Model
+package model
+
+import (
+	"time"
+)
+
+// Webhookで通知するイベントの種類。ドメインイベントの種類と同じ
+const (
+	WebhookEventPostCreated     = DomainEventPostCreated
+	WebhookEventCommentCreated  = DomainEventCommentCreated
+	WebhookEventFavoriteCreated = DomainEventFavoriteCreated
+)
+
+// WebhookEventTypes Webhookで通知するイベントの種類一覧
+var WebhookEventTypes = []string{WebhookEventPostCreated, WebhookEventCommentCreated, WebhookEventFavoriteCreated}
+
+// Webhookの配信状況
+const (
+	// WebhookDeliveryStatusPending 配信待ち(再送待ちを含む)
+	WebhookDeliveryStatusPending = "pending"
+	// WebhookDeliveryStatusSucceeded 配信済み
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	// WebhookDeliveryStatusFailed 再送の上限に達し、配信できなかった
+	WebhookDeliveryStatusFailed = "failed"
+)
+
+// Webhook webhooksテーブルに対応する構造体。
+type Webhook struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	URL       string    `json:"url" gorm:"type:varchar(512);not null;default:''"`
+	// 通知するイベントの種類。カンマ区切り
+	EventTypes string `json:"event_types" gorm:"type:varchar(256);not null;default:''"`
+	// 署名の鍵。登録時のみ返す
+	Secret string `json:"secret,omitempty" gorm:"type:varchar(64);not null;default:''"`
+}
+
+// WebhookDelivery webhook_deliveriesテーブルに対応する構造体。Webhookの配信と、最後に送信した結果。
+type WebhookDelivery struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:current_timestamp"`
+	WebhookID int       `json:"webhook_id" gorm:"not null;default:0"`
+	EventType string    `json:"event_type" gorm:"type:varchar(32);not null;default:''"`
+	// 送信する内容(JSON)。再送時も同じ内容を送る
+	Payload string `json:"payload" gorm:"type:text;not null"`
+	Status  string `json:"status" gorm:"type:varchar(16);not null;default:'pending'"`
+	// 送信した回数
+	Attempts int `json:"attempts" gorm:"not null;default:0"`
+	// 最後に送信した際のHTTPステータスコード。応答がなかった場合は0
+	ResponseCode int `json:"response_code" gorm:"not null;default:0"`
+	// 最後に送信した際のエラー
+	Error string `json:"error" gorm:"type:varchar(256);not null;default:''"`
+	// 次に再送する日時。配信待ちの場合のみ設定する
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	DeliveredAt   *time.Time `json:"delivered_at"`
+}
+
+// WebhookPayload Webhookで送信する内容。
+type WebhookPayload struct {
+	// ドメインイベントのID。再送などで同じイベントが複数回届いた場合の重複の判定に使用できる
+	ID        int         `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
@@ -0,0 +1,19 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// AttributionRepository 出典の証拠・異議(attribution_claimsテーブル)へのアクセスを行うインターフェース。
+type AttributionRepository interface {
+	// 出典の証拠・異議登録
+	CreateAttributionClaim(claim *model.AttributionClaim) error
+	// 出典の証拠・異議一覧取得
+	FetchAttributionClaims(postID int) ([]*model.GetAttributionClaimResult, error)
+	// 出典の証拠・異議1件取得。存在しない場合はnilを返す。
+	FetchAttributionClaimByID(id int) (*model.AttributionClaim, error)
+	// 出典の証拠・異議の審査結果登録。verificationStatusが空文字でない場合は投稿の検証状態も更新する。
+	// 既に審査済みの場合は更新せずfalseを返す。
+	RuleAttributionClaim(claim *model.AttributionClaim, verificationStatus string) (bool, error)
+}
@@ -0,0 +1,32 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// CollectionRepository まとめ(collections、collection_itemsテーブル)へのアクセスを行うインターフェース。
+type CollectionRepository interface {
+	// まとめ登録
+	CreateCollection(collection *model.Collection) error
+	// ユーザーのまとめ一覧取得。includePrivateがfalseの場合は公開されたまとめのみ取得する。
+	FetchCollections(userID int, includePrivate bool, limit, page int) (totalCount int, collections []*model.GetCollectionResult, err error)
+	// まとめ1件取得。存在しない場合はnilを返す。
+	FetchCollectionByID(id int) (*model.GetCollectionResult, error)
+	// 投稿を含むまとめ一覧取得。公開されたまとめと、loginUserIDのユーザーのまとめを取得する。
+	FetchCollectionsByPostID(postID, loginUserID int) ([]*model.GetCollectionResult, error)
+	// まとめ更新
+	UpdateCollection(collection *model.Collection) error
+	// まとめ削除
+	DeleteCollection(id int) error
+	// まとめの投稿一覧取得。並び順に返す。
+	FetchCollectionPosts(collectionID, loginUserID, limit, page int) (totalCount int, posts []*model.GetPostResult, err error)
+	// まとめの投稿ID一覧取得。並び順に返す。
+	FetchCollectionPostIDs(collectionID int) ([]int, error)
+	// まとめへの投稿追加。末尾に追加する。追加済みの場合は何もしない。
+	AddCollectionItem(collectionID, postID int) error
+	// まとめからの投稿削除
+	DeleteCollectionItem(collectionID, postID int) error
+	// まとめの投稿の並び替え。postIDsの順に並び順を更新する。
+	ReorderCollectionItems(collectionID int, postIDs []int) error
+}
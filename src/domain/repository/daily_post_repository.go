@@ -0,0 +1,18 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// DailyPostRepository 今日の言葉(daily_postsテーブル)へのアクセスを行うインターフェース。
+type DailyPostRepository interface {
+	// 今日の言葉取得。存在しない場合はnilを返す。
+	FetchDailyPost(date string) (*model.DailyPost, error)
+	// 今日の言葉の候補一覧取得。since以降の日付で選ばれた投稿は除く。sinceが空文字の場合は除外しない。
+	FetchDailyPostCandidates(since string) ([]*model.DailyPostCandidate, error)
+	// 今日の言葉登録
+	CreateDailyPost(dailyPost *model.DailyPost) error
+	// 今日の言葉の登録または更新
+	SaveDailyPost(dailyPost *model.DailyPost) error
+}
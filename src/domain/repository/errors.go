@@ -0,0 +1,7 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import "errors"
+
+// ErrNotFound 取得対象が存在しない場合のエラー
+var ErrNotFound = errors.New("record not found")
@@ -0,0 +1,38 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"io"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// ExportJobRepository 個人データのエクスポートジョブ(export_jobsテーブル)へのアクセスを行うインターフェース。
+type ExportJobRepository interface {
+	// ジョブ登録。同じユーザーのsince以降の失敗していないジョブがある場合は登録せず、そのうち最新のジョブを返す
+	CreateExportJob(job *model.ExportJob, since time.Time) (latest *model.ExportJob, err error)
+	// ジョブ取得
+	FetchExportJob(id int) (*model.ExportJob, error)
+	// ダウンロードURLのトークンが一致する完了済みのジョブ取得
+	FetchCompletedExportJobByToken(token string) (*model.ExportJob, error)
+	// ジョブの状態更新
+	UpdateExportJob(job *model.ExportJob) error
+	// ダウンロード期限がbefore以前のジョブ一覧取得。完了していないジョブは登録日時がcreatedBefore以前のものを返す
+	FetchExpiredExportJobs(before, createdBefore time.Time) ([]*model.ExportJob, error)
+	// ジョブ削除
+	DeleteExportJob(id int) error
+	// 実行待ち、実行中のまま最後に状態を更新した日時がheartbeatBefore以前のジョブを失敗にする。失敗にした件数を返す
+	FailStaleExportJobs(heartbeatBefore, finishedAt time.Time, message string) (int, error)
+}
+
+// ExportArchiveStorage エクスポートしたファイルを保存するインターフェース。
+// 1台構成ではローカルのディレクトリに保存し、複数台構成では共有のストレージやオブジェクトストレージを使用する実装に差し替える。
+type ExportArchiveStorage interface {
+	// ファイルの保存
+	Save(key string, archive []byte) error
+	// ファイルの読み込み。存在しない場合はnilを返す。呼び出し元で閉じる
+	Open(key string) (io.ReadCloser, error)
+	// ファイルの削除。存在しない場合は何もしない
+	Delete(key string) error
+}
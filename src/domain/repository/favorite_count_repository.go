@@ -0,0 +1,8 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+// FavoriteCountRepository 投稿のお気に入り数の取得を行うインターフェース。
+type FavoriteCountRepository interface {
+	// 投稿のお気に入り数取得
+	CountFavorites(postID int) (int, error)
+}
@@ -0,0 +1,15 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"time"
+)
+
+// FeedRepository ホームのフィードに表示する投稿の取得を行うインターフェース。
+type FeedRepository interface {
+	// フォロー中のユーザーの投稿のID一覧取得。新しい順に返す。beforeIDが0でない場合はそれより前の投稿のみ取得する。
+	FetchFollowingPostIDs(userID, beforeID, limit int) ([]int, error)
+	// since以降に投稿された、お気に入り数がminFavoriteCount以上の投稿のID一覧取得。新しい順に返す。
+	// excludeUserIDが0でない場合はそのユーザーの投稿を除く。beforeIDが0でない場合はそれより前の投稿のみ取得する。
+	FetchPopularPostIDs(since time.Time, minFavoriteCount, excludeUserID, beforeID, limit int) ([]int, error)
+}
@@ -0,0 +1,22 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// ImportJobRepository 投稿の一括登録ジョブ(import_jobsテーブル)へのアクセスを行うインターフェース。
+type ImportJobRepository interface {
+	// ジョブ登録
+	CreateImportJob(job *model.ImportJob) error
+	// ジョブの進捗、結果の更新
+	UpdateImportJob(job *model.ImportJob) error
+	// ジョブ取得
+	FetchImportJob(id int) (*model.ImportJob, error)
+	// 登録日時がbefore以前のジョブ削除
+	DeleteImportJobs(before time.Time) error
+	// 実行待ち、実行中のまま最後に進捗を反映した日時がheartbeatBefore以前のジョブを失敗にする。失敗にした件数を返す
+	FailStaleImportJobs(heartbeatBefore, finishedAt time.Time, message string) (int, error)
+}
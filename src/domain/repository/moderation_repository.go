@@ -0,0 +1,14 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// ModerationRepository モデレーター向けに非表示のものも含めた投稿、コメントの取得を行うインターフェース。
+type ModerationRepository interface {
+	// 非表示の投稿も含めた投稿1件取得(モデレーター用)。存在しない場合はnilを返す。
+	FetchPostForModeration(id int) (*model.Post, error)
+	// コメント1件取得。非表示のコメントも含める。存在しない場合はnilを返す。
+	FetchCommentByID(id int) (*model.Comment, error)
+}
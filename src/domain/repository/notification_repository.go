@@ -0,0 +1,26 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// NotificationRepository 通知(notificationsテーブル)と通知の受け取り設定(notification_settingsテーブル)へのアクセスを行うインターフェース。
+type NotificationRepository interface {
+	// 通知登録。同じユーザー、種類、投稿への未読の通知がある場合はまとめる
+	SaveNotification(notification *model.Notification) error
+	// 通知一覧取得。更新日時の新しい順に返す
+	FetchNotifications(userID int, unreadOnly bool, limit, page int) (totalCount int, notifications []*model.GetNotificationResult, err error)
+	// 未読の通知の件数取得
+	CountUnreadNotifications(userID int) (int, error)
+	// 通知を既読にする。ユーザーの通知が存在しない場合はfalseを返す
+	MarkNotificationRead(id, userID int, readAt time.Time) (bool, error)
+	// ユーザーの未読の通知をすべて既読にする
+	MarkAllNotificationsRead(userID int, readAt time.Time) error
+	// 通知の受け取り設定取得
+	FetchNotificationSettings(userID int) ([]*model.NotificationSetting, error)
+	// 通知の受け取り設定の登録・更新
+	SaveNotificationSetting(setting *model.NotificationSetting) error
+}
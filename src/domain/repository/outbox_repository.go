@@ -0,0 +1,20 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// OutboxRepository 送信箱(outbox_eventsテーブル)へのアクセスを行うインターフェース。
+// イベントの保存は各リポジトリが登録・更新と同じトランザクションで行う。
+type OutboxRepository interface {
+	// 処理する日時がbefore以前の未処理のイベント一覧取得
+	FetchDueOutboxEvents(before time.Time, limit int) ([]*model.OutboxEvent, error)
+	// イベントを処理中にする。処理する日時をuntilに延ばし、他のAPIサーバーが同時に処理しないようにする。
+	// 他のAPIサーバーが処理中にした場合、処理済みの場合はfalseを返す
+	ClaimOutboxEvent(id int, before, until time.Time) (bool, error)
+	// イベントの処理結果更新
+	UpdateOutboxEvent(event *model.OutboxEvent) error
+}
@@ -5,30 +5,55 @@ import (
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
 )
 
-// PostRepository postsや関連テーブルへのアクセスを行うインターフェース。
+// PostRepository 投稿(postsテーブル)とコメント、お気に入りへのアクセスを行うインターフェース。
+// eventsを受け取るメソッドは、ドメインイベントを同じトランザクションで送信箱に保存する。発行しない場合はnilを指定する。
 type PostRepository interface {
 	// 投稿登録
-	Create(post *model.Post) error
+	Create(post *model.Post, events []*model.DomainEvent) error
+	// 投稿の一括登録。1件でも失敗した場合は全件登録しない。
+	CreatePosts(posts []*model.Post, events []*model.DomainEvent) error
 	// 投稿一覧取得
-	Fetch(limit, page int, keyword string, postUserID, loginUserID int) (totalCount int, posts []*model.GetPostResult, err error)
-	// 投稿詳細取得
+	Fetch(limit, page int, keyword string, postUserID, loginUserID int, verifiedOnly bool, language string) (totalCount int, posts []*model.GetPostResult, err error)
+	// 投稿詳細取得。存在しない投稿、非表示の投稿の場合はErrNotFoundを返す
 	FetchByID(id, loginUserID int) (*model.GetPostResult, error)
 	// 投稿更新
-	Update(post *model.Post) error
+	Update(post *model.Post, events []*model.DomainEvent) error
 	// 投稿削除
-	Delete(id int) error
+	Delete(id int, events []*model.DomainEvent) error
+	// 発言者が一致する投稿一覧取得(重複検出用)
+	FetchBySpeaker(normalizedSpeaker, speaker string) ([]*model.Post, error)
+	// 全投稿のタイトル、発言者取得(重複検出用)
+	FetchAllTitles() ([]*model.Post, error)
+	// 項目(発言者、タイトル)の値ごとの使用回数取得
+	FetchFieldCounts(field string) ([]*model.AutocompleteCandidate, error)
+	// 条件に一致する投稿のIDの最小値、最大値取得(ランダム取得用)。一致する投稿がない場合は0を返す。
+	FetchRandomCandidateIDRange(condition *model.RandomPostCondition) (minID, maxID int, err error)
+	// 条件に一致し、IDがfromID以上でexcludeIDsに含まれない投稿のうち、IDが最小のもの取得(ランダム取得用)。ない場合は0を返す。
+	FetchRandomCandidateID(condition *model.RandomPostCondition, fromID int, excludeIDs []int) (int, error)
+	// 投稿ID指定の一覧取得。ids順に返す。
+	FetchByIDs(ids []int, loginUserID int) ([]*model.GetPostResult, error)
+	// ユーザーの全投稿取得(個人データのエクスポート用)
+	FetchPostsByUserID(userID int) ([]*model.Post, error)
 
 	// コメント登録
-	CreateComment(comment *model.Comment) error
+	CreateComment(comment *model.Comment, events []*model.DomainEvent) error
 	// コメント一覧取得
 	FetchComments(postID, limit, page int) (totalCount int, comments []*model.GetCommentResult, err error)
 	// 投稿削除
-	DeleteComment(id int) error
+	DeleteComment(id int, events []*model.DomainEvent) error
+	// ユーザーの全コメント取得(個人データのエクスポート用)
+	FetchCommentsByUserID(userID int) ([]*model.Comment, error)
 
 	// お気に入り登録
-	CreateFavorite(favorite *model.Favorite) error
-	// お気に入り一覧取得
-	FetchFavorites(userID, limit, page int) (totalCount int, posts []*model.GetPostResult, err error)
+	CreateFavorite(favorite *model.Favorite, events []*model.DomainEvent) error
+	// お気に入り一覧取得。includeNoteがtrueの場合はメモ、理由の分類も取得し、検索対象とする。
+	FetchFavorites(userID, limit, page int, keyword, tag string, includeNote bool) (totalCount int, posts []*model.GetPostResult, err error)
+	// お気に入り1件取得。存在しない場合はnilを返す。
+	FetchFavorite(userID, postID int) (*model.Favorite, error)
+	// お気に入りのメモ、理由の分類の更新
+	UpdateFavoriteNote(favorite *model.Favorite) error
 	// お気に入り削除
-	DeleteFavorite(userID, postID int) error
+	DeleteFavorite(userID, postID int, events []*model.DomainEvent) error
+	// ユーザーの全お気に入り取得(個人データのエクスポート用)
+	FetchFavoritesByUserID(userID int) ([]*model.Favorite, error)
 }
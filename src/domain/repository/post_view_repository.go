@@ -0,0 +1,22 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// PostViewRepository 投稿の閲覧(post_viewsテーブル)、閲覧数(post_daily_viewsテーブル)と閲覧済みの投稿(seen_postsテーブル)へのアクセスを行うインターフェース。
+type PostViewRepository interface {
+	// 閲覧の記録。同じ投稿、閲覧者、期間の閲覧が記録済みでないものだけ、日付ごとの閲覧数に加算し、ログインユーザーの閲覧済みの投稿として記録する。
+	// 全て同一トランザクションで行うため、失敗した場合は同じ閲覧をそのまま記録し直してよい。
+	SavePostViews(views []*model.PostView) error
+	// 期間の開始日時がbeforeより前の閲覧の記録を削除する
+	DeletePostViews(before time.Time) error
+	// ログインユーザーが閲覧した投稿の記録。閲覧済みの場合は閲覧日時を更新する。
+	SaveSeenPosts(userID int, postIDs []int, seenAt time.Time) error
+	// ユーザーの投稿の日付ごとの閲覧数、お気に入り数、コメント数取得。postIDが0の場合は全ての投稿の合計を返す。
+	// 期間(fromからtoまで、toを含む)内の件数がある日付のみ、日付の古い順に返す。
+	FetchPostDailyStats(userID, postID int, from, to string) ([]*model.PostDailyStat, error)
+}
@@ -0,0 +1,16 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// ProhibitedWordRepository 禁止語(prohibited_wordsテーブル)へのアクセスを行うインターフェース。
+type ProhibitedWordRepository interface {
+	// 禁止語一覧取得
+	FetchProhibitedWords() ([]*model.ProhibitedWord, error)
+	// 禁止語登録
+	CreateProhibitedWord(word *model.ProhibitedWord) error
+	// 禁止語削除
+	DeleteProhibitedWord(id int) error
+}
@@ -0,0 +1,18 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// ReactionRepository リアクション(reactionsテーブル)へのアクセスを行うインターフェース。
+type ReactionRepository interface {
+	// リアクション登録。既に同じ種類でリアクションしている場合は何もしない。
+	CreateReaction(reaction *model.Reaction) error
+	// リアクション削除
+	DeleteReaction(userID, postID int, reactionType string) error
+	// postIDsのいずれかの投稿のリアクションの種類ごとの件数取得
+	FetchReactionCounts(postIDs []int, loginUserID int) ([]*model.PostReactionCount, error)
+	// 投稿にリアクションしたユーザー一覧取得。新しい順に返す。種類を限定しない場合はreactionTypeに空文字を指定する。
+	FetchReactions(postID int, reactionType string, limit, page int) (totalCount int, reactions []*model.GetReactionResult, err error)
+}
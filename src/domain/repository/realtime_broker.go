@@ -0,0 +1,15 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// RealtimeBroker リアルタイムに配信するイベントを、すべてのAPIサーバーに受け渡すインターフェース。
+// 1台構成ではメモリ内で受け渡し、複数台構成ではメッセージブローカーを使用する実装に差し替える。
+type RealtimeBroker interface {
+	// イベントの発行。購読しているすべてのAPIサーバーに届ける
+	Publish(event *model.RealtimeEvent) error
+	// イベントの購読。いずれかのAPIサーバーで発行されたイベントごとにreceiveを呼び出す
+	Subscribe(receive func(event *model.RealtimeEvent))
+}
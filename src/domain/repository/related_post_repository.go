@@ -0,0 +1,18 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// RelatedPostRepository 関連する投稿(related_postsテーブル)へのアクセスを行うインターフェース。
+type RelatedPostRepository interface {
+	// 関連する投稿の計算用の全投稿取得。非表示の投稿は含めない。
+	FetchRelatedPostSources() ([]*model.Post, error)
+	// 関連する投稿の計算用の全お気に入り取得
+	FetchAllFavorites() ([]*model.Favorite, error)
+	// 関連する投稿の置き換え。登録済みの関連する投稿を全て削除してから登録する。
+	ReplaceRelatedPosts(relatedPosts []*model.RelatedPost) error
+	// 関連する投稿のID一覧取得。関連度の高い順に返す。excludeUserIDが0でない場合はそのユーザーの投稿を除く。
+	FetchRelatedPostIDs(postID, excludeUserID, limit int) ([]int, error)
+}
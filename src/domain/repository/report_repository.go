@@ -0,0 +1,22 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// ReportRepository 通報(reportsテーブル)とモデレーターの対応(moderation_actionsテーブル)へのアクセスを行うインターフェース。
+type ReportRepository interface {
+	// 通報登録。同じユーザーの未対応の通報がある場合は登録せずfalseを返す。対応済みの通報がある場合は未対応に戻す。
+	SaveReport(report *model.Report) (saved bool, err error)
+	// 対象の未対応の通報件数取得
+	CountOpenReports(targetType string, targetID int) (int, error)
+	// 未対応の通報の対象一覧取得。通報件数の多い順に返す。対象の種類を限定しない場合はtargetTypeに空文字を指定する。
+	FetchReportQueue(targetType string, limit, page int) (totalCount int, items []*model.ReportQueueItem, err error)
+	// 対象の通報一覧取得。新しい順に返す。
+	FetchReports(targetType string, targetID int) ([]*model.GetReportResult, error)
+	// モデレーターの対応登録。effectの変更を対象に適用し、resolveReportsがtrueの場合は対象の未対応の通報を対応済みにする。
+	CreateModerationAction(action *model.ModerationAction, effect *model.ModerationEffect, resolveReports bool) error
+	// 対象のモデレーターの対応一覧取得。新しい順に返す。
+	FetchModerationActions(targetType string, targetID int) ([]*model.ModerationAction, error)
+}
@@ -0,0 +1,14 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// StreamTicketRepository 使用済みのリアルタイム配信のチケット(used_stream_ticketsテーブル)へのアクセスを行うインターフェース。
+type StreamTicketRepository interface {
+	// 使用済みのチケットの登録。既に使用済みの場合は登録せずfalseを返す。有効期限がexpiredBeforeより前の記録は削除する。
+	SaveUsedStreamTicket(ticket *model.UsedStreamTicket, expiredBefore time.Time) (saved bool, err error)
+}
@@ -0,0 +1,16 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// TranslationRepository 投稿の翻訳(post_translationsテーブル)へのアクセスを行うインターフェース。
+type TranslationRepository interface {
+	// 翻訳の登録または更新
+	SaveTranslation(translation *model.PostTranslation) error
+	// 翻訳一覧取得。postIDsのいずれかの投稿の翻訳を返す。
+	FetchTranslations(postIDs []int) ([]*model.GetPostTranslationResult, error)
+	// 翻訳削除
+	DeleteTranslation(postID int, language string) error
+}
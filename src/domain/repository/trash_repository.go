@@ -0,0 +1,31 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// TrashRepository 削除済みの投稿、コメントの取得、復元、完全な削除を行うインターフェース。
+// eventsを受け取るメソッドは、ドメインイベントを同じトランザクションで送信箱に保存する。発行しない場合はnilを指定する。
+type TrashRepository interface {
+	// ユーザーの削除済み投稿一覧取得。since以降に削除されたものを削除日時の新しい順に返す。
+	FetchDeletedPosts(userID int, since time.Time, limit, page int) (totalCount int, posts []*model.Post, err error)
+	// ユーザーの削除済みコメント一覧取得。since以降に削除されたものを削除日時の新しい順に返す。
+	FetchDeletedComments(userID int, since time.Time, limit, page int) (totalCount int, comments []*model.Comment, err error)
+	// 削除済み投稿1件取得。削除されていない場合、存在しない場合はnilを返す。
+	FetchDeletedPostByID(id int) (*model.Post, error)
+	// 削除済みコメント1件取得。削除されていない場合、存在しない場合はnilを返す。
+	FetchDeletedCommentByID(id int) (*model.Comment, error)
+	// 削除済み投稿の復元
+	RestorePost(id int, events []*model.DomainEvent) error
+	// 削除済みコメントの復元
+	RestoreComment(id int, events []*model.DomainEvent) error
+	// before以前に削除された投稿を、お気に入りなどの関連データとともに完全に削除する。削除した件数を返す。
+	// 削除した投稿ごとにpost.purgedのドメインイベントを同じトランザクションで送信箱に保存する。
+	PurgePosts(before time.Time) (int, error)
+	// before以前に削除されたコメントを完全に削除する。削除した件数を返す。
+	// 削除したコメントごとにcomment.purgedのドメインイベントを同じトランザクションで送信箱に保存する。
+	PurgeComments(before time.Time) (int, error)
+}
@@ -2,14 +2,32 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
 )
 
 // UserRepository usersテーブルへのアクセスを行うインターフェース。
+// eventsを受け取るメソッドは、ドメインイベントを同じトランザクションで送信箱に保存する。発行しない場合はnilを指定する。
 type UserRepository interface {
-	Create(user *model.User) error
+	Create(user *model.User, events []*model.DomainEvent) error
 	FetchByEmail(email string) (*model.User, error)
+	// 存在しないユーザー、退会済みのユーザーの場合はErrNotFoundを返す
 	FetchByID(id int) (*model.User, error)
-	Update(user *model.User) error
-	Delete(id int) error
+	Update(user *model.User, events []*model.DomainEvent) error
+	Delete(id int, events []*model.DomainEvent) error
+	// before以前に削除されたユーザー一覧取得
+	FetchDeletedUsers(before time.Time) ([]*model.User, error)
+	// 削除済みユーザーを、投稿、コメント、お気に入りなどのユーザーのデータとともに完全に削除する
+	Purge(id int, events []*model.DomainEvent) error
+	// フォロー。既にフォローしている場合は何もせず、イベントも保存しない
+	Follow(follow *model.Follow, events []*model.DomainEvent) error
+	// フォロー解除
+	Unfollow(followerID, followeeID int) error
+	// フォロワー一覧取得
+	FetchFollowers(userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error)
+	// フォロー中のユーザー一覧取得
+	FetchFollowing(userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error)
+	// フォロワー数、フォロー数取得
+	FetchFollowCounts(userID, loginUserID int) (*model.FollowCounts, error)
 }
@@ -0,0 +1,34 @@
+// Package repository Domain Service層のリポジトリ
+package repository
+
+import (
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// WebhookRepository Webhook(webhooksテーブル)と配信(webhook_deliveriesテーブル)へのアクセスを行うインターフェース。
+type WebhookRepository interface {
+	// Webhook一覧取得
+	FetchWebhooks() ([]*model.Webhook, error)
+	// Webhook取得
+	FetchWebhook(id int) (*model.Webhook, error)
+	// イベントの種類を通知するWebhook一覧取得
+	FetchWebhooksByEventType(eventType string) ([]*model.Webhook, error)
+	// Webhook登録
+	CreateWebhook(webhook *model.Webhook) error
+	// Webhook削除。配信履歴も削除する
+	DeleteWebhook(id int) error
+	// Webhookの配信登録
+	CreateWebhookDelivery(delivery *model.WebhookDelivery) error
+	// Webhookの配信の送信前の確保。nowの時点で再送する日時を過ぎた配信待ちの場合のみ、再送する日時をleaseUntilに延ばしてtrueを返す。
+	ClaimWebhookDelivery(id int, now, leaseUntil time.Time) (claimed bool, err error)
+	// Webhookの配信の送信結果更新
+	UpdateWebhookDelivery(delivery *model.WebhookDelivery) error
+	// Webhookの配信履歴取得
+	FetchWebhookDeliveries(webhookID, limit, page int) (totalCount int, deliveries []*model.WebhookDelivery, err error)
+	// Webhookの配信取得
+	FetchWebhookDelivery(id int) (*model.WebhookDelivery, error)
+	// 再送する日時を過ぎた配信待ちのWebhookの配信一覧取得
+	FetchDueWebhookDeliveries(before time.Time, limit int) ([]*model.WebhookDelivery, error)
+}
@@ -0,0 +1,87 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// attributionRepository 構造体
+type attributionRepository struct {
+}
+
+// NewAttributionRepository AttributionRepositoryを生成する。
+func NewAttributionRepository() repository.AttributionRepository {
+	return &attributionRepository{}
+}
+
+// CreateAttributionClaim 出典の証拠・異議登録
+func (repository *attributionRepository) CreateAttributionClaim(claim *model.AttributionClaim) error {
+	db := conf.DBConnection()
+
+	return db.Create(claim).Error
+}
+
+// FetchAttributionClaims 出典の証拠・異議一覧取得。古い順に返す。
+func (repository *attributionRepository) FetchAttributionClaims(postID int) (claims []*model.GetAttributionClaimResult, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Table("attribution_claims").
+		Select("attribution_claims.*, users.name AS user_name, users.image_file_path AS user_image_file_path").
+		Joins("JOIN users ON users.id = attribution_claims.user_id AND users.deleted_at IS NULL").
+		Where("attribution_claims.post_id = ?", postID).
+		Order("attribution_claims.id ASC").
+		Find(&claims).Error; err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// FetchAttributionClaimByID 出典の証拠・異議1件取得。存在しない場合はnilを返す。
+func (repository *attributionRepository) FetchAttributionClaimByID(id int) (*model.AttributionClaim, error) {
+	db := conf.DBConnection()
+
+	claim := model.AttributionClaim{ID: id}
+	if err := db.First(&claim).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &claim, nil
+}
+
+// RuleAttributionClaim 出典の証拠・異議の審査結果登録。
+// 未審査の場合のみ更新し、他のモデレーターが先に審査していた場合はfalseを返す。
+// verificationStatusが空文字でない場合は、同一トランザクションで投稿の検証状態も更新する。
+func (repository *attributionRepository) RuleAttributionClaim(claim *model.AttributionClaim, verificationStatus string) (ruled bool, err error) {
+	db := conf.DBConnection()
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.AttributionClaim{}).
+			Where("id = ? AND status = ?", claim.ID, model.ClaimStatusPending).
+			Updates(map[string]interface{}{
+				"status":         claim.Status,
+				"ruled_by":       claim.RuledBy,
+				"ruling_comment": claim.RulingComment,
+				"ruled_at":       claim.RuledAt,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		ruled = true
+
+		if verificationStatus == "" {
+			return nil
+		}
+		return tx.Model(&model.Post{ID: claim.PostID}).Update("verification_status", verificationStatus).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return ruled, nil
+}
@@ -0,0 +1,68 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// 出典の証拠・異議の審査結果登録
+func TestAttributionRepository_RuleAttributionClaim(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	postForInput := makePost(userForInput.ID)
+	db.Create(&postForInput)
+	db.First(&postForInput)
+
+	claim := &model.AttributionClaim{
+		PostID:         postForInput.ID,
+		UserID:         userForInput.ID,
+		ClaimType:      model.ClaimTypeEvidence,
+		ProposedStatus: model.VerificationStatusVerified,
+		Body:           "body",
+		Status:         model.ClaimStatusPending,
+	}
+	db.Create(claim)
+
+	repository := &attributionRepository{}
+	now := time.Now()
+	claim.Status = model.ClaimStatusAccepted
+	claim.RuledBy = userForInput.ID
+	claim.RuledAt = &now
+
+	// 2. Exercise
+	ruled, err := repository.RuleAttributionClaim(claim, model.VerificationStatusVerified)
+	claim.Status = model.ClaimStatusRejected
+	ruledAgain, againErr := repository.RuleAttributionClaim(claim, model.VerificationStatusDisputed)
+	notFound, notFoundErr := repository.FetchAttributionClaimByID(claim.ID + 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.True(t, ruled)
+	// 審査済みの場合は更新されない
+	assert.NoError(t, againErr)
+	assert.False(t, ruledAgain)
+	assert.NoError(t, notFoundErr)
+	assert.Nil(t, notFound)
+
+	actualClaim, err := repository.FetchAttributionClaimByID(claim.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ClaimStatusAccepted, actualClaim.Status)
+	assert.Equal(t, userForInput.ID, actualClaim.RuledBy)
+
+	post := model.Post{}
+	db.First(&post, postForInput.ID)
+	assert.Equal(t, model.VerificationStatusVerified, post.VerificationStatus)
+
+	// 4. Teardown
+	teardown(db)
+}
@@ -0,0 +1,211 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// collectionRepository 構造体
+type collectionRepository struct {
+}
+
+// NewCollectionRepository CollectionRepositoryを生成する。
+func NewCollectionRepository() repository.CollectionRepository {
+	return &collectionRepository{}
+}
+
+// collectionSelect まとめ取得時の項目
+const collectionSelect = `collections.*,
+	users.name AS user_name,
+	users.image_file_path AS user_image_file_path,
+	(SELECT count(*) FROM collection_items AS ci JOIN posts AS p ON p.id = ci.post_id AND p.deleted_at IS NULL AND p.is_hidden = false
+		WHERE ci.collection_id = collections.id) AS post_count,
+	(SELECT ci.post_id FROM collection_items AS ci JOIN posts AS p ON p.id = ci.post_id AND p.deleted_at IS NULL AND p.is_hidden = false
+		WHERE ci.collection_id = collections.id ORDER BY ci.position ASC, ci.id ASC LIMIT 1) AS first_post_id
+`
+
+// CreateCollection まとめ登録
+func (repository *collectionRepository) CreateCollection(collection *model.Collection) error {
+	db := conf.DBConnection()
+
+	return db.Create(collection).Error
+}
+
+// FetchCollections ユーザーのまとめ一覧取得。新しい順に返す。
+// includePrivateがfalseの場合は公開されたまとめのみ取得する。
+func (repository *collectionRepository) FetchCollections(userID int, includePrivate bool, limit, page int) (totalCount int, collections []*model.GetCollectionResult, err error) {
+	db := conf.DBConnection()
+
+	db = db.Table("collections").
+		Joins("JOIN users ON users.id = collections.user_id AND users.deleted_at IS NULL").
+		Where("collections.user_id = ? AND collections.deleted_at IS NULL", userID)
+	if !includePrivate {
+		db = db.Where("collections.is_public = ?", true)
+	}
+
+	if err = db.Count(&totalCount).Error; err != nil {
+		return 0, nil, err
+	}
+
+	offset := limit * (page - 1)
+	if err = db.Select(collectionSelect).
+		Order("collections.id DESC").Limit(limit).Offset(offset).
+		Find(&collections).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, collections, nil
+}
+
+// FetchCollectionByID まとめ1件取得。存在しない場合はnilを返す。
+func (repository *collectionRepository) FetchCollectionByID(id int) (*model.GetCollectionResult, error) {
+	db := conf.DBConnection()
+
+	collection := model.GetCollectionResult{}
+	err := db.Table("collections").
+		Select(collectionSelect).
+		Joins("JOIN users ON users.id = collections.user_id AND users.deleted_at IS NULL").
+		Where("collections.id = ? AND collections.deleted_at IS NULL", id).
+		First(&collection).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+// FetchCollectionsByPostID 投稿を含むまとめ一覧取得。
+// 公開されたまとめと、loginUserIDのユーザーのまとめを新しい順に返す。
+func (repository *collectionRepository) FetchCollectionsByPostID(postID, loginUserID int) (collections []*model.GetCollectionResult, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Table("collections").
+		Select(collectionSelect).
+		Joins(`JOIN collection_items ON collection_items.collection_id = collections.id
+			JOIN users ON users.id = collections.user_id AND users.deleted_at IS NULL`).
+		Where("collection_items.post_id = ? AND collections.deleted_at IS NULL", postID).
+		Where("collections.is_public = ? OR collections.user_id = ?", true, loginUserID).
+		Order("collections.id DESC").
+		Find(&collections).Error; err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// UpdateCollection まとめ更新
+func (repository *collectionRepository) UpdateCollection(collection *model.Collection) error {
+	db := conf.DBConnection()
+
+	// 非公開への変更や表紙の解除を反映するため、ゼロ値も更新する
+	return db.Model(&model.Collection{ID: collection.ID}).Updates(map[string]interface{}{
+		"name":          collection.Name,
+		"description":   collection.Description,
+		"cover_post_id": collection.CoverPostID,
+		"is_public":     collection.IsPublic,
+	}).Error
+}
+
+// DeleteCollection まとめ削除
+func (repository *collectionRepository) DeleteCollection(id int) error {
+	db := conf.DBConnection()
+
+	collection := model.Collection{ID: id}
+	return db.Delete(&collection).Error
+}
+
+// FetchCollectionPosts まとめの投稿一覧取得。並び順に返す。
+func (repository *collectionRepository) FetchCollectionPosts(collectionID, loginUserID, limit, page int) (totalCount int, posts []*model.GetPostResult, err error) {
+	db := conf.DBConnection()
+
+	db = db.Table("collection_items").
+		Joins(fmt.Sprintf(`JOIN posts ON posts.id = collection_items.post_id AND posts.deleted_at IS NULL AND posts.is_hidden = false
+			JOIN users ON users.id = posts.user_id AND users.deleted_at IS NULL
+			LEFT JOIN favorites ON favorites.post_id = posts.id AND favorites.user_id = %d`, loginUserID)).
+		Where("collection_items.collection_id = ?", collectionID)
+
+	if err = db.Count(&totalCount).Error; err != nil {
+		return 0, nil, err
+	}
+
+	offset := limit * (page - 1)
+	if err = db.Select(`posts.*,
+			users.name AS user_name,
+			users.image_file_path AS user_image_file_path,
+			(SELECT count(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL AND comments.is_hidden = false) AS comment_count,
+			(CASE WHEN favorites.id IS NULL THEN false ELSE true END) AS is_favorite,
+			(SELECT count(*) FROM favorites AS f WHERE f.post_id = posts.id) AS favorite_count,
+			(SELECT COALESCE(SUM(v.views), 0) FROM post_daily_views AS v WHERE v.post_id = posts.id) AS view_count
+		`).
+		Order("collection_items.position ASC, collection_items.id ASC").Limit(limit).Offset(offset).
+		Find(&posts).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, posts, nil
+}
+
+// FetchCollectionPostIDs まとめの投稿ID一覧取得。並び順に返す。
+func (repository *collectionRepository) FetchCollectionPostIDs(collectionID int) (postIDs []int, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Model(&model.CollectionItem{}).
+		Where("collection_id = ?", collectionID).
+		Order("position ASC, id ASC").
+		Pluck("post_id", &postIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return postIDs, nil
+}
+
+// AddCollectionItem まとめへの投稿追加。末尾に追加する。追加済みの場合は何もしない。
+func (repository *collectionRepository) AddCollectionItem(collectionID, postID int) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		position := 0
+		if err := tx.Model(&model.CollectionItem{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("collection_id = ?", collectionID).
+			Row().Scan(&position); err != nil {
+			return err
+		}
+
+		item := model.CollectionItem{}
+		return tx.Where(model.CollectionItem{CollectionID: collectionID, PostID: postID}).
+			Attrs(model.CollectionItem{Position: position + 1}).
+			FirstOrCreate(&item).Error
+	})
+}
+
+// DeleteCollectionItem まとめからの投稿削除
+func (repository *collectionRepository) DeleteCollectionItem(collectionID, postID int) error {
+	db := conf.DBConnection()
+
+	return db.Where("collection_id = ? AND post_id = ?", collectionID, postID).Delete(&model.CollectionItem{}).Error
+}
+
+// ReorderCollectionItems まとめの投稿の並び替え。postIDsの順に並び順を更新する。
+func (repository *collectionRepository) ReorderCollectionItems(collectionID int, postIDs []int) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i, postID := range postIDs {
+			if err := tx.Model(&model.CollectionItem{}).
+				Where("collection_id = ? AND post_id = ?", collectionID, postID).
+				Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
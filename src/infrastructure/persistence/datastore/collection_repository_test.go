@@ -0,0 +1,131 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// まとめ一覧取得
+func TestCollectionRepository_FetchCollections(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	postForInput := makePost(userForInput.ID)
+	db.Create(postForInput)
+
+	publicCollection := &model.Collection{UserID: userForInput.ID, Name: "public", IsPublic: true}
+	db.Create(publicCollection)
+	privateCollection := &model.Collection{UserID: userForInput.ID, Name: "private"}
+	db.Create(privateCollection)
+
+	repository := &collectionRepository{}
+	assert.NoError(t, repository.AddCollectionItem(publicCollection.ID, postForInput.ID))
+
+	// 2. Exercise
+	publicCount, publicCollections, err := repository.FetchCollections(userForInput.ID, false, 10, 1)
+	assert.NoError(t, err)
+	allCount, _, err := repository.FetchCollections(userForInput.ID, true, 10, 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 1, publicCount)
+	assert.Equal(t, 2, allCount)
+	assert.Equal(t, publicCollection.ID, publicCollections[0].ID)
+	assert.Equal(t, 1, publicCollections[0].PostCount)
+	assert.Equal(t, postForInput.ID, publicCollections[0].FirstPostID)
+	assert.Equal(t, userForInput.Name, publicCollections[0].UserName)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// まとめの投稿追加・並び替え
+func TestCollectionRepository_ReorderCollectionItems(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	postForInput := makePost(userForInput.ID)
+	db.Create(postForInput)
+	postForInput2 := makePost(userForInput.ID)
+	db.Create(postForInput2)
+
+	collection := &model.Collection{UserID: userForInput.ID, Name: "name"}
+	db.Create(collection)
+
+	repository := &collectionRepository{}
+	assert.NoError(t, repository.AddCollectionItem(collection.ID, postForInput.ID))
+	assert.NoError(t, repository.AddCollectionItem(collection.ID, postForInput2.ID))
+	// 追加済みの投稿は何もしない
+	assert.NoError(t, repository.AddCollectionItem(collection.ID, postForInput.ID))
+
+	// 2. Exercise
+	err := repository.ReorderCollectionItems(collection.ID, []int{postForInput2.ID, postForInput.ID})
+
+	// 3. Verify
+	assert.NoError(t, err)
+	postIDs, err := repository.FetchCollectionPostIDs(collection.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{postForInput2.ID, postForInput.ID}, postIDs)
+
+	totalCount, posts, err := repository.FetchCollectionPosts(collection.ID, userForInput.ID, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, totalCount)
+	assert.Equal(t, postForInput.ID, posts[0].ID)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// 投稿を含むまとめ一覧取得
+func TestCollectionRepository_FetchCollectionsByPostID(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+	userForInput2 := makeUserForInput(2)
+	db.Create(&userForInput2)
+	db.First(&userForInput2)
+
+	postForInput := makePost(userForInput.ID)
+	db.Create(postForInput)
+
+	publicCollection := &model.Collection{UserID: userForInput.ID, Name: "public", IsPublic: true}
+	db.Create(publicCollection)
+	privateCollection := &model.Collection{UserID: userForInput.ID, Name: "private"}
+	db.Create(privateCollection)
+	otherPrivateCollection := &model.Collection{UserID: userForInput2.ID, Name: "other"}
+	db.Create(otherPrivateCollection)
+
+	repository := &collectionRepository{}
+	for _, collection := range []*model.Collection{publicCollection, privateCollection, otherPrivateCollection} {
+		assert.NoError(t, repository.AddCollectionItem(collection.ID, postForInput.ID))
+	}
+
+	// 2. Exercise
+	collections, err := repository.FetchCollectionsByPostID(postForInput.ID, userForInput.ID)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(collections))
+	assert.Equal(t, privateCollection.ID, collections[0].ID)
+	assert.Equal(t, publicCollection.ID, collections[1].ID)
+
+	// 4. Teardown
+	teardown(db)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/jinzhu/gorm"
 	"github.com/joho/godotenv"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
 )
 
@@ -12,11 +13,37 @@ func setup() {
 	if err := godotenv.Load("../../../test.env"); err != nil {
 		log.Fatal("Error loading test.env file")
 	}
+	// 前のテストのteardownで削除したテーブルを作り直す
+	conf.Migrate(conf.DBConnection())
 }
 
 func teardown(db *gorm.DB) {
+	db.DropTable(&model.OutboxEvent{})
+	db.DropTable(&model.UsedStreamTicket{})
+	db.DropTable(&model.ExportJob{})
+	db.DropTable(&model.ImportJob{})
+	db.DropTable(&model.WebhookDelivery{})
+	db.DropTable(&model.Webhook{})
+	db.DropTable(&model.NotificationSetting{})
+	db.DropTable(&model.NotificationActor{})
+	db.DropTable(&model.Notification{})
+	db.DropTable(&model.Follow{})
+	db.DropTable(&model.RelatedPost{})
+	db.DropTable(&model.Reaction{})
+	db.DropTable(&model.PostDailyView{})
+	db.DropTable(&model.PostView{})
+	db.DropTable(&model.ProhibitedWord{})
+	db.DropTable(&model.ModerationAction{})
+	db.DropTable(&model.Report{})
+	db.DropTable(&model.CollectionItem{})
+	db.DropTable(&model.Collection{})
+	db.DropTable(&model.PostTranslation{})
+	db.DropTable(&model.DailyPost{})
+	db.DropTable(&model.AttributionClaim{})
 	db.DropTable(&model.Favorite{})
 	db.DropTable(&model.Comment{})
+	db.DropTable(&model.SeenPost{})
+	db.DropTable(&model.PostTag{})
 	db.DropTable(&model.Post{})
 	db.DropTable(&model.User{})
 }
@@ -0,0 +1,72 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// dailyPostRepository 構造体
+type dailyPostRepository struct {
+}
+
+// NewDailyPostRepository DailyPostRepositoryを生成する。
+func NewDailyPostRepository() repository.DailyPostRepository {
+	return &dailyPostRepository{}
+}
+
+// FetchDailyPost 今日の言葉取得。存在しない場合はnilを返す。
+func (repository *dailyPostRepository) FetchDailyPost(date string) (*model.DailyPost, error) {
+	db := conf.DBConnection()
+
+	dailyPost := model.DailyPost{}
+	err := db.Where("date = ?", date).First(&dailyPost).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dailyPost, nil
+}
+
+// FetchDailyPostCandidates 今日の言葉の候補一覧取得。
+// since以降の日付で選ばれた投稿は除く。sinceが空文字の場合は除外しない。
+func (repository *dailyPostRepository) FetchDailyPostCandidates(since string) (candidates []*model.DailyPostCandidate, err error) {
+	db := conf.DBConnection()
+
+	db = db.Table("posts").
+		Select(`posts.id AS post_id,
+			(SELECT count(*) FROM favorites WHERE favorites.post_id = posts.id) AS favorite_count
+		`).
+		Joins("JOIN users ON users.id = posts.user_id AND users.deleted_at IS NULL").
+		Where("posts.deleted_at IS NULL AND posts.is_hidden = false")
+	if since != "" {
+		db = db.Where("posts.id NOT IN (SELECT post_id FROM daily_posts WHERE date >= ?)", since)
+	}
+
+	if err = db.Order("posts.id ASC").Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// CreateDailyPost 今日の言葉登録
+func (repository *dailyPostRepository) CreateDailyPost(dailyPost *model.DailyPost) error {
+	db := conf.DBConnection()
+
+	return db.Create(dailyPost).Error
+}
+
+// SaveDailyPost 今日の言葉の登録または更新
+func (repository *dailyPostRepository) SaveDailyPost(dailyPost *model.DailyPost) error {
+	db := conf.DBConnection()
+
+	return db.Where(model.DailyPost{Date: dailyPost.Date}).
+		Assign(model.DailyPost{PostID: dailyPost.PostID, Pinned: dailyPost.Pinned}).
+		FirstOrCreate(dailyPost).Error
+}
@@ -0,0 +1,129 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// exportJobRepository 構造体
+type exportJobRepository struct {
+}
+
+// NewExportJobRepository ExportJobRepositoryを生成する。
+func NewExportJobRepository() repository.ExportJobRepository {
+	return &exportJobRepository{}
+}
+
+// CreateExportJob ジョブ登録。
+// 同時に要求された場合に重複して登録しないよう、ユーザーの行をロックしてから同じユーザーのジョブを確認する。
+func (repository *exportJobRepository) CreateExportJob(job *model.ExportJob, since time.Time) (*model.ExportJob, error) {
+	db := conf.DBConnection()
+
+	var latest *model.ExportJob
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT id FROM users WHERE id = ? FOR UPDATE", job.UserID).Error; err != nil {
+			return err
+		}
+
+		existing := model.ExportJob{}
+		err := tx.Where("user_id = ? AND status <> ? AND created_at > ?", job.UserID, model.ExportJobStatusFailed, since).
+			Order("created_at DESC").First(&existing).Error
+		if err == nil {
+			latest = &existing
+			return nil
+		}
+		if !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+
+		return tx.Create(job).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return latest, nil
+}
+
+// FetchExportJob ジョブ取得。存在しない場合はnilを返す。
+func (repository *exportJobRepository) FetchExportJob(id int) (*model.ExportJob, error) {
+	db := conf.DBConnection()
+
+	job := model.ExportJob{}
+	if err := db.First(&job, id).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// FetchCompletedExportJobByToken ダウンロードURLのトークンが一致する完了済みのジョブ取得。存在しない場合はnilを返す。
+func (repository *exportJobRepository) FetchCompletedExportJobByToken(token string) (*model.ExportJob, error) {
+	db := conf.DBConnection()
+
+	job := model.ExportJob{}
+	if err := db.Where("token = ? AND status = ?", token, model.ExportJobStatusCompleted).First(&job).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// UpdateExportJob ジョブの状態更新
+func (repository *exportJobRepository) UpdateExportJob(job *model.ExportJob) error {
+	db := conf.DBConnection()
+
+	return db.Model(job).Updates(map[string]interface{}{
+		"status":       job.Status,
+		"error":        job.Error,
+		"expires_at":   job.ExpiresAt,
+		"finished_at":  job.FinishedAt,
+		"heartbeat_at": job.HeartbeatAt,
+	}).Error
+}
+
+// FetchExpiredExportJobs ダウンロード期限がbefore以前のジョブ一覧取得。
+// 失敗したジョブ、プロセスの停止で完了しなかったジョブはダウンロード期限がないため、登録日時がcreatedBefore以前のものを返す。
+func (repository *exportJobRepository) FetchExpiredExportJobs(before, createdBefore time.Time) (jobs []*model.ExportJob, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Where("expires_at <= ? OR (expires_at IS NULL AND created_at <= ?)", before, createdBefore).
+		Order("id ASC").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// DeleteExportJob ジョブ削除
+func (repository *exportJobRepository) DeleteExportJob(id int) error {
+	db := conf.DBConnection()
+
+	return db.Where("id = ?", id).Delete(&model.ExportJob{}).Error
+}
+
+// FailStaleExportJobs 実行待ち、実行中のまま最後に状態を更新した日時がheartbeatBefore以前のジョブを失敗にする。
+// 実行中のサーバーが状態を更新した場合と競合しないよう、条件付きで更新する。
+func (repository *exportJobRepository) FailStaleExportJobs(heartbeatBefore, finishedAt time.Time, message string) (int, error) {
+	db := conf.DBConnection()
+
+	result := db.Model(&model.ExportJob{}).
+		Where("status IN (?) AND heartbeat_at <= ?", []string{model.ExportJobStatusPending, model.ExportJobStatusRunning}, heartbeatBefore).
+		Updates(map[string]interface{}{
+			"status":      model.ExportJobStatusFailed,
+			"error":       message,
+			"finished_at": finishedAt,
+		})
+	return int(result.RowsAffected), result.Error
+}
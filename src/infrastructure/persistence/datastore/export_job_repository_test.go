@@ -0,0 +1,88 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportJobRepository(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userRepository := &userRepository{}
+	repository := &exportJobRepository{}
+	user := makeUserForInput(1)
+	assert.NoError(t, userRepository.Create(user, nil))
+	current := time.Now().Truncate(time.Second)
+	since := current.Add(-time.Hour)
+
+	// 2. Exercise
+	failed := &model.ExportJob{UserID: user.ID, Status: model.ExportJobStatusFailed, Token: "token1", CreatedAt: current.Add(-time.Minute), HeartbeatAt: current}
+	_, failedErr := repository.CreateExportJob(failed, since)
+	job := &model.ExportJob{UserID: user.ID, Status: model.ExportJobStatusPending, Token: "token2", CreatedAt: current, HeartbeatAt: current}
+	latest, createErr := repository.CreateExportJob(job, since)
+	duplicated := &model.ExportJob{UserID: user.ID, Status: model.ExportJobStatusPending, Token: "token3", CreatedAt: current, HeartbeatAt: current}
+	duplicatedLatest, duplicateErr := repository.CreateExportJob(duplicated, since)
+
+	beforeComplete, beforeCompleteErr := repository.FetchCompletedExportJobByToken("token2")
+	expiresAt := current.Add(24 * time.Hour)
+	job.Status = model.ExportJobStatusCompleted
+	job.FinishedAt = &current
+	job.ExpiresAt = &expiresAt
+	updateErr := repository.UpdateExportJob(job)
+	completed, completedErr := repository.FetchCompletedExportJobByToken("token2")
+	fetched, fetchErr := repository.FetchExportJob(job.ID)
+	notFound, notFoundErr := repository.FetchExportJob(job.ID + 100)
+
+	expired, expiredErr := repository.FetchExpiredExportJobs(expiresAt, current)
+	stale := &model.ExportJob{UserID: user.ID + 1, Status: model.ExportJobStatusRunning, Token: "token4", CreatedAt: current.Add(time.Minute), HeartbeatAt: current.Add(-time.Hour)}
+	_, staleErr := repository.CreateExportJob(stale, since)
+	failedCount, failErr := repository.FailStaleExportJobs(current.Add(-30*time.Minute), current, "error")
+	interrupted, interruptedErr := repository.FetchExportJob(stale.ID)
+
+	deleteErr := repository.DeleteExportJob(failed.ID)
+	remaining, remainingErr := repository.FetchExpiredExportJobs(expiresAt, current)
+
+	// 3. Verify
+	assert.NoError(t, failedErr)
+	assert.NoError(t, createErr)
+	assert.Nil(t, latest)
+	assert.NotZero(t, job.ID)
+	assert.NoError(t, duplicateErr)
+	assert.Equal(t, job.ID, duplicatedLatest.ID)
+	assert.Zero(t, duplicated.ID)
+
+	assert.NoError(t, beforeCompleteErr)
+	assert.Nil(t, beforeComplete)
+	assert.NoError(t, updateErr)
+	assert.NoError(t, completedErr)
+	assert.Equal(t, job.ID, completed.ID)
+	assert.NoError(t, fetchErr)
+	assert.Equal(t, model.ExportJobStatusCompleted, fetched.Status)
+	assert.Equal(t, "token2", fetched.Token)
+	assert.NoError(t, notFoundErr)
+	assert.Nil(t, notFound)
+
+	assert.NoError(t, staleErr)
+	assert.NoError(t, failErr)
+	assert.Equal(t, 1, failedCount)
+	assert.NoError(t, interruptedErr)
+	assert.Equal(t, model.ExportJobStatusFailed, interrupted.Status)
+	assert.Equal(t, "error", interrupted.Error)
+	assert.NotNil(t, interrupted.FinishedAt)
+
+	assert.NoError(t, expiredErr)
+	assert.Len(t, expired, 2)
+	assert.NoError(t, deleteErr)
+	assert.NoError(t, remainingErr)
+	assert.Len(t, remaining, 1)
+	assert.Equal(t, job.ID, remaining[0].ID)
+
+	// 4. Teardown
+	teardown(db)
+}
@@ -0,0 +1,25 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// favoriteCountRepository 構造体
+type favoriteCountRepository struct {
+}
+
+// NewFavoriteCountRepository FavoriteCountRepositoryを生成する。
+func NewFavoriteCountRepository() repository.FavoriteCountRepository {
+	return &favoriteCountRepository{}
+}
+
+// CountFavorites 投稿のお気に入り数取得
+func (repository *favoriteCountRepository) CountFavorites(postID int) (count int, err error) {
+	db := conf.DBConnection()
+
+	err = db.Model(&model.Favorite{}).Where("post_id = ?", postID).Count(&count).Error
+	return count, err
+}
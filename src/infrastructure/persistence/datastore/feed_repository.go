@@ -0,0 +1,80 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// feedRepository 構造体
+type feedRepository struct {
+}
+
+// NewFeedRepository FeedRepositoryを生成する。
+func NewFeedRepository() repository.FeedRepository {
+	return &feedRepository{}
+}
+
+// FetchFollowingPostIDs フォロー中のユーザーの投稿のID一覧取得。新しい順に返す。
+func (repository *feedRepository) FetchFollowingPostIDs(userID, beforeID, limit int) (ids []int, err error) {
+	db := conf.DBConnection()
+
+	query, args := followingPostIDsQuery(userID, beforeID, limit)
+	if err = db.Raw(query, args...).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// followingPostIDsQuery フォロー中のユーザーの投稿のID一覧を取得するSQLと引数を返す。
+// フォロー中のユーザーごとに、投稿のuser_idのインデックス(主キーを含む)からbeforeIDより前の新しい投稿をlimit件まで読み込み、それらをまとめて並べ替える。
+// フォロー数が多い場合も、読み込む投稿はフォロー数×limit件までとなる。
+func followingPostIDsQuery(userID, beforeID, limit int) (string, []interface{}) {
+	beforeCondition := ""
+	args := []interface{}{}
+	if beforeID > 0 {
+		beforeCondition = "AND posts.id < ?"
+		args = append(args, beforeID)
+	}
+	args = append(args, limit, userID, limit)
+
+	return `SELECT feed.id FROM follows
+		JOIN users ON users.id = follows.followee_id AND users.deleted_at IS NULL
+		CROSS JOIN LATERAL (
+			SELECT posts.id FROM posts
+			WHERE posts.user_id = follows.followee_id AND posts.deleted_at IS NULL AND posts.is_hidden = false ` + beforeCondition + `
+			ORDER BY posts.id DESC
+			LIMIT ?
+		) AS feed
+		WHERE follows.follower_id = ?
+		ORDER BY feed.id DESC
+		LIMIT ?`, args
+}
+
+// FetchPopularPostIDs since以降に投稿された、お気に入り数がminFavoriteCount以上の投稿のID一覧取得。新しい順に返す。
+func (repository *feedRepository) FetchPopularPostIDs(since time.Time, minFavoriteCount, excludeUserID, beforeID, limit int) (ids []int, err error) {
+	db := conf.DBConnection()
+
+	db = db.Table("posts").
+		Joins("JOIN users ON users.id = posts.user_id AND users.deleted_at IS NULL").
+		Joins("JOIN favorites ON favorites.post_id = posts.id").
+		Where("posts.created_at >= ? AND posts.deleted_at IS NULL AND posts.is_hidden = false", since)
+	if excludeUserID > 0 {
+		db = db.Where("posts.user_id <> ?", excludeUserID)
+	}
+	if beforeID > 0 {
+		db = db.Where("posts.id < ?", beforeID)
+	}
+	if err = db.Group("posts.id").
+		Having("COUNT(favorites.id) >= ?", minFavoriteCount).
+		Order("posts.id DESC").
+		Limit(limit).
+		Pluck("posts.id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,109 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// フィード用の投稿ID一覧取得テスト
+func TestFeedRepository_FeedPostIDs(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user1 := makeUserForInput(1)
+	db.Create(user1)
+	user2 := makeUserForInput(2)
+	db.Create(user2)
+	user3 := makeUserForInput(3)
+	db.Create(user3)
+	db.Create(&model.Follow{FollowerID: user1.ID, FolloweeID: user2.ID})
+	post1 := makePost(user2.ID)
+	db.Create(post1)
+	post2 := makePost(user2.ID)
+	db.Create(post2)
+	post3 := makePost(user3.ID)
+	db.Create(post3)
+	post4 := makePost(user1.ID)
+	db.Create(post4)
+	hiddenPost := makePost(user2.ID)
+	hiddenPost.IsHidden = true
+	db.Create(hiddenPost)
+	db.Create(makeFavorite(user1.ID, post3.ID))
+	db.Create(makeFavorite(user2.ID, post3.ID))
+	db.Create(makeFavorite(user2.ID, post4.ID))
+	db.Create(makeFavorite(user3.ID, post4.ID))
+	db.Create(makeFavorite(user3.ID, post1.ID))
+
+	repository := &feedRepository{}
+
+	// 2. Exercise
+	followingIDs, followingErr := repository.FetchFollowingPostIDs(user1.ID, 0, 10)
+	pagedIDs, pagedErr := repository.FetchFollowingPostIDs(user1.ID, post2.ID, 10)
+	popularIDs, popularErr := repository.FetchPopularPostIDs(time.Now().Add(-time.Hour), 2, user1.ID, 0, 10)
+
+	// 3. Verify
+	assert.NoError(t, followingErr)
+	assert.Equal(t, []int{post2.ID, post1.ID}, followingIDs)
+	assert.NoError(t, pagedErr)
+	assert.Equal(t, []int{post1.ID}, pagedIDs)
+	assert.NoError(t, popularErr)
+	assert.Equal(t, []int{post3.ID}, popularIDs)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// フォロー中のユーザーの投稿のID一覧取得の実行計画テスト
+func TestFeedRepository_FetchFollowingPostIDs_plan(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user1 := makeUserForInput(1)
+	db.Create(user1)
+	user2 := makeUserForInput(2)
+	db.Create(user2)
+	user3 := makeUserForInput(3)
+	db.Create(user3)
+	db.Create(&model.Follow{FollowerID: user1.ID, FolloweeID: user2.ID})
+	db.Create(&model.Follow{FollowerID: user1.ID, FolloweeID: user3.ID})
+	for i := 0; i < 20; i++ {
+		db.Create(makePost(user2.ID))
+		db.Create(makePost(user3.ID))
+	}
+
+	type plan struct {
+		SelectType string  `gorm:"column:select_type"`
+		Table      string  `gorm:"column:table"`
+		Type       *string `gorm:"column:type"`
+		Key        *string `gorm:"column:key"`
+	}
+	query, args := followingPostIDsQuery(user1.ID, 100, 10)
+
+	// 2. Exercise
+	plans := []*plan{}
+	err := db.Raw("EXPLAIN "+query, args...).Scan(&plans).Error
+
+	// 3. Verify
+	assert.NoError(t, err)
+	// 投稿はフォロー中のユーザーごとに、user_idのインデックスから読み込む
+	var postsPlan *plan
+	for _, p := range plans {
+		if p.Table == "posts" {
+			postsPlan = p
+		}
+	}
+	if assert.NotNil(t, postsPlan) {
+		assert.Equal(t, "DEPENDENT DERIVED", postsPlan.SelectType)
+		assert.Equal(t, "ref", *postsPlan.Type)
+		assert.Equal(t, "idx_posts_user_id", *postsPlan.Key)
+	}
+
+	// 4. Teardown
+	teardown(db)
+}
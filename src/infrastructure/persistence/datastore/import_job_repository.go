@@ -0,0 +1,104 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// importJobRepository 構造体
+type importJobRepository struct {
+}
+
+// NewImportJobRepository ImportJobRepositoryを生成する。
+func NewImportJobRepository() repository.ImportJobRepository {
+	return &importJobRepository{}
+}
+
+// CreateImportJob ジョブ登録
+func (repository *importJobRepository) CreateImportJob(job *model.ImportJob) error {
+	db := conf.DBConnection()
+
+	if err := encodeImportResults(job); err != nil {
+		return err
+	}
+	return db.Create(job).Error
+}
+
+// UpdateImportJob ジョブの進捗、結果の更新
+func (repository *importJobRepository) UpdateImportJob(job *model.ImportJob) error {
+	db := conf.DBConnection()
+
+	if err := encodeImportResults(job); err != nil {
+		return err
+	}
+	return db.Model(job).Updates(map[string]interface{}{
+		"status":          job.Status,
+		"processed_count": job.ProcessedCount,
+		"succeeded_count": job.SucceededCount,
+		"failed_count":    job.FailedCount,
+		"error":           job.Error,
+		"results":         job.EncodedResults,
+		"finished_at":     job.FinishedAt,
+		"heartbeat_at":    job.HeartbeatAt,
+	}).Error
+}
+
+// FetchImportJob ジョブ取得。存在しない場合はnilを返す。
+func (repository *importJobRepository) FetchImportJob(id int) (*model.ImportJob, error) {
+	db := conf.DBConnection()
+
+	job := model.ImportJob{}
+	if err := db.First(&job, id).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(job.EncodedResults), &job.Results); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// DeleteImportJobs 登録日時がbefore以前のジョブ削除
+func (repository *importJobRepository) DeleteImportJobs(before time.Time) error {
+	db := conf.DBConnection()
+
+	return db.Where("created_at <= ?", before).Delete(&model.ImportJob{}).Error
+}
+
+// FailStaleImportJobs 実行待ち、実行中のまま最後に進捗を反映した日時がheartbeatBefore以前のジョブを失敗にする。
+// 実行中のサーバーが進捗を反映した場合と競合しないよう、条件付きで更新する。
+func (repository *importJobRepository) FailStaleImportJobs(heartbeatBefore, finishedAt time.Time, message string) (int, error) {
+	db := conf.DBConnection()
+
+	result := db.Model(&model.ImportJob{}).
+		Where("status IN (?) AND heartbeat_at <= ?", []string{model.ImportJobStatusPending, model.ImportJobStatusRunning}, heartbeatBefore).
+		Updates(map[string]interface{}{
+			"status":      model.ImportJobStatusFailed,
+			"error":       message,
+			"finished_at": finishedAt,
+		})
+	return int(result.RowsAffected), result.Error
+}
+
+// encodeImportResults 行ごとの結果をJSONにして保存する項目に設定する。
+func encodeImportResults(job *model.ImportJob) error {
+	results := job.Results
+	if results == nil {
+		results = []*model.ImportRowResult{}
+	}
+	encoded, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	job.EncodedResults = string(encoded)
+	return nil
+}
@@ -0,0 +1,75 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportJobRepository(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	repository := &importJobRepository{}
+	current := time.Now().Truncate(time.Second)
+	expired := &model.ImportJob{UserID: 1, Status: model.ImportJobStatusCompleted, CreatedAt: current.Add(-48 * time.Hour), HeartbeatAt: current.Add(-48 * time.Hour)}
+	job := &model.ImportJob{UserID: 1, Status: model.ImportJobStatusPending, TotalCount: 2, CreatedAt: current, HeartbeatAt: current}
+	stale := &model.ImportJob{UserID: 1, Status: model.ImportJobStatusRunning, CreatedAt: current, HeartbeatAt: current.Add(-time.Hour)}
+
+	// 2. Exercise
+	expiredErr := repository.CreateImportJob(expired)
+	createErr := repository.CreateImportJob(job)
+	created, createdErr := repository.FetchImportJob(job.ID)
+
+	job.Status = model.ImportJobStatusCompleted
+	job.ProcessedCount = 2
+	job.SucceededCount = 1
+	job.FailedCount = 1
+	job.Results = []*model.ImportRowResult{{Row: 1, PostID: 10}, {Row: 2, Errors: []string{"error"}}}
+	job.FinishedAt = &current
+	job.HeartbeatAt = current
+	updateErr := repository.UpdateImportJob(job)
+	updated, updatedErr := repository.FetchImportJob(job.ID)
+
+	staleErr := repository.CreateImportJob(stale)
+	failedCount, failErr := repository.FailStaleImportJobs(current.Add(-10*time.Minute), current, "error")
+	failed, failedErr := repository.FetchImportJob(stale.ID)
+
+	deleteErr := repository.DeleteImportJobs(current.Add(-24 * time.Hour))
+	deleted, deletedErr := repository.FetchImportJob(expired.ID)
+
+	// 3. Verify
+	assert.NoError(t, expiredErr)
+	assert.NoError(t, createErr)
+	assert.NoError(t, createdErr)
+	assert.Equal(t, model.ImportJobStatusPending, created.Status)
+	assert.Empty(t, created.Results)
+
+	assert.NoError(t, updateErr)
+	assert.NoError(t, updatedErr)
+	assert.Equal(t, model.ImportJobStatusCompleted, updated.Status)
+	assert.Equal(t, 2, updated.TotalCount)
+	assert.Equal(t, 1, updated.FailedCount)
+	assert.Equal(t, 10, updated.Results[0].PostID)
+	assert.Equal(t, []string{"error"}, updated.Results[1].Errors)
+	assert.NotNil(t, updated.FinishedAt)
+
+	assert.NoError(t, staleErr)
+	assert.NoError(t, failErr)
+	assert.NoError(t, failedErr)
+	assert.Equal(t, 1, failedCount)
+	assert.Equal(t, model.ImportJobStatusFailed, failed.Status)
+	assert.Equal(t, "error", failed.Error)
+	assert.NotNil(t, failed.FinishedAt)
+
+	assert.NoError(t, deleteErr)
+	assert.NoError(t, deletedErr)
+	assert.Nil(t, deleted)
+
+	// 4. Teardown
+	teardown(db)
+}
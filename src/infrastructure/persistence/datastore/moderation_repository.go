@@ -0,0 +1,50 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// moderationRepository 構造体
+type moderationRepository struct {
+}
+
+// NewModerationRepository ModerationRepositoryを生成する。
+func NewModerationRepository() repository.ModerationRepository {
+	return &moderationRepository{}
+}
+
+// FetchPostForModeration 非表示の投稿も含めた投稿1件取得(モデレーター用)。存在しない場合はnilを返す。
+func (repository *moderationRepository) FetchPostForModeration(id int) (*model.Post, error) {
+	db := conf.DBConnection()
+
+	post := model.Post{}
+	err := db.Where("id = ?", id).First(&post).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+// FetchCommentByID コメント1件取得。非表示のコメントも含める。存在しない場合はnilを返す。
+func (repository *moderationRepository) FetchCommentByID(id int) (*model.Comment, error) {
+	db := conf.DBConnection()
+
+	comment := model.Comment{}
+	err := db.Where("id = ?", id).First(&comment).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
@@ -0,0 +1,162 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// notificationRepository 構造体
+type notificationRepository struct {
+}
+
+// NewNotificationRepository NotificationRepositoryを生成する。
+func NewNotificationRepository() repository.NotificationRepository {
+	return &notificationRepository{}
+}
+
+// SaveNotification 通知登録。同じユーザー、種類、投稿への未読の通知がある場合は、行ったユーザーを追加してまとめる。
+// まとめた場合、notificationにはまとめた先の通知を設定する。
+func (repository *notificationRepository) SaveNotification(notification *model.Notification) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		existing := model.Notification{}
+		err := tx.Set("gorm:query_option", "FOR UPDATE").
+			Where("user_id = ? AND type = ? AND post_id = ? AND read_at IS NULL", notification.UserID, notification.Type, notification.PostID).
+			Order("id DESC").
+			First(&existing).Error
+		if gorm.IsRecordNotFoundError(err) {
+			notification.ActorCount = 1
+			if err := tx.Create(notification).Error; err != nil {
+				return err
+			}
+			return tx.Create(&model.NotificationActor{NotificationID: notification.ID, ActorID: notification.ActorID}).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		actor := model.NotificationActor{NotificationID: existing.ID, ActorID: notification.ActorID}
+		if err := tx.Where(actor).FirstOrCreate(&actor).Error; err != nil {
+			return err
+		}
+		actorCount := 0
+		if err := tx.Model(&model.NotificationActor{}).Where("notification_id = ?", existing.ID).Count(&actorCount).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&existing).Updates(map[string]interface{}{
+			"actor_id":    notification.ActorID,
+			"actor_count": actorCount,
+		}).Error; err != nil {
+			return err
+		}
+		*notification = existing
+		return nil
+	})
+}
+
+// FetchNotifications 通知一覧取得。更新日時の新しい順に返す。
+func (repository *notificationRepository) FetchNotifications(userID int, unreadOnly bool, limit, page int) (totalCount int, notifications []*model.GetNotificationResult, err error) {
+	db := conf.DBConnection()
+
+	db = db.Table("notifications").Where("notifications.user_id = ?", userID)
+	if unreadOnly {
+		db = db.Where("notifications.read_at IS NULL")
+	}
+	if err = db.Count(&totalCount).Error; err != nil {
+		return 0, nil, err
+	}
+
+	offset := limit * (page - 1)
+	if err = db.Select(`notifications.*,
+			COALESCE(users.name, '') AS actor_name,
+			COALESCE(users.image_file_path, '') AS actor_image_file_path,
+			COALESCE(posts.title, '') AS post_title`).
+		Joins(`LEFT JOIN users ON users.id = notifications.actor_id AND users.deleted_at IS NULL
+			LEFT JOIN posts ON posts.id = notifications.post_id AND posts.deleted_at IS NULL`).
+		Order("notifications.updated_at DESC, notifications.id DESC").Limit(limit).Offset(offset).
+		Scan(&notifications).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, notifications, nil
+}
+
+// CountUnreadNotifications 未読の通知の件数取得
+func (repository *notificationRepository) CountUnreadNotifications(userID int) (count int, err error) {
+	db := conf.DBConnection()
+
+	err = db.Model(&model.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkNotificationRead 通知を既読にする。既読の場合は既読にした日時を変更しない。
+// 通知の並び順を変えないよう、更新日時は変更しない。
+func (repository *notificationRepository) MarkNotificationRead(id, userID int, readAt time.Time) (bool, error) {
+	db := conf.DBConnection()
+
+	count := 0
+	if err := db.Model(&model.Notification{}).Where("id = ? AND user_id = ?", id, userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count == 0 {
+		return false, nil
+	}
+
+	if err := db.Model(&model.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		UpdateColumn("read_at", readAt).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// MarkAllNotificationsRead ユーザーの未読の通知をすべて既読にする
+func (repository *notificationRepository) MarkAllNotificationsRead(userID int, readAt time.Time) error {
+	db := conf.DBConnection()
+
+	return db.Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		UpdateColumn("read_at", readAt).Error
+}
+
+// FetchNotificationSettings 通知の受け取り設定取得。設定を変更していない種類は含まない。
+func (repository *notificationRepository) FetchNotificationSettings(userID int) (settings []*model.NotificationSetting, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Where("user_id = ?", userID).Order("id ASC").Find(&settings).Error; err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// SaveNotificationSetting 通知の受け取り設定の登録・更新
+func (repository *notificationRepository) SaveNotificationSetting(setting *model.NotificationSetting) error {
+	db := conf.DBConnection()
+
+	return db.Where(model.NotificationSetting{UserID: setting.UserID, Type: setting.Type}).
+		Assign(map[string]interface{}{"enabled": setting.Enabled}).
+		FirstOrCreate(setting).Error
+}
+
+// deleteNotifications 条件に一致する通知と、通知を行ったユーザーを削除する。
+func deleteNotifications(tx *gorm.DB, query string, args ...interface{}) error {
+	var ids []int
+	if err := tx.Model(&model.Notification{}).Where(query, args...).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("notification_id IN (?)", ids).Delete(&model.NotificationActor{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("id IN (?)", ids).Delete(&model.Notification{}).Error
+}
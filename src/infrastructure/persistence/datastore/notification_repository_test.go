@@ -0,0 +1,100 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// 通知テスト
+func TestNotificationRepository_Notifications(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user1 := makeUserForInput(1)
+	db.Create(user1)
+	user2 := makeUserForInput(2)
+	db.Create(user2)
+	user3 := makeUserForInput(3)
+	db.Create(user3)
+	post := makePost(user1.ID)
+	db.Create(post)
+
+	repository := &notificationRepository{}
+	readAt := time.Now()
+
+	// 2. Exercise
+	saveErr1 := repository.SaveNotification(&model.Notification{UserID: user1.ID, Type: model.NotificationTypeFavorite, PostID: post.ID, ActorID: user2.ID})
+	saveErr2 := repository.SaveNotification(&model.Notification{UserID: user1.ID, Type: model.NotificationTypeFavorite, PostID: post.ID, ActorID: user3.ID})
+	saveErr3 := repository.SaveNotification(&model.Notification{UserID: user1.ID, Type: model.NotificationTypeFavorite, PostID: post.ID, ActorID: user3.ID})
+	follow := &model.Notification{UserID: user1.ID, Type: model.NotificationTypeFollow, ActorID: user2.ID}
+	saveErr4 := repository.SaveNotification(follow)
+	found, readErr := repository.MarkNotificationRead(follow.ID, user1.ID, readAt)
+	notFound, _ := repository.MarkNotificationRead(follow.ID, user2.ID, readAt)
+	totalCount, notifications, fetchErr := repository.FetchNotifications(user1.ID, true, 10, 1)
+	unreadCount, countErr := repository.CountUnreadNotifications(user1.ID)
+	saveErr5 := repository.SaveNotification(&model.Notification{UserID: user1.ID, Type: model.NotificationTypeFollow, ActorID: user3.ID})
+	markAllErr := repository.MarkAllNotificationsRead(user1.ID, readAt)
+	unreadCountAfterMarkAll, _ := repository.CountUnreadNotifications(user1.ID)
+	allCount, _, _ := repository.FetchNotifications(user1.ID, false, 10, 1)
+
+	// 3. Verify
+	assert.NoError(t, saveErr1)
+	assert.NoError(t, saveErr2)
+	assert.NoError(t, saveErr3)
+	assert.NoError(t, saveErr4)
+	assert.NoError(t, saveErr5)
+	assert.NoError(t, readErr)
+	assert.True(t, found)
+	assert.False(t, notFound)
+	assert.NoError(t, fetchErr)
+	assert.Equal(t, 1, totalCount)
+	assert.Equal(t, 2, notifications[0].ActorCount)
+	assert.Equal(t, user3.ID, notifications[0].ActorID)
+	assert.Equal(t, user3.Name, notifications[0].ActorName)
+	assert.Equal(t, post.Title, notifications[0].PostTitle)
+	assert.NoError(t, countErr)
+	assert.Equal(t, 1, unreadCount)
+	assert.NoError(t, markAllErr)
+	assert.Equal(t, 0, unreadCountAfterMarkAll)
+	assert.Equal(t, 3, allCount)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// 通知の受け取り設定テスト
+func TestNotificationRepository_NotificationSettings(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user := makeUserForInput(1)
+	db.Create(user)
+
+	repository := &notificationRepository{}
+
+	// 2. Exercise
+	saveErr1 := repository.SaveNotificationSetting(&model.NotificationSetting{UserID: user.ID, Type: model.NotificationTypeFollow, Enabled: false})
+	saveErr2 := repository.SaveNotificationSetting(&model.NotificationSetting{UserID: user.ID, Type: model.NotificationTypeComment, Enabled: true})
+	saveErr3 := repository.SaveNotificationSetting(&model.NotificationSetting{UserID: user.ID, Type: model.NotificationTypeComment, Enabled: false})
+	settings, fetchErr := repository.FetchNotificationSettings(user.ID)
+
+	// 3. Verify
+	assert.NoError(t, saveErr1)
+	assert.NoError(t, saveErr2)
+	assert.NoError(t, saveErr3)
+	assert.NoError(t, fetchErr)
+	assert.Len(t, settings, 2)
+	assert.Equal(t, model.NotificationTypeFollow, settings[0].Type)
+	assert.False(t, settings[0].Enabled)
+	assert.Equal(t, model.NotificationTypeComment, settings[1].Type)
+	assert.False(t, settings[1].Enabled)
+
+	// 4. Teardown
+	teardown(db)
+}
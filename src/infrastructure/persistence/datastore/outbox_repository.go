@@ -0,0 +1,80 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// outboxRepository 構造体
+type outboxRepository struct {
+}
+
+// NewOutboxRepository OutboxRepositoryを生成する。
+func NewOutboxRepository() repository.OutboxRepository {
+	return &outboxRepository{}
+}
+
+// FetchDueOutboxEvents 処理する日時がbefore以前の未処理のイベント一覧取得。古い順にlimit件まで返す。
+func (repository *outboxRepository) FetchDueOutboxEvents(before time.Time, limit int) (events []*model.OutboxEvent, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Where("status = ? AND next_attempt_at <= ?", model.OutboxEventStatusPending, before).
+		Order("id ASC").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ClaimOutboxEvent イベントを処理中にする。他のAPIサーバーが処理中にした場合、処理済みの場合はfalseを返す。
+func (repository *outboxRepository) ClaimOutboxEvent(id int, before, until time.Time) (bool, error) {
+	db := conf.DBConnection()
+
+	result := db.Model(&model.OutboxEvent{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, model.OutboxEventStatusPending, before).
+		UpdateColumn("next_attempt_at", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateOutboxEvent イベントの処理結果更新
+func (repository *outboxRepository) UpdateOutboxEvent(event *model.OutboxEvent) error {
+	db := conf.DBConnection()
+
+	return db.Model(event).Updates(map[string]interface{}{
+		"status":          event.Status,
+		"attempts":        event.Attempts,
+		"error":           event.Error,
+		"next_attempt_at": event.NextAttemptAt,
+		"dispatched_at":   event.DispatchedAt,
+	}).Error
+}
+
+// saveDomainEvents ドメインイベントを送信箱に保存する。登録・更新と同じトランザクションtxで呼び出す。
+// 内容はこの時点でJSONにするため、登録で採番されたIDも含まれる。
+func saveDomainEvents(tx *gorm.DB, events []*model.DomainEvent) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event.Data)
+		if err != nil {
+			return err
+		}
+		nextAttemptAt := time.Now()
+		if err := tx.Create(&model.OutboxEvent{
+			EventType:     event.Type,
+			Payload:       string(payload),
+			Status:        model.OutboxEventStatusPending,
+			NextAttemptAt: &nextAttemptAt,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userRepository := &userRepository{}
+	repository := &outboxRepository{}
+	userForInput := makeUserForInput(1)
+	assert.NoError(t, userRepository.Create(userForInput, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventUserCreated, (*model.UserEventPayload)(userForInput))}))
+	assert.NoError(t, userRepository.Update(userForInput, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventUserUpdated, model.DomainEventTarget{ID: userForInput.ID})}))
+
+	// 2. Exercise
+	current := time.Now().Add(time.Second)
+	events, fetchErr := repository.FetchDueOutboxEvents(current, 10)
+	claimed, claimErr := repository.ClaimOutboxEvent(events[0].ID, current, current.Add(time.Minute))
+	claimedAgain, claimAgainErr := repository.ClaimOutboxEvent(events[0].ID, current, current.Add(time.Minute))
+	dispatchedAt := current
+	events[1].Status = model.OutboxEventStatusDispatched
+	events[1].Attempts = 1
+	events[1].NextAttemptAt = nil
+	events[1].DispatchedAt = &dispatchedAt
+	updateErr := repository.UpdateOutboxEvent(events[1])
+	dueEvents, dueErr := repository.FetchDueOutboxEvents(current, 10)
+
+	// 3. Verify
+	assert.NoError(t, fetchErr)
+	assert.Len(t, events, 2)
+	assert.Equal(t, model.DomainEventUserCreated, events[0].EventType)
+	assert.Contains(t, events[0].Payload, userForInput.Name)
+	assert.NotContains(t, events[0].Payload, userForInput.Email)
+	assert.Equal(t, model.DomainEventUserUpdated, events[1].EventType)
+	assert.Equal(t, model.OutboxEventStatusPending, events[1].Status)
+
+	assert.NoError(t, claimErr)
+	assert.True(t, claimed)
+	assert.NoError(t, claimAgainErr)
+	assert.False(t, claimedAgain)
+
+	assert.NoError(t, updateErr)
+	assert.NoError(t, dueErr)
+	assert.Len(t, dueEvents, 0)
+
+	// 4. Teardown
+	teardown(db)
+}
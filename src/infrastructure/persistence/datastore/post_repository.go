@@ -4,6 +4,7 @@ package datastore
 import (
 	"fmt"
 
+	"github.com/jinzhu/gorm"
 	"github.com/k-kazuya0926/power-phrase2-api/conf"
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
 	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
@@ -19,30 +20,70 @@ func NewPostRepository() repository.PostRepository {
 }
 
 // Create 投稿登録
-func (repository *postRepository) Create(post *model.Post) error {
-	db := conf.NewDBConnection()
-	defer db.Close()
+func (repository *postRepository) Create(post *model.Post, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+		if err := savePostTags(tx, post.ID, post.Tags); err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, events)
+	})
+}
 
-	return db.Create(post).Error
+// CreatePosts 投稿の一括登録。1件でも失敗した場合は全件登録しない。
+func (repository *postRepository) CreatePosts(posts []*model.Post, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, post := range posts {
+			if err := tx.Create(post).Error; err != nil {
+				return err
+			}
+			if err := savePostTags(tx, post.ID, post.Tags); err != nil {
+				return err
+			}
+		}
+		return saveDomainEvents(tx, events)
+	})
 }
 
 // Fetch 投稿一覧取得。
 // キーワード検索を行わない場合はkeywordに空文字を指定する。
 // 投稿ユーザーを限定しない場合はpostUserIDに0を指定する。
 // ログインユーザーを限定しない場合はloginUserIDに0を指定する。
-func (repository *postRepository) Fetch(limit, page int, keyword string, postUserID, loginUserID int) (totalCount int, posts []*model.GetPostResult, err error) {
-	db := conf.NewDBConnection()
-	defer db.Close()
+// verifiedOnlyがtrueの場合は出典が検証済みの投稿のみ取得する。
+// 言語を限定しない場合はlanguageに空文字を指定する。指定した場合は原文または翻訳がその言語である投稿を取得する。
+// 地域などのサブタグ付きの言語(例：languageがenの場合のen-US)も一致させる。
+func (repository *postRepository) Fetch(limit, page int, keyword string, postUserID, loginUserID int, verifiedOnly bool, language string) (totalCount int, posts []*model.GetPostResult, err error) {
+	db := conf.DBConnection()
 
-	countDb := conf.NewDBConnection()
-	defer countDb.Close()
+	countDb := conf.DBConnection()
 
 	offset := limit * (page - 1)
 
 	if keyword != "" {
-		// キーワードがタイトル、発言者、詳細のいずれかに含まれる
-		countDb = countDb.Where("title LIKE ?", "%"+keyword+"%").Or("speaker LIKE ?", "%"+keyword+"%").Or("detail LIKE ?", "%"+keyword+"%")
-		db = db.Where("title LIKE ?", "%"+keyword+"%").Or("speaker LIKE ?", "%"+keyword+"%").Or("detail LIKE ?", "%"+keyword+"%")
+		// キーワードがタイトル、発言者、詳細のいずれかに含まれる。他の条件とORにならないよう括弧で囲む
+		like := "%" + keyword + "%"
+		keywordCondition := "(posts.title LIKE ? OR posts.speaker LIKE ? OR posts.detail LIKE ?)"
+		countDb = countDb.Where(keywordCondition, like, like, like)
+		db = db.Where(keywordCondition, like, like, like)
+	}
+
+	if verifiedOnly {
+		countDb = countDb.Where("posts.verification_status = ?", model.VerificationStatusVerified)
+		db = db.Where("posts.verification_status = ?", model.VerificationStatusVerified)
+	}
+
+	if language != "" {
+		languageCondition := `(posts.language = ? OR posts.language LIKE ?
+			OR posts.id IN (SELECT post_id FROM post_translations WHERE language = ? OR language LIKE ?))`
+		subtagged := language + "-%"
+		countDb = countDb.Where(languageCondition, language, subtagged, language, subtagged)
+		db = db.Where(languageCondition, language, subtagged, language, subtagged)
 	}
 
 	if postUserID > 0 { // ユーザーIDが指定されている場合
@@ -50,6 +91,10 @@ func (repository *postRepository) Fetch(limit, page int, keyword string, postUse
 		db = db.Where("posts.user_id = ?", postUserID)
 	}
 
+	// 非表示の投稿は除く
+	countDb = countDb.Where("posts.is_hidden = false")
+	db = db.Where("posts.is_hidden = false")
+
 	// 投稿総件数取得
 	if err = countDb.Model(&model.Post{}).Count(&totalCount).Error; err != nil {
 		return 0, nil, err
@@ -60,9 +105,10 @@ func (repository *postRepository) Fetch(limit, page int, keyword string, postUse
 		Select(`posts.*,
 			users.name as user_name,
 			users.image_file_path as user_image_file_path,
-			(SELECT count(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL) AS comment_count,
+			(SELECT count(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL AND comments.is_hidden = false) AS comment_count,
 			(CASE WHEN favorites.id IS NULL THEN false ELSE true END) AS is_favorite,
-			(SELECT count(*) FROM favorites AS f WHERE f.post_id = posts.id) AS favorite_count
+			(SELECT count(*) FROM favorites AS f WHERE f.post_id = posts.id) AS favorite_count,
+			(SELECT COALESCE(SUM(v.views), 0) FROM post_daily_views AS v WHERE v.post_id = posts.id) AS view_count
 		`).
 		Joins(fmt.Sprintf(`JOIN users ON users.id = posts.user_id AND users.deleted_at IS NULL
 			LEFT JOIN favorites ON favorites.post_id = posts.id AND favorites.user_id = %d`, loginUserID)).
@@ -70,14 +116,16 @@ func (repository *postRepository) Fetch(limit, page int, keyword string, postUse
 		Find(&posts).Error; err != nil {
 		return 0, nil, err
 	}
+	if err = loadPostTags(db, posts); err != nil {
+		return 0, nil, err
+	}
 
 	return totalCount, posts, err
 }
 
 // FetchByID 投稿1件取得
 func (repository *postRepository) FetchByID(id, loginUserID int) (*model.GetPostResult, error) {
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	post := model.GetPostResult{}
 	post.ID = id
@@ -85,53 +133,301 @@ func (repository *postRepository) FetchByID(id, loginUserID int) (*model.GetPost
 		Select(`posts.*,
 			users.name as user_name,
 			users.image_file_path as user_image_file_path,
-			(SELECT count(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL) AS comment_count,
+			(SELECT count(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL AND comments.is_hidden = false) AS comment_count,
 			(CASE WHEN favorites.id IS NULL THEN false ELSE true END) AS is_favorite,
-			(SELECT count(*) FROM favorites AS f WHERE f.post_id = posts.id) AS favorite_count
+			(SELECT count(*) FROM favorites AS f WHERE f.post_id = posts.id) AS favorite_count,
+			(SELECT COALESCE(SUM(v.views), 0) FROM post_daily_views AS v WHERE v.post_id = posts.id) AS view_count
 		`).
 		Joins(fmt.Sprintf(`JOIN users ON users.id = posts.user_id AND users.deleted_at IS NULL
 			LEFT JOIN favorites ON favorites.post_id = posts.id AND favorites.user_id = %d`, loginUserID)).
+		Where("posts.is_hidden = false").
 		First(&post).Error; err != nil {
+		return nil, notFoundError(err)
+	}
+	if err := loadPostTags(db, []*model.GetPostResult{&post}); err != nil {
 		return nil, err
 	}
 
 	return &post, nil
 }
 
-// Update 投稿更新
-func (repository *postRepository) Update(u *model.Post) error {
-	db := conf.NewDBConnection()
-	defer db.Close()
+// Update 投稿更新。出典、ライセンス、動画URL、タグは空の値でも更新する。言語は空文字の場合は変更しない。
+func (repository *postRepository) Update(u *model.Post, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	values := map[string]interface{}{
+		"title":              u.Title,
+		"speaker":            u.Speaker,
+		"detail":             u.Detail,
+		"movie_url":          u.MovieURL,
+		"license":            u.License,
+		"source_type":        u.SourceType,
+		"source_title":       u.SourceTitle,
+		"source_locator":     u.SourceLocator,
+		"publication_year":   u.PublicationYear,
+		"source_url":         u.SourceURL,
+		"normalized_title":   u.NormalizedTitle,
+		"normalized_speaker": u.NormalizedSpeaker,
+	}
+	if u.Language != "" {
+		values["language"] = u.Language
+	}
+	// 非表示にする場合のみ更新する。更新で非表示を解除しない
+	if u.IsHidden {
+		values["is_hidden"] = true
+	}
 
-	return db.Model(u).Update(u).Error
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(u).Updates(values).Error; err != nil {
+			return err
+		}
+		if err := savePostTags(tx, u.ID, u.Tags); err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, events)
+	})
 }
 
-// Delete 投稿削除
-func (repository *postRepository) Delete(id int) error {
-	db := conf.NewDBConnection()
-	defer db.Close()
+// Delete 投稿削除。削除した場合のみイベントを保存する。
+func (repository *postRepository) Delete(id int, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&model.Post{ID: id})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return saveDomainEvents(tx, events)
+	})
+}
 
-	post := model.Post{ID: id}
-	return db.Delete(&post).Error
+// notFoundError レコードが存在しない場合のgormのエラーをrepository.ErrNotFoundに置き換える。
+func notFoundError(err error) error {
+	if gorm.IsRecordNotFoundError(err) {
+		return repository.ErrNotFound
+	}
+	return err
 }
 
-// CreateComment コメント登録
-func (repository *postRepository) CreateComment(comment *model.Comment) error {
-	db := conf.NewDBConnection()
-	defer db.Close()
+// savePostTags 投稿のタグを置き換える。
+func savePostTags(tx *gorm.DB, postID int, tags []string) error {
+	if err := tx.Where("post_id = ?", postID).Delete(&model.PostTag{}).Error; err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if err := tx.Create(&model.PostTag{PostID: postID, Tag: tag}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadPostTags 投稿一覧のタグをまとめて取得して設定する。
+func loadPostTags(db *gorm.DB, posts []*model.GetPostResult) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]int, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	var postTags []*model.PostTag
+	if err := db.New().Where("post_id IN (?)", postIDs).Order("id ASC").Find(&postTags).Error; err != nil {
+		return err
+	}
+
+	tagMap := map[int][]string{}
+	for _, postTag := range postTags {
+		tagMap[postTag.PostID] = append(tagMap[postTag.PostID], postTag.Tag)
+	}
+	for _, post := range posts {
+		post.Tags = tagMap[post.ID]
+	}
+	return nil
+}
+
+// FetchBySpeaker 発言者が一致する投稿一覧取得(重複検出用)。
+// 正規化済みの発言者が未設定の投稿は、発言者の完全一致で取得する。
+func (repository *postRepository) FetchBySpeaker(normalizedSpeaker, speaker string) (posts []*model.Post, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Select("id, user_id, title, speaker, normalized_title, normalized_speaker").
+		Where("normalized_speaker = ?", normalizedSpeaker).
+		Or("normalized_speaker = '' AND speaker = ?", speaker).
+		Order("id ASC").
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// FetchAllTitles 全投稿のタイトル、発言者取得(重複検出用)
+func (repository *postRepository) FetchAllTitles() (posts []*model.Post, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Select("id, user_id, title, speaker, normalized_title, normalized_speaker").
+		Order("id ASC").
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// FetchPostsByUserID ユーザーの全投稿取得(個人データのエクスポート用)
+func (repository *postRepository) FetchPostsByUserID(userID int) (posts []*model.Post, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Where("user_id = ?", userID).Order("id ASC").Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// FetchFieldCounts 項目(発言者、タイトル)の値ごとの使用回数取得
+func (repository *postRepository) FetchFieldCounts(field string) (candidates []*model.AutocompleteCandidate, err error) {
+	if field != "speaker" && field != "title" {
+		return nil, fmt.Errorf("invalid field: %s", field)
+	}
+
+	db := conf.DBConnection()
+
+	if err = db.Model(&model.Post{}).
+		Select(fmt.Sprintf("%s AS text, count(*) AS count", field)).
+		Where(fmt.Sprintf("%s <> ''", field)).
+		Where("is_hidden = ?", false).
+		Group(field).
+		Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// FetchRandomCandidateIDRange 条件に一致する投稿のIDの最小値、最大値取得(ランダム取得用)。一致する投稿がない場合は0を返す。
+func (repository *postRepository) FetchRandomCandidateIDRange(condition *model.RandomPostCondition) (minID, maxID int, err error) {
+	db := conf.DBConnection()
+
+	if err = randomCandidates(db, condition).
+		Select("COALESCE(MIN(posts.id), 0), COALESCE(MAX(posts.id), 0)").
+		Row().Scan(&minID, &maxID); err != nil {
+		return 0, 0, err
+	}
+
+	return minID, maxID, nil
+}
+
+// FetchRandomCandidateID 条件に一致し、IDがfromID以上でexcludeIDsに含まれない投稿のうち、IDが最小のもの取得(ランダム取得用)。
+// 主キーの範囲検索とするため、ORDER BY RAND()のような全件ソートは行わない。ない場合は0を返す。
+func (repository *postRepository) FetchRandomCandidateID(condition *model.RandomPostCondition, fromID int, excludeIDs []int) (int, error) {
+	db := conf.DBConnection()
+
+	db = randomCandidates(db, condition).Where("posts.id >= ?", fromID)
+	if len(excludeIDs) > 0 {
+		db = db.Where("posts.id NOT IN (?)", excludeIDs)
+	}
+
+	var ids []int
+	if err := db.Order("posts.id ASC").Limit(1).Pluck("posts.id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
 
-	return db.Create(comment).Error
+	return ids[0], nil
+}
+
+// randomCandidates ランダム取得の条件に一致する投稿に絞り込む。
+func randomCandidates(db *gorm.DB, condition *model.RandomPostCondition) *gorm.DB {
+	db = db.Model(&model.Post{}).
+		Joins("JOIN users ON users.id = posts.user_id AND users.deleted_at IS NULL").
+		Where("posts.is_hidden = false")
+	if condition.Speaker != "" {
+		db = db.Where("posts.speaker = ?", condition.Speaker)
+	}
+	if condition.Tag != "" {
+		db = db.Where("posts.id IN (SELECT post_id FROM post_tags WHERE tag = ?)", condition.Tag)
+	}
+	if condition.SourceType != "" {
+		db = db.Where("posts.source_type = ?", condition.SourceType)
+	}
+	if condition.MinFavoriteCount > 0 {
+		db = db.Where("(SELECT count(*) FROM favorites WHERE favorites.post_id = posts.id) >= ?", condition.MinFavoriteCount)
+	}
+	if condition.ExcludeSeen && condition.LoginUserID > 0 {
+		db = db.Where("posts.id NOT IN (SELECT post_id FROM seen_posts WHERE user_id = ?)", condition.LoginUserID)
+	}
+	if condition.ExcludeFavorited && condition.LoginUserID > 0 {
+		db = db.Where("posts.id NOT IN (SELECT post_id FROM favorites WHERE user_id = ?)", condition.LoginUserID)
+	}
+	return db
+}
+
+// FetchByIDs 投稿ID指定の一覧取得。ids順に返し、存在しないIDは除く。
+func (repository *postRepository) FetchByIDs(ids []int, loginUserID int) ([]*model.GetPostResult, error) {
+	if len(ids) == 0 {
+		return []*model.GetPostResult{}, nil
+	}
+
+	db := conf.DBConnection()
+
+	var found []*model.GetPostResult
+	if err := db.Table("posts").
+		Select(`posts.*,
+			users.name as user_name,
+			users.image_file_path as user_image_file_path,
+			(SELECT count(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL AND comments.is_hidden = false) AS comment_count,
+			(CASE WHEN favorites.id IS NULL THEN false ELSE true END) AS is_favorite,
+			(SELECT count(*) FROM favorites AS f WHERE f.post_id = posts.id) AS favorite_count,
+			(SELECT COALESCE(SUM(v.views), 0) FROM post_daily_views AS v WHERE v.post_id = posts.id) AS view_count
+		`).
+		Joins(fmt.Sprintf(`JOIN users ON users.id = posts.user_id AND users.deleted_at IS NULL
+			LEFT JOIN favorites ON favorites.post_id = posts.id AND favorites.user_id = %d`, loginUserID)).
+		Where("posts.id IN (?) AND posts.deleted_at IS NULL AND posts.is_hidden = false", ids).
+		Find(&found).Error; err != nil {
+		return nil, err
+	}
+	if err := loadPostTags(db, found); err != nil {
+		return nil, err
+	}
+
+	postMap := map[int]*model.GetPostResult{}
+	for _, post := range found {
+		postMap[post.ID] = post
+	}
+	posts := make([]*model.GetPostResult, 0, len(found))
+	for _, id := range ids {
+		if post, ok := postMap[id]; ok {
+			posts = append(posts, post)
+		}
+	}
+
+	return posts, nil
+}
+
+// CreateComment コメント登録
+func (repository *postRepository) CreateComment(comment *model.Comment, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, events)
+	})
 }
 
 // FetchComments コメント一覧取得
 func (repository *postRepository) FetchComments(postID, limit, page int) (totalCount int, comments []*model.GetCommentResult, err error) {
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
-	countDb := conf.NewDBConnection()
-	defer countDb.Close()
+	countDb := conf.DBConnection()
 
-	if err = countDb.Model(&model.Comment{}).Where("post_id = ?", postID).Count(&totalCount).Error; err != nil {
+	if err = countDb.Model(&model.Comment{}).Where("post_id = ? AND is_hidden = false", postID).Count(&totalCount).Error; err != nil {
 		return 0, nil, err
 	}
 
@@ -139,7 +435,7 @@ func (repository *postRepository) FetchComments(postID, limit, page int) (totalC
 	if err = db.Table("comments").
 		Select("comments.*, users.name as user_name, users.image_file_path as user_image_file_path").
 		Joins("JOIN users on users.id = comments.user_id AND users.deleted_at IS NULL").
-		Where("post_id = ?", postID).
+		Where("post_id = ? AND comments.is_hidden = false", postID).
 		Order("id DESC").Limit(limit).Offset(offset).
 		Find(&comments).Error; err != nil {
 		return 0, nil, err
@@ -148,47 +444,85 @@ func (repository *postRepository) FetchComments(postID, limit, page int) (totalC
 	return totalCount, comments, err
 }
 
-// DeleteComment コメント削除
-func (repository *postRepository) DeleteComment(id int) error {
-	db := conf.NewDBConnection()
-	defer db.Close()
+// DeleteComment コメント削除。削除した場合のみイベントを保存する。
+func (repository *postRepository) DeleteComment(id int, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&model.Comment{ID: id})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return saveDomainEvents(tx, events)
+	})
+}
 
-	comment := model.Comment{ID: id}
-	return db.Delete(&comment).Error
+// FetchCommentsByUserID ユーザーの全コメント取得(個人データのエクスポート用)
+func (repository *postRepository) FetchCommentsByUserID(userID int) (comments []*model.Comment, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Where("user_id = ?", userID).Order("id ASC").Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
 }
 
 // CreateFavorite お気に入り登録
-func (repository *postRepository) CreateFavorite(favorite *model.Favorite) error {
-	db := conf.NewDBConnection()
-	defer db.Close()
-
-	return db.Create(favorite).Error
+func (repository *postRepository) CreateFavorite(favorite *model.Favorite, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(favorite).Error; err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, events)
+	})
 }
 
-// FetchFavorites お気に入り一覧取得
-func (repository *postRepository) FetchFavorites(userID, limit, page int) (totalCount int, posts []*model.GetPostResult, err error) {
-	db := conf.NewDBConnection()
-	defer db.Close()
+// FetchFavorites お気に入り一覧取得。
+// キーワード検索を行わない場合はkeywordに、理由の分類で絞り込まない場合はtagに空文字を指定する。
+// includeNoteがtrueの場合はメモ、理由の分類も取得し、キーワードの検索対象にメモを含める。
+// includeNoteがfalseの場合、tagは無視する。
+func (repository *postRepository) FetchFavorites(userID, limit, page int, keyword, tag string, includeNote bool) (totalCount int, posts []*model.GetPostResult, err error) {
+	db := conf.DBConnection()
+
+	db = db.Unscoped().Table("favorites").
+		Joins(`JOIN posts ON posts.id = favorites.post_id AND posts.deleted_at IS NULL AND posts.is_hidden = false
+			JOIN users ON users.id = posts.user_id AND users.deleted_at IS NULL`).
+		Where("favorites.user_id = ?", userID)
 
-	countDb := conf.NewDBConnection()
-	defer countDb.Close()
+	if keyword != "" {
+		like := "%" + keyword + "%"
+		if includeNote {
+			// キーワードがタイトル、発言者、メモのいずれかに含まれる
+			db = db.Where("(posts.title LIKE ? OR posts.speaker LIKE ? OR favorites.note LIKE ?)", like, like, like)
+		} else {
+			db = db.Where("(posts.title LIKE ? OR posts.speaker LIKE ?)", like, like)
+		}
+	}
+	if includeNote && tag != "" {
+		db = db.Where("favorites.tag = ?", tag)
+	}
 
-	if err = countDb.Model(&model.Favorite{}).Where("user_id = ?", userID).Count(&totalCount).Error; err != nil {
+	if err = db.Count(&totalCount).Error; err != nil {
 		return 0, nil, err
 	}
 
+	noteColumns := "'' AS favorite_note, '' AS favorite_tag"
+	if includeNote {
+		noteColumns = "favorites.note AS favorite_note, favorites.tag AS favorite_tag"
+	}
+
 	offset := limit * (page - 1)
-	if err = db.Unscoped().Table("favorites").
-		Select(`posts.*,
+	if err = db.Select(`posts.*,
 			users.name AS user_name,
 			users.image_file_path AS user_image_file_path,
-			(SELECT count(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL) AS comment_count,
+			(SELECT count(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL AND comments.is_hidden = false) AS comment_count,
 			true AS is_favorite,
-			(SELECT count(*) FROM favorites AS f WHERE f.post_id = posts.id) AS favorite_count
-		`).
-		Joins(`JOIN posts ON posts.id = favorites.post_id AND posts.deleted_at IS NULL
-			JOIN users ON users.id = posts.user_id AND users.deleted_at IS NULL`).
-		Where("favorites.user_id = ?", userID).
+			(SELECT count(*) FROM favorites AS f WHERE f.post_id = posts.id) AS favorite_count,
+			(SELECT COALESCE(SUM(v.views), 0) FROM post_daily_views AS v WHERE v.post_id = posts.id) AS view_count,
+			` + noteColumns).
 		Order("posts.id DESC").Limit(limit).Offset(offset).
 		Find(&posts).Error; err != nil {
 		return 0, nil, err
@@ -197,10 +531,59 @@ func (repository *postRepository) FetchFavorites(userID, limit, page int) (total
 	return totalCount, posts, err
 }
 
-// DeleteFavorite お気に入り削除
-func (repository *postRepository) DeleteFavorite(userID, postID int) error {
-	db := conf.NewDBConnection()
-	defer db.Close()
+// FetchFavorite お気に入り1件取得。存在しない場合はnilを返す。
+func (repository *postRepository) FetchFavorite(userID, postID int) (*model.Favorite, error) {
+	db := conf.DBConnection()
+
+	favorite := model.Favorite{}
+	err := db.Where("user_id = ? AND post_id = ?", userID, postID).First(&favorite).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &favorite, nil
+}
+
+// UpdateFavoriteNote お気に入りのメモ、理由の分類の更新
+func (repository *postRepository) UpdateFavoriteNote(favorite *model.Favorite) error {
+	db := conf.DBConnection()
+
+	// メモの削除を反映するため、空文字も更新する
+	return db.Model(&model.Favorite{ID: favorite.ID}).Updates(map[string]interface{}{
+		"note": favorite.Note,
+		"tag":  favorite.Tag,
+	}).Error
+}
+
+// DeleteFavorite お気に入り削除。削除した場合のみイベントを保存する。
+func (repository *postRepository) DeleteFavorite(userID, postID int, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND post_id = ?", userID, postID).Delete(&model.Favorite{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return saveDomainEvents(tx, events)
+	})
+}
+
+// FetchFavoritesByUserID ユーザーの全お気に入り取得(個人データのエクスポート用)
+func (repository *postRepository) FetchFavoritesByUserID(userID int) (favorites []*model.Favorite, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Where("user_id = ?", userID).Order("id ASC").Find(&favorites).Error; err != nil {
+		return nil, err
+	}
+
+	return favorites, nil
+}
 
-	return db.Where("user_id = ? AND post_id = ?", userID, postID).Delete(&model.Favorite{}).Error
+// dailyCount 日付ごとの件数
+type dailyCount struct {
+	Date  string
+	Count int
 }
@@ -3,9 +3,11 @@ package datastore
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/k-kazuya0926/power-phrase2-api/conf"
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	domainRepository "github.com/k-kazuya0926/power-phrase2-api/domain/repository"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,8 +15,7 @@ import (
 func TestPostRepository_Create(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	userForInput := makeUserForInput(1)
 	db.Create(&userForInput)
@@ -24,7 +25,7 @@ func TestPostRepository_Create(t *testing.T) {
 	postForInput := makePost(userForInput.ID)
 
 	// 2. Exercise
-	err := repository.Create(postForInput)
+	err := repository.Create(postForInput, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -47,12 +48,46 @@ func TestPostRepository_Create(t *testing.T) {
 	teardown(db)
 }
 
+// 投稿の一括登録
+func TestPostRepository_CreatePosts(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	repository := &postRepository{}
+	posts := []*model.Post{makePost(userForInput.ID), makePost(userForInput.ID)}
+
+	// 2. Exercise
+	err := repository.CreatePosts(posts, nil)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.NotZero(t, posts[0].ID)
+	assert.NotZero(t, posts[1].ID)
+
+	var count int
+	db.Table("posts").Count(&count)
+	assert.Equal(t, 2, count)
+
+	// 1件でも失敗した場合は全件登録しない
+	err = repository.CreatePosts([]*model.Post{makePost(userForInput.ID), makePost(userForInput.ID + 100)}, nil)
+	assert.Error(t, err)
+	db.Table("posts").Count(&count)
+	assert.Equal(t, 2, count)
+
+	// 4. Teardown
+	teardown(db)
+}
+
 // 投稿一覧取得
 func TestPostRepository_Fetch(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	userForInput := makeUserForInput(1)
 	db.Create(&userForInput)
@@ -69,7 +104,7 @@ func TestPostRepository_Fetch(t *testing.T) {
 	loginUserID := 0 // TODO ログインユーザーID指定がある場合
 
 	// 2. Exercise
-	totalCount, posts, err := repository.Fetch(1, 1, "", postUserID, loginUserID)
+	totalCount, posts, err := repository.Fetch(1, 1, "", postUserID, loginUserID, false, "")
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -86,12 +121,91 @@ func TestPostRepository_Fetch(t *testing.T) {
 	teardown(db)
 }
 
+// キーワード指定の投稿一覧取得
+func TestPostRepository_Fetch_keyword(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	postForInput := makePost(userForInput.ID)
+	postForInput.Title = "keyword"
+	db.Create(&postForInput)
+	// 非表示
+	postForInput2 := makePost(userForInput.ID)
+	postForInput2.Speaker = "keyword"
+	postForInput2.IsHidden = true
+	db.Create(&postForInput2)
+	// 言語が一致しない
+	postForInput3 := makePost(userForInput.ID)
+	postForInput3.Detail = "keyword"
+	postForInput3.Language = "en"
+	db.Create(&postForInput3)
+	// キーワードを含まない
+	postForInput4 := makePost(userForInput.ID)
+	db.Create(&postForInput4)
+
+	repository := &postRepository{}
+
+	// 2. Exercise
+	totalCount, posts, err := repository.Fetch(10, 1, "keyword", 0, 0, false, "ja")
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 1, totalCount)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, postForInput.ID, posts[0].ID)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// 言語指定の投稿一覧取得
+func TestPostRepository_Fetch_language(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	// 原文が地域付きの言語
+	postForInput := makePost(userForInput.ID)
+	postForInput.Language = "en-US"
+	db.Create(&postForInput)
+	// 翻訳が地域付きの言語
+	postForInput2 := makePost(userForInput.ID)
+	db.Create(&postForInput2)
+	db.Create(&model.PostTranslation{PostID: postForInput2.ID, Language: "en-GB", Title: "title", TranslatorID: userForInput.ID})
+	// 別の言語
+	postForInput3 := makePost(userForInput.ID)
+	postForInput3.Language = "es"
+	db.Create(&postForInput3)
+
+	repository := &postRepository{}
+
+	// 2. Exercise
+	totalCount, posts, err := repository.Fetch(10, 1, "", 0, 0, false, "en")
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, totalCount)
+	assert.Equal(t, postForInput2.ID, posts[0].ID)
+	assert.Equal(t, postForInput.ID, posts[1].ID)
+
+	// 4. Teardown
+	teardown(db)
+}
+
 // 投稿詳細取得
 func TestPostRepository_FetchById(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	userForInput := makeUserForInput(1)
 	db.Create(&userForInput)
@@ -109,9 +223,11 @@ func TestPostRepository_FetchById(t *testing.T) {
 
 	// 2. Exercise
 	actualPost, err := repository.FetchByID(postForInput.ID, userForInput.ID)
+	_, notFoundErr := repository.FetchByID(postForInput2.ID+1, userForInput.ID)
 
 	// 3. Verify
 	assert.NoError(t, err)
+	assert.Equal(t, domainRepository.ErrNotFound, notFoundErr)
 
 	// 内容
 	assert.Equal(t, postForInput.ID, actualPost.ID)
@@ -129,25 +245,31 @@ func TestPostRepository_FetchById(t *testing.T) {
 func TestPostRepository_Update(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	userForInput := makeUserForInput(1)
 	db.Create(&userForInput)
 	db.First(&userForInput)
 
 	postForInput := makePost(userForInput.ID)
+	postForInput.License = model.LicenseCCBY
+	postForInput.PostSource = model.PostSource{SourceType: model.SourceTypeBook, SourceTitle: "book", SourceLocator: "p.12", PublicationYear: 2000}
 	db.Create(&postForInput)
 	db.First(&postForInput)
+	db.Create(&model.PostTag{PostID: postForInput.ID, Tag: "tag1"})
 	postForInput.Title = "title2"
 	postForInput.Speaker = "speaker2"
-	postForInput.Detail = "detail2"
-	postForInput.MovieURL = "https://www.example.com/watch?v=2"
+	postForInput.Detail = ""
+	postForInput.MovieURL = ""
+	postForInput.License = ""
+	postForInput.PostSource = model.PostSource{}
+	postForInput.Language = ""
+	postForInput.Tags = []string{"tag2"}
 
 	repository := &postRepository{}
 
 	// 2. Exercise
-	err := repository.Update(postForInput)
+	err := repository.Update(postForInput, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -165,6 +287,48 @@ func TestPostRepository_Update(t *testing.T) {
 	assert.Equal(t, postForInput.Speaker, post.Speaker)
 	assert.Equal(t, postForInput.Detail, post.Detail)
 	assert.Equal(t, postForInput.MovieURL, post.MovieURL)
+	// 空の値でも更新される
+	assert.Equal(t, "", post.License)
+	assert.Equal(t, model.PostSource{}, post.PostSource)
+	// 言語は空文字の場合は変更されない
+	assert.Equal(t, model.DefaultLanguage, post.Language)
+	// タグは置き換えられる
+	result, _ := repository.FetchByID(postForInput.ID, 0)
+	assert.Equal(t, []string{"tag2"}, result.Tags)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// 確認待ちの禁止語を含む投稿の更新
+func TestPostRepository_Update_hidden(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user := makeUserForInput(1)
+	db.Create(&user)
+	post := makePost(user.ID)
+	db.Create(post)
+	post.Title = "title2"
+	post.IsHidden = true
+
+	repository := &postRepository{}
+	moderationRepository := &moderationRepository{}
+
+	// 2. Exercise
+	err := repository.Update(post, nil)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	_, hiddenErr := repository.FetchByID(post.ID, 0)
+	assert.Error(t, hiddenErr)
+	moderated, moderatedErr := moderationRepository.FetchPostForModeration(post.ID)
+	assert.NoError(t, moderatedErr)
+	assert.True(t, moderated.IsHidden)
+	assert.Equal(t, "title2", moderated.Title)
+	totalCount, _, _ := repository.Fetch(10, 1, "", 0, 0, false, "")
+	assert.Equal(t, 0, totalCount)
 
 	// 4. Teardown
 	teardown(db)
@@ -174,8 +338,7 @@ func TestPostRepository_Update(t *testing.T) {
 func TestPostRepository_Delete(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	userForInput := makeUserForInput(1)
 	db.Create(&userForInput)
@@ -188,7 +351,7 @@ func TestPostRepository_Delete(t *testing.T) {
 	repository := &postRepository{}
 
 	// 2. Exercise
-	err := repository.Delete(postForInput.ID)
+	err := repository.Delete(postForInput.ID, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -199,12 +362,161 @@ func TestPostRepository_Delete(t *testing.T) {
 	teardown(db)
 }
 
+// 項目の値ごとの使用回数取得
+func TestPostRepository_FetchFieldCounts(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	db.Create(makePost(userForInput.ID))
+	db.Create(makePost(userForInput.ID))
+	// 非表示の投稿は数えない
+	hiddenPost := makePost(userForInput.ID)
+	hiddenPost.IsHidden = true
+	db.Create(hiddenPost)
+
+	repository := &postRepository{}
+
+	// 2. Exercise
+	candidates, err := repository.FetchFieldCounts("speaker")
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.AutocompleteCandidate{{Text: fmt.Sprintf("speaker%d", userForInput.ID), Count: 2}}, candidates)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// ランダム取得用の投稿IDの最小値、最大値取得
+func TestPostRepository_FetchRandomCandidateIDRange(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	postForInput := makePost(userForInput.ID)
+	db.Create(postForInput)
+	postForInput2 := makePost(userForInput.ID)
+	postForInput2.Speaker = "other"
+	db.Create(postForInput2)
+	postForInput3 := makePost(userForInput.ID)
+	db.Create(postForInput3)
+
+	repository := &postRepository{}
+
+	// 2. Exercise
+	minID, maxID, err := repository.FetchRandomCandidateIDRange(&model.RandomPostCondition{Speaker: postForInput.Speaker})
+	noneMinID, noneMaxID, noneErr := repository.FetchRandomCandidateIDRange(&model.RandomPostCondition{Speaker: "none"})
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, postForInput.ID, minID)
+	assert.Equal(t, postForInput3.ID, maxID)
+	assert.NoError(t, noneErr)
+	assert.Equal(t, 0, noneMinID)
+	assert.Equal(t, 0, noneMaxID)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// ランダム取得用の投稿ID取得
+func TestPostRepository_FetchRandomCandidateID(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	repository := &postRepository{}
+	postViewRepository := &postViewRepository{}
+
+	postForInput := makePost(userForInput.ID)
+	postForInput.Tags = []string{"tag1"}
+	repository.Create(postForInput, nil)
+	postForInput2 := makePost(userForInput.ID)
+	postForInput2.Speaker = "other"
+	db.Create(postForInput2)
+	postForInput3 := makePost(userForInput.ID)
+	postForInput3.Tags = []string{"tag1", "tag2"}
+	repository.Create(postForInput3, nil)
+
+	// お気に入り、閲覧済み
+	db.Create(makeFavorite(userForInput.ID, postForInput.ID))
+	postViewRepository.SaveSeenPosts(userForInput.ID, []int{postForInput3.ID}, time.Now())
+
+	// 2. Exercise
+	id, err := repository.FetchRandomCandidateID(&model.RandomPostCondition{Speaker: postForInput.Speaker}, postForInput.ID+1, []int{})
+	excludedID, excludedErr := repository.FetchRandomCandidateID(&model.RandomPostCondition{Speaker: postForInput.Speaker}, postForInput.ID+1, []int{postForInput3.ID})
+	taggedID, taggedErr := repository.FetchRandomCandidateID(&model.RandomPostCondition{Tag: "tag2"}, postForInput.ID, []int{})
+	favoritedID, favoritedErr := repository.FetchRandomCandidateID(&model.RandomPostCondition{MinFavoriteCount: 1}, postForInput.ID, []int{})
+	notFavoritedID, notFavoritedErr := repository.FetchRandomCandidateID(&model.RandomPostCondition{LoginUserID: userForInput.ID, ExcludeFavorited: true}, postForInput.ID, []int{})
+	notSeenID, notSeenErr := repository.FetchRandomCandidateID(&model.RandomPostCondition{Tag: "tag1", LoginUserID: userForInput.ID, ExcludeSeen: true}, postForInput.ID+1, []int{})
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, postForInput3.ID, id)
+	assert.NoError(t, excludedErr)
+	assert.Equal(t, 0, excludedID)
+	assert.NoError(t, taggedErr)
+	assert.Equal(t, postForInput3.ID, taggedID)
+	assert.NoError(t, favoritedErr)
+	assert.Equal(t, postForInput.ID, favoritedID)
+	assert.NoError(t, notFavoritedErr)
+	assert.Equal(t, postForInput2.ID, notFavoritedID)
+	assert.NoError(t, notSeenErr)
+	assert.Equal(t, 0, notSeenID)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// 投稿ID指定の一覧取得
+func TestPostRepository_FetchByIDs(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	postForInput := makePost(userForInput.ID)
+	db.Create(postForInput)
+	postForInput2 := makePost(userForInput.ID)
+	db.Create(postForInput2)
+
+	repository := &postRepository{}
+
+	// 2. Exercise
+	posts, err := repository.FetchByIDs([]int{postForInput2.ID, 0, postForInput.ID}, userForInput.ID)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(posts))
+	assert.Equal(t, postForInput2.ID, posts[0].ID)
+	assert.Equal(t, postForInput.ID, posts[1].ID)
+	assert.Equal(t, userForInput.Name, posts[0].UserName)
+
+	// 4. Teardown
+	teardown(db)
+}
+
 // コメント登録
 func TestPostRepository_CreateComment(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	userForInput := makeUserForInput(1)
 	db.Create(&userForInput)
@@ -218,7 +530,7 @@ func TestPostRepository_CreateComment(t *testing.T) {
 	commentForInput := makeComment(postForInput.ID, userForInput.ID)
 
 	// 2. Exercise
-	err := repository.CreateComment(commentForInput)
+	err := repository.CreateComment(commentForInput, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -243,8 +555,7 @@ func TestPostRepository_CreateComment(t *testing.T) {
 func TestPostRepository_FetchComments(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	userForInput := makeUserForInput(1)
 	db.Create(&userForInput)
@@ -283,8 +594,7 @@ func TestPostRepository_FetchComments(t *testing.T) {
 func TestPostRepository_DeleteComment(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	userForInput := makeUserForInput(1)
 	db.Create(&userForInput)
@@ -301,7 +611,7 @@ func TestPostRepository_DeleteComment(t *testing.T) {
 	repository := &postRepository{}
 
 	// 2. Exercise
-	err := repository.DeleteComment(commentForInput.ID)
+	err := repository.DeleteComment(commentForInput.ID, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -316,8 +626,7 @@ func TestPostRepository_DeleteComment(t *testing.T) {
 func TestPostRepository_CreateFavorite(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	// ユーザー
 	userForInput := makeUserForInput(1)
@@ -333,9 +642,10 @@ func TestPostRepository_CreateFavorite(t *testing.T) {
 	favoriteForInput := makeFavorite(userForInput.ID, postForInput.ID)
 
 	repository := &postRepository{}
+	favoriteCountRepository := &favoriteCountRepository{}
 
 	// 2. Exercise
-	err := repository.CreateFavorite(favoriteForInput)
+	err := repository.CreateFavorite(favoriteForInput, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -351,6 +661,11 @@ func TestPostRepository_CreateFavorite(t *testing.T) {
 	assert.Equal(t, favoriteForInput.UserID, favorite.UserID)
 	assert.Equal(t, favoriteForInput.PostID, favorite.PostID)
 
+	// お気に入り数
+	favoriteCount, err := favoriteCountRepository.CountFavorites(postForInput.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, favoriteCount)
+
 	// 4. Teardown
 	teardown(db)
 }
@@ -359,8 +674,7 @@ func TestPostRepository_CreateFavorite(t *testing.T) {
 func TestPostRepository_FetchFavorites(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	// ユーザー
 	userForInput := makeUserForInput(1)
@@ -386,7 +700,7 @@ func TestPostRepository_FetchFavorites(t *testing.T) {
 	repository := &postRepository{}
 
 	// 2. Exercise
-	totalCount, favorites, err := repository.FetchFavorites(userForInput.ID, 10, 1)
+	totalCount, favorites, err := repository.FetchFavorites(userForInput.ID, 10, 1, "", "", false)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -402,12 +716,58 @@ func TestPostRepository_FetchFavorites(t *testing.T) {
 	teardown(db)
 }
 
+// お気に入りのメモ検索
+func TestPostRepository_FetchFavorites_note(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	postForInput := makePost(userForInput.ID)
+	db.Create(postForInput)
+	postForInput2 := makePost(userForInput.ID)
+	db.Create(postForInput2)
+
+	favoriteForInput := makeFavorite(userForInput.ID, postForInput.ID)
+	db.Create(favoriteForInput)
+	favoriteForInput2 := makeFavorite(userForInput.ID, postForInput2.ID)
+	db.Create(favoriteForInput2)
+
+	repository := &postRepository{}
+	favorite, err := repository.FetchFavorite(userForInput.ID, postForInput.ID)
+	assert.NoError(t, err)
+	favorite.Note = "試合前に読む"
+	favorite.Tag = "励まし"
+	assert.NoError(t, repository.UpdateFavoriteNote(favorite))
+
+	// 2. Exercise
+	ownCount, ownFavorites, ownErr := repository.FetchFavorites(userForInput.ID, 10, 1, "試合前", "励まし", true)
+	otherCount, otherFavorites, otherErr := repository.FetchFavorites(userForInput.ID, 10, 1, "", "励まし", false)
+
+	// 3. Verify
+	assert.NoError(t, ownErr)
+	assert.Equal(t, 1, ownCount)
+	assert.Equal(t, postForInput.ID, ownFavorites[0].ID)
+	assert.Equal(t, "試合前に読む", ownFavorites[0].FavoriteNote)
+	assert.Equal(t, "励まし", ownFavorites[0].FavoriteTag)
+
+	// 本人以外の場合、理由の分類は無視され、メモは取得されない
+	assert.NoError(t, otherErr)
+	assert.Equal(t, 2, otherCount)
+	assert.Equal(t, "", otherFavorites[1].FavoriteNote)
+
+	// 4. Teardown
+	teardown(db)
+}
+
 // お気に入り削除
 func TestPostRepository_DeleteFavorite(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	// ユーザー
 	userForInput := makeUserForInput(1)
@@ -427,7 +787,7 @@ func TestPostRepository_DeleteFavorite(t *testing.T) {
 	repository := &postRepository{}
 
 	// 2. Exercise
-	err := repository.DeleteFavorite(userForInput.ID, postForInput.ID)
+	err := repository.DeleteFavorite(userForInput.ID, postForInput.ID, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -475,3 +835,44 @@ func makeFavorite(userID, postID int) *model.Favorite {
 		PostID: postID,
 	}
 }
+
+// ユーザーの全投稿、コメント、お気に入り取得(個人データのエクスポート用)
+func TestPostRepository_FetchByUserID(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user1 := makeUserForInput(1)
+	db.Create(&user1)
+	user2 := makeUserForInput(2)
+	db.Create(&user2)
+
+	post1 := makePost(user1.ID)
+	db.Create(post1)
+	post2 := makePost(user2.ID)
+	db.Create(post2)
+	db.Create(&model.Comment{PostID: post2.ID, UserID: user1.ID, Body: "comment1"})
+	db.Create(&model.Comment{PostID: post1.ID, UserID: user2.ID, Body: "comment2"})
+	db.Create(&model.Favorite{UserID: user1.ID, PostID: post2.ID, Note: "note1"})
+
+	repository := &postRepository{}
+
+	// 2. Exercise
+	posts, postsErr := repository.FetchPostsByUserID(user1.ID)
+	comments, commentsErr := repository.FetchCommentsByUserID(user1.ID)
+	favorites, favoritesErr := repository.FetchFavoritesByUserID(user1.ID)
+
+	// 3. Verify
+	assert.NoError(t, postsErr)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, post1.ID, posts[0].ID)
+	assert.NoError(t, commentsErr)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "comment1", comments[0].Body)
+	assert.NoError(t, favoritesErr)
+	assert.Len(t, favorites, 1)
+	assert.Equal(t, "note1", favorites[0].Note)
+
+	// 4. Teardown
+	teardown(db)
+}
@@ -0,0 +1,145 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// postViewRepository 構造体
+type postViewRepository struct {
+}
+
+// NewPostViewRepository PostViewRepositoryを生成する。
+func NewPostViewRepository() repository.PostViewRepository {
+	return &postViewRepository{}
+}
+
+// SavePostViews 閲覧の記録。同じ投稿、閲覧者、期間の閲覧が記録済みでないものだけ、日付ごとの閲覧数に加算し、ログインユーザーの閲覧済みの投稿として記録する。
+// 重複の除外はpost_viewsテーブルの一意キーで行うため、複数のサーバーで閲覧した場合も1回と数える。
+func (repository *postViewRepository) SavePostViews(views []*model.PostView) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, view := range views {
+			// 記録済みの場合は何も更新せず、RowsAffectedが0になる
+			result := tx.Exec(`INSERT INTO post_views (created_at, post_id, viewer, window_start) VALUES (?, ?, ?, ?)
+				ON DUPLICATE KEY UPDATE id = id`,
+				view.CreatedAt, view.PostID, view.Viewer, view.WindowStart)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+			if err := tx.Exec(`INSERT INTO post_daily_views (created_at, updated_at, post_id, date, views)
+				VALUES (NOW(), NOW(), ?, ?, 1)
+				ON DUPLICATE KEY UPDATE updated_at = NOW(), views = views + 1`,
+				view.PostID, view.Date).Error; err != nil {
+				return err
+			}
+			if view.UserID == 0 {
+				continue
+			}
+			if err := saveSeenPost(tx, view.UserID, view.PostID, view.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeletePostViews 期間の開始日時がbeforeより前の閲覧の記録を削除する
+func (repository *postViewRepository) DeletePostViews(before time.Time) error {
+	db := conf.DBConnection()
+
+	return db.Where("window_start < ?", before).Delete(&model.PostView{}).Error
+}
+
+// SaveSeenPosts ログインユーザーが閲覧した投稿の記録。閲覧済みの場合は閲覧日時を更新する。
+func (repository *postViewRepository) SaveSeenPosts(userID int, postIDs []int, seenAt time.Time) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, postID := range postIDs {
+			if err := saveSeenPost(tx, userID, postID, seenAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// saveSeenPost ログインユーザーが閲覧した投稿を記録する。閲覧済みの場合は閲覧日時を更新する。
+func saveSeenPost(tx *gorm.DB, userID, postID int, seenAt time.Time) error {
+	return tx.Exec(`INSERT INTO seen_posts (user_id, post_id, seen_at) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE seen_at = VALUES(seen_at)`,
+		userID, postID, seenAt).Error
+}
+
+// FetchPostDailyStats ユーザーの投稿の日付ごとの閲覧数、お気に入り数、コメント数取得。postIDが0の場合は全ての投稿の合計を返す。
+// 削除済みの投稿は含めない。お気に入り、コメントは登録日時の日付で数え、削除されたものは含めない。
+func (repository *postViewRepository) FetchPostDailyStats(userID, postID int, from, to string) ([]*model.PostDailyStat, error) {
+	db := conf.DBConnection()
+
+	postCondition := func(query *gorm.DB) *gorm.DB {
+		query = query.Joins("JOIN posts ON posts.id = post_id AND posts.deleted_at IS NULL").
+			Where("posts.user_id = ?", userID)
+		if postID > 0 {
+			query = query.Where("posts.id = ?", postID)
+		}
+		return query
+	}
+
+	var views, favorites, comments []*dailyCount
+	if err := postCondition(db.Table("post_daily_views")).
+		Select("post_daily_views.date AS date, SUM(post_daily_views.views) AS count").
+		Where("post_daily_views.date BETWEEN ? AND ?", from, to).
+		Group("post_daily_views.date").
+		Scan(&views).Error; err != nil {
+		return nil, err
+	}
+	if err := postCondition(db.Table("favorites")).
+		Select("DATE_FORMAT(favorites.created_at, '%Y-%m-%d') AS date, COUNT(*) AS count").
+		Where("DATE(favorites.created_at) BETWEEN ? AND ?", from, to).
+		Group("date").
+		Scan(&favorites).Error; err != nil {
+		return nil, err
+	}
+	if err := postCondition(db.Table("comments")).
+		Select("DATE_FORMAT(comments.created_at, '%Y-%m-%d') AS date, COUNT(*) AS count").
+		Where("comments.deleted_at IS NULL AND DATE(comments.created_at) BETWEEN ? AND ?", from, to).
+		Group("date").
+		Scan(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	statsByDate := map[string]*model.PostDailyStat{}
+	stat := func(date string) *model.PostDailyStat {
+		if _, ok := statsByDate[date]; !ok {
+			statsByDate[date] = &model.PostDailyStat{Date: date}
+		}
+		return statsByDate[date]
+	}
+	for _, count := range views {
+		stat(count.Date).Views = count.Count
+	}
+	for _, count := range favorites {
+		stat(count.Date).Favorites = count.Count
+	}
+	for _, count := range comments {
+		stat(count.Date).Comments = count.Count
+	}
+
+	stats := make([]*model.PostDailyStat, 0, len(statsByDate))
+	for _, stat := range statsByDate {
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Date < stats[j].Date })
+	return stats, nil
+}
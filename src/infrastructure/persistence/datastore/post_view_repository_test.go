@@ -0,0 +1,77 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostViewRepository_PostDailyStats(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user := makeUserForInput(1)
+	db.Create(&user)
+	otherUser := makeUserForInput(2)
+	db.Create(&otherUser)
+	post := makePost(user.ID)
+	db.Create(post)
+	db.Create(makeFavorite(otherUser.ID, post.ID))
+	db.Create(makeComment(post.ID, otherUser.ID))
+	now := time.Now()
+	today := now.Format("2006-01-02")
+	window := now.Truncate(time.Hour)
+	yesterdayWindow := time.Date(2020, 12, 31, 12, 0, 0, 0, time.Local)
+	view := func(viewer string, userID int, windowStart time.Time, date string) *model.PostView {
+		return &model.PostView{CreatedAt: windowStart, PostID: post.ID, Viewer: viewer, WindowStart: windowStart, UserID: userID, Date: date}
+	}
+
+	postRepository := &postRepository{}
+	repository := &postViewRepository{}
+
+	// 2. Exercise
+	err1 := repository.SavePostViews([]*model.PostView{
+		view("ip:192.0.2.1", 0, yesterdayWindow, "2020-12-31"),
+		view("ip:192.0.2.2", 0, yesterdayWindow, "2020-12-31"),
+		view("ip:192.0.2.1", 0, window, today),
+		view("user:2", otherUser.ID, window, today),
+	})
+	// 同じ投稿、閲覧者、期間の閲覧は、再度反映しても数えない
+	err2 := repository.SavePostViews([]*model.PostView{
+		view("ip:192.0.2.1", 0, window, today),
+		view("user:2", otherUser.ID, window, today),
+		view("ip:192.0.2.1", 0, window.Add(time.Minute), today),
+		view("ip:192.0.2.3", 0, window, today),
+	})
+	deleteErr := repository.DeletePostViews(window)
+	stats, fetchErr := repository.FetchPostDailyStats(user.ID, 0, "2020-12-31", today)
+	otherStats, _ := repository.FetchPostDailyStats(otherUser.ID, 0, "2020-12-31", today)
+	fetchedPost, _ := postRepository.FetchByID(post.ID, 0)
+
+	// 3. Verify
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, deleteErr)
+	assert.NoError(t, fetchErr)
+	assert.Equal(t, []*model.PostDailyStat{
+		{Date: "2020-12-31", Views: 2},
+		{Date: today, Views: 4, Favorites: 1, Comments: 1},
+	}, stats)
+	assert.Empty(t, otherStats)
+	assert.Equal(t, 6, fetchedPost.ViewCount)
+	// ログインユーザーの閲覧は閲覧済みの投稿としても記録する
+	var seenCount int
+	db.Model(&model.SeenPost{}).Where("user_id = ? AND post_id = ?", otherUser.ID, post.ID).Count(&seenCount)
+	assert.Equal(t, 1, seenCount)
+	// 期間を過ぎた閲覧の記録のみ削除する
+	var viewCount int
+	db.Model(&model.PostView{}).Count(&viewCount)
+	assert.Equal(t, 4, viewCount)
+
+	// 4. Teardown
+	teardown(db)
+}
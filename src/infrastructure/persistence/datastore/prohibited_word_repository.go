@@ -0,0 +1,42 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// prohibitedWordRepository 構造体
+type prohibitedWordRepository struct {
+}
+
+// NewProhibitedWordRepository ProhibitedWordRepositoryを生成する。
+func NewProhibitedWordRepository() repository.ProhibitedWordRepository {
+	return &prohibitedWordRepository{}
+}
+
+// FetchProhibitedWords 禁止語一覧取得。登録順に返す。
+func (repository *prohibitedWordRepository) FetchProhibitedWords() (words []*model.ProhibitedWord, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Order("id ASC").Find(&words).Error; err != nil {
+		return nil, err
+	}
+
+	return words, nil
+}
+
+// CreateProhibitedWord 禁止語登録
+func (repository *prohibitedWordRepository) CreateProhibitedWord(word *model.ProhibitedWord) error {
+	db := conf.DBConnection()
+
+	return db.Create(word).Error
+}
+
+// DeleteProhibitedWord 禁止語削除
+func (repository *prohibitedWordRepository) DeleteProhibitedWord(id int) error {
+	db := conf.DBConnection()
+
+	return db.Delete(&model.ProhibitedWord{ID: id}).Error
+}
@@ -0,0 +1,38 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProhibitedWordRepository_ProhibitedWords(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	repository := &prohibitedWordRepository{}
+	word1 := &model.ProhibitedWord{Word: "禁止", NormalizedWord: "禁止", Action: model.ProhibitedWordActionBlock}
+	word2 := &model.ProhibitedWord{Word: "バカ", NormalizedWord: "ばか", Action: model.ProhibitedWordActionMask}
+
+	// 2. Exercise
+	createErr1 := repository.CreateProhibitedWord(word1)
+	createErr2 := repository.CreateProhibitedWord(word2)
+	duplicateErr := repository.CreateProhibitedWord(&model.ProhibitedWord{Word: "ﾊﾞｶ", NormalizedWord: "ばか"})
+	deleteErr := repository.DeleteProhibitedWord(word1.ID)
+	words, fetchErr := repository.FetchProhibitedWords()
+
+	// 3. Verify
+	assert.NoError(t, createErr1)
+	assert.NoError(t, createErr2)
+	assert.Error(t, duplicateErr)
+	assert.NoError(t, deleteErr)
+	assert.NoError(t, fetchErr)
+	assert.Len(t, words, 1)
+	assert.Equal(t, model.ProhibitedWordActionMask, words[0].Action)
+
+	// 4. Teardown
+	teardown(db)
+}
@@ -0,0 +1,77 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// reactionRepository 構造体
+type reactionRepository struct {
+}
+
+// NewReactionRepository ReactionRepositoryを生成する。
+func NewReactionRepository() repository.ReactionRepository {
+	return &reactionRepository{}
+}
+
+// CreateReaction リアクション登録。既に同じ種類でリアクションしている場合は何もしない。
+func (repository *reactionRepository) CreateReaction(reaction *model.Reaction) error {
+	db := conf.DBConnection()
+
+	return db.Where(model.Reaction{UserID: reaction.UserID, PostID: reaction.PostID, Type: reaction.Type}).
+		FirstOrCreate(reaction).Error
+}
+
+// DeleteReaction リアクション削除
+func (repository *reactionRepository) DeleteReaction(userID, postID int, reactionType string) error {
+	db := conf.DBConnection()
+
+	return db.Where("user_id = ? AND post_id = ? AND type = ?", userID, postID, reactionType).
+		Delete(&model.Reaction{}).Error
+}
+
+// FetchReactionCounts postIDsのいずれかの投稿のリアクションの種類ごとの件数取得
+func (repository *reactionRepository) FetchReactionCounts(postIDs []int, loginUserID int) (counts []*model.PostReactionCount, err error) {
+	if len(postIDs) == 0 {
+		return []*model.PostReactionCount{}, nil
+	}
+
+	db := conf.DBConnection()
+
+	if err = db.Table("reactions").
+		Select("reactions.post_id, reactions.type, COUNT(*) AS count, MAX(reactions.user_id = ?) AS reacted", loginUserID).
+		Joins("JOIN users ON users.id = reactions.user_id AND users.deleted_at IS NULL").
+		Where("reactions.post_id IN (?)", postIDs).
+		Group("reactions.post_id, reactions.type").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// FetchReactions 投稿にリアクションしたユーザー一覧取得。新しい順に返す。種類を限定しない場合はreactionTypeに空文字を指定する。
+func (repository *reactionRepository) FetchReactions(postID int, reactionType string, limit, page int) (totalCount int, reactions []*model.GetReactionResult, err error) {
+	db := conf.DBConnection()
+
+	db = db.Table("reactions").
+		Joins("JOIN users ON users.id = reactions.user_id AND users.deleted_at IS NULL").
+		Where("reactions.post_id = ?", postID)
+	if reactionType != "" {
+		db = db.Where("reactions.type = ?", reactionType)
+	}
+	if err = db.Count(&totalCount).Error; err != nil {
+		return 0, nil, err
+	}
+
+	offset := limit * (page - 1)
+	if err = db.Select("reactions.*, users.name AS user_name, users.image_file_path AS user_image_file_path").
+		Order("reactions.id DESC").Limit(limit).Offset(offset).
+		Scan(&reactions).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, reactions, nil
+}
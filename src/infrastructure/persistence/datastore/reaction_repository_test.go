@@ -0,0 +1,49 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReactionRepository_Reactions(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user1 := makeUserForInput(1)
+	db.Create(&user1)
+	user2 := makeUserForInput(2)
+	db.Create(&user2)
+	post := makePost(user1.ID)
+	db.Create(post)
+
+	repository := &reactionRepository{}
+
+	// 2. Exercise
+	createErr1 := repository.CreateReaction(&model.Reaction{UserID: user1.ID, PostID: post.ID, Type: "moved"})
+	createErr2 := repository.CreateReaction(&model.Reaction{UserID: user1.ID, PostID: post.ID, Type: "moved"})
+	createErr3 := repository.CreateReaction(&model.Reaction{UserID: user2.ID, PostID: post.ID, Type: "moved"})
+	createErr4 := repository.CreateReaction(&model.Reaction{UserID: user2.ID, PostID: post.ID, Type: "laughed"})
+	deleteErr := repository.DeleteReaction(user2.ID, post.ID, "laughed")
+	counts, countErr := repository.FetchReactionCounts([]int{post.ID}, user2.ID)
+	totalCount, reactions, fetchErr := repository.FetchReactions(post.ID, "moved", 10, 1)
+
+	// 3. Verify
+	assert.NoError(t, createErr1)
+	assert.NoError(t, createErr2)
+	assert.NoError(t, createErr3)
+	assert.NoError(t, createErr4)
+	assert.NoError(t, deleteErr)
+	assert.NoError(t, countErr)
+	assert.Equal(t, []*model.PostReactionCount{{PostID: post.ID, Type: "moved", Count: 2, Reacted: true}}, counts)
+	assert.NoError(t, fetchErr)
+	assert.Equal(t, 2, totalCount)
+	assert.Equal(t, user2.ID, reactions[0].UserID)
+	assert.Equal(t, user2.Name, reactions[0].UserName)
+
+	// 4. Teardown
+	teardown(db)
+}
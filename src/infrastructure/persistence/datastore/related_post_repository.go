@@ -0,0 +1,82 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// relatedPostRepository 構造体
+type relatedPostRepository struct {
+}
+
+// NewRelatedPostRepository RelatedPostRepositoryを生成する。
+func NewRelatedPostRepository() repository.RelatedPostRepository {
+	return &relatedPostRepository{}
+}
+
+// FetchRelatedPostSources 関連する投稿の計算用の全投稿取得。非表示の投稿は含めない。
+func (repository *relatedPostRepository) FetchRelatedPostSources() (posts []*model.Post, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Select("id, user_id, title, speaker, detail, normalized_title, normalized_speaker").
+		Where("is_hidden = false").
+		Order("id ASC").
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// FetchAllFavorites 関連する投稿の計算用の全お気に入り取得
+func (repository *relatedPostRepository) FetchAllFavorites() (favorites []*model.Favorite, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Select("user_id, post_id, tag").
+		Order("id ASC").
+		Find(&favorites).Error; err != nil {
+		return nil, err
+	}
+
+	return favorites, nil
+}
+
+// ReplaceRelatedPosts 関連する投稿の置き換え。登録済みの関連する投稿を全て削除してから登録する。
+func (repository *relatedPostRepository) ReplaceRelatedPosts(relatedPosts []*model.RelatedPost) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.RelatedPost{}).Error; err != nil {
+			return err
+		}
+		for _, relatedPost := range relatedPosts {
+			if err := tx.Create(relatedPost).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FetchRelatedPostIDs 関連する投稿のID一覧取得。関連度の高い順に返す。excludeUserIDが0でない場合はそのユーザーの投稿を除く。
+// 計算後に削除、非表示にされた投稿は除く。
+func (repository *relatedPostRepository) FetchRelatedPostIDs(postID, excludeUserID, limit int) (ids []int, err error) {
+	db := conf.DBConnection()
+
+	db = db.Table("related_posts").
+		Joins("JOIN posts ON posts.id = related_posts.related_post_id AND posts.deleted_at IS NULL AND posts.is_hidden = false").
+		Where("related_posts.post_id = ?", postID)
+	if excludeUserID > 0 {
+		db = db.Where("posts.user_id <> ?", excludeUserID)
+	}
+	if err = db.Order("related_posts.score DESC, related_posts.related_post_id DESC").
+		Limit(limit).
+		Pluck("related_posts.related_post_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,55 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// 関連する投稿テスト
+func TestRelatedPostRepository_RelatedPosts(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user1 := makeUserForInput(1)
+	db.Create(&user1)
+	user2 := makeUserForInput(2)
+	db.Create(&user2)
+	post1 := makePost(user1.ID)
+	db.Create(post1)
+	post2 := makePost(user1.ID)
+	db.Create(post2)
+	post3 := makePost(user2.ID)
+	db.Create(post3)
+	post4 := makePost(user2.ID)
+	db.Create(post4)
+	db.Delete(post4)
+
+	repository := &relatedPostRepository{}
+	repository.ReplaceRelatedPosts([]*model.RelatedPost{{PostID: post1.ID, RelatedPostID: post3.ID, Score: 0.9}})
+
+	// 2. Exercise
+	replaceErr := repository.ReplaceRelatedPosts([]*model.RelatedPost{
+		{PostID: post1.ID, RelatedPostID: post2.ID, Score: 0.5},
+		{PostID: post1.ID, RelatedPostID: post3.ID, Score: 0.8},
+		{PostID: post1.ID, RelatedPostID: post4.ID, Score: 0.9},
+	})
+	ids, fetchErr := repository.FetchRelatedPostIDs(post1.ID, 0, 10)
+	excludedIDs, excludedErr := repository.FetchRelatedPostIDs(post1.ID, user2.ID, 10)
+	limitedIDs, limitedErr := repository.FetchRelatedPostIDs(post1.ID, 0, 1)
+
+	// 3. Verify
+	assert.NoError(t, replaceErr)
+	assert.NoError(t, fetchErr)
+	assert.Equal(t, []int{post3.ID, post2.ID}, ids)
+	assert.NoError(t, excludedErr)
+	assert.Equal(t, []int{post2.ID}, excludedIDs)
+	assert.NoError(t, limitedErr)
+	assert.Equal(t, []int{post3.ID}, limitedIDs)
+
+	// 4. Teardown
+	teardown(db)
+}
@@ -0,0 +1,183 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// reportRepository 構造体
+type reportRepository struct {
+}
+
+// NewReportRepository ReportRepositoryを生成する。
+func NewReportRepository() repository.ReportRepository {
+	return &reportRepository{}
+}
+
+// SaveReport 通報登録。
+// 同じユーザーの未対応の通報がある場合は登録せずfalseを返す。対応済みの通報がある場合は理由を更新して未対応に戻す。
+func (repository *reportRepository) SaveReport(report *model.Report) (saved bool, err error) {
+	db := conf.DBConnection()
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		existing := model.Report{}
+		err := tx.Set("gorm:query_option", "FOR UPDATE").
+			Where("reporter_id = ? AND target_type = ? AND target_id = ?", report.ReporterID, report.TargetType, report.TargetID).
+			First(&existing).Error
+		if gorm.IsRecordNotFoundError(err) {
+			saved = true
+			return tx.Create(report).Error
+		}
+		if err != nil {
+			return err
+		}
+		if existing.Status == model.ReportStatusOpen {
+			return nil
+		}
+
+		saved = true
+		report.ID = existing.ID
+		return tx.Model(&existing).Updates(map[string]interface{}{
+			"reason_code": report.ReasonCode,
+			"detail":      report.Detail,
+			"status":      model.ReportStatusOpen,
+			"resolved_at": nil,
+		}).Error
+	})
+	return saved, err
+}
+
+// CountOpenReports 対象の未対応の通報件数取得
+func (repository *reportRepository) CountOpenReports(targetType string, targetID int) (count int, err error) {
+	db := conf.DBConnection()
+
+	err = db.Model(&model.Report{}).
+		Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, model.ReportStatusOpen).
+		Count(&count).Error
+	return count, err
+}
+
+// FetchReportQueue 未対応の通報の対象一覧取得。通報件数の多い順、同じ件数の場合は最後に通報された順に返す。
+// 対象の種類を限定しない場合はtargetTypeに空文字を指定する。
+func (repository *reportRepository) FetchReportQueue(targetType string, limit, page int) (totalCount int, items []*model.ReportQueueItem, err error) {
+	db := conf.DBConnection()
+
+	db = db.Table("reports").Where("status = ?", model.ReportStatusOpen)
+	if targetType != "" {
+		db = db.Where("target_type = ?", targetType)
+	}
+
+	if err = db.Select("COUNT(DISTINCT target_type, target_id)").Row().Scan(&totalCount); err != nil {
+		return 0, nil, err
+	}
+
+	offset := limit * (page - 1)
+	if err = db.Select(`target_type,
+			target_id,
+			COUNT(*) AS report_count,
+			MAX(created_at) AS latest_reported_at,
+			GROUP_CONCAT(DISTINCT reason_code ORDER BY reason_code) AS concatenated_reason_codes
+		`).
+		Group("target_type, target_id").
+		Order("report_count DESC, latest_reported_at DESC").Limit(limit).Offset(offset).
+		Find(&items).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, items, nil
+}
+
+// FetchReports 対象の通報一覧取得。新しい順に返す。
+func (repository *reportRepository) FetchReports(targetType string, targetID int) (reports []*model.GetReportResult, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Table("reports").
+		Select("reports.*, users.name AS reporter_name").
+		Joins("LEFT JOIN users ON users.id = reports.reporter_id").
+		Where("reports.target_type = ? AND reports.target_id = ?", targetType, targetID).
+		Order("reports.updated_at DESC, reports.id DESC").
+		Find(&reports).Error; err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// CreateModerationAction モデレーターの対応登録。
+// 対象への変更の適用、対応の記録、resolveReportsがtrueの場合の未対応の通報の対応済みへの変更を同一トランザクションで行う。
+// 途中で失敗した場合は全て取り消すため、対応の記録がないまま非表示になることや、対応済みの対象の通報が未対応のまま残ることはない。
+func (repository *reportRepository) CreateModerationAction(action *model.ModerationAction, effect *model.ModerationEffect, resolveReports bool) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if effect != nil {
+			if err := applyModerationEffect(tx, action, effect); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(action).Error; err != nil {
+			return err
+		}
+		if resolveReports {
+			if err := tx.Model(&model.Report{}).
+				Where("target_type = ? AND target_id = ? AND status = ?", action.TargetType, action.TargetID, model.ReportStatusOpen).
+				Updates(map[string]interface{}{
+					"status":      model.ReportStatusResolved,
+					"resolved_at": time.Now(),
+				}).Error; err != nil {
+				return err
+			}
+		}
+		if effect == nil {
+			return nil
+		}
+		return saveDomainEvents(tx, effect.Events)
+	})
+}
+
+// applyModerationEffect モデレーターの対応による変更を対象に適用する。
+func applyModerationEffect(tx *gorm.DB, action *model.ModerationAction, effect *model.ModerationEffect) error {
+	var target interface{}
+	switch action.TargetType {
+	case model.ReportTargetPost:
+		target = &model.Post{ID: action.TargetID}
+	case model.ReportTargetComment:
+		target = &model.Comment{ID: action.TargetID}
+	}
+
+	if target != nil && effect.Hidden != nil {
+		// falseも更新するため、構造体ではなく項目名を指定する
+		if err := tx.Model(target).Update("is_hidden", *effect.Hidden).Error; err != nil {
+			return err
+		}
+	}
+	if target != nil && effect.Delete {
+		if err := tx.Delete(target).Error; err != nil {
+			return err
+		}
+	}
+	if effect.SuspendedAt != nil {
+		if err := tx.Model(&model.User{ID: action.TargetUserID}).Update("suspended_at", *effect.SuspendedAt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FetchModerationActions 対象のモデレーターの対応一覧取得。新しい順に返す。
+func (repository *reportRepository) FetchModerationActions(targetType string, targetID int) (actions []*model.ModerationAction, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Where("target_type = ? AND target_id = ?", targetType, targetID).
+		Order("id DESC").
+		Find(&actions).Error; err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,150 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportRepository_SaveReport(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user1 := makeUserForInput(1)
+	db.Create(&user1)
+	user2 := makeUserForInput(2)
+	db.Create(&user2)
+	post := makePost(user1.ID)
+	db.Create(post)
+
+	repository := &reportRepository{}
+	newReport := func() *model.Report {
+		return &model.Report{ReporterID: user2.ID, TargetType: model.ReportTargetPost, TargetID: post.ID, ReasonCode: model.ReportReasonSpam, Status: model.ReportStatusOpen}
+	}
+
+	// 2. Exercise
+	first, firstErr := repository.SaveReport(newReport())
+	duplicate, duplicateErr := repository.SaveReport(newReport())
+	count, countErr := repository.CountOpenReports(model.ReportTargetPost, post.ID)
+	resolveErr := repository.CreateModerationAction(&model.ModerationAction{ModeratorID: user1.ID, TargetType: model.ReportTargetPost, TargetID: post.ID, Action: model.ModerationActionDismiss}, nil, true)
+	resolvedCount, _ := repository.CountOpenReports(model.ReportTargetPost, post.ID)
+	reopened, reopenedErr := repository.SaveReport(newReport())
+	reopenedCount, _ := repository.CountOpenReports(model.ReportTargetPost, post.ID)
+
+	// 3. Verify
+	assert.NoError(t, firstErr)
+	assert.True(t, first)
+	assert.NoError(t, duplicateErr)
+	assert.False(t, duplicate)
+	assert.NoError(t, countErr)
+	assert.Equal(t, 1, count)
+	assert.NoError(t, resolveErr)
+	assert.Equal(t, 0, resolvedCount)
+	assert.NoError(t, reopenedErr)
+	assert.True(t, reopened)
+	assert.Equal(t, 1, reopenedCount)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+func TestReportRepository_CreateModerationAction(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user1 := makeUserForInput(1)
+	db.Create(&user1)
+	user2 := makeUserForInput(2)
+	db.Create(&user2)
+	post := makePost(user1.ID)
+	db.Create(post)
+	db.Create(&model.Report{ReporterID: user2.ID, TargetType: model.ReportTargetPost, TargetID: post.ID, ReasonCode: model.ReportReasonSpam, Status: model.ReportStatusOpen})
+
+	moderationRepository := &moderationRepository{}
+	repository := &reportRepository{}
+	hidden := true
+	suspendedAt := time.Now()
+
+	// 2. Exercise
+	hideErr := repository.CreateModerationAction(
+		&model.ModerationAction{ModeratorID: user2.ID, TargetType: model.ReportTargetPost, TargetID: post.ID, TargetUserID: user1.ID, Action: model.ModerationActionHide},
+		&model.ModerationEffect{Hidden: &hidden},
+		true,
+	)
+	suspendErr := repository.CreateModerationAction(
+		&model.ModerationAction{ModeratorID: user2.ID, TargetType: model.ReportTargetPost, TargetID: post.ID, TargetUserID: user1.ID, Action: model.ModerationActionSuspend},
+		&model.ModerationEffect{SuspendedAt: &suspendedAt},
+		true,
+	)
+	deleteErr := repository.CreateModerationAction(
+		&model.ModerationAction{ModeratorID: user2.ID, TargetType: model.ReportTargetPost, TargetID: post.ID, TargetUserID: user1.ID, Action: model.ModerationActionDelete},
+		&model.ModerationEffect{Delete: true, Events: []*model.DomainEvent{model.NewDomainEvent(model.DomainEventPostDeleted, &model.DomainEventTarget{ID: post.ID})}},
+		true,
+	)
+
+	// 3. Verify
+	assert.NoError(t, hideErr)
+	assert.NoError(t, suspendErr)
+	assert.NoError(t, deleteErr)
+	openCount, _ := repository.CountOpenReports(model.ReportTargetPost, post.ID)
+	assert.Equal(t, 0, openCount)
+	actions, _ := repository.FetchModerationActions(model.ReportTargetPost, post.ID)
+	assert.Len(t, actions, 3)
+	deleted, deletedErr := moderationRepository.FetchPostForModeration(post.ID)
+	assert.NoError(t, deletedErr)
+	assert.Nil(t, deleted)
+	var suspended model.User
+	db.First(&suspended, user1.ID)
+	assert.NotNil(t, suspended.SuspendedAt)
+	var eventCount int
+	db.Model(&model.DomainEvent{}).Where("type = ?", model.DomainEventPostDeleted).Count(&eventCount)
+	assert.Equal(t, 1, eventCount)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+func TestReportRepository_FetchReportQueue(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user1 := makeUserForInput(1)
+	db.Create(&user1)
+	user2 := makeUserForInput(2)
+	db.Create(&user2)
+	post := makePost(user1.ID)
+	db.Create(post)
+	comment := makeComment(post.ID, user1.ID)
+	db.Create(comment)
+
+	db.Create(&model.Report{ReporterID: user1.ID, TargetType: model.ReportTargetComment, TargetID: comment.ID, ReasonCode: model.ReportReasonSpam, Status: model.ReportStatusOpen})
+	db.Create(&model.Report{ReporterID: user2.ID, TargetType: model.ReportTargetPost, TargetID: post.ID, ReasonCode: model.ReportReasonSpam, Status: model.ReportStatusOpen})
+	db.Create(&model.Report{ReporterID: user1.ID, TargetType: model.ReportTargetPost, TargetID: post.ID, ReasonCode: model.ReportReasonCopyright, Status: model.ReportStatusOpen})
+	db.Create(&model.Report{ReporterID: user2.ID, TargetType: model.ReportTargetUser, TargetID: user1.ID, ReasonCode: model.ReportReasonOther, Status: model.ReportStatusResolved})
+
+	repository := &reportRepository{}
+
+	// 2. Exercise
+	totalCount, items, err := repository.FetchReportQueue("", 10, 1)
+	commentCount, commentItems, commentErr := repository.FetchReportQueue(model.ReportTargetComment, 10, 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, totalCount)
+	assert.Len(t, items, 2)
+	assert.Equal(t, model.ReportTargetPost, items[0].TargetType)
+	assert.Equal(t, 2, items[0].ReportCount)
+	assert.Equal(t, "copyright,spam", items[0].ConcatenatedReasonCodes)
+	assert.NoError(t, commentErr)
+	assert.Equal(t, 1, commentCount)
+	assert.Equal(t, comment.ID, commentItems[0].TargetID)
+
+	// 4. Teardown
+	teardown(db)
+}
@@ -0,0 +1,42 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// streamTicketRepository 構造体
+type streamTicketRepository struct {
+}
+
+// NewStreamTicketRepository StreamTicketRepositoryを生成する。
+func NewStreamTicketRepository() repository.StreamTicketRepository {
+	return &streamTicketRepository{}
+}
+
+// SaveUsedStreamTicket 使用済みのチケットの登録。既に使用済みの場合は登録せずfalseを返す。有効期限がexpiredBeforeより前の記録は削除する。
+// 使用済みかどうかはticket_idの一意キーで判定するため、複数のAPIサーバーに同じチケットで接続した場合も1回しか使用できない。
+func (repository *streamTicketRepository) SaveUsedStreamTicket(ticket *model.UsedStreamTicket, expiredBefore time.Time) (saved bool, err error) {
+	db := conf.DBConnection()
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", expiredBefore).Delete(&model.UsedStreamTicket{}).Error; err != nil {
+			return err
+		}
+		// 使用済みの場合は何も更新せず、RowsAffectedが0になる
+		result := tx.Exec(`INSERT INTO used_stream_tickets (ticket_id, expires_at) VALUES (?, ?)
+			ON DUPLICATE KEY UPDATE id = id`,
+			ticket.TicketID, ticket.ExpiresAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		saved = result.RowsAffected > 0
+		return nil
+	})
+	return saved, err
+}
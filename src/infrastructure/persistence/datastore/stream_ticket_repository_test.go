@@ -0,0 +1,42 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// 使用済みのチケットの登録テスト
+func TestStreamTicketRepository_SaveUsedStreamTicket(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	now := time.Now().Truncate(time.Second)
+	db.Create(&model.UsedStreamTicket{TicketID: "expired", ExpiresAt: now.Add(-time.Minute)})
+
+	repository := &streamTicketRepository{}
+
+	// 2. Exercise
+	first, firstErr := repository.SaveUsedStreamTicket(&model.UsedStreamTicket{TicketID: "ticket1", ExpiresAt: now.Add(time.Minute)}, now)
+	replayed, replayedErr := repository.SaveUsedStreamTicket(&model.UsedStreamTicket{TicketID: "ticket1", ExpiresAt: now.Add(time.Minute)}, now)
+	other, otherErr := repository.SaveUsedStreamTicket(&model.UsedStreamTicket{TicketID: "ticket2", ExpiresAt: now.Add(time.Minute)}, now)
+
+	// 3. Verify
+	assert.NoError(t, firstErr)
+	assert.True(t, first)
+	assert.NoError(t, replayedErr)
+	assert.False(t, replayed)
+	assert.NoError(t, otherErr)
+	assert.True(t, other)
+	// 有効期限を過ぎた記録は削除する
+	var count int
+	db.Model(&model.UsedStreamTicket{}).Count(&count)
+	assert.Equal(t, 2, count)
+
+	// 4. Teardown
+	teardown(db)
+}
@@ -0,0 +1,53 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// translationRepository 構造体
+type translationRepository struct {
+}
+
+// NewTranslationRepository TranslationRepositoryを生成する。
+func NewTranslationRepository() repository.TranslationRepository {
+	return &translationRepository{}
+}
+
+// SaveTranslation 翻訳の登録または更新。投稿と言語の組み合わせが同じ翻訳は上書きする。
+func (repository *translationRepository) SaveTranslation(translation *model.PostTranslation) error {
+	db := conf.DBConnection()
+
+	return db.Where(model.PostTranslation{PostID: translation.PostID, Language: translation.Language}).
+		Assign(model.PostTranslation{Title: translation.Title, Detail: translation.Detail, TranslatorID: translation.TranslatorID}).
+		FirstOrCreate(translation).Error
+}
+
+// FetchTranslations 翻訳一覧取得。postIDsのいずれかの投稿の翻訳を返す。
+func (repository *translationRepository) FetchTranslations(postIDs []int) (translations []*model.GetPostTranslationResult, err error) {
+	if len(postIDs) == 0 {
+		return []*model.GetPostTranslationResult{}, nil
+	}
+
+	db := conf.DBConnection()
+
+	if err = db.Table("post_translations").
+		Select("post_translations.*, users.name AS translator_name").
+		Joins("JOIN users ON users.id = post_translations.translator_id AND users.deleted_at IS NULL").
+		Where("post_translations.post_id IN (?)", postIDs).
+		Order("post_translations.post_id ASC, post_translations.language ASC").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// DeleteTranslation 翻訳削除
+func (repository *translationRepository) DeleteTranslation(postID int, language string) error {
+	db := conf.DBConnection()
+
+	return db.Where("post_id = ? AND language = ?", postID, language).Delete(&model.PostTranslation{}).Error
+}
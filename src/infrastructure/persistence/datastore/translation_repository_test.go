@@ -0,0 +1,70 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// 翻訳の登録または更新・取得
+func TestTranslationRepository_SaveTranslation(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	postForInput := makePost(userForInput.ID)
+	db.Create(postForInput)
+
+	repository := &translationRepository{}
+
+	// 2. Exercise
+	err := repository.SaveTranslation(&model.PostTranslation{PostID: postForInput.ID, Language: "en", Title: "title1", TranslatorID: userForInput.ID})
+	assert.NoError(t, err)
+	err = repository.SaveTranslation(&model.PostTranslation{PostID: postForInput.ID, Language: "en", Title: "title2", TranslatorID: userForInput.ID})
+
+	// 3. Verify
+	assert.NoError(t, err)
+	translations, err := repository.FetchTranslations([]int{postForInput.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(translations))
+	assert.Equal(t, "title2", translations[0].Title)
+	assert.Equal(t, userForInput.Name, translations[0].TranslatorName)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// 翻訳削除
+func TestTranslationRepository_DeleteTranslation(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	userForInput := makeUserForInput(1)
+	db.Create(&userForInput)
+	db.First(&userForInput)
+
+	postForInput := makePost(userForInput.ID)
+	db.Create(postForInput)
+	db.Create(&model.PostTranslation{PostID: postForInput.ID, Language: "en", Title: "title", TranslatorID: userForInput.ID})
+
+	repository := &translationRepository{}
+
+	// 2. Exercise
+	err := repository.DeleteTranslation(postForInput.ID, "en")
+
+	// 3. Verify
+	assert.NoError(t, err)
+	translations, err := repository.FetchTranslations([]int{postForInput.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(translations))
+
+	// 4. Teardown
+	teardown(db)
+}
@@ -0,0 +1,224 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// trashRepository 構造体
+type trashRepository struct {
+}
+
+// NewTrashRepository TrashRepositoryを生成する。
+func NewTrashRepository() repository.TrashRepository {
+	return &trashRepository{}
+}
+
+// FetchDeletedPosts ユーザーの削除済み投稿一覧取得。since以降に削除されたものを削除日時の新しい順に返す。
+func (repository *trashRepository) FetchDeletedPosts(userID int, since time.Time, limit, page int) (totalCount int, posts []*model.Post, err error) {
+	db := conf.DBConnection()
+
+	db = db.Unscoped().Model(&model.Post{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL AND deleted_at >= ?", userID, since)
+	if err = db.Count(&totalCount).Error; err != nil {
+		return 0, nil, err
+	}
+
+	offset := limit * (page - 1)
+	if err = db.Order("deleted_at DESC, id DESC").Limit(limit).Offset(offset).Find(&posts).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, posts, nil
+}
+
+// FetchDeletedComments ユーザーの削除済みコメント一覧取得。since以降に削除されたものを削除日時の新しい順に返す。
+func (repository *trashRepository) FetchDeletedComments(userID int, since time.Time, limit, page int) (totalCount int, comments []*model.Comment, err error) {
+	db := conf.DBConnection()
+
+	db = db.Unscoped().Model(&model.Comment{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL AND deleted_at >= ?", userID, since)
+	if err = db.Count(&totalCount).Error; err != nil {
+		return 0, nil, err
+	}
+
+	offset := limit * (page - 1)
+	if err = db.Order("deleted_at DESC, id DESC").Limit(limit).Offset(offset).Find(&comments).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, comments, nil
+}
+
+// FetchDeletedPostByID 削除済み投稿1件取得。削除されていない場合、存在しない場合はnilを返す。
+func (repository *trashRepository) FetchDeletedPostByID(id int) (*model.Post, error) {
+	db := conf.DBConnection()
+
+	post := model.Post{}
+	err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&post).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+// FetchDeletedCommentByID 削除済みコメント1件取得。削除されていない場合、存在しない場合はnilを返す。
+func (repository *trashRepository) FetchDeletedCommentByID(id int) (*model.Comment, error) {
+	db := conf.DBConnection()
+
+	comment := model.Comment{}
+	err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&comment).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// RestorePost 削除済み投稿の復元。復元した場合のみイベントを保存する。
+func (repository *trashRepository) RestorePost(id int, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&model.Post{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return saveDomainEvents(tx, events)
+	})
+}
+
+// RestoreComment 削除済みコメントの復元。復元した場合のみイベントを保存する。
+func (repository *trashRepository) RestoreComment(id int, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&model.Comment{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return saveDomainEvents(tx, events)
+	})
+}
+
+// PurgePosts before以前に削除された投稿を完全に削除する。
+// 投稿へのコメント、お気に入り、今日の言葉、出典の証拠・異議、翻訳、コレクションへの追加、通報も削除する。
+// 削除した投稿はこの中で決まるため、post.purgedのイベントもこの中で生成して保存する。
+func (repository *trashRepository) PurgePosts(before time.Time) (count int, err error) {
+	db := conf.DBConnection()
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var ids []int
+		if err := tx.Unscoped().Model(&model.Post{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		count = len(ids)
+		if err := purgePosts(tx, ids); err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, purgedEvents(model.DomainEventPostPurged, ids))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// PurgeComments before以前に削除されたコメントを、コメントへの通報とともに完全に削除する。
+// 削除したコメントはこの中で決まるため、comment.purgedのイベントもこの中で生成して保存する。
+func (repository *trashRepository) PurgeComments(before time.Time) (count int, err error) {
+	db := conf.DBConnection()
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var ids []int
+		if err := tx.Unscoped().Model(&model.Comment{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		count = len(ids)
+		if err := purgeComments(tx, ids); err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, purgedEvents(model.DomainEventCommentPurged, ids))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// purgedEvents 完全に削除した対象ごとのドメインイベントを生成する。
+func purgedEvents(eventType string, ids []int) []*model.DomainEvent {
+	events := make([]*model.DomainEvent, 0, len(ids))
+	for _, id := range ids {
+		events = append(events, model.NewDomainEvent(eventType, &model.DomainEventTarget{ID: id}))
+	}
+	return events
+}
+
+// purgePosts 投稿と関連データを完全に削除する。モデレーターの対応の記録は残す。
+func purgePosts(tx *gorm.DB, postIDs []int) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	var commentIDs []int
+	if err := tx.Unscoped().Model(&model.Comment{}).Where("post_id IN (?)", postIDs).Pluck("id", &commentIDs).Error; err != nil {
+		return err
+	}
+	if err := purgeComments(tx, commentIDs); err != nil {
+		return err
+	}
+	if err := tx.Where("target_type = ? AND target_id IN (?)", model.ReportTargetPost, postIDs).Delete(&model.Report{}).Error; err != nil {
+		return err
+	}
+	for _, dependent := range []interface{}{
+		&model.PostTag{},
+		&model.SeenPost{},
+		&model.Favorite{},
+		&model.DailyPost{},
+		&model.PostDailyView{},
+		&model.Reaction{},
+		&model.AttributionClaim{},
+		&model.PostTranslation{},
+		&model.CollectionItem{},
+	} {
+		if err := tx.Unscoped().Where("post_id IN (?)", postIDs).Delete(dependent).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Where("post_id IN (?) OR related_post_id IN (?)", postIDs, postIDs).Delete(&model.RelatedPost{}).Error; err != nil {
+		return err
+	}
+	if err := deleteNotifications(tx, "post_id IN (?)", postIDs); err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN (?)", postIDs).Delete(&model.Post{}).Error
+}
+
+// purgeComments コメントと、コメントへの通報を完全に削除する。
+func purgeComments(tx *gorm.DB, commentIDs []int) error {
+	if len(commentIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("target_type = ? AND target_id IN (?)", model.ReportTargetComment, commentIDs).Delete(&model.Report{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN (?)", commentIDs).Delete(&model.Comment{}).Error
+}
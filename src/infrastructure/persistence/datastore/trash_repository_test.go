@@ -0,0 +1,95 @@
+package datastore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashRepository_RestorePost(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user := makeUserForInput(1)
+	db.Create(&user)
+	post := makePost(user.ID)
+	db.Create(post)
+	db.Delete(post)
+
+	postRepository := &postRepository{}
+	repository := &trashRepository{}
+
+	// 2. Exercise
+	totalCount, deletedPosts, fetchErr := repository.FetchDeletedPosts(user.ID, time.Now().Add(-time.Hour), 10, 1)
+	deleted, deletedErr := repository.FetchDeletedPostByID(post.ID)
+	restoreErr := repository.RestorePost(post.ID, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventPostRestored, &model.DomainEventTarget{ID: post.ID})})
+	restoreAgainErr := repository.RestorePost(post.ID, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventPostRestored, &model.DomainEventTarget{ID: post.ID})})
+
+	// 3. Verify
+	assert.NoError(t, fetchErr)
+	assert.Equal(t, 1, totalCount)
+	assert.Equal(t, post.ID, deletedPosts[0].ID)
+	assert.NoError(t, deletedErr)
+	assert.NotNil(t, deleted.DeletedAt)
+	assert.NoError(t, restoreErr)
+	restored, err := postRepository.FetchByID(post.ID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, post.ID, restored.ID)
+	notDeleted, _ := repository.FetchDeletedPostByID(post.ID)
+	assert.Nil(t, notDeleted)
+	// 復元していない場合はイベントを保存しない
+	assert.NoError(t, restoreAgainErr)
+	var restoredEventCount int
+	db.Model(&model.OutboxEvent{}).Where("event_type = ?", model.DomainEventPostRestored).Count(&restoredEventCount)
+	assert.Equal(t, 1, restoredEventCount)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+func TestTrashRepository_PurgePosts(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	user := makeUserForInput(1)
+	db.Create(&user)
+	expiredPost := makePost(user.ID)
+	db.Create(expiredPost)
+	db.Create(makeComment(expiredPost.ID, user.ID))
+	db.Create(makeFavorite(user.ID, expiredPost.ID))
+	recentPost := makePost(user.ID)
+	db.Create(recentPost)
+	db.Delete(expiredPost)
+	db.Delete(recentPost)
+	db.Unscoped().Model(expiredPost).Update("deleted_at", time.Now().AddDate(0, 0, -31))
+
+	repository := &trashRepository{}
+
+	// 2. Exercise
+	count, err := repository.PurgePosts(time.Now().AddDate(0, 0, -30))
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	purged, _ := repository.FetchDeletedPostByID(expiredPost.ID)
+	assert.Nil(t, purged)
+	remaining, _ := repository.FetchDeletedPostByID(recentPost.ID)
+	assert.NotNil(t, remaining)
+	var commentCount, favoriteCount int
+	db.Unscoped().Model(&model.Comment{}).Where("post_id = ?", expiredPost.ID).Count(&commentCount)
+	db.Model(&model.Favorite{}).Where("post_id = ?", expiredPost.ID).Count(&favoriteCount)
+	assert.Equal(t, 0, commentCount)
+	assert.Equal(t, 0, favoriteCount)
+	var purgedEventCount int
+	db.Model(&model.OutboxEvent{}).Where("event_type = ? AND payload = ?", model.DomainEventPostPurged, fmt.Sprintf(`{"id":%d}`, expiredPost.ID)).Count(&purgedEventCount)
+	assert.Equal(t, 1, purgedEventCount)
+
+	// 4. Teardown
+	teardown(db)
+}
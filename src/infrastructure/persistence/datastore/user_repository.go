@@ -2,6 +2,10 @@
 package datastore
 
 import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+
 	"github.com/k-kazuya0926/power-phrase2-api/conf"
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
 	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
@@ -17,19 +21,22 @@ func NewUserRepository() repository.UserRepository {
 }
 
 // Create 登録
-func (repository *userRepository) Create(user *model.User) error {
-	db := conf.NewDBConnection()
-	defer db.Close()
+func (repository *userRepository) Create(user *model.User, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
 
-	return db.Create(user).Error
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, events)
+	})
 }
 
 // FetchByEmail メールアドレスが一致するUserを1件取得。
 func (repository *userRepository) FetchByEmail(email string) (*model.User, error) {
 	var user model.User
 
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	err := db.Where("email = ?", email).First(&user).Error
 	return &user, err
@@ -37,12 +44,11 @@ func (repository *userRepository) FetchByEmail(email string) (*model.User, error
 
 // FetchByID IDが一致するUserを1件取得。
 func (repository *userRepository) FetchByID(id int) (*model.User, error) {
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	u := model.User{ID: id}
 	if err := db.First(&u).Error; err != nil {
-		return nil, err
+		return nil, notFoundError(err)
 	}
 	u.Password = ""
 
@@ -50,18 +56,201 @@ func (repository *userRepository) FetchByID(id int) (*model.User, error) {
 }
 
 // Update 更新
-func (repository *userRepository) Update(u *model.User) error {
-	db := conf.NewDBConnection()
-	defer db.Close()
+func (repository *userRepository) Update(u *model.User, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(u).Update(u).Error; err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, events)
+	})
+}
+
+// Delete 削除。削除した場合のみイベントを保存する。
+func (repository *userRepository) Delete(id int, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&model.User{ID: id})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return saveDomainEvents(tx, events)
+	})
+}
+
+// FetchDeletedUsers before以前に削除されたユーザー一覧取得
+func (repository *userRepository) FetchDeletedUsers(before time.Time) (users []*model.User, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("id ASC").
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Purge 削除済みユーザーを完全に削除する。
+// ユーザーの投稿(関連データを含む)、コメント、お気に入り、コレクション、フォロー、通知、出典の証拠・異議、翻訳、通報も削除する。
+// プロフィール画像のファイルは削除しない。
+// eventsとともに、削除したユーザーの投稿、コメントごとのpost.purged、comment.purgedのイベントを保存する。
+func (repository *userRepository) Purge(id int, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var postIDs []int
+		if err := tx.Unscoped().Model(&model.Post{}).Where("user_id = ?", id).Pluck("id", &postIDs).Error; err != nil {
+			return err
+		}
+		if err := purgePosts(tx, postIDs); err != nil {
+			return err
+		}
+
+		var commentIDs []int
+		if err := tx.Unscoped().Model(&model.Comment{}).Where("user_id = ?", id).Pluck("id", &commentIDs).Error; err != nil {
+			return err
+		}
+		if err := purgeComments(tx, commentIDs); err != nil {
+			return err
+		}
+
+		var collectionIDs []int
+		if err := tx.Unscoped().Model(&model.Collection{}).Where("user_id = ?", id).Pluck("id", &collectionIDs).Error; err != nil {
+			return err
+		}
+		if len(collectionIDs) > 0 {
+			if err := tx.Where("collection_id IN (?)", collectionIDs).Delete(&model.CollectionItem{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Where("id IN (?)", collectionIDs).Delete(&model.Collection{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("user_id = ?", id).Delete(&model.Favorite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&model.Reaction{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&model.SeenPost{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("follower_id = ? OR followee_id = ?", id, id).Delete(&model.Follow{}).Error; err != nil {
+			return err
+		}
+		// 他のユーザーへの通知は、このユーザーのみが行ったものを削除する
+		if err := deleteNotifications(tx, "user_id = ? OR (actor_id = ? AND actor_count <= 1)", id, id); err != nil {
+			return err
+		}
+		if err := tx.Where("actor_id = ?", id).Delete(&model.NotificationActor{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&model.NotificationSetting{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&model.AttributionClaim{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("translator_id = ?", id).Delete(&model.PostTranslation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("reporter_id = ? OR (target_type = ? AND target_id = ?)", id, model.ReportTargetUser, id).Delete(&model.Report{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&model.User{ID: id}).Error; err != nil {
+			return err
+		}
+		events = append(events, purgedEvents(model.DomainEventPostPurged, postIDs)...)
+		events = append(events, purgedEvents(model.DomainEventCommentPurged, commentIDs)...)
+		return saveDomainEvents(tx, events)
+	})
+}
+
+// Follow フォロー。既にフォローしている場合は何もせず、イベントも保存しない。
+func (repository *userRepository) Follow(follow *model.Follow, events []*model.DomainEvent) error {
+	db := conf.DBConnection()
 
-	return db.Model(u).Update(u).Error
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("follower_id = ? AND followee_id = ?", follow.FollowerID, follow.FolloweeID).First(follow).Error
+		if err == nil || !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+		if err := tx.Create(follow).Error; err != nil {
+			return err
+		}
+		return saveDomainEvents(tx, events)
+	})
 }
 
-// Delete 削除
-func (repository *userRepository) Delete(id int) error {
-	db := conf.NewDBConnection()
-	defer db.Close()
+// Unfollow フォロー解除
+func (repository *userRepository) Unfollow(followerID, followeeID int) error {
+	db := conf.DBConnection()
+
+	return db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Delete(&model.Follow{}).Error
+}
+
+// FetchFollowers フォロワー一覧取得。フォローされた日時の新しい順に返す。退会済みのユーザーは除く。
+func (repository *userRepository) FetchFollowers(userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error) {
+	return fetchFollowUsers("follows.followee_id", "follows.follower_id", userID, limit, page)
+}
+
+// FetchFollowing フォロー中のユーザー一覧取得。フォローした日時の新しい順に返す。退会済みのユーザーは除く。
+func (repository *userRepository) FetchFollowing(userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error) {
+	return fetchFollowUsers("follows.follower_id", "follows.followee_id", userID, limit, page)
+}
+
+// fetchFollowUsers userColumnがuserIDに一致するフォローについて、otherColumnのユーザー一覧を取得する。
+func fetchFollowUsers(userColumn, otherColumn string, userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error) {
+	db := conf.DBConnection()
+
+	db = db.Table("follows").
+		Joins("JOIN users ON users.id = "+otherColumn+" AND users.deleted_at IS NULL").
+		Where(userColumn+" = ?", userID)
+	if err = db.Count(&totalCount).Error; err != nil {
+		return 0, nil, err
+	}
+
+	offset := limit * (page - 1)
+	if err = db.Select("users.id AS user_id, users.name AS user_name, users.image_file_path AS user_image_file_path, follows.created_at AS followed_at").
+		Order("follows.id DESC").Limit(limit).Offset(offset).
+		Scan(&users).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, users, nil
+}
+
+// FetchFollowCounts フォロワー数、フォロー数取得。退会済みのユーザーは数えない。
+func (repository *userRepository) FetchFollowCounts(userID, loginUserID int) (*model.FollowCounts, error) {
+	db := conf.DBConnection()
+
+	counts := &model.FollowCounts{}
+	if err := db.Table("follows").
+		Joins("JOIN users ON users.id = follows.follower_id AND users.deleted_at IS NULL").
+		Where("follows.followee_id = ?", userID).
+		Count(&counts.FollowerCount).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Table("follows").
+		Joins("JOIN users ON users.id = follows.followee_id AND users.deleted_at IS NULL").
+		Where("follows.follower_id = ?", userID).
+		Count(&counts.FollowingCount).Error; err != nil {
+		return nil, err
+	}
+	if loginUserID > 0 {
+		count := 0
+		if err := db.Model(&model.Follow{}).
+			Where("follower_id = ? AND followee_id = ?", loginUserID, userID).
+			Count(&count).Error; err != nil {
+			return nil, err
+		}
+		counts.IsFollowing = count > 0
+	}
 
-	user := model.User{ID: id}
-	return db.Delete(&user).Error
+	return counts, nil
 }
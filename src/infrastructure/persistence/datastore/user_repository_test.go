@@ -3,23 +3,24 @@ package datastore
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/k-kazuya0926/power-phrase2-api/conf"
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	domainRepository "github.com/k-kazuya0926/power-phrase2-api/domain/repository"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestUserRepository_Create(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	repository := &userRepository{}
 	userForInput := makeUserForInput(1)
 
 	// 2. Exercise
-	err := repository.Create(userForInput)
+	err := repository.Create(userForInput, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -44,8 +45,7 @@ func TestUserRepository_Create(t *testing.T) {
 func TestUserRepository_FetchByEmail(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	repository := &userRepository{}
 	userForInput := makeUserForInput(1)
@@ -72,8 +72,7 @@ func TestUserRepository_FetchByEmail(t *testing.T) {
 func TestUserRepository_FetchById(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	userForInput := makeUserForInput(1)
 	db.Create(&userForInput)
@@ -83,9 +82,11 @@ func TestUserRepository_FetchById(t *testing.T) {
 
 	// 2. Exercise
 	actualUser, err := repository.FetchByID(userForInput.ID)
+	_, notFoundErr := repository.FetchByID(userForInput.ID + 1)
 
 	// 3. Verify
 	assert.NoError(t, err)
+	assert.Equal(t, domainRepository.ErrNotFound, notFoundErr)
 
 	// 内容
 	assert.Equal(t, userForInput.ID, actualUser.ID)
@@ -102,8 +103,7 @@ func TestUserRepository_FetchById(t *testing.T) {
 func TestUserRepository_Update(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	repository := &userRepository{}
 	userForInput := makeUserForInput(1)
@@ -115,7 +115,7 @@ func TestUserRepository_Update(t *testing.T) {
 	userForInput.ImageFilePath = "images/2.png"
 
 	// 2. Exercise
-	err := repository.Update(userForInput)
+	err := repository.Update(userForInput, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -140,8 +140,7 @@ func TestUserRepository_Update(t *testing.T) {
 func TestUserRepository_Delete(t *testing.T) {
 	// 1. Setup
 	setup()
-	db := conf.NewDBConnection()
-	defer db.Close()
+	db := conf.DBConnection()
 
 	repository := &userRepository{}
 	userForInput := makeUserForInput(1)
@@ -149,7 +148,7 @@ func TestUserRepository_Delete(t *testing.T) {
 	db.First(&userForInput)
 
 	// 2. Exercise
-	err := repository.Delete(userForInput.ID)
+	err := repository.Delete(userForInput.ID, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -160,6 +159,95 @@ func TestUserRepository_Delete(t *testing.T) {
 	teardown(db)
 }
 
+func TestUserRepository_Purge(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	repository := &userRepository{}
+	user := makeUserForInput(1)
+	db.Create(user)
+	otherUser := makeUserForInput(2)
+	db.Create(otherUser)
+	post := makePost(user.ID)
+	db.Create(post)
+	db.Create(makeComment(post.ID, otherUser.ID))
+	db.Create(makeFavorite(otherUser.ID, post.ID))
+	db.Delete(user)
+
+	// 2. Exercise
+	users, fetchErr := repository.FetchDeletedUsers(time.Now().Add(time.Hour))
+	err := repository.Purge(user.ID, nil)
+
+	// 3. Verify
+	assert.NoError(t, fetchErr)
+	assert.Len(t, users, 1)
+	assert.NoError(t, err)
+	var userCount, postCount, favoriteCount int
+	db.Unscoped().Model(&model.User{}).Where("id = ?", user.ID).Count(&userCount)
+	db.Unscoped().Model(&model.Post{}).Where("user_id = ?", user.ID).Count(&postCount)
+	db.Model(&model.Favorite{}).Where("user_id = ?", otherUser.ID).Count(&favoriteCount)
+	assert.Equal(t, 0, userCount)
+	assert.Equal(t, 0, postCount)
+	assert.Equal(t, 0, favoriteCount)
+
+	// 4. Teardown
+	teardown(db)
+}
+
+// フォローテスト
+func TestUserRepository_Follow(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	repository := &userRepository{}
+	user1 := makeUserForInput(1)
+	db.Create(user1)
+	user2 := makeUserForInput(2)
+	db.Create(user2)
+	user3 := makeUserForInput(3)
+	db.Create(user3)
+	deletedUser := makeUserForInput(4)
+	db.Create(deletedUser)
+
+	// 2. Exercise
+	follow1 := &model.Follow{FollowerID: user1.ID, FolloweeID: user2.ID}
+	followErr1 := repository.Follow(follow1, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventFollowCreated, follow1)})
+	follow2 := &model.Follow{FollowerID: user1.ID, FolloweeID: user2.ID}
+	followErr2 := repository.Follow(follow2, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventFollowCreated, follow2)})
+	repository.Follow(&model.Follow{FollowerID: user3.ID, FolloweeID: user2.ID}, nil)
+	repository.Follow(&model.Follow{FollowerID: deletedUser.ID, FolloweeID: user2.ID}, nil)
+	repository.Follow(&model.Follow{FollowerID: user1.ID, FolloweeID: user3.ID}, nil)
+	db.Delete(deletedUser)
+	unfollowErr := repository.Unfollow(user1.ID, user3.ID)
+	followerCount, followers, followersErr := repository.FetchFollowers(user2.ID, 10, 1)
+	followingCount, following, followingErr := repository.FetchFollowing(user1.ID, 10, 1)
+	counts, countsErr := repository.FetchFollowCounts(user2.ID, user1.ID)
+
+	// 3. Verify
+	assert.NoError(t, followErr1)
+	assert.NoError(t, followErr2)
+	// 既にフォローしている場合はイベントを保存しない
+	assert.Equal(t, follow1.ID, follow2.ID)
+	var followEventCount int
+	db.Model(&model.OutboxEvent{}).Where("event_type = ?", model.DomainEventFollowCreated).Count(&followEventCount)
+	assert.Equal(t, 1, followEventCount)
+	assert.NoError(t, unfollowErr)
+	assert.NoError(t, followersErr)
+	assert.Equal(t, 2, followerCount)
+	assert.Equal(t, user3.ID, followers[0].UserID)
+	assert.Equal(t, user1.Name, followers[1].UserName)
+	assert.NoError(t, followingErr)
+	assert.Equal(t, 1, followingCount)
+	assert.Equal(t, user2.ID, following[0].UserID)
+	assert.NoError(t, countsErr)
+	assert.Equal(t, &model.FollowCounts{FollowerCount: 2, FollowingCount: 0, IsFollowing: true}, counts)
+
+	// 4. Teardown
+	teardown(db)
+}
+
 // 入力用ユーザー
 func makeUserForInput(id int) *model.User {
 	user := &model.User{
@@ -0,0 +1,156 @@
+// Package datastore Infra層のリポジトリ
+package datastore
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// webhookRepository 構造体
+type webhookRepository struct {
+}
+
+// NewWebhookRepository WebhookRepositoryを生成する。
+func NewWebhookRepository() repository.WebhookRepository {
+	return &webhookRepository{}
+}
+
+// FetchWebhooks Webhook一覧取得
+func (repository *webhookRepository) FetchWebhooks() (webhooks []*model.Webhook, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Order("id ASC").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// FetchWebhook Webhook取得。存在しない場合はnilを返す。
+func (repository *webhookRepository) FetchWebhook(id int) (*model.Webhook, error) {
+	db := conf.DBConnection()
+
+	webhook := model.Webhook{}
+	if err := db.First(&webhook, id).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// FetchWebhooksByEventType イベントの種類を通知するWebhook一覧取得
+func (repository *webhookRepository) FetchWebhooksByEventType(eventType string) (webhooks []*model.Webhook, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Where("FIND_IN_SET(?, event_types) > 0", eventType).Order("id ASC").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// CreateWebhook Webhook登録
+func (repository *webhookRepository) CreateWebhook(webhook *model.Webhook) error {
+	db := conf.DBConnection()
+
+	return db.Create(webhook).Error
+}
+
+// DeleteWebhook Webhook削除。配信履歴も削除する。
+func (repository *webhookRepository) DeleteWebhook(id int) error {
+	db := conf.DBConnection()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Webhook{ID: id}).Error
+	})
+}
+
+// CreateWebhookDelivery Webhookの配信登録
+func (repository *webhookRepository) CreateWebhookDelivery(delivery *model.WebhookDelivery) error {
+	db := conf.DBConnection()
+
+	return db.Create(delivery).Error
+}
+
+// ClaimWebhookDelivery Webhookの配信の送信前の確保。nowの時点で再送する日時を過ぎた配信待ちの場合のみ、再送する日時をleaseUntilに延ばしてtrueを返す。
+// 条件付きの更新で確保するため、APIサーバーと再送のコマンドなど複数の処理が同じ配信を同時に送信することはない。
+// 確保した処理が送信結果を記録せずに中断した場合は、leaseUntilを過ぎてから再送される。
+func (repository *webhookRepository) ClaimWebhookDelivery(id int, now, leaseUntil time.Time) (claimed bool, err error) {
+	db := conf.DBConnection()
+
+	result := db.Model(&model.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, model.WebhookDeliveryStatusPending, now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateWebhookDelivery Webhookの配信の送信結果更新
+func (repository *webhookRepository) UpdateWebhookDelivery(delivery *model.WebhookDelivery) error {
+	db := conf.DBConnection()
+
+	return db.Model(delivery).Updates(map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"response_code":   delivery.ResponseCode,
+		"error":           delivery.Error,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"delivered_at":    delivery.DeliveredAt,
+	}).Error
+}
+
+// FetchWebhookDeliveries Webhookの配信履歴取得。新しい順に返す。
+func (repository *webhookRepository) FetchWebhookDeliveries(webhookID, limit, page int) (totalCount int, deliveries []*model.WebhookDelivery, err error) {
+	db := conf.DBConnection()
+
+	db = db.Model(&model.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if err = db.Count(&totalCount).Error; err != nil {
+		return 0, nil, err
+	}
+
+	offset := limit * (page - 1)
+	if err = db.Order("id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, deliveries, nil
+}
+
+// FetchWebhookDelivery Webhookの配信取得。存在しない場合はnilを返す。
+func (repository *webhookRepository) FetchWebhookDelivery(id int) (*model.WebhookDelivery, error) {
+	db := conf.DBConnection()
+
+	delivery := model.WebhookDelivery{}
+	if err := db.First(&delivery, id).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// FetchDueWebhookDeliveries 再送する日時がbefore以前の配信待ちのWebhookの配信一覧取得。古い順にlimit件まで返す。
+func (repository *webhookRepository) FetchDueWebhookDeliveries(before time.Time, limit int) (deliveries []*model.WebhookDelivery, err error) {
+	db := conf.DBConnection()
+
+	if err = db.Where("status = ? AND next_attempt_at <= ?", model.WebhookDeliveryStatusPending, before).
+		Order("next_attempt_at ASC, id ASC").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
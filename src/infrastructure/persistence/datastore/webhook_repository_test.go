@@ -0,0 +1,91 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// Webhookテスト
+func TestWebhookRepository_Webhooks(t *testing.T) {
+	// 1. Setup
+	setup()
+	db := conf.DBConnection()
+
+	repository := &webhookRepository{}
+	webhook1 := &model.Webhook{URL: "https://example.com/hook1", EventTypes: "post.created,comment.created", Secret: "secret1"}
+	webhook2 := &model.Webhook{URL: "https://example.com/hook2", EventTypes: "favorite.created", Secret: "secret2"}
+	assert.NoError(t, repository.CreateWebhook(webhook1))
+	assert.NoError(t, repository.CreateWebhook(webhook2))
+
+	current := time.Now().Truncate(time.Second)
+	due := current.Add(-time.Minute)
+	later := current.Add(time.Minute)
+	deliveries := []*model.WebhookDelivery{
+		{WebhookID: webhook1.ID, EventType: "post.created", Payload: "{}", Status: model.WebhookDeliveryStatusPending, NextAttemptAt: &due},
+		{WebhookID: webhook1.ID, EventType: "post.created", Payload: "{}", Status: model.WebhookDeliveryStatusPending, NextAttemptAt: &later},
+		{WebhookID: webhook2.ID, EventType: "favorite.created", Payload: "{}", Status: model.WebhookDeliveryStatusPending, NextAttemptAt: &due},
+	}
+	for _, delivery := range deliveries {
+		assert.NoError(t, repository.CreateWebhookDelivery(delivery))
+	}
+
+	// 2. Exercise
+	commentWebhooks, byTypeErr := repository.FetchWebhooksByEventType("comment.created")
+	deliveries[2].Status = model.WebhookDeliveryStatusSucceeded
+	deliveries[2].Attempts = 1
+	deliveries[2].ResponseCode = 200
+	deliveries[2].NextAttemptAt = nil
+	deliveries[2].DeliveredAt = &current
+	updateErr := repository.UpdateWebhookDelivery(deliveries[2])
+	dueDeliveries, dueErr := repository.FetchDueWebhookDeliveries(current, 10)
+	totalCount, webhook1Deliveries, deliveriesErr := repository.FetchWebhookDeliveries(webhook1.ID, 1, 1)
+	delivered, deliveryErr := repository.FetchWebhookDelivery(deliveries[2].ID)
+	claimed, claimErr := repository.ClaimWebhookDelivery(deliveries[0].ID, current, later)
+	// 確保済みの配信、再送する日時前の配信、配信済みの配信は確保できない
+	claimedAgain, _ := repository.ClaimWebhookDelivery(deliveries[0].ID, current, later)
+	notDue, _ := repository.ClaimWebhookDelivery(deliveries[1].ID, current, later)
+	succeeded, _ := repository.ClaimWebhookDelivery(deliveries[2].ID, current, later)
+	leasedDeliveries, _ := repository.FetchDueWebhookDeliveries(current, 10)
+	deleteErr := repository.DeleteWebhook(webhook1.ID)
+	deleted, deletedErr := repository.FetchWebhook(webhook1.ID)
+	webhooks, webhooksErr := repository.FetchWebhooks()
+
+	// 3. Verify
+	assert.NoError(t, byTypeErr)
+	assert.Len(t, commentWebhooks, 1)
+	assert.Equal(t, webhook1.ID, commentWebhooks[0].ID)
+	assert.NoError(t, updateErr)
+	assert.NoError(t, dueErr)
+	assert.Len(t, dueDeliveries, 1)
+	assert.Equal(t, deliveries[0].ID, dueDeliveries[0].ID)
+	assert.NoError(t, deliveriesErr)
+	assert.Equal(t, 2, totalCount)
+	assert.Len(t, webhook1Deliveries, 1)
+	assert.Equal(t, deliveries[1].ID, webhook1Deliveries[0].ID)
+	assert.NoError(t, deliveryErr)
+	assert.Equal(t, model.WebhookDeliveryStatusSucceeded, delivered.Status)
+	assert.Equal(t, 200, delivered.ResponseCode)
+	assert.Nil(t, delivered.NextAttemptAt)
+	assert.NotNil(t, delivered.DeliveredAt)
+	assert.NoError(t, claimErr)
+	assert.True(t, claimed)
+	assert.False(t, claimedAgain)
+	assert.False(t, notDue)
+	assert.False(t, succeeded)
+	assert.Empty(t, leasedDeliveries)
+	assert.NoError(t, deleteErr)
+	assert.NoError(t, deletedErr)
+	assert.Nil(t, deleted)
+	assert.NoError(t, webhooksErr)
+	assert.Len(t, webhooks, 1)
+	var count int
+	db.Model(&model.WebhookDelivery{}).Where("webhook_id = ?", webhook1.ID).Count(&count)
+	assert.Equal(t, 0, count)
+
+	// 4. Teardown
+	teardown(db)
+}
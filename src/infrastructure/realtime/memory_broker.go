@@ -0,0 +1,39 @@
+// Package realtime Infra層のリアルタイム配信
+package realtime
+
+import (
+	"sync"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// memoryBroker 構造体。1台構成用に、同じプロセス内でイベントを受け渡す。
+type memoryBroker struct {
+	mutex     sync.RWMutex
+	receivers []func(event *model.RealtimeEvent)
+}
+
+// NewMemoryBroker メモリ内でイベントを受け渡すRealtimeBrokerを生成する。
+func NewMemoryBroker() repository.RealtimeBroker {
+	return &memoryBroker{}
+}
+
+// Publish イベントの発行。購読している関数を順に呼び出す。
+func (broker *memoryBroker) Publish(event *model.RealtimeEvent) error {
+	broker.mutex.RLock()
+	defer broker.mutex.RUnlock()
+
+	for _, receive := range broker.receivers {
+		receive(event)
+	}
+	return nil
+}
+
+// Subscribe イベントの購読
+func (broker *memoryBroker) Subscribe(receive func(event *model.RealtimeEvent)) {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	broker.receivers = append(broker.receivers, receive)
+}
@@ -0,0 +1,73 @@
+// Package storage Infra層のファイルの保存
+package storage
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// fileArchiveStorage 構造体。エクスポートしたファイルをローカルのディレクトリに保存する。
+type fileArchiveStorage struct {
+	dir string
+}
+
+// defaultArchiveDir ファイルを保存するディレクトリのデフォルト値
+const defaultArchiveDir = "exports"
+
+// NewFileArchiveStorage ディレクトリdirにファイルを保存するExportArchiveStorageを生成する。dirが空の場合はdefaultArchiveDirに保存する。
+func NewFileArchiveStorage(dir string) repository.ExportArchiveStorage {
+	if dir == "" {
+		dir = defaultArchiveDir
+	}
+	return &fileArchiveStorage{dir}
+}
+
+// Save ファイルの保存。書き込み途中のファイルを読み込まないよう、一時ファイルに書き込んでから名前を変更する。
+func (storage *fileArchiveStorage) Save(key string, archive []byte) error {
+	if err := os.MkdirAll(storage.dir, 0700); err != nil {
+		return err
+	}
+	file, err := ioutil.TempFile(storage.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(archive); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), storage.path(key))
+}
+
+// Open ファイルの読み込み。存在しない場合はnilを返す。
+func (storage *fileArchiveStorage) Open(key string) (io.ReadCloser, error) {
+	file, err := os.Open(storage.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Delete ファイルの削除。存在しない場合は何もしない。
+func (storage *fileArchiveStorage) Delete(key string) error {
+	if err := os.Remove(storage.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path ファイルのパス。キーにディレクトリを含めても、ディレクトリの外には保存しない。
+func (storage *fileArchiveStorage) path(key string) string {
+	return filepath.Join(storage.dir, filepath.Base(key)+".zip")
+}
@@ -2,8 +2,13 @@
 package interactor
 
 import (
+	"os"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
 	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
 	"github.com/k-kazuya0926/power-phrase2-api/infrastructure/persistence/datastore"
+	"github.com/k-kazuya0926/power-phrase2-api/infrastructure/realtime"
+	"github.com/k-kazuya0926/power-phrase2-api/infrastructure/storage"
 	"github.com/k-kazuya0926/power-phrase2-api/ui/http/handler"
 	"github.com/k-kazuya0926/power-phrase2-api/usecase"
 )
@@ -11,10 +16,20 @@ import (
 // Interactor インターフェース。AppHandlerのインターフェースを保持。
 type Interactor interface {
 	NewAppHandler() handler.AppHandler
+	NewDomainEventDispatcher() usecase.DomainEventDispatcher
+	NewImportUseCase() usecase.ImportUseCase
+	NewExportUseCase() usecase.ExportUseCase
+	NewViewCounter() *usecase.ViewCounter
 }
 
 // interactor 構造体
 type interactor struct {
+	// realtimeUseCase 共有のハブをブローカーに一度だけ接続するため、ハンドラーと購読者で同じものを使う
+	realtimeUseCase usecase.RealtimeUseCase
+	// autocompleteIndexes 投稿を変更するユースケースが破棄できるよう、入力補完と同じキャッシュを使う
+	autocompleteIndexes *usecase.AutocompleteIndexCache
+	// viewCounter 停止時に反映していない閲覧数を反映できるよう、全APIリクエストで同じものを使う
+	viewCounter *usecase.ViewCounter
 }
 
 // NewInteractor intractorを生成。
@@ -24,7 +39,7 @@ func NewInteractor() Interactor {
 
 // NewAppHandler AppHandlerを生成。
 func (interactor *interactor) NewAppHandler() handler.AppHandler {
-	return handler.NewAppHandler(interactor.NewUserHandler(), interactor.NewPostHandler(), interactor.NewCommentHandler())
+	return handler.NewAppHandler(interactor.NewUserHandler(), interactor.NewPostHandler(), interactor.NewCommentHandler(), interactor.NewAutocompleteHandler(), interactor.NewAttributionHandler(), interactor.NewDuplicatePostHandler(), interactor.NewDailyPostHandler(), interactor.NewRandomPostHandler(), interactor.NewQuoteCardHandler(), interactor.NewShareHandler(), interactor.NewEmbedHandler(), interactor.NewTranslationHandler(), interactor.NewCollectionHandler(), interactor.NewImportHandler(), interactor.NewExportHandler(), interactor.NewReportHandler(), interactor.NewProhibitedWordHandler(), interactor.NewTrashHandler(), interactor.NewReactionHandler(), interactor.NewRelatedPostHandler(), interactor.NewFollowHandler(), interactor.NewNotificationHandler(), interactor.NewRealtimeHandler(), interactor.NewWebhookHandler())
 }
 
 // ユーザー関連
@@ -49,9 +64,22 @@ func (interactor *interactor) NewPostRepository() repository.PostRepository {
 	return datastore.NewPostRepository()
 }
 
+// NewPostViewRepository PostViewRepositoryを生成。
+func (interactor *interactor) NewPostViewRepository() repository.PostViewRepository {
+	return datastore.NewPostViewRepository()
+}
+
 // NewPostUseCase PostUseCaseを生成。
 func (interactor *interactor) NewPostUseCase() usecase.PostUseCase {
-	return usecase.NewPostUseCase(interactor.NewPostRepository())
+	return usecase.NewPostUseCase(interactor.NewPostRepository(), interactor.NewTranslationRepository(), interactor.NewAttributionRepository(), interactor.NewModerationRepository(), interactor.NewProhibitedWordRepository(), interactor.NewReportRepository(), interactor.NewReactionRepository(), interactor.NewPostViewRepository(), interactor.NewAutocompleteIndexCache(), interactor.NewViewCounter(), usecase.RunInBackground)
+}
+
+// NewViewCounter ViewCounterを生成。生成済みの場合はそれを返す。
+func (interactor *interactor) NewViewCounter() *usecase.ViewCounter {
+	if interactor.viewCounter == nil {
+		interactor.viewCounter = usecase.NewViewCounter(interactor.NewPostViewRepository(), usecase.RunInBackground)
+	}
+	return interactor.viewCounter
 }
 
 // NewPostHandler PostHandlerを生成。
@@ -62,10 +90,374 @@ func (interactor *interactor) NewPostHandler() handler.PostHandler {
 // コメント関連
 // NewCommentUseCase CommentUseCaseを生成。
 func (interactor *interactor) NewCommentUseCase() usecase.CommentUseCase {
-	return usecase.NewCommentUseCase(interactor.NewPostRepository())
+	return usecase.NewCommentUseCase(interactor.NewPostRepository(), interactor.NewProhibitedWordRepository(), interactor.NewReportRepository())
 }
 
 // NewCommentHandler CommentHandlerを生成。
 func (interactor *interactor) NewCommentHandler() handler.CommentHandler {
 	return handler.NewCommentHandler(interactor.NewCommentUseCase())
 }
+
+// 入力補完関連
+// NewAutocompleteIndexCache AutocompleteIndexCacheを生成。生成済みの場合はそれを返す。
+func (interactor *interactor) NewAutocompleteIndexCache() *usecase.AutocompleteIndexCache {
+	if interactor.autocompleteIndexes == nil {
+		interactor.autocompleteIndexes = usecase.NewAutocompleteIndexCache()
+	}
+	return interactor.autocompleteIndexes
+}
+
+// NewAutocompleteUseCase AutocompleteUseCaseを生成。
+func (interactor *interactor) NewAutocompleteUseCase() usecase.AutocompleteUseCase {
+	return usecase.NewAutocompleteUseCase(interactor.NewPostRepository(), interactor.NewAutocompleteIndexCache())
+}
+
+// NewAutocompleteHandler AutocompleteHandlerを生成。
+func (interactor *interactor) NewAutocompleteHandler() handler.AutocompleteHandler {
+	return handler.NewAutocompleteHandler(interactor.NewAutocompleteUseCase())
+}
+
+// 出典検証関連
+// NewAttributionRepository AttributionRepositoryを生成。
+func (interactor *interactor) NewAttributionRepository() repository.AttributionRepository {
+	return datastore.NewAttributionRepository()
+}
+
+// NewAttributionUseCase AttributionUseCaseを生成。
+func (interactor *interactor) NewAttributionUseCase() usecase.AttributionUseCase {
+	return usecase.NewAttributionUseCase(interactor.NewAttributionRepository())
+}
+
+// NewAttributionHandler AttributionHandlerを生成。
+func (interactor *interactor) NewAttributionHandler() handler.AttributionHandler {
+	return handler.NewAttributionHandler(interactor.NewAttributionUseCase())
+}
+
+// 重複投稿関連
+// NewDuplicatePostUseCase DuplicatePostUseCaseを生成。
+func (interactor *interactor) NewDuplicatePostUseCase() usecase.DuplicatePostUseCase {
+	return usecase.NewDuplicatePostUseCase(interactor.NewPostRepository())
+}
+
+// NewDuplicatePostHandler DuplicatePostHandlerを生成。
+func (interactor *interactor) NewDuplicatePostHandler() handler.DuplicatePostHandler {
+	return handler.NewDuplicatePostHandler(interactor.NewDuplicatePostUseCase())
+}
+
+// 今日の言葉関連
+// NewDailyPostRepository DailyPostRepositoryを生成。
+func (interactor *interactor) NewDailyPostRepository() repository.DailyPostRepository {
+	return datastore.NewDailyPostRepository()
+}
+
+// NewDailyPostUseCase DailyPostUseCaseを生成。
+func (interactor *interactor) NewDailyPostUseCase() usecase.DailyPostUseCase {
+	return usecase.NewDailyPostUseCase(interactor.NewPostRepository(), interactor.NewDailyPostRepository(), interactor.NewModerationRepository(), interactor.NewUserRepository())
+}
+
+// NewDailyPostHandler DailyPostHandlerを生成。
+func (interactor *interactor) NewDailyPostHandler() handler.DailyPostHandler {
+	return handler.NewDailyPostHandler(interactor.NewDailyPostUseCase())
+}
+
+// ランダム投稿関連
+// NewRandomPostUseCase RandomPostUseCaseを生成。
+func (interactor *interactor) NewRandomPostUseCase() usecase.RandomPostUseCase {
+	return usecase.NewRandomPostUseCase(interactor.NewPostRepository(), interactor.NewPostViewRepository(), usecase.RunInBackground)
+}
+
+// NewRandomPostHandler RandomPostHandlerを生成。
+func (interactor *interactor) NewRandomPostHandler() handler.RandomPostHandler {
+	return handler.NewRandomPostHandler(interactor.NewRandomPostUseCase())
+}
+
+// 引用カード関連
+// NewQuoteCardUseCase QuoteCardUseCaseを生成。
+func (interactor *interactor) NewQuoteCardUseCase() usecase.QuoteCardUseCase {
+	return usecase.NewQuoteCardUseCase(interactor.NewPostRepository())
+}
+
+// NewQuoteCardHandler QuoteCardHandlerを生成。
+func (interactor *interactor) NewQuoteCardHandler() handler.QuoteCardHandler {
+	return handler.NewQuoteCardHandler(interactor.NewQuoteCardUseCase())
+}
+
+// リンクプレビュー関連
+// NewShareUseCase ShareUseCaseを生成。
+func (interactor *interactor) NewShareUseCase() usecase.ShareUseCase {
+	return usecase.NewShareUseCase(interactor.NewPostRepository(), interactor.NewCollectionRepository(), interactor.NewUserRepository())
+}
+
+// NewShareHandler ShareHandlerを生成。
+func (interactor *interactor) NewShareHandler() handler.ShareHandler {
+	return handler.NewShareHandler(interactor.NewShareUseCase())
+}
+
+// 埋め込み関連
+// NewEmbedUseCase EmbedUseCaseを生成。
+func (interactor *interactor) NewEmbedUseCase() usecase.EmbedUseCase {
+	return usecase.NewEmbedUseCase(interactor.NewPostRepository())
+}
+
+// NewEmbedHandler EmbedHandlerを生成。
+func (interactor *interactor) NewEmbedHandler() handler.EmbedHandler {
+	return handler.NewEmbedHandler(interactor.NewEmbedUseCase())
+}
+
+// 翻訳関連
+// NewTranslationRepository TranslationRepositoryを生成。
+func (interactor *interactor) NewTranslationRepository() repository.TranslationRepository {
+	return datastore.NewTranslationRepository()
+}
+
+// NewTranslationUseCase TranslationUseCaseを生成。
+func (interactor *interactor) NewTranslationUseCase() usecase.TranslationUseCase {
+	return usecase.NewTranslationUseCase(interactor.NewPostRepository(), interactor.NewTranslationRepository(), interactor.NewUserRepository())
+}
+
+// NewTranslationHandler TranslationHandlerを生成。
+func (interactor *interactor) NewTranslationHandler() handler.TranslationHandler {
+	return handler.NewTranslationHandler(interactor.NewTranslationUseCase())
+}
+
+// まとめ関連
+// NewCollectionRepository CollectionRepositoryを生成。
+func (interactor *interactor) NewCollectionRepository() repository.CollectionRepository {
+	return datastore.NewCollectionRepository()
+}
+
+// NewCollectionUseCase CollectionUseCaseを生成。
+func (interactor *interactor) NewCollectionUseCase() usecase.CollectionUseCase {
+	return usecase.NewCollectionUseCase(interactor.NewPostRepository(), interactor.NewCollectionRepository())
+}
+
+// NewCollectionHandler CollectionHandlerを生成。
+func (interactor *interactor) NewCollectionHandler() handler.CollectionHandler {
+	return handler.NewCollectionHandler(interactor.NewCollectionUseCase())
+}
+
+// 一括登録関連
+// NewImportJobRepository ImportJobRepositoryを生成。
+func (interactor *interactor) NewImportJobRepository() repository.ImportJobRepository {
+	return datastore.NewImportJobRepository()
+}
+
+// NewImportUseCase ImportUseCaseを生成。
+func (interactor *interactor) NewImportUseCase() usecase.ImportUseCase {
+	return usecase.NewImportUseCase(interactor.NewPostRepository(), interactor.NewImportJobRepository(), interactor.NewProhibitedWordRepository(), interactor.NewReportRepository(), interactor.NewAutocompleteIndexCache(), usecase.RunInBackground)
+}
+
+// NewImportHandler ImportHandlerを生成。
+func (interactor *interactor) NewImportHandler() handler.ImportHandler {
+	return handler.NewImportHandler(interactor.NewImportUseCase())
+}
+
+// 個人データのエクスポート関連
+// NewExportJobRepository ExportJobRepositoryを生成。
+func (interactor *interactor) NewExportJobRepository() repository.ExportJobRepository {
+	return datastore.NewExportJobRepository()
+}
+
+// NewExportArchiveStorage ExportArchiveStorageを生成。
+// 環境変数EXPORT_ARCHIVE_DIRのディレクトリに保存する。複数台で運用する場合は共有のストレージのディレクトリを指定するか、
+// オブジェクトストレージに保存する実装に差し替える。
+func (interactor *interactor) NewExportArchiveStorage() repository.ExportArchiveStorage {
+	return storage.NewFileArchiveStorage(os.Getenv("EXPORT_ARCHIVE_DIR"))
+}
+
+// NewExportUseCase ExportUseCaseを生成。
+func (interactor *interactor) NewExportUseCase() usecase.ExportUseCase {
+	return usecase.NewExportUseCase(interactor.NewPostRepository(), interactor.NewUserRepository(), interactor.NewExportJobRepository(), interactor.NewExportArchiveStorage(), usecase.RunInBackground)
+}
+
+// NewExportHandler ExportHandlerを生成。
+func (interactor *interactor) NewExportHandler() handler.ExportHandler {
+	return handler.NewExportHandler(interactor.NewExportUseCase())
+}
+
+// 通報関連
+// NewReportRepository ReportRepositoryを生成。
+func (interactor *interactor) NewReportRepository() repository.ReportRepository {
+	return datastore.NewReportRepository()
+}
+
+// NewModerationRepository ModerationRepositoryを生成。
+func (interactor *interactor) NewModerationRepository() repository.ModerationRepository {
+	return datastore.NewModerationRepository()
+}
+
+// NewReportUseCase ReportUseCaseを生成。
+func (interactor *interactor) NewReportUseCase() usecase.ReportUseCase {
+	return usecase.NewReportUseCase(interactor.NewModerationRepository(), interactor.NewUserRepository(), interactor.NewReportRepository(), interactor.NewAutocompleteIndexCache())
+}
+
+// NewReportHandler ReportHandlerを生成。
+func (interactor *interactor) NewReportHandler() handler.ReportHandler {
+	return handler.NewReportHandler(interactor.NewReportUseCase())
+}
+
+// 禁止語関連
+// NewProhibitedWordRepository ProhibitedWordRepositoryを生成。
+func (interactor *interactor) NewProhibitedWordRepository() repository.ProhibitedWordRepository {
+	return datastore.NewProhibitedWordRepository()
+}
+
+// NewProhibitedWordUseCase ProhibitedWordUseCaseを生成。
+func (interactor *interactor) NewProhibitedWordUseCase() usecase.ProhibitedWordUseCase {
+	return usecase.NewProhibitedWordUseCase(interactor.NewProhibitedWordRepository())
+}
+
+// NewProhibitedWordHandler ProhibitedWordHandlerを生成。
+func (interactor *interactor) NewProhibitedWordHandler() handler.ProhibitedWordHandler {
+	return handler.NewProhibitedWordHandler(interactor.NewProhibitedWordUseCase())
+}
+
+// ゴミ箱関連
+// NewTrashRepository TrashRepositoryを生成。
+func (interactor *interactor) NewTrashRepository() repository.TrashRepository {
+	return datastore.NewTrashRepository()
+}
+
+// NewTrashUseCase TrashUseCaseを生成。
+func (interactor *interactor) NewTrashUseCase() usecase.TrashUseCase {
+	return usecase.NewTrashUseCase(interactor.NewTrashRepository(), interactor.NewUserRepository(), interactor.NewAutocompleteIndexCache())
+}
+
+// NewTrashHandler TrashHandlerを生成。
+func (interactor *interactor) NewTrashHandler() handler.TrashHandler {
+	return handler.NewTrashHandler(interactor.NewTrashUseCase())
+}
+
+// リアクション関連
+// NewReactionRepository ReactionRepositoryを生成。
+func (interactor *interactor) NewReactionRepository() repository.ReactionRepository {
+	return datastore.NewReactionRepository()
+}
+
+// NewReactionUseCase ReactionUseCaseを生成。
+func (interactor *interactor) NewReactionUseCase() usecase.ReactionUseCase {
+	return usecase.NewReactionUseCase(interactor.NewModerationRepository(), interactor.NewReactionRepository())
+}
+
+// NewReactionHandler ReactionHandlerを生成。
+func (interactor *interactor) NewReactionHandler() handler.ReactionHandler {
+	return handler.NewReactionHandler(interactor.NewReactionUseCase())
+}
+
+// 関連する投稿関連
+// NewRelatedPostRepository RelatedPostRepositoryを生成。
+func (interactor *interactor) NewRelatedPostRepository() repository.RelatedPostRepository {
+	return datastore.NewRelatedPostRepository()
+}
+
+// NewRelatedPostUseCase RelatedPostUseCaseを生成。
+func (interactor *interactor) NewRelatedPostUseCase() usecase.RelatedPostUseCase {
+	return usecase.NewRelatedPostUseCase(interactor.NewPostRepository(), interactor.NewRelatedPostRepository())
+}
+
+// NewRelatedPostHandler RelatedPostHandlerを生成。
+func (interactor *interactor) NewRelatedPostHandler() handler.RelatedPostHandler {
+	return handler.NewRelatedPostHandler(interactor.NewRelatedPostUseCase())
+}
+
+// フォロー関連
+// NewFeedRepository FeedRepositoryを生成。
+func (interactor *interactor) NewFeedRepository() repository.FeedRepository {
+	return datastore.NewFeedRepository()
+}
+
+// NewFollowUseCase FollowUseCaseを生成。
+func (interactor *interactor) NewFollowUseCase() usecase.FollowUseCase {
+	return usecase.NewFollowUseCase(interactor.NewPostRepository(), interactor.NewUserRepository(), interactor.NewFeedRepository(), interactor.NewReactionRepository())
+}
+
+// NewFollowHandler FollowHandlerを生成。
+func (interactor *interactor) NewFollowHandler() handler.FollowHandler {
+	return handler.NewFollowHandler(interactor.NewFollowUseCase())
+}
+
+// 通知関連
+// NewNotificationRepository NotificationRepositoryを生成。
+func (interactor *interactor) NewNotificationRepository() repository.NotificationRepository {
+	return datastore.NewNotificationRepository()
+}
+
+// NewNotificationUseCase NotificationUseCaseを生成。
+func (interactor *interactor) NewNotificationUseCase() usecase.NotificationUseCase {
+	return usecase.NewNotificationUseCase(interactor.NewModerationRepository(), interactor.NewNotificationRepository())
+}
+
+// NewNotificationHandler NotificationHandlerを生成。
+func (interactor *interactor) NewNotificationHandler() handler.NotificationHandler {
+	return handler.NewNotificationHandler(interactor.NewNotificationUseCase())
+}
+
+// リアルタイム配信関連
+// NewStreamTicketRepository StreamTicketRepositoryを生成。
+func (interactor *interactor) NewStreamTicketRepository() repository.StreamTicketRepository {
+	return datastore.NewStreamTicketRepository()
+}
+
+// NewFavoriteCountRepository FavoriteCountRepositoryを生成。
+func (interactor *interactor) NewFavoriteCountRepository() repository.FavoriteCountRepository {
+	return datastore.NewFavoriteCountRepository()
+}
+
+// NewRealtimeBroker RealtimeBrokerを生成。
+// 単一のAPIサーバーのみのため、メモリ上で配信する。複数台で運用する場合はサーバー間で配信するブローカーに差し替える。
+func (interactor *interactor) NewRealtimeBroker() repository.RealtimeBroker {
+	return realtime.NewMemoryBroker()
+}
+
+// NewRealtimeUseCase RealtimeUseCaseを生成。生成済みの場合はそれを返す。
+func (interactor *interactor) NewRealtimeUseCase() usecase.RealtimeUseCase {
+	if interactor.realtimeUseCase == nil {
+		interactor.realtimeUseCase = usecase.NewRealtimeUseCase(interactor.NewRealtimeBroker(), interactor.NewFavoriteCountRepository(), interactor.NewStreamTicketRepository())
+	}
+	return interactor.realtimeUseCase
+}
+
+// NewRealtimeHandler RealtimeHandlerを生成。
+func (interactor *interactor) NewRealtimeHandler() handler.RealtimeHandler {
+	return handler.NewRealtimeHandler(interactor.NewRealtimeUseCase())
+}
+
+// Webhook関連
+// NewWebhookRepository WebhookRepositoryを生成。
+func (interactor *interactor) NewWebhookRepository() repository.WebhookRepository {
+	return datastore.NewWebhookRepository()
+}
+
+// NewWebhookUseCase WebhookUseCaseを生成。
+func (interactor *interactor) NewWebhookUseCase() usecase.WebhookUseCase {
+	return usecase.NewWebhookUseCase(interactor.NewWebhookRepository(), usecase.RunInBackground)
+}
+
+// NewWebhookHandler WebhookHandlerを生成。
+func (interactor *interactor) NewWebhookHandler() handler.WebhookHandler {
+	return handler.NewWebhookHandler(interactor.NewWebhookUseCase())
+}
+
+// ドメインイベント関連
+// NewOutboxRepository OutboxRepositoryを生成。
+func (interactor *interactor) NewOutboxRepository() repository.OutboxRepository {
+	return datastore.NewOutboxRepository()
+}
+
+// NewDomainEventDispatcher DomainEventDispatcherを生成し、購読者を登録。
+func (interactor *interactor) NewDomainEventDispatcher() usecase.DomainEventDispatcher {
+	dispatcher := usecase.NewDomainEventDispatcher(interactor.NewOutboxRepository())
+	webhookUseCase := interactor.NewWebhookUseCase()
+	for _, eventType := range model.WebhookEventTypes {
+		dispatcher.Subscribe(eventType, webhookUseCase.HandleDomainEvent)
+	}
+	notificationUseCase := interactor.NewNotificationUseCase()
+	for _, eventType := range []string{model.DomainEventCommentCreated, model.DomainEventFavoriteCreated, model.DomainEventFollowCreated} {
+		dispatcher.Subscribe(eventType, notificationUseCase.HandleDomainEvent)
+	}
+	realtimeUseCase := interactor.NewRealtimeUseCase()
+	for _, eventType := range []string{model.DomainEventCommentCreated, model.DomainEventFavoriteCreated, model.DomainEventFavoriteDeleted} {
+		dispatcher.Subscribe(eventType, realtimeUseCase.HandleDomainEvent)
+	}
+	return dispatcher
+}
@@ -1,25 +1,70 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // タイムゾーン情報がない環境でもtime.LoadLocationを使えるようにする
 
+	"github.com/k-kazuya0926/power-phrase2-api/conf"
 	"github.com/k-kazuya0926/power-phrase2-api/interactor"
 	"github.com/k-kazuya0926/power-phrase2-api/ui/http/router"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
 	"github.com/k-kazuya0926/power-phrase2-api/validator"
 	"github.com/labstack/echo"
 )
 
+// shutdownTimeout 停止時に処理中のリクエストの終了を待つ時間
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	e := echo.New()
+
+	// 引用カード画像の日本語のフォントがない場合も、引用カード以外は使えるため警告のみとする
+	if err := usecase.CheckQuoteCardFont(); err != nil {
+		e.Logger.Warn(fmt.Sprintf("Failed to load quote card font: %v", err))
+	}
+	// テーブルの作成、更新は起動時に1回だけ行う
+	conf.Migrate(conf.DBConnection())
+
 	interactor := interactor.NewInteractor()
 	handler := interactor.NewAppHandler()
 
+	// 前回の停止時に実行中だったジョブを失敗にする
+	if _, err := interactor.NewImportUseCase().RecoverImportJobs(); err != nil {
+		e.Logger.Warn(fmt.Sprintf("Failed to recover import jobs: %v", err))
+	}
+	if _, err := interactor.NewExportUseCase().RecoverExportJobs(); err != nil {
+		e.Logger.Warn(fmt.Sprintf("Failed to recover export jobs: %v", err))
+	}
+
 	router.SetRoutes(e, handler)
 
+	// 送信箱に保存されたドメインイベントを購読者へ配信
+	go interactor.NewDomainEventDispatcher().Run(nil)
+
 	e.Validator = validator.NewValidator()
 
-	if err := e.Start(fmt.Sprintf(":%s", os.Getenv("SERVER_PORT"))); err != nil {
-		e.Logger.Fatal(fmt.Sprintf("Failed to start: %v", err))
+	go func() {
+		if err := e.Start(fmt.Sprintf(":%s", os.Getenv("SERVER_PORT"))); err != nil && err != http.ErrServerClosed {
+			e.Logger.Fatal(fmt.Sprintf("Failed to start: %v", err))
+		}
+	}()
+
+	// 停止時は処理中のリクエストの終了を待ってから、反映していない閲覧数を反映する
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(ctx); err != nil {
+		e.Logger.Warn(fmt.Sprintf("Failed to shutdown: %v", err))
+	}
+	if err := interactor.NewViewCounter().Flush(); err != nil {
+		e.Logger.Warn(fmt.Sprintf("Failed to flush post views: %v", err))
 	}
 }
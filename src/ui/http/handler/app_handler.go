@@ -1,11 +1,41 @@
 // Package handler UI層
 package handler
 
+import (
+	"net"
+	"os"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
 // AppHandler 全てのHandlerのinterfaceを満たす。
 type AppHandler interface {
 	UserHandler
 	PostHandler
 	CommentHandler
+	AutocompleteHandler
+	AttributionHandler
+	DuplicatePostHandler
+	DailyPostHandler
+	RandomPostHandler
+	QuoteCardHandler
+	ShareHandler
+	EmbedHandler
+	TranslationHandler
+	CollectionHandler
+	ImportHandler
+	ExportHandler
+	ReportHandler
+	ProhibitedWordHandler
+	TrashHandler
+	ReactionHandler
+	RelatedPostHandler
+	FollowHandler
+	NotificationHandler
+	RealtimeHandler
+	WebhookHandler
 	// embed all handler interfaces
 }
 
@@ -14,10 +44,107 @@ type appHandler struct {
 	UserHandler
 	PostHandler
 	CommentHandler
+	AutocompleteHandler
+	AttributionHandler
+	DuplicatePostHandler
+	DailyPostHandler
+	RandomPostHandler
+	QuoteCardHandler
+	ShareHandler
+	EmbedHandler
+	TranslationHandler
+	CollectionHandler
+	ImportHandler
+	ExportHandler
+	ReportHandler
+	ProhibitedWordHandler
+	TrashHandler
+	ReactionHandler
+	RelatedPostHandler
+	FollowHandler
+	NotificationHandler
+	RealtimeHandler
+	WebhookHandler
 	// embed all handler interfaces
 }
 
 // NewAppHandler AppHandlerを生成
-func NewAppHandler(userHandler UserHandler, postHandler PostHandler, commentHandler CommentHandler) AppHandler {
-	return &appHandler{userHandler, postHandler, commentHandler}
+func NewAppHandler(userHandler UserHandler, postHandler PostHandler, commentHandler CommentHandler, autocompleteHandler AutocompleteHandler, attributionHandler AttributionHandler, duplicatePostHandler DuplicatePostHandler, dailyPostHandler DailyPostHandler, randomPostHandler RandomPostHandler, quoteCardHandler QuoteCardHandler, shareHandler ShareHandler, embedHandler EmbedHandler, translationHandler TranslationHandler, collectionHandler CollectionHandler, importHandler ImportHandler, exportHandler ExportHandler, reportHandler ReportHandler, prohibitedWordHandler ProhibitedWordHandler, trashHandler TrashHandler, reactionHandler ReactionHandler, relatedPostHandler RelatedPostHandler, followHandler FollowHandler, notificationHandler NotificationHandler, realtimeHandler RealtimeHandler, webhookHandler WebhookHandler) AppHandler {
+	return &appHandler{userHandler, postHandler, commentHandler, autocompleteHandler, attributionHandler, duplicatePostHandler, dailyPostHandler, randomPostHandler, quoteCardHandler, shareHandler, embedHandler, translationHandler, collectionHandler, importHandler, exportHandler, reportHandler, prohibitedWordHandler, trashHandler, reactionHandler, relatedPostHandler, followHandler, notificationHandler, realtimeHandler, webhookHandler}
+}
+
+// loginUserID JWTトークンからログインユーザーIDを取得する。取得できない場合は0を返す。
+func loginUserID(c echo.Context) int {
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return 0
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0
+	}
+	sub, ok := claims["sub"].(float64)
+	if !ok {
+		return 0
+	}
+	return int(sub)
+}
+
+// clientIP リクエスト元のIPアドレスを取得する。
+// X-Forwarded-Forは詐称できるため、接続元が環境変数TRUSTED_PROXIES(カンマ区切りのIPアドレスまたはCIDR)で
+// 指定したプロキシの場合のみ使用し、信頼するプロキシを除いた最も右のアドレスをリクエスト元とする。
+func clientIP(c echo.Context) string {
+	remoteIP, _, err := net.SplitHostPort(c.Request().RemoteAddr)
+	if err != nil {
+		remoteIP = c.Request().RemoteAddr
+	}
+
+	proxies := trustedProxies()
+	if !containsIP(proxies, remoteIP) {
+		return remoteIP
+	}
+	forwarded := strings.Split(c.Request().Header.Get(echo.HeaderXForwardedFor), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(forwarded[i])
+		if ip != "" && !containsIP(proxies, ip) {
+			return ip
+		}
+	}
+	return remoteIP
+}
+
+// trustedProxies 環境変数TRUSTED_PROXIESで指定した信頼するプロキシのアドレス範囲。解析できない値は無視する。
+func trustedProxies() []*net.IPNet {
+	proxies := []*net.IPNet{}
+	for _, value := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			if strings.Contains(value, ":") {
+				value += "/128"
+			} else {
+				value += "/32"
+			}
+		}
+		if _, network, err := net.ParseCIDR(value); err == nil {
+			proxies = append(proxies, network)
+		}
+	}
+	return proxies
+}
+
+// containsIP ipがnetworksのいずれかに含まれるか
+func containsIP(networks []*net.IPNet, ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
 }
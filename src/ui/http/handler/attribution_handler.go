@@ -0,0 +1,116 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// AttributionHandler interface
+	AttributionHandler interface {
+		// 出典の証拠・異議登録
+		CreateAttributionClaim(c echo.Context) error
+		// 出典の証拠・異議一覧取得
+		GetAttributionClaims(c echo.Context) error
+		// 出典の証拠・異議の審査
+		RuleAttributionClaim(c echo.Context) error
+	}
+
+	// attributionHandler 構造体
+	attributionHandler struct {
+		AttributionUseCase usecase.AttributionUseCase
+	}
+)
+
+// NewAttributionHandler AttributionHandlerを生成。
+func NewAttributionHandler(usecase usecase.AttributionUseCase) AttributionHandler {
+	return &attributionHandler{usecase}
+}
+
+// CreateAttributionClaim 出典の証拠・異議登録
+func (handler *attributionHandler) CreateAttributionClaim(c echo.Context) error {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	request := &request.CreateAttributionClaimRequest{PostID: postID}
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err = handler.AttributionUseCase.CreateAttributionClaim(
+		request.PostID,
+		request.UserID,
+		request.ClaimType,
+		request.ProposedStatus,
+		request.Body,
+		request.SourceURL,
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetAttributionClaims 出典の証拠・異議一覧取得
+func (handler *attributionHandler) GetAttributionClaims(c echo.Context) error {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.GetAttributionClaimsRequest{PostID: postID}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	claims, err := handler.AttributionUseCase.GetAttributionClaims(postID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"attributionClaims": claims,
+	})
+}
+
+// RuleAttributionClaim 出典の証拠・異議の審査。モデレーターのみ実行できる。
+func (handler *attributionHandler) RuleAttributionClaim(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.RuleAttributionClaimRequest{ID: id}
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	// モデレーターIDはリクエストボディで上書きさせない
+	request.ModeratorID = loginUserID(c)
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err = handler.AttributionUseCase.RuleAttributionClaim(request.ID, request.ModeratorID, request.Status, request.Comment)
+	if err == usecase.ErrAttributionClaimNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err == usecase.ErrAttributionClaimAlreadyRuled {
+		return c.JSON(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
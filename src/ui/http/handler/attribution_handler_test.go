@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockAttributionUseCase struct {
+	mock.Mock
+}
+
+// 出典の証拠・異議登録
+func (usecase *mockAttributionUseCase) CreateAttributionClaim(postID, userID int, claimType, proposedStatus, body, sourceURL string) error {
+	return usecase.Called(postID, userID, claimType, proposedStatus, body, sourceURL).Error(0)
+}
+
+// 出典の証拠・異議一覧取得
+func (usecase *mockAttributionUseCase) GetAttributionClaims(postID int) ([]*model.GetAttributionClaimResult, error) {
+	args := usecase.Called(postID)
+	claims, ok := args.Get(0).([]*model.GetAttributionClaimResult)
+	if ok {
+		return claims, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 出典の証拠・異議の審査
+func (usecase *mockAttributionUseCase) RuleAttributionClaim(id, moderatorID int, status, comment string) error {
+	return usecase.Called(id, moderatorID, status, comment).Error(0)
+}
+
+// ログインユーザーのJWTトークンをコンテキストに設定する
+func setLoginUser(c echo.Context, userID int, role string) {
+	c.Set("user", &jwt.Token{Claims: jwt.MapClaims{"sub": float64(userID), "role": role}})
+}
+
+// 証拠・異議登録テスト
+func TestCreateAttributionClaim_success(t *testing.T) {
+	// 1. Setup
+	body := `{"user_id":1,"claim_type":"dispute","proposed_status":"misattributed","body":"別人の発言です","source_url":"https://example.com"}`
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/posts/1/attribution_claims", strings.NewReader(body), rec)
+	c.SetPath("/posts/:id/attribution_claims")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	usecase := mockAttributionUseCase{}
+	usecase.On("CreateAttributionClaim", 1, 1, "dispute", "misattributed", "別人の発言です", "https://example.com").Return(nil)
+	handler := NewAttributionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.CreateAttributionClaim(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestCreateAttributionClaim_error_validationError(t *testing.T) {
+	cases := []struct {
+		label string
+		id    string
+		body  string
+	}{
+		{"ID形式", "a", `{"user_id":1,"claim_type":"evidence","body":"body"}`},
+		{"UserID必須", "1", `{"claim_type":"evidence","body":"body"}`},
+		{"種類不正", "1", `{"user_id":1,"claim_type":"other","body":"body"}`},
+		{"提案状態不正", "1", `{"user_id":1,"claim_type":"dispute","proposed_status":"verified","body":"body"}`},
+		{"本文必須", "1", `{"user_id":1,"claim_type":"evidence"}`},
+		{"URL形式", "1", `{"user_id":1,"claim_type":"evidence","body":"body","source_url":"example"}`},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.POST, "/posts/1/attribution_claims", strings.NewReader(test.body), rec)
+		c.SetPath("/posts/:id/attribution_claims")
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+
+		usecase := mockAttributionUseCase{}
+		handler := NewAttributionHandler(&usecase)
+
+		// 2. Exercise
+		err := handler.CreateAttributionClaim(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+func TestCreateAttributionClaim_error_usecaseError(t *testing.T) {
+	// 1. Setup
+	body := `{"user_id":1,"claim_type":"evidence","body":"body"}`
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/posts/1/attribution_claims", strings.NewReader(body), rec)
+	c.SetPath("/posts/:id/attribution_claims")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	usecase := mockAttributionUseCase{}
+	usecase.On("CreateAttributionClaim", 1, 1, "evidence", "", "body", "").Return(errors.New("error"))
+	handler := NewAttributionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.CreateAttributionClaim(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// 4. Teardown
+}
+
+// 証拠・異議一覧取得テスト
+func TestGetAttributionClaims_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/1/attribution_claims", nil, rec)
+	c.SetPath("/posts/:id/attribution_claims")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	claim := &model.GetAttributionClaimResult{
+		AttributionClaim: model.AttributionClaim{ID: 1, PostID: 1, UserID: 1, ClaimType: model.ClaimTypeEvidence, Body: "body"},
+		UserName:         "testuser1",
+	}
+	usecase := mockAttributionUseCase{}
+	usecase.On("GetAttributionClaims", 1).Return([]*model.GetAttributionClaimResult{claim}, nil)
+	handler := NewAttributionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetAttributionClaims(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	response := struct {
+		AttributionClaims []*model.GetAttributionClaimResult `json:"attributionClaims"`
+	}{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, claim.Body, response.AttributionClaims[0].Body)
+
+	// 4. Teardown
+}
+
+func TestGetAttributionClaims_error_usecaseError(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/1/attribution_claims", nil, rec)
+	c.SetPath("/posts/:id/attribution_claims")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	usecase := mockAttributionUseCase{}
+	usecase.On("GetAttributionClaims", 1).Return(nil, errors.New("error"))
+	handler := NewAttributionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetAttributionClaims(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// 4. Teardown
+}
+
+// 証拠・異議審査テスト
+func TestRuleAttributionClaim_success(t *testing.T) {
+	// 1. Setup
+	// リクエストボディのモデレーターIDは無視される
+	body := `{"moderator_id":99,"status":"accepted","comment":"一次資料を確認"}`
+	rec := httptest.NewRecorder()
+	c := createContext(echo.PUT, "/attribution_claims/1/ruling", strings.NewReader(body), rec)
+	c.SetPath("/attribution_claims/:id/ruling")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	setLoginUser(c, 2, model.RoleModerator)
+
+	usecase := mockAttributionUseCase{}
+	usecase.On("RuleAttributionClaim", 1, 2, "accepted", "一次資料を確認").Return(nil)
+	handler := NewAttributionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.RuleAttributionClaim(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestRuleAttributionClaim_error_validationError(t *testing.T) {
+	cases := []struct {
+		label  string
+		userID int
+		body   string
+	}{
+		{"ログインユーザーなし", 0, `{"status":"accepted"}`},
+		{"状態必須", 2, `{}`},
+		{"状態不正", 2, `{"status":"pending"}`},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/attribution_claims/1/ruling", strings.NewReader(test.body), rec)
+		c.SetPath("/attribution_claims/:id/ruling")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleModerator)
+		}
+
+		usecase := mockAttributionUseCase{}
+		handler := NewAttributionHandler(&usecase)
+
+		// 2. Exercise
+		err := handler.RuleAttributionClaim(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+func TestRuleAttributionClaim_error_usecaseError(t *testing.T) {
+	cases := []struct {
+		label    string
+		err      error
+		expected int
+	}{
+		{"存在しない", usecase.ErrAttributionClaimNotFound, http.StatusNotFound},
+		{"審査済み", usecase.ErrAttributionClaimAlreadyRuled, http.StatusConflict},
+		{"その他", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/attribution_claims/1/ruling", strings.NewReader(`{"status":"rejected"}`), rec)
+		c.SetPath("/attribution_claims/:id/ruling")
+		c.SetParamNames("id")
+		c.SetParamValues(fmt.Sprint(1))
+		setLoginUser(c, 2, model.RoleModerator)
+
+		mockUseCase := mockAttributionUseCase{}
+		mockUseCase.On("RuleAttributionClaim", 1, 2, "rejected", "").Return(test.err)
+		handler := NewAttributionHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.RuleAttributionClaim(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
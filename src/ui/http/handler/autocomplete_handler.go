@@ -0,0 +1,62 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+// defaultAutocompleteLimit 入力補完候補の件数の既定値
+const defaultAutocompleteLimit = 10
+
+type (
+	// AutocompleteHandler interface
+	AutocompleteHandler interface {
+		// 入力補完候補取得
+		Autocomplete(c echo.Context) error
+	}
+
+	// autocompleteHandler 構造体
+	autocompleteHandler struct {
+		AutocompleteUseCase usecase.AutocompleteUseCase
+	}
+)
+
+// NewAutocompleteHandler AutocompleteHandlerを生成。
+func NewAutocompleteHandler(usecase usecase.AutocompleteUseCase) AutocompleteHandler {
+	return &autocompleteHandler{usecase}
+}
+
+// Autocomplete 入力補完候補取得
+func (handler *autocompleteHandler) Autocomplete(c echo.Context) error {
+	limit := defaultAutocompleteLimit
+	if c.QueryParam("limit") != "" {
+		var err error
+		limit, err = strconv.Atoi(c.QueryParam("limit"))
+		if err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "limit：数値で入力してください。")
+		}
+	}
+
+	request := &request.AutocompleteRequest{
+		Field: c.QueryParam("field"),
+		Q:     c.QueryParam("q"),
+		Limit: limit,
+	}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	candidates, err := handler.AutocompleteUseCase.Autocomplete(request.Field, request.Q, request.Limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"candidates": candidates,
+	})
+}
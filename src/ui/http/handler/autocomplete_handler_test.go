@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockAutocompleteUseCase struct {
+	mock.Mock
+}
+
+// 入力補完候補取得
+func (usecase *mockAutocompleteUseCase) Autocomplete(field, query string, limit int) ([]*model.AutocompleteCandidate, error) {
+	args := usecase.Called(field, query, limit)
+	candidates, ok := args.Get(0).([]*model.AutocompleteCandidate)
+	if ok {
+		return candidates, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 入力補完テスト
+func TestAutocomplete_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	q := make(url.Values)
+	q.Set("field", "speaker")
+	q.Set("q", "まつ")
+	c := createContext(echo.GET, "/autocomplete?"+q.Encode(), nil, rec)
+
+	expected := []*model.AutocompleteCandidate{{Text: "松下幸之助", Count: 3}}
+	usecase := mockAutocompleteUseCase{}
+	usecase.On("Autocomplete", "speaker", "まつ", defaultAutocompleteLimit).Return(expected, nil)
+	handler := NewAutocompleteHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.Autocomplete(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	response := struct {
+		Candidates []*model.AutocompleteCandidate `json:"candidates"`
+	}{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, expected, response.Candidates)
+
+	// 4. Teardown
+}
+
+func TestAutocomplete_error_validationError(t *testing.T) {
+	cases := []struct {
+		label string
+		field string
+		q     string
+		limit string
+	}{
+		{"field空", "", "a", "1"},
+		{"field不正", "detail", "a", "1"},
+		{"q空", "speaker", "", "1"},
+		{"limit形式", "speaker", "a", "a"},
+		{"limit下限", "speaker", "a", "0"},
+		{"limit上限", "speaker", "a", "51"},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		q := make(url.Values)
+		q.Set("field", test.field)
+		q.Set("q", test.q)
+		q.Set("limit", test.limit)
+		c := createContext(echo.GET, "/autocomplete?"+q.Encode(), nil, rec)
+
+		usecase := mockAutocompleteUseCase{}
+		handler := NewAutocompleteHandler(&usecase)
+
+		// 2. Exercise
+		err := handler.Autocomplete(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+func TestAutocomplete_error_usecaseError(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	q := make(url.Values)
+	q.Set("field", "title")
+	q.Set("q", "a")
+	q.Set("limit", "5")
+	c := createContext(echo.GET, "/autocomplete?"+q.Encode(), nil, rec)
+
+	usecase := mockAutocompleteUseCase{}
+	usecase.On("Autocomplete", "title", "a", 5).Return(nil, errors.New("error"))
+	handler := NewAutocompleteHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.Autocomplete(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// 4. Teardown
+}
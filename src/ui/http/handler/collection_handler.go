@@ -0,0 +1,301 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// CollectionHandler interface
+	CollectionHandler interface {
+		// まとめ登録
+		CreateCollection(c echo.Context) error
+		// ユーザーのまとめ一覧取得
+		GetCollections(c echo.Context) error
+		// まとめ詳細取得
+		GetCollection(c echo.Context) error
+		// まとめ更新
+		UpdateCollection(c echo.Context) error
+		// まとめ削除
+		DeleteCollection(c echo.Context) error
+
+		// まとめの投稿一覧取得
+		GetCollectionPosts(c echo.Context) error
+		// まとめへの投稿追加
+		AddCollectionPost(c echo.Context) error
+		// まとめからの投稿削除
+		DeleteCollectionPost(c echo.Context) error
+		// まとめの投稿の並び替え
+		ReorderCollectionPosts(c echo.Context) error
+
+		// 投稿を含むまとめ一覧取得
+		GetPostCollections(c echo.Context) error
+	}
+
+	// collectionHandler 構造体
+	collectionHandler struct {
+		CollectionUseCase usecase.CollectionUseCase
+	}
+)
+
+// NewCollectionHandler CollectionHandlerを生成。
+func NewCollectionHandler(usecase usecase.CollectionUseCase) CollectionHandler {
+	return &collectionHandler{usecase}
+}
+
+// CreateCollection まとめ登録。ログインユーザーを作成者とする。
+func (handler *collectionHandler) CreateCollection(c echo.Context) error {
+	request := new(request.CreateCollectionRequest)
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	request.UserID = loginUserID(c)
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	collection, err := handler.CollectionUseCase.CreateCollection(request.UserID, request.Name, request.Description, request.IsPublic)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, collection)
+}
+
+// GetCollections ユーザーのまとめ一覧取得。本人以外には公開されたまとめのみ返す。
+func (handler *collectionHandler) GetCollections(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "limit：数値で入力してください。")
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "page：数値で入力してください。")
+	}
+
+	request := &request.GetCollectionsRequest{UserID: userID, Limit: limit, Page: page}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	totalCount, collections, err := handler.CollectionUseCase.GetCollections(request.UserID, loginUserID(c), request.Limit, request.Page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"totalCount":  totalCount,
+		"collections": collections,
+	})
+}
+
+// GetCollection まとめ詳細取得
+func (handler *collectionHandler) GetCollection(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.GetCollectionRequest{ID: id}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	collection, err := handler.CollectionUseCase.GetCollection(request.ID, loginUserID(c))
+	if err != nil {
+		return c.JSON(collectionErrorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, collection)
+}
+
+// UpdateCollection まとめ更新。作成者のみ実行できる。
+func (handler *collectionHandler) UpdateCollection(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.UpdateCollectionRequest{}
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	request.ID = id
+	request.UserID = loginUserID(c)
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err = handler.CollectionUseCase.UpdateCollection(request.ID, request.UserID, request.Name, request.Description, request.CoverPostID, request.IsPublic)
+	if err != nil {
+		return c.JSON(collectionErrorStatus(err), err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteCollection まとめ削除。作成者のみ実行できる。
+func (handler *collectionHandler) DeleteCollection(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.DeleteCollectionRequest{ID: id, UserID: loginUserID(c)}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := handler.CollectionUseCase.DeleteCollection(request.ID, request.UserID); err != nil {
+		return c.JSON(collectionErrorStatus(err), err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetCollectionPosts まとめの投稿一覧取得
+func (handler *collectionHandler) GetCollectionPosts(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "limit：数値で入力してください。")
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "page：数値で入力してください。")
+	}
+
+	request := &request.GetCollectionPostsRequest{ID: id, Limit: limit, Page: page}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	totalCount, posts, err := handler.CollectionUseCase.GetCollectionPosts(request.ID, loginUserID(c), request.Limit, request.Page)
+	if err != nil {
+		return c.JSON(collectionErrorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"totalCount": totalCount,
+		"posts":      posts,
+	})
+}
+
+// AddCollectionPost まとめへの投稿追加。作成者のみ実行できる。
+func (handler *collectionHandler) AddCollectionPost(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.AddCollectionPostRequest{}
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	request.ID = id
+	request.UserID = loginUserID(c)
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := handler.CollectionUseCase.AddCollectionPost(request.ID, request.UserID, request.PostID); err != nil {
+		return c.JSON(collectionErrorStatus(err), err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteCollectionPost まとめからの投稿削除。作成者のみ実行できる。
+func (handler *collectionHandler) DeleteCollectionPost(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	postID, err := strconv.Atoi(c.Param("post_id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "post_id：数値で入力してください。")
+	}
+
+	request := &request.DeleteCollectionPostRequest{ID: id, UserID: loginUserID(c), PostID: postID}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := handler.CollectionUseCase.DeleteCollectionPost(request.ID, request.UserID, request.PostID); err != nil {
+		return c.JSON(collectionErrorStatus(err), err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ReorderCollectionPosts まとめの投稿の並び替え。作成者のみ実行できる。
+func (handler *collectionHandler) ReorderCollectionPosts(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.ReorderCollectionPostsRequest{}
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	request.ID = id
+	request.UserID = loginUserID(c)
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := handler.CollectionUseCase.ReorderCollectionPosts(request.ID, request.UserID, request.PostIDs); err != nil {
+		return c.JSON(collectionErrorStatus(err), err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetPostCollections 投稿を含むまとめ一覧取得
+func (handler *collectionHandler) GetPostCollections(c echo.Context) error {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.GetPostCollectionsRequest{PostID: postID}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	collections, err := handler.CollectionUseCase.GetPostCollections(request.PostID, loginUserID(c))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"collections": collections,
+	})
+}
+
+// collectionErrorStatus まとめ関連のエラーに対応するHTTPステータスコード
+func collectionErrorStatus(err error) int {
+	switch err {
+	case usecase.ErrCollectionNotFound:
+		return http.StatusNotFound
+	case usecase.ErrCollectionForbidden:
+		return http.StatusForbidden
+	case usecase.ErrCollectionPostNotIncluded, usecase.ErrCollectionOrderMismatch:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,463 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockCollectionUseCase struct {
+	mock.Mock
+}
+
+// まとめ登録
+func (usecase *mockCollectionUseCase) CreateCollection(userID int, name, description string, isPublic bool) (*model.Collection, error) {
+	args := usecase.Called(userID, name, description, isPublic)
+	collection, ok := args.Get(0).(*model.Collection)
+	if ok {
+		return collection, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// ユーザーのまとめ一覧取得
+func (usecase *mockCollectionUseCase) GetCollections(userID, loginUserID, limit, page int) (int, []*model.GetCollectionResult, error) {
+	args := usecase.Called(userID, loginUserID, limit, page)
+	collections, ok := args.Get(1).([]*model.GetCollectionResult)
+	if ok {
+		return args.Int(0), collections, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// まとめ詳細取得
+func (usecase *mockCollectionUseCase) GetCollection(id, loginUserID int) (*model.GetCollectionResult, error) {
+	args := usecase.Called(id, loginUserID)
+	collection, ok := args.Get(0).(*model.GetCollectionResult)
+	if ok {
+		return collection, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// まとめ更新
+func (usecase *mockCollectionUseCase) UpdateCollection(id, loginUserID int, name, description string, coverPostID int, isPublic bool) error {
+	return usecase.Called(id, loginUserID, name, description, coverPostID, isPublic).Error(0)
+}
+
+// まとめ削除
+func (usecase *mockCollectionUseCase) DeleteCollection(id, loginUserID int) error {
+	return usecase.Called(id, loginUserID).Error(0)
+}
+
+// まとめの投稿一覧取得
+func (usecase *mockCollectionUseCase) GetCollectionPosts(id, loginUserID, limit, page int) (int, []*model.GetPostResult, error) {
+	args := usecase.Called(id, loginUserID, limit, page)
+	posts, ok := args.Get(1).([]*model.GetPostResult)
+	if ok {
+		return args.Int(0), posts, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// まとめへの投稿追加
+func (usecase *mockCollectionUseCase) AddCollectionPost(id, loginUserID, postID int) error {
+	return usecase.Called(id, loginUserID, postID).Error(0)
+}
+
+// まとめからの投稿削除
+func (usecase *mockCollectionUseCase) DeleteCollectionPost(id, loginUserID, postID int) error {
+	return usecase.Called(id, loginUserID, postID).Error(0)
+}
+
+// まとめの投稿の並び替え
+func (usecase *mockCollectionUseCase) ReorderCollectionPosts(id, loginUserID int, postIDs []int) error {
+	return usecase.Called(id, loginUserID, postIDs).Error(0)
+}
+
+// 投稿を含むまとめ一覧取得
+func (usecase *mockCollectionUseCase) GetPostCollections(postID, loginUserID int) ([]*model.GetCollectionResult, error) {
+	args := usecase.Called(postID, loginUserID)
+	collections, ok := args.Get(0).([]*model.GetCollectionResult)
+	if ok {
+		return collections, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// まとめ登録テスト
+func TestCreateCollection_success(t *testing.T) {
+	// 1. Setup
+	body := `{"user_id":9,"name":"朝読む言葉","description":"description","is_public":true}`
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/collections", strings.NewReader(body), rec)
+	setLoginUser(c, 1, model.RoleUser)
+
+	expected := &model.Collection{ID: 1, UserID: 1, Name: "朝読む言葉", Description: "description", IsPublic: true}
+	usecase := mockCollectionUseCase{}
+	usecase.On("CreateCollection", 1, "朝読む言葉", "description", true).Return(expected, nil)
+	handler := NewCollectionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.CreateCollection(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	actual := model.Collection{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+	assert.Equal(t, 1, actual.ID)
+
+	// 4. Teardown
+}
+
+func TestCreateCollection_error_validationError(t *testing.T) {
+	cases := []struct {
+		label  string
+		userID int
+		body   string
+	}{
+		{"ログインユーザー必須", 0, `{"name":"name"}`},
+		{"名前必須", 1, `{"description":"description"}`},
+		{"名前桁数", 1, `{"name":"` + strings.Repeat("a", 51) + `"}`},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.POST, "/collections", strings.NewReader(test.body), rec)
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		usecase := mockCollectionUseCase{}
+		handler := NewCollectionHandler(&usecase)
+
+		// 2. Exercise
+		err := handler.CreateCollection(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// ユーザーのまとめ一覧取得テスト
+func TestGetCollections_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/users/1/collections?limit=10&page=1", nil, rec)
+	c.SetPath("/users/:id/collections")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	collections := []*model.GetCollectionResult{{Collection: model.Collection{ID: 1, UserID: 1, IsPublic: true}}}
+	usecase := mockCollectionUseCase{}
+	usecase.On("GetCollections", 1, 0, 10, 1).Return(1, collections, nil)
+	handler := NewCollectionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetCollections(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"totalCount":1`)
+
+	// 4. Teardown
+}
+
+// まとめ詳細取得テスト
+func TestGetCollection_error(t *testing.T) {
+	cases := []struct {
+		label    string
+		err      error
+		expected int
+	}{
+		{"存在しない", usecase.ErrCollectionNotFound, http.StatusNotFound},
+		{"その他", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/collections/1", nil, rec)
+		c.SetPath("/collections/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		setLoginUser(c, 2, model.RoleUser)
+
+		mockUseCase := mockCollectionUseCase{}
+		mockUseCase.On("GetCollection", 1, 2).Return(nil, test.err)
+		handler := NewCollectionHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetCollection(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// まとめ更新テスト
+func TestUpdateCollection_success(t *testing.T) {
+	// 1. Setup
+	body := `{"name":"試合前","description":"","cover_post_id":3,"is_public":false}`
+	rec := httptest.NewRecorder()
+	c := createContext(echo.PUT, "/collections/1", strings.NewReader(body), rec)
+	c.SetPath("/collections/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	setLoginUser(c, 1, model.RoleUser)
+
+	usecase := mockCollectionUseCase{}
+	usecase.On("UpdateCollection", 1, 1, "試合前", "", 3, false).Return(nil)
+	handler := NewCollectionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.UpdateCollection(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestUpdateCollection_error_usecaseError(t *testing.T) {
+	cases := []struct {
+		label    string
+		err      error
+		expected int
+	}{
+		{"作成者以外", usecase.ErrCollectionForbidden, http.StatusForbidden},
+		{"含まれない表紙", usecase.ErrCollectionPostNotIncluded, http.StatusUnprocessableEntity},
+		{"その他", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		body := `{"name":"name","cover_post_id":3}`
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/collections/1", strings.NewReader(body), rec)
+		c.SetPath("/collections/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		setLoginUser(c, 2, model.RoleUser)
+
+		mockUseCase := mockCollectionUseCase{}
+		mockUseCase.On("UpdateCollection", 1, 2, "name", "", 3, false).Return(test.err)
+		handler := NewCollectionHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.UpdateCollection(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// まとめ削除テスト
+func TestDeleteCollection_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.DELETE, "/collections/1", nil, rec)
+	c.SetPath("/collections/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	setLoginUser(c, 1, model.RoleUser)
+
+	usecase := mockCollectionUseCase{}
+	usecase.On("DeleteCollection", 1, 1).Return(nil)
+	handler := NewCollectionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.DeleteCollection(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+// まとめの投稿一覧取得テスト
+func TestGetCollectionPosts_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/collections/1/posts?limit=10&page=2", nil, rec)
+	c.SetPath("/collections/:id/posts")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	usecase := mockCollectionUseCase{}
+	usecase.On("GetCollectionPosts", 1, 0, 10, 2).Return(11, []*model.GetPostResult{makeGetPostResult(1)}, nil)
+	handler := NewCollectionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetCollectionPosts(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"totalCount":11`)
+
+	// 4. Teardown
+}
+
+// まとめへの投稿追加テスト
+func TestAddCollectionPost_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/collections/1/posts", strings.NewReader(`{"post_id":3}`), rec)
+	c.SetPath("/collections/:id/posts")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	setLoginUser(c, 1, model.RoleUser)
+
+	usecase := mockCollectionUseCase{}
+	usecase.On("AddCollectionPost", 1, 1, 3).Return(nil)
+	handler := NewCollectionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.AddCollectionPost(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+// まとめからの投稿削除テスト
+func TestDeleteCollectionPost_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.DELETE, "/collections/1/posts/3", nil, rec)
+	c.SetPath("/collections/:id/posts/:post_id")
+	c.SetParamNames("id", "post_id")
+	c.SetParamValues("1", "3")
+	setLoginUser(c, 1, model.RoleUser)
+
+	usecase := mockCollectionUseCase{}
+	usecase.On("DeleteCollectionPost", 1, 1, 3).Return(nil)
+	handler := NewCollectionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.DeleteCollectionPost(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+// まとめの投稿の並び替えテスト
+func TestReorderCollectionPosts_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.PUT, "/collections/1/posts/order", strings.NewReader(`{"post_ids":[5,3,4]}`), rec)
+	c.SetPath("/collections/:id/posts/order")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	setLoginUser(c, 1, model.RoleUser)
+
+	usecase := mockCollectionUseCase{}
+	usecase.On("ReorderCollectionPosts", 1, 1, []int{5, 3, 4}).Return(nil)
+	handler := NewCollectionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.ReorderCollectionPosts(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestReorderCollectionPosts_error(t *testing.T) {
+	cases := []struct {
+		label    string
+		body     string
+		err      error
+		expected int
+	}{
+		{"投稿ID必須", `{"post_ids":[]}`, nil, http.StatusUnprocessableEntity},
+		{"投稿ID不正", `{"post_ids":[0]}`, nil, http.StatusUnprocessableEntity},
+		{"不一致", `{"post_ids":[5]}`, usecase.ErrCollectionOrderMismatch, http.StatusUnprocessableEntity},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/collections/1/posts/order", strings.NewReader(test.body), rec)
+		c.SetPath("/collections/:id/posts/order")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		setLoginUser(c, 1, model.RoleUser)
+
+		mockUseCase := mockCollectionUseCase{}
+		mockUseCase.On("ReorderCollectionPosts", 1, 1, mock.Anything).Return(test.err)
+		handler := NewCollectionHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.ReorderCollectionPosts(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// 投稿を含むまとめ一覧取得テスト
+func TestGetPostCollections_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/3/collections", nil, rec)
+	c.SetPath("/posts/:id/collections")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	setLoginUser(c, 2, model.RoleUser)
+
+	collections := []*model.GetCollectionResult{{Collection: model.Collection{ID: 1}}}
+	usecase := mockCollectionUseCase{}
+	usecase.On("GetPostCollections", 3, 2).Return(collections, nil)
+	handler := NewCollectionHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetPostCollections(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"collections":[{"id":1`)
+
+	// 4. Teardown
+}
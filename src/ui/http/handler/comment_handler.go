@@ -49,6 +49,12 @@ func (handler *commentHandler) CreateComment(c echo.Context) error {
 		request.UserID,
 		request.Body,
 	)
+	if prohibitedErr, ok := err.(*usecase.ProhibitedWordError); ok {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"message": prohibitedErr.Error(),
+			"fields":  prohibitedErr.Fields,
+		})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
 	}
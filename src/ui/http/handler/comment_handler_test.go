@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
 	"github.com/labstack/echo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -152,6 +153,35 @@ func TestCreateComment_error_usecaseError(t *testing.T) {
 	// 4. Teardown
 }
 
+func TestCreateComment_error_prohibitedWord(t *testing.T) {
+	// 1. Setup
+	postID := 1
+	comment := makeComment(1, postID, 1)
+	jsonBytes, err := json.Marshal(comment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/posts/:id/comments", strings.NewReader(string(jsonBytes)), rec)
+	c.SetParamNames("id")
+	c.SetParamValues(fmt.Sprint(postID))
+
+	mockUseCase := mockCommentUseCase{}
+	mockUseCase.On("CreateComment", comment.PostID, comment.UserID, comment.Body).Return(&usecase.ProhibitedWordError{Fields: []string{"Body"}})
+	handler := NewCommentHandler(&mockUseCase)
+
+	// 2. Exercise
+	err = handler.CreateComment(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"fields":["Body"]`)
+
+	// 4. Teardown
+}
+
 // 一覧取得テスト
 func TestGetComments_success(t *testing.T) {
 	// 1. Setup
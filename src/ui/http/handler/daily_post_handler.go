@@ -0,0 +1,82 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// DailyPostHandler interface
+	DailyPostHandler interface {
+		// 今日の言葉取得
+		GetDailyPost(c echo.Context) error
+		// 今日の言葉指定
+		PinDailyPost(c echo.Context) error
+	}
+
+	// dailyPostHandler 構造体
+	dailyPostHandler struct {
+		DailyPostUseCase usecase.DailyPostUseCase
+	}
+)
+
+// NewDailyPostHandler DailyPostHandlerを生成。
+func NewDailyPostHandler(usecase usecase.DailyPostUseCase) DailyPostHandler {
+	return &dailyPostHandler{usecase}
+}
+
+// GetDailyPost 今日の言葉取得
+func (handler *dailyPostHandler) GetDailyPost(c echo.Context) error {
+	loginUserID, err := strconv.Atoi(c.QueryParam("login_user_id"))
+	if err != nil {
+		loginUserID = 0
+	}
+
+	request := &request.GetDailyPostRequest{
+		TimeZone:    c.QueryParam("tz"),
+		LoginUserID: loginUserID,
+	}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	date, post, err := handler.DailyPostUseCase.GetDailyPost(request.TimeZone, request.LoginUserID)
+	if err == usecase.ErrNoDailyPostCandidate {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"date": date,
+		"post": post,
+	})
+}
+
+// PinDailyPost 今日の言葉指定。管理者のみ実行できる。
+func (handler *dailyPostHandler) PinDailyPost(c echo.Context) error {
+	request := new(request.PinDailyPostRequest)
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	request.Date = c.Param("date")
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err := handler.DailyPostUseCase.PinDailyPost(request.Date, request.PostID)
+	if err == usecase.ErrPostNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockDailyPostUseCase struct {
+	mock.Mock
+}
+
+// 今日の言葉取得
+func (usecase *mockDailyPostUseCase) GetDailyPost(timeZone string, loginUserID int) (string, *model.GetPostResult, error) {
+	args := usecase.Called(timeZone, loginUserID)
+	post, ok := args.Get(1).(*model.GetPostResult)
+	if ok {
+		return args.String(0), post, args.Error(2)
+	}
+
+	return args.String(0), nil, args.Error(2)
+}
+
+// 今日の言葉指定
+func (usecase *mockDailyPostUseCase) PinDailyPost(date string, postID int) error {
+	return usecase.Called(date, postID).Error(0)
+}
+
+// 今日の言葉取得テスト
+func TestGetDailyPost_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	q := make(url.Values)
+	q.Set("tz", "Asia/Tokyo")
+	q.Set("login_user_id", "1")
+	c := createContext(echo.GET, "/posts/daily?"+q.Encode(), nil, rec)
+
+	expected := makeGetPostResult(1)
+	usecase := mockDailyPostUseCase{}
+	usecase.On("GetDailyPost", "Asia/Tokyo", 1).Return("2021-01-01", expected, nil)
+	handler := NewDailyPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetDailyPost(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	response := struct {
+		Date string               `json:"date"`
+		Post *model.GetPostResult `json:"post"`
+	}{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "2021-01-01", response.Date)
+	assert.Equal(t, expected, response.Post)
+
+	// 4. Teardown
+}
+
+func TestGetDailyPost_error_validationError(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/daily?tz=Mars/Olympus", nil, rec)
+
+	usecase := mockDailyPostUseCase{}
+	handler := NewDailyPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetDailyPost(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestGetDailyPost_error_usecaseError(t *testing.T) {
+	cases := []struct {
+		label    string
+		err      error
+		expected int
+	}{
+		{"候補なし", usecase.ErrNoDailyPostCandidate, http.StatusNotFound},
+		{"その他", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/posts/daily", nil, rec)
+
+		mockUseCase := mockDailyPostUseCase{}
+		mockUseCase.On("GetDailyPost", "", 0).Return("", nil, test.err)
+		handler := NewDailyPostHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetDailyPost(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// 今日の言葉指定テスト
+func TestPinDailyPost_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.PUT, "/admin/posts/daily/2021-01-01", strings.NewReader(`{"post_id":1}`), rec)
+	c.SetPath("/admin/posts/daily/:date")
+	c.SetParamNames("date")
+	c.SetParamValues("2021-01-01")
+
+	usecase := mockDailyPostUseCase{}
+	usecase.On("PinDailyPost", "2021-01-01", 1).Return(nil)
+	handler := NewDailyPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.PinDailyPost(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestPinDailyPost_error_validationError(t *testing.T) {
+	cases := []struct {
+		label string
+		date  string
+		body  string
+	}{
+		{"日付形式", "2021-13-01", `{"post_id":1}`},
+		{"投稿ID必須", "2021-01-01", `{}`},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/admin/posts/daily/"+test.date, strings.NewReader(test.body), rec)
+		c.SetPath("/admin/posts/daily/:date")
+		c.SetParamNames("date")
+		c.SetParamValues(test.date)
+
+		usecase := mockDailyPostUseCase{}
+		handler := NewDailyPostHandler(&usecase)
+
+		// 2. Exercise
+		err := handler.PinDailyPost(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+func TestPinDailyPost_error_usecaseError(t *testing.T) {
+	cases := []struct {
+		label    string
+		err      error
+		expected int
+	}{
+		{"投稿なし", usecase.ErrPostNotFound, http.StatusNotFound},
+		{"その他", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/admin/posts/daily/2021-01-01", strings.NewReader(`{"post_id":1}`), rec)
+		c.SetPath("/admin/posts/daily/:date")
+		c.SetParamNames("date")
+		c.SetParamValues("2021-01-01")
+
+		mockUseCase := mockDailyPostUseCase{}
+		mockUseCase.On("PinDailyPost", "2021-01-01", 1).Return(test.err)
+		handler := NewDailyPostHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.PinDailyPost(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
@@ -0,0 +1,39 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// DuplicatePostHandler interface
+	DuplicatePostHandler interface {
+		// 重複の可能性がある投稿のまとまり一覧取得
+		GetDuplicateClusters(c echo.Context) error
+	}
+
+	// duplicatePostHandler 構造体
+	duplicatePostHandler struct {
+		DuplicatePostUseCase usecase.DuplicatePostUseCase
+	}
+)
+
+// NewDuplicatePostHandler DuplicatePostHandlerを生成。
+func NewDuplicatePostHandler(usecase usecase.DuplicatePostUseCase) DuplicatePostHandler {
+	return &duplicatePostHandler{usecase}
+}
+
+// GetDuplicateClusters 重複の可能性がある投稿のまとまり一覧取得。管理者のみ実行できる。
+func (handler *duplicatePostHandler) GetDuplicateClusters(c echo.Context) error {
+	clusters, err := handler.DuplicatePostUseCase.GetDuplicateClusters()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"clusters": clusters,
+	})
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockDuplicatePostUseCase struct {
+	mock.Mock
+}
+
+// 重複の可能性がある投稿のまとまり一覧取得
+func (usecase *mockDuplicatePostUseCase) GetDuplicateClusters() ([]*model.DuplicateCluster, error) {
+	args := usecase.Called()
+	clusters, ok := args.Get(0).([]*model.DuplicateCluster)
+	if ok {
+		return clusters, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 重複まとまり一覧取得テスト
+func TestGetDuplicateClusters_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/admin/posts/duplicates", nil, rec)
+
+	expected := []*model.DuplicateCluster{{
+		Speaker: "speaker1",
+		Posts: []*model.DuplicateCandidate{
+			{PostID: 1, Title: "title1", Speaker: "speaker1", Similarity: 1},
+			{PostID: 2, Title: "title1!", Speaker: "speaker1", Similarity: 1},
+		},
+	}}
+	usecase := mockDuplicatePostUseCase{}
+	usecase.On("GetDuplicateClusters").Return(expected, nil)
+	handler := NewDuplicatePostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetDuplicateClusters(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	response := struct {
+		Clusters []*model.DuplicateCluster `json:"clusters"`
+	}{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, expected, response.Clusters)
+
+	// 4. Teardown
+}
+
+func TestGetDuplicateClusters_error_usecaseError(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/admin/posts/duplicates", nil, rec)
+
+	usecase := mockDuplicatePostUseCase{}
+	usecase.On("GetDuplicateClusters").Return(nil, errors.New("error"))
+	handler := NewDuplicatePostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetDuplicateClusters(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// 4. Teardown
+}
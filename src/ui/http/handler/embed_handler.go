@@ -0,0 +1,140 @@
+// Package handler UI層
+package handler
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+// embedWidgetTemplate 埋め込みウィジェットのHTML。外部リソースを読み込まない単体のページとする。
+var embedWidgetTemplate = template.Must(template.New("embed").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width,initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}}</title>
+<style>
+html,body{margin:0;height:100%;}
+body{box-sizing:border-box;display:flex;flex-direction:column;justify-content:space-between;padding:16px 20px;border-left:6px solid {{.Accent}};background:{{.Background}};color:{{.Text}};font-family:"Hiragino Sans","Noto Sans JP",sans-serif;overflow:hidden;}
+blockquote{margin:0;font-size:20px;line-height:1.6;font-weight:bold;word-break:break-all;line-break:strict;overflow:hidden;}
+.speaker{margin:8px 0 0;text-align:right;color:{{.Sub}};}
+.brand{font-size:12px;}
+a{color:{{.Accent}};text-decoration:none;}
+</style>
+</head>
+<body>
+<blockquote>{{.Title}}</blockquote>
+<p class="speaker">― {{.Speaker}}</p>
+<a class="brand" href="{{.PostURL}}" target="_blank" rel="noopener">Power Phrase</a>
+</body>
+</html>
+`))
+
+type (
+	// EmbedHandler interface
+	EmbedHandler interface {
+		// oEmbed取得
+		GetOEmbed(c echo.Context) error
+		// 埋め込みウィジェット取得
+		GetEmbedWidget(c echo.Context) error
+	}
+
+	// embedHandler 構造体
+	embedHandler struct {
+		EmbedUseCase usecase.EmbedUseCase
+	}
+)
+
+// NewEmbedHandler EmbedHandlerを生成。
+func NewEmbedHandler(usecase usecase.EmbedUseCase) EmbedHandler {
+	return &embedHandler{usecase}
+}
+
+// GetOEmbed oEmbed取得。JSON形式のみ対応する。
+func (handler *embedHandler) GetOEmbed(c echo.Context) error {
+	maxWidth, maxHeight := 0, 0
+	if c.QueryParam("maxwidth") != "" {
+		var err error
+		if maxWidth, err = strconv.Atoi(c.QueryParam("maxwidth")); err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "maxwidth：数値で入力してください。")
+		}
+	}
+	if c.QueryParam("maxheight") != "" {
+		var err error
+		if maxHeight, err = strconv.Atoi(c.QueryParam("maxheight")); err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "maxheight：数値で入力してください。")
+		}
+	}
+
+	request := &request.GetOEmbedRequest{
+		URL:       c.QueryParam("url"),
+		Format:    c.QueryParam("format"),
+		MaxWidth:  maxWidth,
+		MaxHeight: maxHeight,
+		Theme:     c.QueryParam("theme"),
+	}
+	if request.Theme == "" {
+		request.Theme = usecase.QuoteCardThemeLight
+	}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	// oEmbedの仕様上、対応していない形式は501を返す
+	if request.Format == "xml" {
+		return c.JSON(http.StatusNotImplemented, "format：jsonのみ対応しています。")
+	}
+
+	oembed, err := handler.EmbedUseCase.GetOEmbed(request.URL, request.MaxWidth, request.MaxHeight, request.Theme)
+	switch err {
+	case nil:
+	case usecase.ErrOEmbedURLNotSupported, usecase.ErrPostNotFound:
+		// oEmbedの仕様上、埋め込みできないURLは404を返す
+		return c.JSON(http.StatusNotFound, err.Error())
+	case usecase.ErrOEmbedSizeNotSupported:
+		return c.JSON(http.StatusNotImplemented, err.Error())
+	default:
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, oembed)
+}
+
+// GetEmbedWidget 埋め込みウィジェット取得。
+// 他サイトのiframeから表示されるため、スクリプトを実行できないようContent-Security-Policyで制限する。
+func (handler *embedHandler) GetEmbedWidget(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.GetEmbedWidgetRequest{ID: id, Theme: c.QueryParam("theme")}
+	if request.Theme == "" {
+		request.Theme = usecase.QuoteCardThemeLight
+	}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	widget, err := handler.EmbedUseCase.GetEmbedWidget(request.ID, request.Theme)
+	if err == usecase.ErrPostNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := embedWidgetTemplate.Execute(buffer, widget); err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+	c.Response().Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors *; sandbox allow-popups allow-popups-to-escape-sandbox")
+	c.Response().Header().Set("Cache-Control", "public, max-age=600")
+	return c.HTMLBlob(http.StatusOK, buffer.Bytes())
+}
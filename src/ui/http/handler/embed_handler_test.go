@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockEmbedUseCase struct {
+	mock.Mock
+}
+
+// oEmbed取得
+func (usecase *mockEmbedUseCase) GetOEmbed(rawURL string, maxWidth, maxHeight int, theme string) (*model.OEmbed, error) {
+	args := usecase.Called(rawURL, maxWidth, maxHeight, theme)
+	oembed, ok := args.Get(0).(*model.OEmbed)
+	if ok {
+		return oembed, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 埋め込みウィジェット取得
+func (usecase *mockEmbedUseCase) GetEmbedWidget(postID int, theme string) (*model.EmbedWidget, error) {
+	args := usecase.Called(postID, theme)
+	widget, ok := args.Get(0).(*model.EmbedWidget)
+	if ok {
+		return widget, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// oEmbed取得テスト
+func TestGetOEmbed_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	q := make(url.Values)
+	q.Set("url", "https://www.example.com/posts/1")
+	q.Set("format", "json")
+	q.Set("maxwidth", "400")
+	c := createContext(echo.GET, "/oembed?"+q.Encode(), nil, rec)
+
+	expected := &model.OEmbed{Type: "rich", Version: "1.0", HTML: "<iframe></iframe>", Width: 400, Height: 250}
+	mockUseCase := mockEmbedUseCase{}
+	mockUseCase.On("GetOEmbed", "https://www.example.com/posts/1", 400, 0, usecase.QuoteCardThemeLight).Return(expected, nil)
+	handler := NewEmbedHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetOEmbed(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	actual := &model.OEmbed{}
+	json.Unmarshal(rec.Body.Bytes(), actual)
+	assert.Equal(t, expected, actual)
+
+	// 4. Teardown
+}
+
+func TestGetOEmbed_error(t *testing.T) {
+	cases := []struct {
+		label    string
+		query    string
+		err      error
+		expected int
+	}{
+		{"URL必須", "format=json", nil, http.StatusUnprocessableEntity},
+		{"最大幅数値", "url=https%3A%2F%2Fwww.example.com%2Fposts%2F1&maxwidth=a", nil, http.StatusUnprocessableEntity},
+		{"テーマ", "url=https%3A%2F%2Fwww.example.com%2Fposts%2F1&theme=blue", nil, http.StatusUnprocessableEntity},
+		{"XML形式", "url=https%3A%2F%2Fwww.example.com%2Fposts%2F1&format=xml", nil, http.StatusNotImplemented},
+		{"対象外のURL", "url=https%3A%2F%2Fwww.example.com%2Fposts%2F1", usecase.ErrOEmbedURLNotSupported, http.StatusNotFound},
+		{"投稿なし", "url=https%3A%2F%2Fwww.example.com%2Fposts%2F1", usecase.ErrPostNotFound, http.StatusNotFound},
+		{"大きさ", "url=https%3A%2F%2Fwww.example.com%2Fposts%2F1", usecase.ErrOEmbedSizeNotSupported, http.StatusNotImplemented},
+		{"その他", "url=https%3A%2F%2Fwww.example.com%2Fposts%2F1", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/oembed?"+test.query, nil, rec)
+
+		mockUseCase := mockEmbedUseCase{}
+		mockUseCase.On("GetOEmbed", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, test.err)
+		handler := NewEmbedHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetOEmbed(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// 埋め込みウィジェット取得テスト
+func TestGetEmbedWidget_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/embed/posts/1?theme=dark", nil, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	widget := &model.EmbedWidget{
+		Title:      "<b>title</b>",
+		Speaker:    "speaker",
+		PostURL:    "https://www.example.com/posts/1",
+		Background: "#212121",
+		Text:       "#f5f5f5",
+		Sub:        "#bdbdbd",
+		Accent:     "#ffb74d",
+	}
+	mockUseCase := mockEmbedUseCase{}
+	mockUseCase.On("GetEmbedWidget", 1, usecase.QuoteCardThemeDark).Return(widget, nil)
+	handler := NewEmbedHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetEmbedWidget(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "&lt;b&gt;title&lt;/b&gt;")
+	assert.Contains(t, body, "background:#212121;")
+	assert.Contains(t, body, `href="https://www.example.com/posts/1"`)
+	assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "sandbox")
+
+	// 4. Teardown
+}
+
+func TestGetEmbedWidget_error(t *testing.T) {
+	cases := []struct {
+		label    string
+		id       string
+		theme    string
+		err      error
+		expected int
+	}{
+		{"ID数値", "a", "light", nil, http.StatusUnprocessableEntity},
+		{"テーマ", "1", "blue", nil, http.StatusUnprocessableEntity},
+		{"投稿なし", "1", "light", usecase.ErrPostNotFound, http.StatusNotFound},
+		{"usecaseエラー", "1", "light", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/embed/posts/"+test.id+"?theme="+test.theme, nil, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+
+		mockUseCase := mockEmbedUseCase{}
+		mockUseCase.On("GetEmbedWidget", 1, mock.Anything).Return(nil, test.err)
+		handler := NewEmbedHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetEmbedWidget(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
@@ -0,0 +1,99 @@
+// Package handler UI層
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// ExportHandler interface
+	ExportHandler interface {
+		// 個人データのエクスポート要求
+		CreateExport(c echo.Context) error
+		// エクスポートジョブ取得
+		GetExport(c echo.Context) error
+		// エクスポートしたファイルのダウンロード
+		DownloadExport(c echo.Context) error
+	}
+
+	// exportHandler 構造体
+	exportHandler struct {
+		ExportUseCase usecase.ExportUseCase
+	}
+)
+
+// NewExportHandler ExportHandlerを生成。
+func NewExportHandler(usecase usecase.ExportUseCase) ExportHandler {
+	return &exportHandler{usecase}
+}
+
+// CreateExport 個人データのエクスポート要求。ログインユーザーのデータをエクスポートする。
+// ジョブを登録して202を返す。要求が多すぎる場合はRetry-Afterヘッダーを付けて429を返す。
+func (handler *exportHandler) CreateExport(c echo.Context) error {
+	request := &request.CreateExportRequest{UserID: loginUserID(c)}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	job, err := handler.ExportUseCase.CreateExport(request.UserID)
+	if rateLimitErr, ok := err.(*usecase.ExportRateLimitError); ok {
+		c.Response().Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))))
+		return c.JSON(http.StatusTooManyRequests, rateLimitErr.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// GetExport エクスポートジョブ取得。ジョブを要求したユーザーのみ取得できる。
+func (handler *exportHandler) GetExport(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	request := &request.GetExportRequest{ID: id, UserID: loginUserID(c)}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	job, err := handler.ExportUseCase.GetExport(request.ID, request.UserID)
+	if err == usecase.ErrExportNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// DownloadExport エクスポートしたファイルのダウンロード。ダウンロードURLのトークンで認可する。
+func (handler *exportHandler) DownloadExport(c echo.Context) error {
+	request := &request.DownloadExportRequest{Token: c.Param("token")}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusNotFound, usecase.ErrExportNotFound.Error())
+	}
+
+	fileName, archive, err := handler.ExportUseCase.DownloadExport(request.Token)
+	if err == usecase.ErrExportNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	defer archive.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	return c.Stream(http.StatusOK, "application/zip", archive)
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockExportUseCase struct {
+	mock.Mock
+}
+
+// 個人データのエクスポート要求
+func (usecase *mockExportUseCase) CreateExport(userID int) (*model.ExportJob, error) {
+	args := usecase.Called(userID)
+	job, ok := args.Get(0).(*model.ExportJob)
+	if ok {
+		return job, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// エクスポートジョブ取得
+func (usecase *mockExportUseCase) GetExport(id, userID int) (*model.ExportJob, error) {
+	args := usecase.Called(id, userID)
+	job, ok := args.Get(0).(*model.ExportJob)
+	if ok {
+		return job, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// エクスポートしたファイルのダウンロード
+func (usecase *mockExportUseCase) DownloadExport(token string) (string, io.ReadCloser, error) {
+	args := usecase.Called(token)
+	archive, _ := args.Get(1).(io.ReadCloser)
+	return args.String(0), archive, args.Error(2)
+}
+
+// ダウンロード期限を過ぎたエクスポートの削除
+func (usecase *mockExportUseCase) PurgeExpiredExports() (int, error) {
+	args := usecase.Called()
+	return args.Int(0), args.Error(1)
+}
+
+// 中断したジョブを失敗にする
+func (usecase *mockExportUseCase) RecoverExportJobs() (int, error) {
+	args := usecase.Called()
+	return args.Int(0), args.Error(1)
+}
+
+// エクスポート要求テスト
+func TestCreateExport(t *testing.T) {
+	cases := []struct {
+		label      string
+		userID     int
+		err        error
+		status     int
+		retryAfter string
+	}{
+		{"成功", 1, nil, http.StatusAccepted, ""},
+		{"ログインユーザー必須", 0, nil, http.StatusUnprocessableEntity, ""},
+		{"要求が多すぎる", 1, &usecase.ExportRateLimitError{RetryAfter: 90 * time.Second}, http.StatusTooManyRequests, "90"},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.POST, "/exports", nil, rec)
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		mockUseCase := mockExportUseCase{}
+		if test.err == nil {
+			mockUseCase.On("CreateExport", 1).Return(&model.ExportJob{ID: 1, UserID: 1}, nil)
+		} else {
+			mockUseCase.On("CreateExport", 1).Return(nil, test.err)
+		}
+		handler := NewExportHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.CreateExport(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+		assert.Equal(t, test.retryAfter, rec.Header().Get("Retry-After"), test.label)
+
+		// 4. Teardown
+	}
+}
+
+// エクスポートジョブ取得テスト
+func TestGetExport(t *testing.T) {
+	cases := []struct {
+		label  string
+		id     string
+		err    error
+		status int
+	}{
+		{"成功", "1", nil, http.StatusOK},
+		{"ID形式", "a", nil, http.StatusUnprocessableEntity},
+		{"ジョブなし", "2", usecase.ErrExportNotFound, http.StatusNotFound},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/exports/"+test.id, nil, rec)
+		c.SetPath("/exports/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+		setLoginUser(c, 1, model.RoleUser)
+
+		mockUseCase := mockExportUseCase{}
+		if test.err == nil {
+			mockUseCase.On("GetExport", 1, 1).Return(&model.ExportJob{ID: 1, UserID: 1}, nil)
+		} else {
+			mockUseCase.On("GetExport", 2, 1).Return(nil, test.err)
+		}
+		handler := NewExportHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetExport(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// ダウンロードテスト
+func TestDownloadExport(t *testing.T) {
+	token := strings.Repeat("0a", 32)
+	cases := []struct {
+		label  string
+		token  string
+		err    error
+		status int
+	}{
+		{"成功", token, nil, http.StatusOK},
+		{"トークン形式", "token", nil, http.StatusNotFound},
+		{"期限切れ", token, usecase.ErrExportNotFound, http.StatusNotFound},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/exports/download/"+test.token, nil, rec)
+		c.SetPath("/exports/download/:token")
+		c.SetParamNames("token")
+		c.SetParamValues(test.token)
+
+		mockUseCase := mockExportUseCase{}
+		if test.err == nil {
+			mockUseCase.On("DownloadExport", token).Return("export.zip", ioutil.NopCloser(strings.NewReader("zip")), nil)
+		} else {
+			mockUseCase.On("DownloadExport", token).Return("", nil, test.err)
+		}
+		handler := NewExportHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.DownloadExport(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+		if test.status == http.StatusOK {
+			assert.Equal(t, "application/zip", rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, `attachment; filename="export.zip"`, rec.Header().Get(echo.HeaderContentDisposition))
+			assert.Equal(t, "zip", rec.Body.String())
+		}
+
+		// 4. Teardown
+	}
+}
@@ -0,0 +1,200 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+// defaultFeedLimit フィードの取得件数の指定がない場合の件数
+const defaultFeedLimit = 20
+
+type (
+	// FollowHandler interface
+	FollowHandler interface {
+		// フォロー
+		Follow(c echo.Context) error
+		// フォロー解除
+		Unfollow(c echo.Context) error
+		// フォロワー一覧取得
+		GetFollowers(c echo.Context) error
+		// フォロー中のユーザー一覧取得
+		GetFollowing(c echo.Context) error
+		// フォロワー数、フォロー数取得
+		GetFollowCounts(c echo.Context) error
+		// フィード取得
+		GetFeed(c echo.Context) error
+	}
+
+	// followHandler 構造体
+	followHandler struct {
+		FollowUseCase usecase.FollowUseCase
+	}
+)
+
+// NewFollowHandler FollowHandlerを生成。
+func NewFollowHandler(usecase usecase.FollowUseCase) FollowHandler {
+	return &followHandler{usecase}
+}
+
+// Follow フォロー。ログインユーザーが指定したユーザーをフォローする。
+// 既にフォローしている場合も200を返す。存在しないユーザーの場合は404を返す。
+func (handler *followHandler) Follow(c echo.Context) error {
+	followeeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.FollowRequest{FollowerID: loginUserID(c), FolloweeID: followeeID}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err = handler.FollowUseCase.Follow(request.FollowerID, request.FolloweeID)
+	if err == usecase.ErrFollowSelf {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	if err == usecase.ErrUserNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// Unfollow フォロー解除。ログインユーザーのフォローを対象とする。
+func (handler *followHandler) Unfollow(c echo.Context) error {
+	followeeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.FollowRequest{FollowerID: loginUserID(c), FolloweeID: followeeID}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := handler.FollowUseCase.Unfollow(request.FollowerID, request.FolloweeID); err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetFollowers フォロワー一覧取得
+func (handler *followHandler) GetFollowers(c echo.Context) error {
+	request, errMessage := newGetFollowUsersRequest(c)
+	if errMessage != "" {
+		return c.JSON(http.StatusUnprocessableEntity, errMessage)
+	}
+
+	totalCount, users, err := handler.FollowUseCase.GetFollowers(request.UserID, request.Limit, request.Page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"totalCount": totalCount,
+		"users":      users,
+	})
+}
+
+// GetFollowing フォロー中のユーザー一覧取得
+func (handler *followHandler) GetFollowing(c echo.Context) error {
+	request, errMessage := newGetFollowUsersRequest(c)
+	if errMessage != "" {
+		return c.JSON(http.StatusUnprocessableEntity, errMessage)
+	}
+
+	totalCount, users, err := handler.FollowUseCase.GetFollowing(request.UserID, request.Limit, request.Page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"totalCount": totalCount,
+		"users":      users,
+	})
+}
+
+// newGetFollowUsersRequest フォロワー、フォロー中のユーザー一覧取得リクエストを生成し、入力チェックする。
+// 入力エラーの場合はエラーメッセージを返す。
+func newGetFollowUsersRequest(c echo.Context) (*request.GetFollowUsersRequest, string) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return nil, "ID：数値で入力してください。"
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil {
+		return nil, "limit：数値で入力してください。"
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		return nil, "page：数値で入力してください。"
+	}
+
+	request := &request.GetFollowUsersRequest{UserID: userID, Limit: limit, Page: page}
+	if err := c.Validate(request); err != nil {
+		return nil, err.Error()
+	}
+	return request, ""
+}
+
+// GetFollowCounts フォロワー数、フォロー数取得。ログインしている場合はフォローしているかも返す。
+func (handler *followHandler) GetFollowCounts(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.GetFollowCountsRequest{UserID: userID}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	counts, err := handler.FollowUseCase.GetFollowCounts(request.UserID, loginUserID(c))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, counts)
+}
+
+// GetFeed フィード取得。ログインユーザーのフィードを返す。
+func (handler *followHandler) GetFeed(c echo.Context) error {
+	cursor := 0
+	if c.QueryParam("cursor") != "" {
+		var err error
+		if cursor, err = strconv.Atoi(c.QueryParam("cursor")); err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "cursor：数値で入力してください。")
+		}
+	}
+	limit := defaultFeedLimit
+	if c.QueryParam("limit") != "" {
+		var err error
+		if limit, err = strconv.Atoi(c.QueryParam("limit")); err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "limit：数値で入力してください。")
+		}
+	}
+
+	request := &request.GetFeedRequest{UserID: loginUserID(c), Cursor: cursor, Limit: limit}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	posts, nextCursor, err := handler.FollowUseCase.GetFeed(request.UserID, request.Cursor, request.Limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"posts":      posts,
+		"nextCursor": nextCursor,
+	})
+}
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockFollowUseCase struct {
+	mock.Mock
+}
+
+// フォロー
+func (usecase *mockFollowUseCase) Follow(followerID, followeeID int) error {
+	return usecase.Called(followerID, followeeID).Error(0)
+}
+
+// フォロー解除
+func (usecase *mockFollowUseCase) Unfollow(followerID, followeeID int) error {
+	return usecase.Called(followerID, followeeID).Error(0)
+}
+
+// フォロワー一覧取得
+func (usecase *mockFollowUseCase) GetFollowers(userID, limit, page int) (int, []*model.GetFollowUserResult, error) {
+	args := usecase.Called(userID, limit, page)
+	users, ok := args.Get(1).([]*model.GetFollowUserResult)
+	if ok {
+		return args.Int(0), users, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// フォロー中のユーザー一覧取得
+func (usecase *mockFollowUseCase) GetFollowing(userID, limit, page int) (int, []*model.GetFollowUserResult, error) {
+	args := usecase.Called(userID, limit, page)
+	users, ok := args.Get(1).([]*model.GetFollowUserResult)
+	if ok {
+		return args.Int(0), users, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// フォロワー数、フォロー数取得
+func (usecase *mockFollowUseCase) GetFollowCounts(userID, loginUserID int) (*model.FollowCounts, error) {
+	args := usecase.Called(userID, loginUserID)
+	counts, ok := args.Get(0).(*model.FollowCounts)
+	if ok {
+		return counts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// フィード取得
+func (usecase *mockFollowUseCase) GetFeed(userID, cursor, limit int) ([]*model.GetPostResult, int, error) {
+	args := usecase.Called(userID, cursor, limit)
+	posts, ok := args.Get(0).([]*model.GetPostResult)
+	if ok {
+		return posts, args.Int(1), args.Error(2)
+	}
+
+	return nil, args.Int(1), args.Error(2)
+}
+
+// フォローテスト
+func TestFollow(t *testing.T) {
+	cases := []struct {
+		label  string
+		userID int
+		id     string
+		err    error
+		status int
+	}{
+		{"成功", 1, "2", nil, http.StatusOK},
+		{"ログインユーザー必須", 0, "2", nil, http.StatusUnprocessableEntity},
+		{"ID形式", 1, "a", nil, http.StatusUnprocessableEntity},
+		{"自分自身", 1, "2", usecase.ErrFollowSelf, http.StatusUnprocessableEntity},
+		{"ユーザーなし", 1, "2", usecase.ErrUserNotFound, http.StatusNotFound},
+		{"その他", 1, "2", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/users/"+test.id+"/follow", nil, rec)
+		c.SetPath("/users/:id/follow")
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		mockUseCase := mockFollowUseCase{}
+		mockUseCase.On("Follow", 1, 2).Return(test.err)
+		handler := NewFollowHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.Follow(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// フォロー解除テスト
+func TestUnfollow(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.DELETE, "/users/2/follow", nil, rec)
+	c.SetPath("/users/:id/follow")
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	setLoginUser(c, 1, model.RoleUser)
+
+	mockUseCase := mockFollowUseCase{}
+	mockUseCase.On("Unfollow", 1, 2).Return(nil)
+	handler := NewFollowHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.Unfollow(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCase.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+// フォロワー一覧取得テスト
+func TestGetFollowers(t *testing.T) {
+	cases := []struct {
+		label  string
+		id     string
+		query  string
+		err    error
+		status int
+	}{
+		{"成功", "1", "?limit=10&page=1", nil, http.StatusOK},
+		{"ID形式", "a", "?limit=10&page=1", nil, http.StatusUnprocessableEntity},
+		{"limit必須", "1", "?page=1", nil, http.StatusUnprocessableEntity},
+		{"page最小値", "1", "?limit=10&page=0", nil, http.StatusUnprocessableEntity},
+		{"その他", "1", "?limit=10&page=1", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/users/"+test.id+"/followers"+test.query, nil, rec)
+		c.SetPath("/users/:id/followers")
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+
+		mockUseCase := mockFollowUseCase{}
+		mockUseCase.On("GetFollowers", 1, 10, 1).Return(1, []*model.GetFollowUserResult{{UserID: 2, UserName: "user2"}}, test.err)
+		handler := NewFollowHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetFollowers(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// フォロー中のユーザー一覧取得テスト
+func TestGetFollowing(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/users/1/following?limit=10&page=2", nil, rec)
+	c.SetPath("/users/:id/following")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockUseCase := mockFollowUseCase{}
+	mockUseCase.On("GetFollowing", 1, 10, 2).Return(11, []*model.GetFollowUserResult{{UserID: 2, UserName: "user2"}}, nil)
+	handler := NewFollowHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetFollowing(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	response := struct {
+		TotalCount int                          `json:"totalCount"`
+		Users      []*model.GetFollowUserResult `json:"users"`
+	}{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, 11, response.TotalCount)
+	assert.Equal(t, "user2", response.Users[0].UserName)
+
+	// 4. Teardown
+}
+
+// フォロワー数、フォロー数取得テスト
+func TestGetFollowCounts(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/users/2/follow_counts", nil, rec)
+	c.SetPath("/users/:id/follow_counts")
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	setLoginUser(c, 1, model.RoleUser)
+
+	expected := &model.FollowCounts{FollowerCount: 3, FollowingCount: 4, IsFollowing: true}
+	mockUseCase := mockFollowUseCase{}
+	mockUseCase.On("GetFollowCounts", 2, 1).Return(expected, nil)
+	handler := NewFollowHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetFollowCounts(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	actual := &model.FollowCounts{}
+	json.Unmarshal(rec.Body.Bytes(), actual)
+	assert.Equal(t, expected, actual)
+
+	// 4. Teardown
+}
+
+// フィード取得テスト
+func TestGetFeed(t *testing.T) {
+	cases := []struct {
+		label  string
+		userID int
+		query  string
+		cursor int
+		limit  int
+		status int
+	}{
+		{"成功", 1, "?cursor=100&limit=10", 100, 10, http.StatusOK},
+		{"デフォルト", 1, "", 0, defaultFeedLimit, http.StatusOK},
+		{"ログインユーザー必須", 0, "", 0, defaultFeedLimit, http.StatusUnprocessableEntity},
+		{"cursor形式", 1, "?cursor=a", 0, defaultFeedLimit, http.StatusUnprocessableEntity},
+		{"limit最大値", 1, "?limit=101", 0, 101, http.StatusUnprocessableEntity},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/feed"+test.query, nil, rec)
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		mockUseCase := mockFollowUseCase{}
+		mockUseCase.On("GetFeed", 1, test.cursor, test.limit).Return([]*model.GetPostResult{makeGetPostResult(99)}, 99, nil)
+		handler := NewFollowHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetFeed(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+		if test.status == http.StatusOK {
+			response := struct {
+				Posts      []*model.GetPostResult `json:"posts"`
+				NextCursor int                    `json:"nextCursor"`
+			}{}
+			json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.Len(t, response.Posts, 1, test.label)
+			assert.Equal(t, 99, response.NextCursor, test.label)
+		}
+
+		// 4. Teardown
+	}
+}
+
+func TestGetFeed_error_usecaseError(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/feed", nil, rec)
+	setLoginUser(c, 1, model.RoleUser)
+
+	mockUseCase := mockFollowUseCase{}
+	mockUseCase.On("GetFeed", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, errors.New("error"))
+	handler := NewFollowHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetFeed(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// 4. Teardown
+}
@@ -0,0 +1,114 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/ui/importer"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+// maxImportFileSize 一括登録ファイルの最大サイズ
+const maxImportFileSize = 5 << 20
+
+type (
+	// ImportHandler interface
+	ImportHandler interface {
+		// 投稿の一括登録
+		ImportPosts(c echo.Context) error
+		// 一括登録ジョブ取得
+		GetImportJob(c echo.Context) error
+	}
+
+	// importHandler 構造体
+	importHandler struct {
+		ImportUseCase usecase.ImportUseCase
+	}
+)
+
+// NewImportHandler ImportHandlerを生成。
+func NewImportHandler(usecase usecase.ImportUseCase) ImportHandler {
+	return &importHandler{usecase}
+}
+
+// ImportPosts 投稿の一括登録。ログインユーザーを投稿者とする。
+// ドライランの場合は各行のチェック結果を返す。それ以外の場合はジョブを登録して202を返す。
+func (handler *importHandler) ImportPosts(c echo.Context) error {
+	request := &request.ImportPostsRequest{
+		UserID: loginUserID(c),
+		Format: c.FormValue("format"),
+	}
+	for name, value := range map[string]*bool{
+		"dry_run":         &request.DryRun,
+		"atomic":          &request.Atomic,
+		"allow_duplicate": &request.AllowDuplicate,
+	} {
+		if c.FormValue(name) == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(c.FormValue(name))
+		if err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, name+"：trueまたはfalseを入力してください。")
+		}
+		*value = parsed
+	}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	fileHeader, err := c.FormFile("File")
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "File：必須です。")
+	}
+	if fileHeader.Size > maxImportFileSize {
+		return c.JSON(http.StatusUnprocessableEntity, "File：5MB以下のファイルを指定してください。")
+	}
+	if request.Format == "" {
+		request.Format = importer.FormatFromFileName(fileHeader.Filename)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+	defer file.Close()
+
+	rows, err := importer.Parse(request.Format, file, c.Echo().Validator)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	job, err := handler.ImportUseCase.ImportPosts(request.UserID, rows, request.DryRun, request.Atomic, request.AllowDuplicate)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if request.DryRun {
+		return c.JSON(http.StatusOK, job)
+	}
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// GetImportJob 一括登録ジョブ取得。ジョブを登録したユーザーのみ取得できる。
+func (handler *importHandler) GetImportJob(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	request := &request.GetImportJobRequest{ID: id, UserID: loginUserID(c)}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	job, err := handler.ImportUseCase.GetImportJob(request.ID, request.UserID)
+	if err == usecase.ErrImportJobNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockImportUseCase struct {
+	mock.Mock
+}
+
+// 投稿の一括登録
+func (usecase *mockImportUseCase) ImportPosts(userID int, rows []*model.ImportRow, dryRun, atomic, allowDuplicate bool) (*model.ImportJob, error) {
+	args := usecase.Called(userID, rows, dryRun, atomic, allowDuplicate)
+	job, ok := args.Get(0).(*model.ImportJob)
+	if ok {
+		return job, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 一括登録ジョブ取得
+func (usecase *mockImportUseCase) GetImportJob(id, userID int) (*model.ImportJob, error) {
+	args := usecase.Called(id, userID)
+	job, ok := args.Get(0).(*model.ImportJob)
+	if ok {
+		return job, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 中断したジョブを失敗にする
+func (usecase *mockImportUseCase) RecoverImportJobs() (int, error) {
+	args := usecase.Called()
+	return args.Int(0), args.Error(1)
+}
+
+// 一括登録ファイルを送信するコンテキストを生成
+func createImportContext(t *testing.T, fields map[string]string, fileName, content string, rec *httptest.ResponseRecorder) echo.Context {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fileName != "" {
+		part, err := writer.CreateFormFile("File", fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte(content))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c := createContext(echo.POST, "/admin/posts/import", body, rec)
+	c.Request().Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	return c
+}
+
+// 一括登録テスト
+func TestImportPosts_success(t *testing.T) {
+	cases := []struct {
+		label  string
+		fields map[string]string
+		dryRun bool
+		atomic bool
+		status int
+	}{
+		{"ドライラン", map[string]string{"dry_run": "true"}, true, false, http.StatusOK},
+		{"行ごと", map[string]string{}, false, false, http.StatusAccepted},
+		{"一括", map[string]string{"atomic": "1"}, false, true, http.StatusAccepted},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createImportContext(t, test.fields, "phrases.csv", "title,speaker\ntitle1,speaker1\n,speaker2\n", rec)
+		setLoginUser(c, 1, model.RoleAdmin)
+
+		mockUseCase := mockImportUseCase{}
+		mockUseCase.On("ImportPosts", 1, mock.MatchedBy(func(rows []*model.ImportRow) bool {
+			return len(rows) == 2 && rows[0].Post.Title == "title1" && len(rows[0].Errors) == 0 && len(rows[1].Errors) == 1
+		}), test.dryRun, test.atomic, false).Return(&model.ImportJob{ID: 1}, nil)
+		handler := NewImportHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.ImportPosts(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+		mockUseCase.AssertExpectations(t)
+
+		// 4. Teardown
+	}
+}
+
+func TestImportPosts_error_validationError(t *testing.T) {
+	cases := []struct {
+		label    string
+		fields   map[string]string
+		fileName string
+		content  string
+	}{
+		{"ドライラン形式", map[string]string{"dry_run": "yes"}, "phrases.csv", "title,speaker\ntitle1,speaker1\n"},
+		{"ファイル必須", map[string]string{}, "", ""},
+		{"ファイル形式", map[string]string{"format": "xml"}, "phrases.csv", "title,speaker\ntitle1,speaker1\n"},
+		{"拡張子", map[string]string{}, "phrases.txt", "title,speaker\ntitle1,speaker1\n"},
+		{"ファイル内容", map[string]string{}, "phrases.json", "title,speaker\ntitle1,speaker1\n"},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createImportContext(t, test.fields, test.fileName, test.content, rec)
+		setLoginUser(c, 1, model.RoleAdmin)
+
+		mockUseCase := mockImportUseCase{}
+		handler := NewImportHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.ImportPosts(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+		mockUseCase.AssertNotCalled(t, "ImportPosts", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		// 4. Teardown
+	}
+}
+
+// 一括登録ジョブ取得テスト
+func TestGetImportJob(t *testing.T) {
+	cases := []struct {
+		label  string
+		id     string
+		err    error
+		status int
+	}{
+		{"成功", "1", nil, http.StatusOK},
+		{"ID形式", "a", nil, http.StatusUnprocessableEntity},
+		{"ジョブなし", "2", usecase.ErrImportJobNotFound, http.StatusNotFound},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/admin/posts/import/"+test.id, nil, rec)
+		c.SetPath("/admin/posts/import/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+		setLoginUser(c, 1, model.RoleAdmin)
+
+		mockUseCase := mockImportUseCase{}
+		if test.err == nil {
+			mockUseCase.On("GetImportJob", 1, 1).Return(&model.ImportJob{ID: 1, UserID: 1}, nil)
+		} else {
+			mockUseCase.On("GetImportJob", 2, 1).Return(nil, test.err)
+		}
+		handler := NewImportHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetImportJob(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
@@ -0,0 +1,163 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// NotificationHandler interface
+	NotificationHandler interface {
+		// 通知一覧取得
+		GetNotifications(c echo.Context) error
+		// 未読の通知の件数取得
+		GetUnreadNotificationCount(c echo.Context) error
+		// 通知を既読にする
+		MarkNotificationRead(c echo.Context) error
+		// すべての通知を既読にする
+		MarkAllNotificationsRead(c echo.Context) error
+		// 通知の受け取り設定取得
+		GetNotificationSettings(c echo.Context) error
+		// 通知の受け取り設定の更新
+		UpdateNotificationSetting(c echo.Context) error
+	}
+
+	// notificationHandler 構造体
+	notificationHandler struct {
+		NotificationUseCase usecase.NotificationUseCase
+	}
+)
+
+// NewNotificationHandler NotificationHandlerを生成。
+func NewNotificationHandler(usecase usecase.NotificationUseCase) NotificationHandler {
+	return &notificationHandler{usecase}
+}
+
+// GetNotifications 通知一覧取得。ログインユーザーの通知を返す。
+func (handler *notificationHandler) GetNotifications(c echo.Context) error {
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "limit：数値で入力してください。")
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "page：数値で入力してください。")
+	}
+	unreadOnly, err := strconv.ParseBool(c.QueryParam("unread_only"))
+	if err != nil {
+		unreadOnly = false
+	}
+
+	request := &request.GetNotificationsRequest{UserID: loginUserID(c), Limit: limit, Page: page, UnreadOnly: unreadOnly}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	totalCount, unreadCount, notifications, err := handler.NotificationUseCase.GetNotifications(request.UserID, request.UnreadOnly, request.Limit, request.Page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"totalCount":    totalCount,
+		"unreadCount":   unreadCount,
+		"notifications": notifications,
+	})
+}
+
+// GetUnreadNotificationCount 未読の通知の件数取得
+func (handler *notificationHandler) GetUnreadNotificationCount(c echo.Context) error {
+	request := &request.NotificationUserRequest{UserID: loginUserID(c)}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	unreadCount, err := handler.NotificationUseCase.GetUnreadCount(request.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"unreadCount": unreadCount,
+	})
+}
+
+// MarkNotificationRead 通知を既読にする。ログインユーザーの通知のみ既読にできる。存在しない通知の場合は404を返す。
+func (handler *notificationHandler) MarkNotificationRead(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.MarkNotificationReadRequest{ID: id, UserID: loginUserID(c)}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err = handler.NotificationUseCase.MarkAsRead(request.ID, request.UserID)
+	if err == usecase.ErrNotificationNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// MarkAllNotificationsRead ログインユーザーのすべての通知を既読にする
+func (handler *notificationHandler) MarkAllNotificationsRead(c echo.Context) error {
+	request := &request.NotificationUserRequest{UserID: loginUserID(c)}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := handler.NotificationUseCase.MarkAllAsRead(request.UserID); err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetNotificationSettings 通知の受け取り設定取得
+func (handler *notificationHandler) GetNotificationSettings(c echo.Context) error {
+	request := &request.NotificationUserRequest{UserID: loginUserID(c)}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	settings, err := handler.NotificationUseCase.GetSettings(request.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, settings)
+}
+
+// UpdateNotificationSetting 通知の受け取り設定の更新
+func (handler *notificationHandler) UpdateNotificationSetting(c echo.Context) error {
+	request := &request.UpdateNotificationSettingRequest{}
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	request.UserID = loginUserID(c)
+	request.Type = c.Param("type")
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err := handler.NotificationUseCase.UpdateSetting(request.UserID, request.Type, request.Enabled)
+	if err == usecase.ErrInvalidNotificationType {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockNotificationUseCase struct {
+	mock.Mock
+}
+
+// 通知一覧取得
+func (usecase *mockNotificationUseCase) GetNotifications(userID int, unreadOnly bool, limit, page int) (int, int, []*model.GetNotificationResult, error) {
+	args := usecase.Called(userID, unreadOnly, limit, page)
+	notifications, ok := args.Get(2).([]*model.GetNotificationResult)
+	if ok {
+		return args.Int(0), args.Int(1), notifications, args.Error(3)
+	}
+
+	return args.Int(0), args.Int(1), nil, args.Error(3)
+}
+
+// 未読の通知の件数取得
+func (usecase *mockNotificationUseCase) GetUnreadCount(userID int) (int, error) {
+	args := usecase.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+// 通知を既読にする
+func (usecase *mockNotificationUseCase) MarkAsRead(id, userID int) error {
+	return usecase.Called(id, userID).Error(0)
+}
+
+// すべての通知を既読にする
+func (usecase *mockNotificationUseCase) MarkAllAsRead(userID int) error {
+	return usecase.Called(userID).Error(0)
+}
+
+// 通知の受け取り設定取得
+func (usecase *mockNotificationUseCase) GetSettings(userID int) ([]*model.NotificationSetting, error) {
+	args := usecase.Called(userID)
+	settings, ok := args.Get(0).([]*model.NotificationSetting)
+	if ok {
+		return settings, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 通知の受け取り設定の更新
+func (usecase *mockNotificationUseCase) UpdateSetting(userID int, notificationType string, enabled bool) error {
+	return usecase.Called(userID, notificationType, enabled).Error(0)
+}
+
+func (usecase *mockNotificationUseCase) HandleDomainEvent(event *model.OutboxEvent) error {
+	return usecase.Called(event).Error(0)
+}
+
+// 通知一覧取得テスト
+func TestGetNotifications(t *testing.T) {
+	cases := []struct {
+		label  string
+		userID int
+		query  string
+		err    error
+		status int
+	}{
+		{"成功", 1, "?limit=10&page=1&unread_only=true", nil, http.StatusOK},
+		{"ログインユーザー必須", 0, "?limit=10&page=1&unread_only=true", nil, http.StatusUnprocessableEntity},
+		{"limit必須", 1, "?page=1", nil, http.StatusUnprocessableEntity},
+		{"page最小値", 1, "?limit=10&page=0", nil, http.StatusUnprocessableEntity},
+		{"その他", 1, "?limit=10&page=1&unread_only=true", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/notifications"+test.query, nil, rec)
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		mockUseCase := mockNotificationUseCase{}
+		mockUseCase.On("GetNotifications", 1, true, 10, 1).Return(1, 2, []*model.GetNotificationResult{{Message: "message"}}, test.err)
+		handler := NewNotificationHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetNotifications(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+		if test.status == http.StatusOK {
+			response := struct {
+				TotalCount    int                            `json:"totalCount"`
+				UnreadCount   int                            `json:"unreadCount"`
+				Notifications []*model.GetNotificationResult `json:"notifications"`
+			}{}
+			json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.Equal(t, 1, response.TotalCount, test.label)
+			assert.Equal(t, 2, response.UnreadCount, test.label)
+			assert.Equal(t, "message", response.Notifications[0].Message, test.label)
+		}
+
+		// 4. Teardown
+	}
+}
+
+// 未読の通知の件数取得テスト
+func TestGetUnreadNotificationCount(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/notifications/unread_count", nil, rec)
+	setLoginUser(c, 1, model.RoleUser)
+
+	mockUseCase := mockNotificationUseCase{}
+	mockUseCase.On("GetUnreadCount", 1).Return(3, nil)
+	handler := NewNotificationHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetUnreadNotificationCount(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"unreadCount":3}`, rec.Body.String())
+
+	// 4. Teardown
+}
+
+// 既読テスト
+func TestMarkNotificationRead(t *testing.T) {
+	cases := []struct {
+		label  string
+		userID int
+		id     string
+		err    error
+		status int
+	}{
+		{"成功", 1, "2", nil, http.StatusOK},
+		{"ログインユーザー必須", 0, "2", nil, http.StatusUnprocessableEntity},
+		{"ID形式", 1, "a", nil, http.StatusUnprocessableEntity},
+		{"通知なし", 1, "2", usecase.ErrNotificationNotFound, http.StatusNotFound},
+		{"その他", 1, "2", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/notifications/"+test.id+"/read", nil, rec)
+		c.SetPath("/notifications/:id/read")
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		mockUseCase := mockNotificationUseCase{}
+		mockUseCase.On("MarkAsRead", 2, 1).Return(test.err)
+		handler := NewNotificationHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.MarkNotificationRead(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// すべて既読テスト
+func TestMarkAllNotificationsRead(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.PUT, "/notifications/read", nil, rec)
+	setLoginUser(c, 1, model.RoleUser)
+
+	mockUseCase := mockNotificationUseCase{}
+	mockUseCase.On("MarkAllAsRead", 1).Return(nil)
+	handler := NewNotificationHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.MarkAllNotificationsRead(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCase.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+// 通知の受け取り設定取得テスト
+func TestGetNotificationSettings(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/notification_settings", nil, rec)
+	setLoginUser(c, 1, model.RoleUser)
+
+	mockUseCase := mockNotificationUseCase{}
+	mockUseCase.On("GetSettings", 1).Return([]*model.NotificationSetting{{UserID: 1, Type: model.NotificationTypeFollow, Enabled: false}}, nil)
+	handler := NewNotificationHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetNotificationSettings(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[{"type":"follow","enabled":false}]`, rec.Body.String())
+
+	// 4. Teardown
+}
+
+// 通知の受け取り設定の更新テスト
+func TestUpdateNotificationSetting(t *testing.T) {
+	cases := []struct {
+		label  string
+		userID int
+		body   string
+		err    error
+		status int
+	}{
+		{"成功", 1, `{"enabled":false}`, nil, http.StatusOK},
+		{"ログインユーザー必須", 0, `{"enabled":false}`, nil, http.StatusUnprocessableEntity},
+		{"形式", 1, `{"enabled":"no"}`, nil, http.StatusUnprocessableEntity},
+		{"種類不正", 1, `{"enabled":false}`, usecase.ErrInvalidNotificationType, http.StatusUnprocessableEntity},
+		{"その他", 1, `{"enabled":false}`, errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/notification_settings/follow", strings.NewReader(test.body), rec)
+		c.SetPath("/notification_settings/:type")
+		c.SetParamNames("type")
+		c.SetParamValues(model.NotificationTypeFollow)
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		mockUseCase := mockNotificationUseCase{}
+		mockUseCase.On("UpdateSetting", 1, model.NotificationTypeFollow, false).Return(test.err)
+		handler := NewNotificationHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.UpdateNotificationSetting(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
 	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
 	"github.com/k-kazuya0926/power-phrase2-api/usecase"
 	"github.com/labstack/echo"
@@ -28,8 +29,13 @@ type (
 		CreateFavorite(c echo.Context) error
 		// お気に入り一覧取得
 		GetFavorites(c echo.Context) error
+		// お気に入りのメモ、理由の分類の更新
+		UpdateFavoriteNote(c echo.Context) error
 		// お気に入り削除
 		DeleteFavorite(c echo.Context) error
+
+		// 投稿の統計取得
+		GetPostStats(c echo.Context) error
 	}
 
 	// postHandler 構造体
@@ -60,7 +66,24 @@ func (handler *postHandler) CreatePost(c echo.Context) error {
 		request.Speaker,
 		request.Detail,
 		request.MovieURL,
+		makePostSource(&request.PostSourceRequest),
+		request.License,
+		request.Language,
+		request.Tags,
+		request.AllowDuplicate,
 	)
+	if prohibitedErr, ok := err.(*usecase.ProhibitedWordError); ok {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"message": prohibitedErr.Error(),
+			"fields":  prohibitedErr.Fields,
+		})
+	}
+	if duplicateErr, ok := err.(*usecase.DuplicatePostError); ok {
+		return c.JSON(http.StatusConflict, map[string]interface{}{
+			"message":    duplicateErr.Error(),
+			"candidates": duplicateErr.Candidates,
+		})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
 	}
@@ -68,6 +91,17 @@ func (handler *postHandler) CreatePost(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// makePostSource リクエストの出典情報をモデルに変換する。
+func makePostSource(request *request.PostSourceRequest) model.PostSource {
+	return model.PostSource{
+		SourceType:      request.SourceType,
+		SourceTitle:     request.SourceTitle,
+		SourceLocator:   request.SourceLocator,
+		PublicationYear: request.PublicationYear,
+		SourceURL:       request.SourceURL,
+	}
+}
+
 // GetPosts 投稿一覧取得
 func (handler *postHandler) GetPosts(c echo.Context) error {
 	limit, err := strconv.Atoi(c.QueryParam("limit"))
@@ -87,20 +121,28 @@ func (handler *postHandler) GetPosts(c echo.Context) error {
 		loginUserID = 0
 	}
 
+	verifiedOnly, err := strconv.ParseBool(c.QueryParam("verified_only"))
+	if err != nil {
+		verifiedOnly = false
+	}
+
 	keyword := c.QueryParam("keyword")
 
 	request := &request.GetPostsRequest{
-		Limit:       limit,
-		Page:        page,
-		Keyword:     keyword,
-		PostUserID:  postUserID,
-		LoginUserID: loginUserID,
+		Limit:        limit,
+		Page:         page,
+		Keyword:      keyword,
+		PostUserID:   postUserID,
+		LoginUserID:  loginUserID,
+		VerifiedOnly: verifiedOnly,
+		Language:     c.QueryParam("language"),
+		Lang:         c.QueryParam("lang"),
 	}
 	if err := c.Validate(request); err != nil {
 		return c.JSON(http.StatusUnprocessableEntity, err.Error())
 	}
 
-	totalCount, posts, err := handler.PostUseCase.GetPosts(limit, page, keyword, postUserID, loginUserID)
+	totalCount, posts, err := handler.PostUseCase.GetPosts(limit, page, keyword, postUserID, loginUserID, verifiedOnly, request.Language, preferredLanguages(c, request.Lang))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
 	}
@@ -111,8 +153,10 @@ func (handler *postHandler) GetPosts(c echo.Context) error {
 	})
 }
 
-// GetPost　投稿詳細取得
+// GetPost　投稿詳細取得。ログインしている場合はJWTトークンのユーザー、していない場合はリクエスト元のIPアドレスを閲覧者として閲覧を記録する。
 func (handler *postHandler) GetPost(c echo.Context) error {
+	// 閲覧者はクエリパラメータでは詐称できるため、検証済みのJWTトークンから取得する
+	viewerID := loginUserID(c)
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
@@ -122,15 +166,16 @@ func (handler *postHandler) GetPost(c echo.Context) error {
 		return c.JSON(http.StatusUnprocessableEntity, "login_user_id：数値で入力してください。")
 	}
 
-	request := &request.GetPostRequest{ID: id, LoginUserID: loginUserID}
+	request := &request.GetPostRequest{ID: id, LoginUserID: loginUserID, Lang: c.QueryParam("lang")}
 	if err := c.Validate(request); err != nil {
 		return c.JSON(http.StatusUnprocessableEntity, err.Error())
 	}
 
-	post, err := handler.PostUseCase.GetPost(id, loginUserID)
+	post, err := handler.PostUseCase.GetPost(id, loginUserID, preferredLanguages(c, request.Lang))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
 	}
+	handler.PostUseCase.RecordView(id, viewerID, clientIP(c))
 
 	return c.JSON(http.StatusOK, post)
 }
@@ -156,7 +201,17 @@ func (handler *postHandler) UpdatePost(c echo.Context) error {
 		request.Speaker,
 		request.Detail,
 		request.MovieURL,
+		makePostSource(&request.PostSourceRequest),
+		request.License,
+		request.Language,
+		request.Tags,
 	)
+	if prohibitedErr, ok := err.(*usecase.ProhibitedWordError); ok {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"message": prohibitedErr.Error(),
+			"fields":  prohibitedErr.Fields,
+		})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
 	}
@@ -201,6 +256,8 @@ func (handler *postHandler) CreateFavorite(c echo.Context) error {
 	err = handler.PostUseCase.CreateFavorite(
 		request.UserID,
 		request.PostID,
+		request.Note,
+		request.Tag,
 	)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
@@ -209,7 +266,7 @@ func (handler *postHandler) CreateFavorite(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
-// GetFavorites お気に入り一覧取得
+// GetFavorites お気に入り一覧取得。メモ、理由の分類は本人のみに返す。
 func (handler *postHandler) GetFavorites(c echo.Context) error {
 	userID, err := strconv.Atoi(c.QueryParam("user_id"))
 	if err != nil {
@@ -225,15 +282,17 @@ func (handler *postHandler) GetFavorites(c echo.Context) error {
 	}
 
 	request := &request.GetFavoritesRequest{
-		UserID: userID,
-		Limit:  limit,
-		Page:   page,
+		UserID:  userID,
+		Limit:   limit,
+		Page:    page,
+		Keyword: c.QueryParam("keyword"),
+		Tag:     c.QueryParam("tag"),
 	}
 	if err := c.Validate(request); err != nil {
 		return c.JSON(http.StatusUnprocessableEntity, err.Error())
 	}
 
-	totalCount, posts, err := handler.PostUseCase.GetFavorites(userID, limit, page)
+	totalCount, posts, err := handler.PostUseCase.GetFavorites(userID, loginUserID(c), limit, page, request.Keyword, request.Tag)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
 	}
@@ -244,6 +303,34 @@ func (handler *postHandler) GetFavorites(c echo.Context) error {
 	})
 }
 
+// UpdateFavoriteNote お気に入りのメモ、理由の分類の更新。ログインユーザーのお気に入りを対象とする。
+func (handler *postHandler) UpdateFavoriteNote(c echo.Context) error {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.UpdateFavoriteNoteRequest{}
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	request.UserID = loginUserID(c)
+	request.PostID = postID
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err = handler.PostUseCase.UpdateFavoriteNote(request.UserID, request.PostID, request.Note, request.Tag)
+	if err == usecase.ErrFavoriteNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 // DeleteFavorite お気に入り削除
 func (handler *postHandler) DeleteFavorite(c echo.Context) error {
 	userID, err := strconv.Atoi(c.Param("user_id"))
@@ -269,3 +356,42 @@ func (handler *postHandler) DeleteFavorite(c echo.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+// defaultPostStatsDays 投稿の統計の集計日数の指定がない場合の日数
+const defaultPostStatsDays = 30
+
+// GetPostStats 投稿の統計取得。ログインユーザーの投稿を対象とし、投稿IDの指定がない場合は全ての投稿の合計を返す。
+// 存在しない投稿、他のユーザーの投稿を指定した場合は404を返す。
+func (handler *postHandler) GetPostStats(c echo.Context) error {
+	postID := 0
+	if c.Param("id") != "" {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+		}
+		postID = id
+	}
+	days := defaultPostStatsDays
+	if c.QueryParam("days") != "" {
+		value, err := strconv.Atoi(c.QueryParam("days"))
+		if err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "days：数値で入力してください。")
+		}
+		days = value
+	}
+
+	request := &request.GetPostStatsRequest{UserID: loginUserID(c), PostID: postID, Days: days}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	stats, err := handler.PostUseCase.GetPostStats(request.UserID, request.PostID, request.Days)
+	if err == usecase.ErrPostNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
 	"github.com/labstack/echo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -22,13 +23,13 @@ type mockPostUseCase struct {
 }
 
 // 投稿登録
-func (usecase *mockPostUseCase) CreatePost(userID int, title, speaker, detail, movieURL string) (err error) {
-	return usecase.Called(userID, title, speaker, detail, movieURL).Error(0)
+func (usecase *mockPostUseCase) CreatePost(userID int, title, speaker, detail, movieURL string, source model.PostSource, license, language string, tags []string, allowDuplicate bool) (err error) {
+	return usecase.Called(userID, title, speaker, detail, movieURL, source, license, language, tags, allowDuplicate).Error(0)
 }
 
 // 投稿一覧取得
-func (usecase *mockPostUseCase) GetPosts(limit, offset int, keyword string, postUserID, loginUserID int) (totalCount int, posts []*model.GetPostResult, err error) {
-	args := usecase.Called(limit, offset, keyword, postUserID, loginUserID)
+func (usecase *mockPostUseCase) GetPosts(limit, offset int, keyword string, postUserID, loginUserID int, verifiedOnly bool, language string, preferredLanguages []string) (totalCount int, posts []*model.GetPostResult, err error) {
+	args := usecase.Called(limit, offset, keyword, postUserID, loginUserID, verifiedOnly, language, preferredLanguages)
 	posts, ok := args.Get(1).([]*model.GetPostResult)
 	if ok {
 		return args.Int(0), posts, args.Error(2)
@@ -38,8 +39,8 @@ func (usecase *mockPostUseCase) GetPosts(limit, offset int, keyword string, post
 }
 
 // 投稿詳細取得
-func (usecase *mockPostUseCase) GetPost(id, loginUserID int) (*model.GetPostResult, error) {
-	args := usecase.Called(id, loginUserID)
+func (usecase *mockPostUseCase) GetPost(id, loginUserID int, preferredLanguages []string) (*model.GetPostResult, error) {
+	args := usecase.Called(id, loginUserID, preferredLanguages)
 	post, ok := args.Get(0).(*model.GetPostResult)
 	if ok {
 		return post, args.Error(1)
@@ -49,8 +50,8 @@ func (usecase *mockPostUseCase) GetPost(id, loginUserID int) (*model.GetPostResu
 }
 
 // 投稿更新
-func (usecase *mockPostUseCase) UpdatePost(ID int, title, speaker, detail, movieURL string) error {
-	return usecase.Called(ID, title, speaker, detail, movieURL).Error(0)
+func (usecase *mockPostUseCase) UpdatePost(ID int, title, speaker, detail, movieURL string, source model.PostSource, license, language string, tags []string) error {
+	return usecase.Called(ID, title, speaker, detail, movieURL, source, license, language, tags).Error(0)
 }
 
 // 投稿削除
@@ -59,13 +60,13 @@ func (usecase *mockPostUseCase) DeletePost(id int) error {
 }
 
 // お気に入り登録
-func (usecase *mockPostUseCase) CreateFavorite(userID, postID int) (err error) {
-	return usecase.Called(userID, postID).Error(0)
+func (usecase *mockPostUseCase) CreateFavorite(userID, postID int, note, tag string) (err error) {
+	return usecase.Called(userID, postID, note, tag).Error(0)
 }
 
 // お気に入り一覧取得
-func (usecase *mockPostUseCase) GetFavorites(userID, limit, offset int) (totalCount int, posts []*model.GetPostResult, err error) {
-	args := usecase.Called(userID, limit, offset)
+func (usecase *mockPostUseCase) GetFavorites(userID, loginUserID, limit, offset int, keyword, tag string) (totalCount int, posts []*model.GetPostResult, err error) {
+	args := usecase.Called(userID, loginUserID, limit, offset, keyword, tag)
 	posts, ok := args.Get(1).([]*model.GetPostResult)
 	if ok {
 		return args.Int(0), posts, args.Error(2)
@@ -74,11 +75,30 @@ func (usecase *mockPostUseCase) GetFavorites(userID, limit, offset int) (totalCo
 	return args.Int(0), nil, args.Error(2)
 }
 
+// お気に入りのメモ、理由の分類の更新
+func (usecase *mockPostUseCase) UpdateFavoriteNote(userID, postID int, note, tag string) error {
+	return usecase.Called(userID, postID, note, tag).Error(0)
+}
+
 // お気に入り削除
 func (usecase *mockPostUseCase) DeleteFavorite(userID, postID int) error {
 	return usecase.Called(userID, postID).Error(0)
 }
 
+func (usecase *mockPostUseCase) RecordView(postID, loginUserID int, ipAddress string) {
+	usecase.Called(postID, loginUserID, ipAddress)
+}
+
+func (usecase *mockPostUseCase) GetPostStats(userID, postID, days int) (*model.PostStats, error) {
+	args := usecase.Called(userID, postID, days)
+	stats, ok := args.Get(0).(*model.PostStats)
+	if ok {
+		return stats, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
 func makePost(id int) *model.Post {
 	return &model.Post{
 		ID:       id,
@@ -112,7 +132,7 @@ func TestCreatePost_success(t *testing.T) {
 	c := createContext(echo.POST, "/posts", strings.NewReader(string(jsonBytes)), rec)
 
 	usecase := mockPostUseCase{}
-	usecase.On("CreatePost", post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL).Return(nil)
+	usecase.On("CreatePost", post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, post.Language, post.Tags, false).Return(nil)
 	handler := NewPostHandler(&usecase)
 
 	// 2. Exercise
@@ -167,6 +187,170 @@ func TestCreatePost_error_validationError(t *testing.T) {
 	}
 }
 
+// 出典付き登録テスト
+func TestCreatePost_success_withSource(t *testing.T) {
+	// 1. Setup
+	post := makePost(1)
+	post.License = model.LicenseAllRightsReserved
+	post.PostSource = model.PostSource{
+		SourceType:      model.SourceTypeBook,
+		SourceTitle:     "道をひらく",
+		SourceLocator:   "p.12",
+		PublicationYear: 1968,
+	}
+	jsonBytes, err := json.Marshal(post)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/posts", strings.NewReader(string(jsonBytes)), rec)
+
+	usecase := mockPostUseCase{}
+	usecase.On("CreatePost", post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, post.Language, post.Tags, false).Return(nil)
+	handler := NewPostHandler(&usecase)
+
+	// 2. Exercise
+	err = handler.CreatePost(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestCreatePost_error_sourceValidationError(t *testing.T) {
+	cases := []struct {
+		label   string
+		license string
+		source  model.PostSource
+	}{
+		{"ライセンス不正", "unknown", model.PostSource{}},
+		{"出典の種類不正", "", model.PostSource{SourceType: "magazine", SourceTitle: "title"}},
+		{"出典の種類なし", "", model.PostSource{SourceTitle: "title"}},
+		{"書籍名なし", "", model.PostSource{SourceType: model.SourceTypeBook}},
+		{"ページ形式", "", model.PostSource{SourceType: model.SourceTypeBook, SourceTitle: "title", SourceLocator: "12ページ"}},
+		{"スピーチ名なし", "", model.PostSource{SourceType: model.SourceTypeSpeech}},
+		{"WebのURLなし", "", model.PostSource{SourceType: model.SourceTypeWeb, SourceTitle: "title"}},
+		{"URL形式", "", model.PostSource{SourceType: model.SourceTypeWeb, SourceURL: "example"}},
+		{"動画の再生位置形式", "", model.PostSource{SourceType: model.SourceTypeVideo, SourceURL: "https://youtu.be/a", SourceLocator: "1分23秒"}},
+		{"出版年上限", "", model.PostSource{SourceType: model.SourceTypeBook, SourceTitle: "title", PublicationYear: 10000}},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		post := makePost(1)
+		post.License = test.license
+		post.PostSource = test.source
+		jsonBytes, err := json.Marshal(post)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rec := httptest.NewRecorder()
+		c := createContext(echo.POST, "/posts", strings.NewReader(string(jsonBytes)), rec)
+
+		usecase := mockPostUseCase{}
+		handler := NewPostHandler(&usecase)
+
+		// 2. Exercise
+		err = handler.CreatePost(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+func TestCreatePost_error_duplicate(t *testing.T) {
+	// 1. Setup
+	post := makePost(1)
+	jsonBytes, err := json.Marshal(post)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/posts", strings.NewReader(string(jsonBytes)), rec)
+
+	candidates := []*model.DuplicateCandidate{{PostID: 2, Title: post.Title, Speaker: post.Speaker, Similarity: 1}}
+	mockUseCase := mockPostUseCase{}
+	mockUseCase.On("CreatePost", post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, post.Language, post.Tags, false).
+		Return(&usecase.DuplicatePostError{Candidates: candidates})
+	handler := NewPostHandler(&mockUseCase)
+
+	// 2. Exercise
+	err = handler.CreatePost(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	response := struct {
+		Candidates []*model.DuplicateCandidate `json:"candidates"`
+	}{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, candidates, response.Candidates)
+
+	// 4. Teardown
+}
+
+func TestCreatePost_error_prohibitedWord(t *testing.T) {
+	// 1. Setup
+	post := makePost(1)
+	jsonBytes, err := json.Marshal(post)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/posts", strings.NewReader(string(jsonBytes)), rec)
+
+	mockUseCase := mockPostUseCase{}
+	mockUseCase.On("CreatePost", post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, post.Language, post.Tags, false).
+		Return(&usecase.ProhibitedWordError{Fields: []string{"Title"}})
+	handler := NewPostHandler(&mockUseCase)
+
+	// 2. Exercise
+	err = handler.CreatePost(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	response := struct {
+		Message string   `json:"message"`
+		Fields  []string `json:"fields"`
+	}{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "Title：使用できない語句が含まれています。", response.Message)
+	assert.Equal(t, []string{"Title"}, response.Fields)
+
+	// 4. Teardown
+}
+
+func TestCreatePost_success_allowDuplicate(t *testing.T) {
+	// 1. Setup
+	post := makePost(1)
+	body := fmt.Sprintf(`{"user_id":%d,"title":"%s","speaker":"%s","allow_duplicate":true}`, post.UserID, post.Title, post.Speaker)
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/posts", strings.NewReader(body), rec)
+
+	usecase := mockPostUseCase{}
+	usecase.On("CreatePost", post.UserID, post.Title, post.Speaker, "", "", model.PostSource{}, "", "", []string(nil), true).Return(nil)
+	handler := NewPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.CreatePost(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
 func TestCreatePost_error_usecaseError(t *testing.T) {
 	// 1. Setup
 	post := makePost(1)
@@ -179,7 +363,7 @@ func TestCreatePost_error_usecaseError(t *testing.T) {
 	c := createContext(echo.POST, "/posts", strings.NewReader(string(jsonBytes)), rec)
 
 	usecase := mockPostUseCase{}
-	usecase.On("CreatePost", post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL).Return(errors.New("error"))
+	usecase.On("CreatePost", post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, post.Language, post.Tags, false).Return(errors.New("error"))
 	handler := NewPostHandler(&usecase)
 
 	// 2. Exercise
@@ -206,7 +390,7 @@ func TestGetPosts_success(t *testing.T) {
 
 	usecase := mockPostUseCase{}
 	expected := []*model.GetPostResult{makeGetPostResult(1), makeGetPostResult(2)}
-	usecase.On("GetPosts", 1, 1, "", postUserID, loginUserID).Return(2, expected, nil)
+	usecase.On("GetPosts", 1, 1, "", postUserID, loginUserID, false, "", []string(nil)).Return(2, expected, nil)
 	handler := NewPostHandler(&usecase)
 
 	// 2. Exercise
@@ -269,7 +453,7 @@ func TestGetPosts_error_usecaseError(t *testing.T) {
 	loginUserID := 0 // TODO ログインユーザーID指定がある場合
 
 	usecase := mockPostUseCase{}
-	usecase.On("GetPosts", 1, 1, "", postUserID, loginUserID).Return(0, nil, errors.New("error"))
+	usecase.On("GetPosts", 1, 1, "", postUserID, loginUserID, false, "", []string(nil)).Return(0, nil, errors.New("error"))
 	handler := NewPostHandler(&usecase)
 
 	// 2. Exercise
@@ -283,6 +467,70 @@ func TestGetPosts_error_usecaseError(t *testing.T) {
 }
 
 // 詳細取得テスト
+func TestGetPost_success_anonymousViewer(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	q := make(url.Values)
+	q.Set("login_user_id", "1")
+	c := createContext(echo.GET, "/posts?"+q.Encode(), nil, rec)
+	c.SetPath("/posts/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	// 信頼するプロキシを経由していないため、X-Forwarded-Forは使用しない
+	c.Request().Header.Set(echo.HeaderXForwardedFor, "203.0.113.1")
+
+	usecase := mockPostUseCase{}
+	usecase.On("GetPost", 1, 1, []string(nil)).Return(makeGetPostResult(1), nil)
+	// クエリパラメータのユーザーではなく、IPアドレスで閲覧者を識別する
+	usecase.On("RecordView", 1, 0, "192.0.2.1").Return()
+	handler := NewPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetPost(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	usecase.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+// リクエスト元のIPアドレス取得テスト
+func TestClientIP(t *testing.T) {
+	cases := []struct {
+		label          string
+		trustedProxies string
+		remoteAddr     string
+		forwardedFor   string
+		expected       string
+	}{
+		{"プロキシ指定なし", "", "192.0.2.1:1234", "203.0.113.1", "192.0.2.1"},
+		{"信頼しない接続元", "10.0.0.0/8", "192.0.2.1:1234", "203.0.113.1", "192.0.2.1"},
+		{"信頼するプロキシ経由", "10.0.0.0/8", "10.0.0.1:1234", "203.0.113.1", "203.0.113.1"},
+		{"詐称されたアドレスを除く", "10.0.0.0/8,192.0.2.10", "10.0.0.1:1234", "198.51.100.1, 203.0.113.1, 192.0.2.10", "203.0.113.1"},
+		{"X-Forwarded-Forなし", "10.0.0.1", "10.0.0.1:1234", "", "10.0.0.1"},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		t.Setenv("TRUSTED_PROXIES", test.trustedProxies)
+		c := createContext(echo.GET, "/posts/1", nil, httptest.NewRecorder())
+		c.Request().RemoteAddr = test.remoteAddr
+		if test.forwardedFor != "" {
+			c.Request().Header.Set(echo.HeaderXForwardedFor, test.forwardedFor)
+		}
+
+		// 2. Exercise
+		ip := clientIP(c)
+
+		// 3. Verify
+		assert.Equal(t, test.expected, ip, test.label)
+
+		// 4. Teardown
+	}
+}
+
 func TestGetPost_success(t *testing.T) {
 	// 1. Setup
 	rec := httptest.NewRecorder()
@@ -293,11 +541,13 @@ func TestGetPost_success(t *testing.T) {
 	c.SetParamNames("id")
 	id := 1
 	c.SetParamValues(fmt.Sprint(id))
+	setLoginUser(c, 1, model.RoleUser)
 
 	expectedPost := makeGetPostResult(id)
 
 	usecase := mockPostUseCase{}
-	usecase.On("GetPost", id, 1).Return(expectedPost, nil)
+	usecase.On("GetPost", id, 1, []string(nil)).Return(expectedPost, nil)
+	usecase.On("RecordView", id, 1, "192.0.2.1").Return()
 	handler := NewPostHandler(&usecase)
 
 	// 2. Exercise
@@ -309,6 +559,7 @@ func TestGetPost_success(t *testing.T) {
 	json.Unmarshal(rec.Body.Bytes(), post)
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Equal(t, expectedPost, post)
+	usecase.AssertExpectations(t)
 
 	// 4. Teardown
 }
@@ -361,7 +612,7 @@ func TestGetPost_error_usecaseError(t *testing.T) {
 	c.SetParamValues(fmt.Sprint(id))
 
 	usecase := mockPostUseCase{}
-	usecase.On("GetPost", id, 1).Return(nil, errors.New("error"))
+	usecase.On("GetPost", id, 1, []string(nil)).Return(nil, errors.New("error"))
 	handler := NewPostHandler(&usecase)
 
 	// 2. Exercise
@@ -370,6 +621,7 @@ func TestGetPost_error_usecaseError(t *testing.T) {
 	// 3. Verify
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	usecase.AssertNotCalled(t, "RecordView", mock.Anything, mock.Anything, mock.Anything)
 
 	// 4. Teardown
 }
@@ -389,7 +641,7 @@ func TestUpdatePost_success(t *testing.T) {
 	c.SetParamValues(fmt.Sprint(1))
 
 	usecase := mockPostUseCase{}
-	usecase.On("UpdatePost", post.ID, post.Title, post.Speaker, post.Detail, post.MovieURL).Return(nil)
+	usecase.On("UpdatePost", post.ID, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, post.Language, post.Tags).Return(nil)
 	handler := NewPostHandler(&usecase)
 
 	// 2. Exercise
@@ -458,7 +710,7 @@ func TestUpdatePost_error_usecaseError(t *testing.T) {
 	c.SetParamValues(fmt.Sprint(id))
 
 	usecase := mockPostUseCase{}
-	usecase.On("UpdatePost", post.ID, post.Title, post.Speaker, post.Detail, post.MovieURL).Return(errors.New("error"))
+	usecase.On("UpdatePost", post.ID, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, post.Language, post.Tags).Return(errors.New("error"))
 	handler := NewPostHandler(&usecase)
 
 	// 2. Exercise
@@ -553,3 +805,141 @@ func TestDeletePost_error_usecaseError(t *testing.T) {
 }
 
 // TODO お気に入り関連追加
+
+// お気に入り一覧取得テスト
+func TestGetFavorites_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/favorites?user_id=1&limit=10&page=1&keyword=%E8%A9%A6%E5%90%88&tag=%E5%8A%B1%E3%81%BE%E3%81%97", nil, rec)
+	setLoginUser(c, 1, model.RoleUser)
+
+	post := makeGetPostResult(1)
+	post.FavoriteNote = "試合前に読む"
+	usecase := mockPostUseCase{}
+	usecase.On("GetFavorites", 1, 1, 10, 1, "試合", "励まし").Return(1, []*model.GetPostResult{post}, nil)
+	handler := NewPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetFavorites(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"favorite_note":"試合前に読む"`)
+
+	// 4. Teardown
+}
+
+// お気に入りのメモ更新テスト
+func TestUpdateFavoriteNote_success(t *testing.T) {
+	// 1. Setup
+	body := `{"note":"試合前に読む","tag":"励まし"}`
+	rec := httptest.NewRecorder()
+	c := createContext(echo.PUT, "/posts/2/favorites/note", strings.NewReader(body), rec)
+	c.SetPath("/posts/:id/favorites/note")
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	setLoginUser(c, 1, model.RoleUser)
+
+	usecase := mockPostUseCase{}
+	usecase.On("UpdateFavoriteNote", 1, 2, "試合前に読む", "励まし").Return(nil)
+	handler := NewPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.UpdateFavoriteNote(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestUpdateFavoriteNote_error(t *testing.T) {
+	cases := []struct {
+		label    string
+		userID   int
+		body     string
+		err      error
+		expected int
+	}{
+		{"ログインユーザー必須", 0, `{"note":"note"}`, nil, http.StatusUnprocessableEntity},
+		{"メモ桁数", 1, `{"note":"` + strings.Repeat("a", 1001) + `"}`, nil, http.StatusUnprocessableEntity},
+		{"お気に入りなし", 1, `{"note":"note"}`, usecase.ErrFavoriteNotFound, http.StatusNotFound},
+		{"その他", 1, `{"note":"note"}`, errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/posts/2/favorites/note", strings.NewReader(test.body), rec)
+		c.SetPath("/posts/:id/favorites/note")
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		mockUseCase := mockPostUseCase{}
+		mockUseCase.On("UpdateFavoriteNote", test.userID, 2, "note", "").Return(test.err)
+		handler := NewPostHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.UpdateFavoriteNote(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// 投稿の統計取得テスト
+func TestGetPostStats(t *testing.T) {
+	cases := []struct {
+		label  string
+		id     string
+		query  string
+		postID int
+		days   int
+		err    error
+		status int
+	}{
+		{"全ての投稿", "", "", 0, 30, nil, http.StatusOK},
+		{"投稿指定", "2", "?days=7", 2, 7, nil, http.StatusOK},
+		{"ID形式", "a", "", 0, 30, nil, http.StatusUnprocessableEntity},
+		{"日数形式", "", "?days=a", 0, 30, nil, http.StatusUnprocessableEntity},
+		{"日数上限", "", "?days=366", 0, 366, nil, http.StatusUnprocessableEntity},
+		{"他のユーザーの投稿", "2", "", 2, 30, usecase.ErrPostNotFound, http.StatusNotFound},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/posts/stats"+test.query, nil, rec)
+		if test.id != "" {
+			c.SetPath("/posts/:id/stats")
+			c.SetParamNames("id")
+			c.SetParamValues(test.id)
+		}
+		setLoginUser(c, 1, model.RoleUser)
+
+		mockUseCase := mockPostUseCase{}
+		if test.err == nil {
+			mockUseCase.On("GetPostStats", 1, test.postID, test.days).Return(&model.PostStats{PostID: test.postID}, nil)
+		} else {
+			mockUseCase.On("GetPostStats", 1, test.postID, test.days).Return(nil, test.err)
+		}
+		handler := NewPostHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetPostStats(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
@@ -0,0 +1,89 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// ProhibitedWordHandler interface
+	ProhibitedWordHandler interface {
+		// 禁止語一覧取得
+		GetProhibitedWords(c echo.Context) error
+		// 禁止語登録
+		CreateProhibitedWord(c echo.Context) error
+		// 禁止語削除
+		DeleteProhibitedWord(c echo.Context) error
+	}
+
+	// prohibitedWordHandler 構造体
+	prohibitedWordHandler struct {
+		ProhibitedWordUseCase usecase.ProhibitedWordUseCase
+	}
+)
+
+// NewProhibitedWordHandler ProhibitedWordHandlerを生成。
+func NewProhibitedWordHandler(usecase usecase.ProhibitedWordUseCase) ProhibitedWordHandler {
+	return &prohibitedWordHandler{usecase}
+}
+
+// GetProhibitedWords 禁止語一覧取得。管理者のみ実行できる。
+func (handler *prohibitedWordHandler) GetProhibitedWords(c echo.Context) error {
+	words, err := handler.ProhibitedWordUseCase.GetProhibitedWords()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"prohibitedWords": words,
+	})
+}
+
+// CreateProhibitedWord 禁止語登録。管理者のみ実行できる。
+func (handler *prohibitedWordHandler) CreateProhibitedWord(c echo.Context) error {
+	request := new(request.CreateProhibitedWordRequest)
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	word, err := handler.ProhibitedWordUseCase.CreateProhibitedWord(request.Word, request.Action)
+	if err == usecase.ErrInvalidProhibitedWord {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	if err == usecase.ErrProhibitedWordAlreadyExists {
+		return c.JSON(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, word)
+}
+
+// DeleteProhibitedWord 禁止語削除。管理者のみ実行できる。
+func (handler *prohibitedWordHandler) DeleteProhibitedWord(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.DeleteProhibitedWordRequest{ID: id}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := handler.ProhibitedWordUseCase.DeleteProhibitedWord(request.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockProhibitedWordUseCase struct {
+	mock.Mock
+}
+
+// 禁止語一覧取得
+func (usecase *mockProhibitedWordUseCase) GetProhibitedWords() ([]*model.ProhibitedWord, error) {
+	args := usecase.Called()
+	words, ok := args.Get(0).([]*model.ProhibitedWord)
+	if ok {
+		return words, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 禁止語登録
+func (usecase *mockProhibitedWordUseCase) CreateProhibitedWord(word, action string) (*model.ProhibitedWord, error) {
+	args := usecase.Called(word, action)
+	prohibitedWord, ok := args.Get(0).(*model.ProhibitedWord)
+	if ok {
+		return prohibitedWord, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 禁止語削除
+func (usecase *mockProhibitedWordUseCase) DeleteProhibitedWord(id int) error {
+	return usecase.Called(id).Error(0)
+}
+
+// 禁止語登録テスト
+func TestCreateProhibitedWord(t *testing.T) {
+	cases := []struct {
+		label  string
+		body   string
+		err    error
+		status int
+	}{
+		{"成功", `{"word":"禁止","action":"block"}`, nil, http.StatusCreated},
+		{"語句必須", `{"action":"block"}`, nil, http.StatusUnprocessableEntity},
+		{"扱い不正", `{"word":"禁止","action":"delete"}`, nil, http.StatusUnprocessableEntity},
+		{"記号のみ", `{"word":"禁止","action":"block"}`, usecase.ErrInvalidProhibitedWord, http.StatusUnprocessableEntity},
+		{"登録済み", `{"word":"禁止","action":"block"}`, usecase.ErrProhibitedWordAlreadyExists, http.StatusConflict},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.POST, "/admin/prohibited_words", strings.NewReader(test.body), rec)
+		setLoginUser(c, 1, model.RoleAdmin)
+
+		mockUseCase := mockProhibitedWordUseCase{}
+		if test.err == nil {
+			mockUseCase.On("CreateProhibitedWord", "禁止", "block").Return(&model.ProhibitedWord{ID: 1, Word: "禁止"}, nil)
+		} else {
+			mockUseCase.On("CreateProhibitedWord", "禁止", "block").Return(nil, test.err)
+		}
+		handler := NewProhibitedWordHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.CreateProhibitedWord(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// 禁止語削除テスト
+func TestDeleteProhibitedWord(t *testing.T) {
+	cases := []struct {
+		label  string
+		id     string
+		status int
+	}{
+		{"成功", "1", http.StatusOK},
+		{"ID形式", "a", http.StatusUnprocessableEntity},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.DELETE, "/admin/prohibited_words/"+test.id, nil, rec)
+		c.SetPath("/admin/prohibited_words/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+		setLoginUser(c, 1, model.RoleAdmin)
+
+		mockUseCase := mockProhibitedWordUseCase{}
+		mockUseCase.On("DeleteProhibitedWord", 1).Return(nil)
+		handler := NewProhibitedWordHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.DeleteProhibitedWord(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
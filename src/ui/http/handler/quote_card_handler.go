@@ -0,0 +1,68 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// QuoteCardHandler interface
+	QuoteCardHandler interface {
+		// 引用カード画像取得
+		GetQuoteCard(c echo.Context) error
+	}
+
+	// quoteCardHandler 構造体
+	quoteCardHandler struct {
+		QuoteCardUseCase usecase.QuoteCardUseCase
+	}
+)
+
+// NewQuoteCardHandler QuoteCardHandlerを生成。
+func NewQuoteCardHandler(usecase usecase.QuoteCardUseCase) QuoteCardHandler {
+	return &quoteCardHandler{usecase}
+}
+
+// GetQuoteCard 引用カード画像取得。
+// 内容のハッシュ値をETagとして返し、If-None-Matchが一致する場合は304を返す。
+func (handler *quoteCardHandler) GetQuoteCard(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.GetQuoteCardRequest{
+		ID:    id,
+		Theme: c.QueryParam("theme"),
+		Size:  c.QueryParam("size"),
+	}
+	if request.Theme == "" {
+		request.Theme = usecase.QuoteCardThemeLight
+	}
+	if request.Size == "" {
+		request.Size = usecase.QuoteCardSizeOGP
+	}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	png, hash, err := handler.QuoteCardUseCase.GetQuoteCard(request.ID, request.Theme, request.Size)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	etag := `"` + hash + `"`
+	c.Response().Header().Set("ETag", etag)
+	// 投稿の編集、非表示、削除をすぐに反映するため、キャッシュした画像は毎回ETagで再検証させる
+	c.Response().Header().Set("Cache-Control", "public, no-cache")
+	if c.Request().Header.Get("If-None-Match") == etag {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.Blob(http.StatusOK, "image/png", png)
+}
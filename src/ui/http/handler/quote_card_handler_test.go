@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockQuoteCardUseCase struct {
+	mock.Mock
+}
+
+// 引用カード画像取得
+func (usecase *mockQuoteCardUseCase) GetQuoteCard(postID int, theme, size string) ([]byte, string, error) {
+	args := usecase.Called(postID, theme, size)
+	png, ok := args.Get(0).([]byte)
+	if ok {
+		return png, args.String(1), args.Error(2)
+	}
+
+	return nil, args.String(1), args.Error(2)
+}
+
+// 引用カード画像取得用のContextを生成
+func createQuoteCardContext(target string, rec *httptest.ResponseRecorder) echo.Context {
+	c := createContext(echo.GET, target, nil, rec)
+	c.SetPath("/posts/:id/card.png")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	return c
+}
+
+// 引用カード画像取得テスト
+func TestGetQuoteCard_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createQuoteCardContext("/posts/1/card.png?theme=dark&size=square", rec)
+
+	mockUseCase := mockQuoteCardUseCase{}
+	mockUseCase.On("GetQuoteCard", 1, usecase.QuoteCardThemeDark, usecase.QuoteCardSizeSquare).Return([]byte("png"), "hash", nil)
+	handler := NewQuoteCardHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetQuoteCard(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `"hash"`, rec.Header().Get("ETag"))
+	assert.Equal(t, "public, no-cache", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "png", rec.Body.String())
+
+	// 4. Teardown
+}
+
+func TestGetQuoteCard_success_default(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createQuoteCardContext("/posts/1/card.png", rec)
+
+	mockUseCase := mockQuoteCardUseCase{}
+	mockUseCase.On("GetQuoteCard", 1, usecase.QuoteCardThemeLight, usecase.QuoteCardSizeOGP).Return([]byte("png"), "hash", nil)
+	handler := NewQuoteCardHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetQuoteCard(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestGetQuoteCard_success_notModified(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createQuoteCardContext("/posts/1/card.png", rec)
+	c.Request().Header.Set("If-None-Match", `"hash"`)
+
+	mockUseCase := mockQuoteCardUseCase{}
+	mockUseCase.On("GetQuoteCard", 1, usecase.QuoteCardThemeLight, usecase.QuoteCardSizeOGP).Return([]byte("png"), "hash", nil)
+	handler := NewQuoteCardHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetQuoteCard(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	// 4. Teardown
+}
+
+func TestGetQuoteCard_error_validationError(t *testing.T) {
+	cases := []struct {
+		label  string
+		target string
+	}{
+		{"テーマ", "/posts/1/card.png?theme=blue"},
+		{"サイズ", "/posts/1/card.png?size=huge"},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createQuoteCardContext(test.target, rec)
+
+		mockUseCase := mockQuoteCardUseCase{}
+		handler := NewQuoteCardHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetQuoteCard(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+func TestGetQuoteCard_error_usecaseError(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createQuoteCardContext("/posts/1/card.png", rec)
+
+	mockUseCase := mockQuoteCardUseCase{}
+	mockUseCase.On("GetQuoteCard", 1, mock.Anything, mock.Anything).Return(nil, "", errors.New("error"))
+	handler := NewQuoteCardHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetQuoteCard(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// 4. Teardown
+}
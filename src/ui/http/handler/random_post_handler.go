@@ -0,0 +1,89 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+// defaultRandomPostCount 取得件数の指定がない場合の件数
+const defaultRandomPostCount = 1
+
+type (
+	// RandomPostHandler interface
+	RandomPostHandler interface {
+		// ランダム投稿取得
+		GetRandomPosts(c echo.Context) error
+	}
+
+	// randomPostHandler 構造体
+	randomPostHandler struct {
+		RandomPostUseCase usecase.RandomPostUseCase
+	}
+)
+
+// NewRandomPostHandler RandomPostHandlerを生成。
+func NewRandomPostHandler(usecase usecase.RandomPostUseCase) RandomPostHandler {
+	return &randomPostHandler{usecase}
+}
+
+// GetRandomPosts ランダム投稿取得。閲覧済み、お気に入り登録済みの投稿の除外はログインしている場合のみ行う。
+func (handler *randomPostHandler) GetRandomPosts(c echo.Context) error {
+	count := defaultRandomPostCount
+	if c.QueryParam("count") != "" {
+		var err error
+		if count, err = strconv.Atoi(c.QueryParam("count")); err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "count：数値で入力してください。")
+		}
+	}
+	minFavoriteCount := 0
+	if c.QueryParam("min_favorite_count") != "" {
+		var err error
+		if minFavoriteCount, err = strconv.Atoi(c.QueryParam("min_favorite_count")); err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "min_favorite_count：数値で入力してください。")
+		}
+	}
+	excludeSeen, err := strconv.ParseBool(c.QueryParam("exclude_seen"))
+	if err != nil {
+		excludeSeen = false
+	}
+	excludeFavorited, err := strconv.ParseBool(c.QueryParam("exclude_favorited"))
+	if err != nil {
+		excludeFavorited = false
+	}
+
+	request := &request.GetRandomPostsRequest{
+		Count:            count,
+		Speaker:          c.QueryParam("speaker"),
+		Tag:              c.QueryParam("tag"),
+		SourceType:       c.QueryParam("source_type"),
+		MinFavoriteCount: minFavoriteCount,
+		ExcludeSeen:      excludeSeen,
+		ExcludeFavorited: excludeFavorited,
+	}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	posts, err := handler.RandomPostUseCase.GetRandomPosts(request.Count, &model.RandomPostCondition{
+		Speaker:          request.Speaker,
+		Tag:              request.Tag,
+		SourceType:       request.SourceType,
+		MinFavoriteCount: request.MinFavoriteCount,
+		LoginUserID:      loginUserID(c),
+		ExcludeSeen:      request.ExcludeSeen,
+		ExcludeFavorited: request.ExcludeFavorited,
+	})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"posts": posts,
+	})
+}
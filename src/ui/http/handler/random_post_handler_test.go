@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockRandomPostUseCase struct {
+	mock.Mock
+}
+
+// ランダム投稿取得
+func (usecase *mockRandomPostUseCase) GetRandomPosts(count int, condition *model.RandomPostCondition) ([]*model.GetPostResult, error) {
+	args := usecase.Called(count, condition)
+	posts, ok := args.Get(0).([]*model.GetPostResult)
+	if ok {
+		return posts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// ランダム投稿取得テスト
+func TestGetRandomPosts_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	q := make(url.Values)
+	q.Set("count", "2")
+	q.Set("speaker", "speaker1")
+	q.Set("tag", "tag1")
+	q.Set("source_type", "book")
+	q.Set("min_favorite_count", "3")
+	q.Set("exclude_seen", "true")
+	q.Set("exclude_favorited", "true")
+	c := createContext(echo.GET, "/posts/random?"+q.Encode(), nil, rec)
+	setLoginUser(c, 1, model.RoleUser)
+
+	expected := []*model.GetPostResult{makeGetPostResult(2), makeGetPostResult(1)}
+	condition := &model.RandomPostCondition{
+		Speaker:          "speaker1",
+		Tag:              "tag1",
+		SourceType:       "book",
+		MinFavoriteCount: 3,
+		LoginUserID:      1,
+		ExcludeSeen:      true,
+		ExcludeFavorited: true,
+	}
+	usecase := mockRandomPostUseCase{}
+	usecase.On("GetRandomPosts", 2, condition).Return(expected, nil)
+	handler := NewRandomPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetRandomPosts(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	response := struct {
+		Posts []*model.GetPostResult `json:"posts"`
+	}{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, expected, response.Posts)
+
+	// 4. Teardown
+}
+
+func TestGetRandomPosts_success_default(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/random", nil, rec)
+
+	usecase := mockRandomPostUseCase{}
+	usecase.On("GetRandomPosts", defaultRandomPostCount, &model.RandomPostCondition{}).Return([]*model.GetPostResult{}, nil)
+	handler := NewRandomPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetRandomPosts(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestGetRandomPosts_error_validationError(t *testing.T) {
+	cases := []struct {
+		label string
+		key   string
+		value string
+	}{
+		{"件数数値", "count", "a"},
+		{"件数最小値", "count", "0"},
+		{"件数最大値", "count", "51"},
+		{"お気に入り数数値", "min_favorite_count", "a"},
+		{"お気に入り数最小値", "min_favorite_count", "-1"},
+		{"出典の種類", "source_type", "magazine"},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		q := make(url.Values)
+		q.Set(test.key, test.value)
+		c := createContext(echo.GET, "/posts/random?"+q.Encode(), nil, rec)
+
+		usecase := mockRandomPostUseCase{}
+		handler := NewRandomPostHandler(&usecase)
+
+		// 2. Exercise
+		err := handler.GetRandomPosts(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+func TestGetRandomPosts_error_usecaseError(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/random", nil, rec)
+
+	usecase := mockRandomPostUseCase{}
+	usecase.On("GetRandomPosts", mock.Anything, mock.Anything).Return(nil, errors.New("error"))
+	handler := NewRandomPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetRandomPosts(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// 4. Teardown
+}
@@ -0,0 +1,125 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// ReactionHandler interface
+	ReactionHandler interface {
+		// リアクションの種類一覧取得
+		GetReactionTypes(c echo.Context) error
+		// リアクション登録
+		CreateReaction(c echo.Context) error
+		// リアクション削除
+		DeleteReaction(c echo.Context) error
+		// リアクションしたユーザー一覧取得
+		GetReactions(c echo.Context) error
+	}
+
+	// reactionHandler 構造体
+	reactionHandler struct {
+		ReactionUseCase usecase.ReactionUseCase
+	}
+)
+
+// NewReactionHandler ReactionHandlerを生成。
+func NewReactionHandler(usecase usecase.ReactionUseCase) ReactionHandler {
+	return &reactionHandler{usecase}
+}
+
+// GetReactionTypes リアクションの種類一覧取得
+func (handler *reactionHandler) GetReactionTypes(c echo.Context) error {
+	return c.JSON(http.StatusOK, handler.ReactionUseCase.GetReactionTypes())
+}
+
+// CreateReaction リアクション登録。ログインユーザーのリアクションとして登録する。
+// 既にリアクションしている場合も200を返す。存在しない投稿の場合は404を返す。
+func (handler *reactionHandler) CreateReaction(c echo.Context) error {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.ReactionRequest{UserID: loginUserID(c), PostID: postID, Type: c.Param("type")}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err = handler.ReactionUseCase.CreateReaction(request.UserID, request.PostID, request.Type)
+	if err == usecase.ErrInvalidReactionType {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	if err == usecase.ErrPostNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteReaction リアクション削除。ログインユーザーのリアクションを対象とする。
+func (handler *reactionHandler) DeleteReaction(c echo.Context) error {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.ReactionRequest{UserID: loginUserID(c), PostID: postID, Type: c.Param("type")}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := handler.ReactionUseCase.DeleteReaction(request.UserID, request.PostID, request.Type); err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetReactions リアクションしたユーザー一覧取得
+func (handler *reactionHandler) GetReactions(c echo.Context) error {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "limit：数値で入力してください。")
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "page：数値で入力してください。")
+	}
+
+	request := &request.GetReactionsRequest{
+		PostID: postID,
+		Type:   c.QueryParam("type"),
+		Limit:  limit,
+		Page:   page,
+	}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	totalCount, reactions, err := handler.ReactionUseCase.GetReactions(request.PostID, request.Type, request.Limit, request.Page)
+	if err == usecase.ErrInvalidReactionType {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"totalCount": totalCount,
+		"reactions":  reactions,
+	})
+}
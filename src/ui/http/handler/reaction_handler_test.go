@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockReactionUseCase struct {
+	mock.Mock
+}
+
+// リアクションの種類一覧取得
+func (usecase *mockReactionUseCase) GetReactionTypes() []*model.ReactionType {
+	return usecase.Called().Get(0).([]*model.ReactionType)
+}
+
+// リアクション登録
+func (usecase *mockReactionUseCase) CreateReaction(userID, postID int, reactionType string) error {
+	return usecase.Called(userID, postID, reactionType).Error(0)
+}
+
+// リアクション削除
+func (usecase *mockReactionUseCase) DeleteReaction(userID, postID int, reactionType string) error {
+	return usecase.Called(userID, postID, reactionType).Error(0)
+}
+
+// リアクションしたユーザー一覧取得
+func (usecase *mockReactionUseCase) GetReactions(postID int, reactionType string, limit, page int) (int, []*model.GetReactionResult, error) {
+	args := usecase.Called(postID, reactionType, limit, page)
+	reactions, ok := args.Get(1).([]*model.GetReactionResult)
+	if ok {
+		return args.Int(0), reactions, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// リアクション登録テスト
+func TestCreateReaction(t *testing.T) {
+	cases := []struct {
+		label  string
+		userID int
+		id     string
+		err    error
+		status int
+	}{
+		{"成功", 1, "2", nil, http.StatusOK},
+		{"ログインユーザー必須", 0, "2", nil, http.StatusUnprocessableEntity},
+		{"ID形式", 1, "a", nil, http.StatusUnprocessableEntity},
+		{"種類不正", 1, "2", usecase.ErrInvalidReactionType, http.StatusUnprocessableEntity},
+		{"投稿なし", 1, "2", usecase.ErrPostNotFound, http.StatusNotFound},
+		{"その他", 1, "2", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/posts/"+test.id+"/reactions/moved", nil, rec)
+		c.SetPath("/posts/:id/reactions/:type")
+		c.SetParamNames("id", "type")
+		c.SetParamValues(test.id, "moved")
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		mockUseCase := mockReactionUseCase{}
+		mockUseCase.On("CreateReaction", 1, 2, "moved").Return(test.err)
+		handler := NewReactionHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.CreateReaction(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// リアクションしたユーザー一覧取得テスト
+func TestGetReactions(t *testing.T) {
+	cases := []struct {
+		label  string
+		query  string
+		err    error
+		status int
+	}{
+		{"成功", "?limit=10&page=1", nil, http.StatusOK},
+		{"種類で絞り込み", "?limit=10&page=1&type=moved", nil, http.StatusOK},
+		{"limit必須", "?page=1", nil, http.StatusUnprocessableEntity},
+		{"種類不正", "?limit=10&page=1&type=angry", usecase.ErrInvalidReactionType, http.StatusUnprocessableEntity},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/posts/1/reactions"+test.query, nil, rec)
+		c.SetPath("/posts/:id/reactions")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockUseCase := mockReactionUseCase{}
+		mockUseCase.On("GetReactions", 1, mock.AnythingOfType("string"), 10, 1).Return(1, []*model.GetReactionResult{{UserName: "user"}}, test.err)
+		handler := NewReactionHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetReactions(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
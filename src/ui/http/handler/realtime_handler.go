@@ -0,0 +1,134 @@
+// Package handler UI層
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+// realtimeKeepAliveInterval 接続を維持するためにコメント行を送る間隔。プロキシなどによる切断を防ぐ。
+var realtimeKeepAliveInterval = 30 * time.Second
+
+type (
+	// RealtimeHandler interface
+	RealtimeHandler interface {
+		// リアルタイム配信に接続するためのチケット発行
+		CreateStreamTicket(c echo.Context) error
+		// リアルタイム配信のチケットによる認証
+		AuthenticateStreamTicket(next echo.HandlerFunc) echo.HandlerFunc
+		// リアルタイム配信(Server-Sent Events)
+		SubscribeEvents(c echo.Context) error
+	}
+
+	// realtimeHandler 構造体
+	realtimeHandler struct {
+		RealtimeUseCase usecase.RealtimeUseCase
+	}
+)
+
+// NewRealtimeHandler RealtimeHandlerを生成。
+func NewRealtimeHandler(usecase usecase.RealtimeUseCase) RealtimeHandler {
+	return &realtimeHandler{usecase}
+}
+
+// CreateStreamTicket リアルタイム配信に接続するためのチケット発行。
+// EventSourceはヘッダーを指定できないため、ログイン用のトークンの代わりに有効期限の短いチケットをクエリパラメータで渡す。
+func (handler *realtimeHandler) CreateStreamTicket(c echo.Context) error {
+	request := &request.CreateStreamTicketRequest{UserID: loginUserID(c)}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	ticket, err := handler.RealtimeUseCase.CreateStreamTicket(request.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, ticket)
+}
+
+// AuthenticateStreamTicket リアルタイム配信のチケットによる認証を行うミドルウェア。
+// Authorizationヘッダーがない場合、クエリパラメータticketのチケットを検証し、発行したユーザーをログインユーザーとする。
+func (handler *realtimeHandler) AuthenticateStreamTicket(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c.Request().Header.Get(echo.HeaderAuthorization) != "" {
+			return next(c)
+		}
+
+		userID, err := handler.RealtimeUseCase.VerifyStreamTicket(c.QueryParam("ticket"))
+		if err == usecase.ErrInvalidStreamTicket {
+			return c.JSON(http.StatusUnauthorized, err.Error())
+		}
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err.Error())
+		}
+
+		c.Set("user", &jwt.Token{Claims: jwt.MapClaims{"sub": float64(userID)}, Valid: true})
+		return next(c)
+	}
+}
+
+// SubscribeEvents リアルタイム配信(Server-Sent Events)。接続が切れるまでイベントを送り続ける。
+// ログインユーザーへの通知と、クエリパラメータpost_id(複数指定可)の投稿へのコメント、お気に入り数の変化を配信する。
+// EventSourceはヘッダーを指定できないため、CreateStreamTicketで発行したチケットをクエリパラメータticketでも指定できる。
+func (handler *realtimeHandler) SubscribeEvents(c echo.Context) error {
+	postIDs := []int{}
+	for _, param := range c.QueryParams()["post_id"] {
+		postID, err := strconv.Atoi(param)
+		if err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "post_id：数値で入力してください。")
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	request := &request.SubscribeRealtimeRequest{UserID: loginUserID(c), PostIDs: postIDs}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	events, unsubscribe := handler.RealtimeUseCase.Subscribe(request.UserID, request.PostIDs)
+	defer unsubscribe()
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	keepAlive := time.NewTicker(realtimeKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(response, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			response.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(response, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return nil
+			}
+			response.Flush()
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockRealtimeUseCase struct {
+	mock.Mock
+}
+
+// リアルタイム配信の購読
+func (usecase *mockRealtimeUseCase) Subscribe(userID int, postIDs []int) (<-chan *model.RealtimeEvent, func()) {
+	args := usecase.Called(userID, postIDs)
+	return args.Get(0).(chan *model.RealtimeEvent), func() {}
+}
+
+// リアルタイム配信に接続するためのチケット発行
+func (usecase *mockRealtimeUseCase) CreateStreamTicket(userID int) (*model.StreamTicket, error) {
+	args := usecase.Called(userID)
+	ticket, _ := args.Get(0).(*model.StreamTicket)
+	return ticket, args.Error(1)
+}
+
+// リアルタイム配信のチケットの検証
+func (usecase *mockRealtimeUseCase) VerifyStreamTicket(ticket string) (int, error) {
+	args := usecase.Called(ticket)
+	return args.Int(0), args.Error(1)
+}
+
+func (usecase *mockRealtimeUseCase) HandleDomainEvent(event *model.OutboxEvent) error {
+	return usecase.Called(event).Error(0)
+}
+
+// チケット発行テスト
+func TestCreateStreamTicket_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/events/ticket", nil, rec)
+	setLoginUser(c, 3, model.RoleUser)
+
+	usecase := mockRealtimeUseCase{}
+	usecase.On("CreateStreamTicket", 3).Return(&model.StreamTicket{Ticket: "ticket", ExpiresAt: time.Date(2020, 6, 1, 12, 0, 30, 0, time.UTC)}, nil)
+	handler := NewRealtimeHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.CreateStreamTicket(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"ticket":"ticket","expires_at":"2020-06-01T12:00:30Z"}`, rec.Body.String())
+
+	// 4. Teardown
+}
+
+func TestCreateStreamTicket_error(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/events/ticket", nil, rec)
+	usecase := mockRealtimeUseCase{}
+	handler := NewRealtimeHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.CreateStreamTicket(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	usecase.AssertNotCalled(t, "CreateStreamTicket", mock.Anything)
+
+	// 4. Teardown
+}
+
+// チケットによる認証テスト
+func TestAuthenticateStreamTicket(t *testing.T) {
+	// 1. Setup
+	cases := []struct {
+		label          string
+		authorization  string
+		verifiedUserID int
+		verifyErr      error
+		expectedCode   int
+		expectedUserID int
+	}{
+		{"チケット", "", 3, nil, http.StatusOK, 3},
+		{"チケット不正", "", 0, usecase.ErrInvalidStreamTicket, http.StatusUnauthorized, 0},
+		{"検証失敗", "", 0, errors.New("error"), http.StatusInternalServerError, 0},
+		{"Authorizationヘッダー", "Bearer token", 0, nil, http.StatusOK, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			context := createContext(echo.GET, "/events?ticket=ticket", nil, rec)
+			if c.authorization != "" {
+				context.Request().Header.Set(echo.HeaderAuthorization, c.authorization)
+			}
+			mockUseCase := mockRealtimeUseCase{}
+			mockUseCase.On("VerifyStreamTicket", "ticket").Return(c.verifiedUserID, c.verifyErr)
+			handler := NewRealtimeHandler(&mockUseCase)
+			userID := 0
+			next := func(c echo.Context) error {
+				userID = loginUserID(c)
+				return c.NoContent(http.StatusOK)
+			}
+
+			// 2. Exercise
+			err := handler.AuthenticateStreamTicket(next)(context)
+
+			// 3. Verify
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedCode, rec.Code)
+			assert.Equal(t, c.expectedUserID, userID)
+			if c.authorization != "" {
+				mockUseCase.AssertNotCalled(t, "VerifyStreamTicket", mock.Anything)
+			}
+		})
+	}
+
+	// 4. Teardown
+}
+
+// リアルタイム配信テスト
+func TestSubscribeEvents_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/events?post_id=1&post_id=2", nil, rec)
+	setLoginUser(c, 3, model.RoleUser)
+
+	events := make(chan *model.RealtimeEvent, 1)
+	events <- &model.RealtimeEvent{Type: model.RealtimeEventComment, PostID: 1, Data: []byte(`{"id":5}`)}
+	close(events)
+	usecase := mockRealtimeUseCase{}
+	usecase.On("Subscribe", 3, []int{1, 2}).Return(events)
+	handler := NewRealtimeHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.SubscribeEvents(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "event: comment\ndata: {\"type\":\"comment\",\"post_id\":1,\"data\":{\"id\":5}}\n\n", rec.Body.String())
+
+	// 4. Teardown
+}
+
+func TestSubscribeEvents_error(t *testing.T) {
+	// 1. Setup
+	cases := []struct {
+		label    string
+		query    string
+		userID   int
+		expected string
+	}{
+		{"post_id数値以外", "?post_id=a", 3, "\"post_id：数値で入力してください。\"\n"},
+		{"未ログイン", "", 0, ""},
+		{"post_idが0", "?post_id=0", 3, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			context := createContext(echo.GET, "/events"+c.query, nil, rec)
+			if c.userID > 0 {
+				setLoginUser(context, c.userID, model.RoleUser)
+			}
+			usecase := mockRealtimeUseCase{}
+			handler := NewRealtimeHandler(&usecase)
+
+			// 2. Exercise
+			err := handler.SubscribeEvents(context)
+
+			// 3. Verify
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			if c.expected != "" {
+				assert.Equal(t, c.expected, rec.Body.String())
+			}
+			usecase.AssertNotCalled(t, "Subscribe", mock.Anything, mock.Anything)
+		})
+	}
+
+	// 4. Teardown
+}
@@ -0,0 +1,64 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+// defaultRelatedPostsLimit 取得件数の指定がない場合の件数
+const defaultRelatedPostsLimit = 5
+
+type (
+	// RelatedPostHandler interface
+	RelatedPostHandler interface {
+		// 関連する投稿一覧取得
+		GetRelatedPosts(c echo.Context) error
+	}
+
+	// relatedPostHandler 構造体
+	relatedPostHandler struct {
+		RelatedPostUseCase usecase.RelatedPostUseCase
+	}
+)
+
+// NewRelatedPostHandler RelatedPostHandlerを生成。
+func NewRelatedPostHandler(usecase usecase.RelatedPostUseCase) RelatedPostHandler {
+	return &relatedPostHandler{usecase}
+}
+
+// GetRelatedPosts 関連する投稿一覧取得
+func (handler *relatedPostHandler) GetRelatedPosts(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	limit := defaultRelatedPostsLimit
+	if c.QueryParam("limit") != "" {
+		if limit, err = strconv.Atoi(c.QueryParam("limit")); err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, "limit：数値で入力してください。")
+		}
+	}
+	loginUserID, err := strconv.Atoi(c.QueryParam("login_user_id"))
+	if err != nil {
+		loginUserID = 0
+	}
+
+	request := &request.GetRelatedPostsRequest{ID: id, Limit: limit, LoginUserID: loginUserID}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	posts, err := handler.RelatedPostUseCase.GetRelatedPosts(request.ID, request.LoginUserID, request.Limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"posts": posts,
+	})
+}
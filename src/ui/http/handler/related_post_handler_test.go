@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockRelatedPostUseCase struct {
+	mock.Mock
+}
+
+// 関連する投稿一覧取得
+func (usecase *mockRelatedPostUseCase) GetRelatedPosts(postID, loginUserID, limit int) ([]*model.GetPostResult, error) {
+	args := usecase.Called(postID, loginUserID, limit)
+	posts, ok := args.Get(0).([]*model.GetPostResult)
+	if ok {
+		return posts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 関連する投稿の再計算
+func (usecase *mockRelatedPostUseCase) ComputeRelatedPosts() (int, error) {
+	args := usecase.Called()
+	return args.Int(0), args.Error(1)
+}
+
+// 関連する投稿一覧取得テスト
+func TestGetRelatedPosts_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	q := make(url.Values)
+	q.Set("limit", "2")
+	q.Set("login_user_id", "3")
+	c := createContext(echo.GET, "/posts/1/related?"+q.Encode(), nil, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	expected := []*model.GetPostResult{makeGetPostResult(5), makeGetPostResult(4)}
+	usecase := mockRelatedPostUseCase{}
+	usecase.On("GetRelatedPosts", 1, 3, 2).Return(expected, nil)
+	handler := NewRelatedPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetRelatedPosts(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	response := struct {
+		Posts []*model.GetPostResult `json:"posts"`
+	}{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, expected, response.Posts)
+
+	// 4. Teardown
+}
+
+func TestGetRelatedPosts_success_default(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/1/related", nil, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	usecase := mockRelatedPostUseCase{}
+	usecase.On("GetRelatedPosts", 1, 0, defaultRelatedPostsLimit).Return([]*model.GetPostResult{}, nil)
+	handler := NewRelatedPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetRelatedPosts(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	usecase.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestGetRelatedPosts_error_validationError(t *testing.T) {
+	cases := []struct {
+		label string
+		id    string
+		limit string
+	}{
+		{"ID数値", "a", "5"},
+		{"ID最小値", "0", "5"},
+		{"件数数値", "1", "a"},
+		{"件数最小値", "1", "0"},
+		{"件数最大値", "1", "21"},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		q := make(url.Values)
+		q.Set("limit", test.limit)
+		c := createContext(echo.GET, "/posts/"+test.id+"/related?"+q.Encode(), nil, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+
+		usecase := mockRelatedPostUseCase{}
+		handler := NewRelatedPostHandler(&usecase)
+
+		// 2. Exercise
+		err := handler.GetRelatedPosts(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+func TestGetRelatedPosts_error_usecaseError(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/1/related", nil, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	usecase := mockRelatedPostUseCase{}
+	usecase.On("GetRelatedPosts", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("error"))
+	handler := NewRelatedPostHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetRelatedPosts(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// 4. Teardown
+}
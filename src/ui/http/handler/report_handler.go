@@ -0,0 +1,141 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// ReportHandler interface
+	ReportHandler interface {
+		// 通報
+		CreateReport(c echo.Context) error
+		// 未対応の通報の対象一覧取得
+		GetReportQueue(c echo.Context) error
+		// 通報の対象取得
+		GetReportTarget(c echo.Context) error
+		// モデレーターの対応
+		ModerateTarget(c echo.Context) error
+	}
+
+	// reportHandler 構造体
+	reportHandler struct {
+		ReportUseCase usecase.ReportUseCase
+	}
+)
+
+// NewReportHandler ReportHandlerを生成。
+func NewReportHandler(usecase usecase.ReportUseCase) ReportHandler {
+	return &reportHandler{usecase}
+}
+
+// CreateReport 通報。同じ対象を既に通報している場合は409を返す。
+func (handler *reportHandler) CreateReport(c echo.Context) error {
+	request := new(request.CreateReportRequest)
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	// 通報者はリクエストボディで上書きさせない
+	request.ReporterID = loginUserID(c)
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err := handler.ReportUseCase.CreateReport(request.ReporterID, request.TargetType, request.TargetID, request.ReasonCode, request.Detail)
+	if err == usecase.ErrReportTargetNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err == usecase.ErrAlreadyReported {
+		return c.JSON(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusCreated)
+}
+
+// GetReportQueue 未対応の通報の対象一覧取得。モデレーターのみ実行できる。
+func (handler *reportHandler) GetReportQueue(c echo.Context) error {
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "limit：数値で入力してください。")
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "page：数値で入力してください。")
+	}
+
+	request := &request.GetReportQueueRequest{
+		TargetType: c.QueryParam("target_type"),
+		Limit:      limit,
+		Page:       page,
+	}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	totalCount, items, err := handler.ReportUseCase.GetReportQueue(request.TargetType, request.Limit, request.Page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"totalCount": totalCount,
+		"reports":    items,
+	})
+}
+
+// GetReportTarget 通報の対象取得。通報と対応の履歴を含む。モデレーターのみ実行できる。
+func (handler *reportHandler) GetReportTarget(c echo.Context) error {
+	targetID, err := strconv.Atoi(c.Param("target_id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.GetReportTargetRequest{TargetType: c.Param("target_type"), TargetID: targetID}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	target, err := handler.ReportUseCase.GetReportTarget(request.TargetType, request.TargetID)
+	if err == usecase.ErrReportTargetNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, target)
+}
+
+// ModerateTarget モデレーターの対応。モデレーターのみ実行できる。
+func (handler *reportHandler) ModerateTarget(c echo.Context) error {
+	request := new(request.ModerateTargetRequest)
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	// モデレーターIDはリクエストボディで上書きさせない
+	request.ModeratorID = loginUserID(c)
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err := handler.ReportUseCase.ModerateTarget(request.ModeratorID, request.TargetType, request.TargetID, request.Action, request.Reason)
+	if err == usecase.ErrReportTargetNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err == usecase.ErrModerationActionNotApplicable {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockReportUseCase struct {
+	mock.Mock
+}
+
+// 通報
+func (usecase *mockReportUseCase) CreateReport(reporterID int, targetType string, targetID int, reasonCode, detail string) error {
+	return usecase.Called(reporterID, targetType, targetID, reasonCode, detail).Error(0)
+}
+
+// 未対応の通報の対象一覧取得
+func (usecase *mockReportUseCase) GetReportQueue(targetType string, limit, page int) (int, []*model.ReportQueueItem, error) {
+	args := usecase.Called(targetType, limit, page)
+	items, ok := args.Get(1).([]*model.ReportQueueItem)
+	if ok {
+		return args.Int(0), items, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// 通報の対象取得
+func (usecase *mockReportUseCase) GetReportTarget(targetType string, targetID int) (*model.ReportTarget, error) {
+	args := usecase.Called(targetType, targetID)
+	target, ok := args.Get(0).(*model.ReportTarget)
+	if ok {
+		return target, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// モデレーターの対応
+func (usecase *mockReportUseCase) ModerateTarget(moderatorID int, targetType string, targetID int, action, reason string) error {
+	return usecase.Called(moderatorID, targetType, targetID, action, reason).Error(0)
+}
+
+// 通報テスト
+func TestCreateReport(t *testing.T) {
+	cases := []struct {
+		label  string
+		userID int
+		body   string
+		err    error
+		status int
+	}{
+		// リクエストボディの通報者IDは無視される
+		{"成功", 1, `{"reporter_id":99,"target_type":"post","target_id":2,"reason_code":"spam","detail":"宣伝です"}`, nil, http.StatusCreated},
+		{"ログインユーザー必須", 0, `{"target_type":"post","target_id":2,"reason_code":"spam"}`, nil, http.StatusUnprocessableEntity},
+		{"対象の種類不正", 1, `{"target_type":"favorite","target_id":2,"reason_code":"spam"}`, nil, http.StatusUnprocessableEntity},
+		{"理由不正", 1, `{"target_type":"post","target_id":2,"reason_code":"dislike"}`, nil, http.StatusUnprocessableEntity},
+		{"対象なし", 1, `{"target_type":"post","target_id":2,"reason_code":"spam"}`, usecase.ErrReportTargetNotFound, http.StatusNotFound},
+		{"通報済み", 1, `{"target_type":"post","target_id":2,"reason_code":"spam"}`, usecase.ErrAlreadyReported, http.StatusConflict},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.POST, "/reports", strings.NewReader(test.body), rec)
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		mockUseCase := mockReportUseCase{}
+		mockUseCase.On("CreateReport", 1, "post", 2, "spam", mock.AnythingOfType("string")).Return(test.err)
+		handler := NewReportHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.CreateReport(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// 未対応の通報の対象一覧取得テスト
+func TestGetReportQueue(t *testing.T) {
+	cases := []struct {
+		label  string
+		query  string
+		status int
+	}{
+		{"成功", "?limit=10&page=1", http.StatusOK},
+		{"対象の種類で絞り込み", "?limit=10&page=1&target_type=comment", http.StatusOK},
+		{"limit必須", "?page=1", http.StatusUnprocessableEntity},
+		{"対象の種類不正", "?limit=10&page=1&target_type=favorite", http.StatusUnprocessableEntity},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/moderation/reports"+test.query, nil, rec)
+		setLoginUser(c, 1, model.RoleModerator)
+
+		mockUseCase := mockReportUseCase{}
+		mockUseCase.On("GetReportQueue", mock.AnythingOfType("string"), 10, 1).Return(1, []*model.ReportQueueItem{{TargetType: "post", TargetID: 1, ReportCount: 3}}, nil)
+		handler := NewReportHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetReportQueue(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// 通報の対象取得テスト
+func TestGetReportTarget(t *testing.T) {
+	cases := []struct {
+		label      string
+		targetType string
+		targetID   string
+		err        error
+		status     int
+	}{
+		{"成功", "comment", "1", nil, http.StatusOK},
+		{"ID形式", "comment", "a", nil, http.StatusUnprocessableEntity},
+		{"対象の種類不正", "favorite", "1", nil, http.StatusUnprocessableEntity},
+		{"対象なし", "comment", "1", usecase.ErrReportTargetNotFound, http.StatusNotFound},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/moderation/reports/"+test.targetType+"/"+test.targetID, nil, rec)
+		c.SetPath("/moderation/reports/:target_type/:target_id")
+		c.SetParamNames("target_type", "target_id")
+		c.SetParamValues(test.targetType, test.targetID)
+		setLoginUser(c, 1, model.RoleModerator)
+
+		mockUseCase := mockReportUseCase{}
+		if test.err == nil {
+			mockUseCase.On("GetReportTarget", "comment", 1).Return(&model.ReportTarget{TargetType: "comment", TargetID: 1}, nil)
+		} else {
+			mockUseCase.On("GetReportTarget", "comment", 1).Return(nil, test.err)
+		}
+		handler := NewReportHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetReportTarget(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// モデレーターの対応テスト
+func TestModerateTarget(t *testing.T) {
+	cases := []struct {
+		label  string
+		body   string
+		err    error
+		status int
+	}{
+		// リクエストボディのモデレーターIDは無視される
+		{"成功", `{"moderator_id":99,"target_type":"user","target_id":3,"action":"suspend","reason":"度重なる迷惑行為"}`, nil, http.StatusOK},
+		{"理由必須", `{"target_type":"user","target_id":3,"action":"suspend"}`, nil, http.StatusUnprocessableEntity},
+		{"対応不正", `{"target_type":"user","target_id":3,"action":"auto_hide","reason":"理由"}`, nil, http.StatusUnprocessableEntity},
+		{"対象なし", `{"target_type":"user","target_id":3,"action":"suspend","reason":"理由"}`, usecase.ErrReportTargetNotFound, http.StatusNotFound},
+		{"適用できない対応", `{"target_type":"user","target_id":3,"action":"suspend","reason":"理由"}`, usecase.ErrModerationActionNotApplicable, http.StatusUnprocessableEntity},
+		{"その他", `{"target_type":"user","target_id":3,"action":"suspend","reason":"理由"}`, errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.POST, "/moderation/actions", strings.NewReader(test.body), rec)
+		setLoginUser(c, 2, model.RoleModerator)
+
+		mockUseCase := mockReportUseCase{}
+		mockUseCase.On("ModerateTarget", 2, "user", 3, "suspend", mock.AnythingOfType("string")).Return(test.err)
+		handler := NewReportHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.ModerateTarget(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
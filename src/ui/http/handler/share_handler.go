@@ -0,0 +1,195 @@
+// Package handler UI層
+package handler
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+// sharePageTemplate リンクプレビュー用HTML。html/templateにより属性値はエスケープされる。
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="ja" prefix="og: http://ogp.me/ns#">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<meta name="description" content="{{.Description}}">
+<link rel="canonical" href="{{.CanonicalURL}}">
+<meta property="og:site_name" content="Power Phrase">
+<meta property="og:type" content="{{.Type}}">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:url" content="{{.CanonicalURL}}">
+{{- if .ImageURL}}
+<meta property="og:image" content="{{.ImageURL}}">
+<meta name="twitter:image" content="{{.ImageURL}}">
+{{- end}}
+<meta name="twitter:card" content="{{.TwitterCard}}">
+<meta name="twitter:title" content="{{.Title}}">
+<meta name="twitter:description" content="{{.Description}}">
+<meta http-equiv="refresh" content="0; url={{.CanonicalURL}}">
+</head>
+<body>
+<p><a href="{{.CanonicalURL}}">{{.Title}}</a></p>
+</body>
+</html>
+`))
+
+// crawlerUserAgents リンクプレビューを取得するクローラーのUser-Agentに含まれる文字列(小文字)
+var crawlerUserAgents = []string{
+	"twitterbot",
+	"facebookexternalhit",
+	"facebot",
+	"slackbot",
+	"discordbot",
+	"linkedinbot",
+	"line-poker",
+	"hatena",
+	"embedly",
+	"pinterest",
+	"skypeuripreview",
+	"telegrambot",
+	"whatsapp",
+	"googlebot",
+	"bingbot",
+	"bot",
+	"crawler",
+	"spider",
+}
+
+type (
+	// ShareHandler interface
+	ShareHandler interface {
+		// 投稿のリンクプレビュー用ページ取得
+		GetPostSharePage(c echo.Context) error
+		// ユーザーのリンクプレビュー用ページ取得
+		GetUserSharePage(c echo.Context) error
+		// まとめのリンクプレビュー用ページ取得
+		GetCollectionSharePage(c echo.Context) error
+	}
+
+	// shareHandler 構造体
+	shareHandler struct {
+		ShareUseCase usecase.ShareUseCase
+	}
+)
+
+// NewShareHandler ShareHandlerを生成。
+func NewShareHandler(usecase usecase.ShareUseCase) ShareHandler {
+	return &shareHandler{usecase}
+}
+
+// GetPostSharePage 投稿のリンクプレビュー用ページ取得。
+// クローラー以外からのアクセスはフロントエンドの投稿詳細ページへリダイレクトする。
+func (handler *shareHandler) GetPostSharePage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	request := &request.GetSharePageRequest{ID: id}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	// User-Agentによってリダイレクトとリンクプレビュー用HTMLを返し分けるため、共有キャッシュで取り違えないようにする
+	c.Response().Header().Set("Vary", "User-Agent")
+	if !isCrawler(c.Request().UserAgent()) {
+		return c.Redirect(http.StatusFound, usecase.PostPageURL(request.ID))
+	}
+
+	page, err := handler.ShareUseCase.GetPostSharePage(request.ID)
+	if err == usecase.ErrPostNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return renderSharePage(c, page)
+}
+
+// GetUserSharePage ユーザーのリンクプレビュー用ページ取得。
+// クローラー以外からのアクセスはフロントエンドのユーザーページへリダイレクトする。
+func (handler *shareHandler) GetUserSharePage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	request := &request.GetSharePageRequest{ID: id}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	// User-Agentによってリダイレクトとリンクプレビュー用HTMLを返し分けるため、共有キャッシュで取り違えないようにする
+	c.Response().Header().Set("Vary", "User-Agent")
+	if !isCrawler(c.Request().UserAgent()) {
+		return c.Redirect(http.StatusFound, usecase.UserPageURL(request.ID))
+	}
+
+	page, err := handler.ShareUseCase.GetUserSharePage(request.ID)
+	if err == usecase.ErrUserNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return renderSharePage(c, page)
+}
+
+// GetCollectionSharePage まとめのリンクプレビュー用ページ取得。
+// クローラー以外からのアクセスはフロントエンドのまとめページへリダイレクトする。
+func (handler *shareHandler) GetCollectionSharePage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	request := &request.GetSharePageRequest{ID: id}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	// User-Agentによってリダイレクトとリンクプレビュー用HTMLを返し分けるため、共有キャッシュで取り違えないようにする
+	c.Response().Header().Set("Vary", "User-Agent")
+	if !isCrawler(c.Request().UserAgent()) {
+		return c.Redirect(http.StatusFound, usecase.CollectionPageURL(request.ID))
+	}
+
+	page, err := handler.ShareUseCase.GetCollectionSharePage(request.ID)
+	if err == usecase.ErrCollectionNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return renderSharePage(c, page)
+}
+
+// renderSharePage リンクプレビュー用HTMLを出力する。
+func renderSharePage(c echo.Context, page *model.SharePage) error {
+	buffer := new(bytes.Buffer)
+	if err := sharePageTemplate.Execute(buffer, page); err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+	c.Response().Header().Set("Cache-Control", "public, max-age=600")
+	return c.HTMLBlob(http.StatusOK, buffer.Bytes())
+}
+
+// isCrawler User-Agentがクローラーのものかどうか
+func isCrawler(userAgent string) bool {
+	userAgent = strings.ToLower(userAgent)
+	for _, crawler := range crawlerUserAgents {
+		if strings.Contains(userAgent, crawler) {
+			return true
+		}
+	}
+	return false
+}
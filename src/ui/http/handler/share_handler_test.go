@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockShareUseCase struct {
+	mock.Mock
+}
+
+// 投稿のリンクプレビュー用ページ情報取得
+func (usecase *mockShareUseCase) GetPostSharePage(postID int) (*model.SharePage, error) {
+	args := usecase.Called(postID)
+	page, ok := args.Get(0).(*model.SharePage)
+	if ok {
+		return page, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// ユーザーのリンクプレビュー用ページ情報取得
+func (usecase *mockShareUseCase) GetUserSharePage(userID int) (*model.SharePage, error) {
+	args := usecase.Called(userID)
+	page, ok := args.Get(0).(*model.SharePage)
+	if ok {
+		return page, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// まとめのリンクプレビュー用ページ情報取得
+func (usecase *mockShareUseCase) GetCollectionSharePage(collectionID int) (*model.SharePage, error) {
+	args := usecase.Called(collectionID)
+	page, ok := args.Get(0).(*model.SharePage)
+	if ok {
+		return page, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// リンクプレビュー用ページ取得用のContextを生成
+func createShareContext(path, id, userAgent string, rec *httptest.ResponseRecorder) echo.Context {
+	c := createContext(echo.GET, path, nil, rec)
+	c.Request().Header.Set("User-Agent", userAgent)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c
+}
+
+// 投稿のリンクプレビュー用ページ取得テスト(クローラー)
+func TestGetPostSharePage_success_crawler(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createShareContext("/share/posts/1", "1", "Twitterbot/1.0", rec)
+
+	page := &model.SharePage{
+		Title:        `「"><script>alert(1)</script>」 | Power Phrase`,
+		Description:  "description",
+		ImageURL:     "https://api.example.com/api/v1/posts/1/card.png",
+		CanonicalURL: "https://www.example.com/posts/1",
+		Type:         "article",
+		TwitterCard:  model.TwitterCardSummaryLargeImage,
+	}
+	usecase := mockShareUseCase{}
+	usecase.On("GetPostSharePage", 1).Return(page, nil)
+	handler := NewShareHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetPostSharePage(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "User-Agent", rec.Header().Get("Vary"))
+	body := rec.Body.String()
+	assert.Contains(t, body, `<meta property="og:image" content="https://api.example.com/api/v1/posts/1/card.png">`)
+	assert.Contains(t, body, `<meta name="twitter:card" content="summary_large_image">`)
+	assert.Contains(t, body, `<link rel="canonical" href="https://www.example.com/posts/1">`)
+	assert.NotContains(t, body, "<script>")
+	assert.Contains(t, body, "&lt;script&gt;")
+
+	// 4. Teardown
+}
+
+// 投稿のリンクプレビュー用ページ取得テスト(ブラウザ)
+func TestGetPostSharePage_success_browser(t *testing.T) {
+	// 1. Setup
+	os.Setenv("FRONTEND_BASE_URL", "https://www.example.com")
+	rec := httptest.NewRecorder()
+	c := createShareContext("/share/posts/1", "1", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)", rec)
+
+	usecase := mockShareUseCase{}
+	handler := NewShareHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetPostSharePage(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://www.example.com/posts/1", rec.Header().Get(echo.HeaderLocation))
+	assert.Equal(t, "User-Agent", rec.Header().Get("Vary"))
+	usecase.AssertNotCalled(t, "GetPostSharePage", mock.Anything)
+
+	// 4. Teardown
+	os.Unsetenv("FRONTEND_BASE_URL")
+}
+
+func TestGetPostSharePage_error(t *testing.T) {
+	cases := []struct {
+		label    string
+		id       string
+		err      error
+		expected int
+	}{
+		{"ID数値", "a", nil, http.StatusUnprocessableEntity},
+		{"ID最小値", "0", nil, http.StatusUnprocessableEntity},
+		{"投稿なし", "1", usecase.ErrPostNotFound, http.StatusNotFound},
+		{"usecaseエラー", "1", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createShareContext("/share/posts/"+test.id, test.id, "facebookexternalhit/1.1", rec)
+
+		usecase := mockShareUseCase{}
+		usecase.On("GetPostSharePage", 1).Return(nil, test.err)
+		handler := NewShareHandler(&usecase)
+
+		// 2. Exercise
+		err := handler.GetPostSharePage(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// ユーザーのリンクプレビュー用ページ取得テスト(クローラー)
+func TestGetUserSharePage_success_crawler(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createShareContext("/share/users/1", "1", "Slackbot-LinkExpanding 1.0", rec)
+
+	page := &model.SharePage{
+		Title:        "testuser1 | Power Phrase",
+		Description:  "description",
+		CanonicalURL: "https://www.example.com/users/1",
+		Type:         "profile",
+		TwitterCard:  model.TwitterCardSummary,
+	}
+	usecase := mockShareUseCase{}
+	usecase.On("GetUserSharePage", 1).Return(page, nil)
+	handler := NewShareHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetUserSharePage(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `<meta property="og:type" content="profile">`)
+	// 画像がない場合は出力しない
+	assert.NotContains(t, rec.Body.String(), "og:image")
+
+	// 4. Teardown
+}
+
+// ユーザーのリンクプレビュー用ページ取得テスト(ブラウザ)
+func TestGetUserSharePage_success_browser(t *testing.T) {
+	// 1. Setup
+	os.Setenv("FRONTEND_BASE_URL", "https://www.example.com")
+	rec := httptest.NewRecorder()
+	c := createShareContext("/share/users/1", "1", "Mozilla/5.0 (iPhone; CPU iPhone OS 14_0 like Mac OS X)", rec)
+
+	usecase := mockShareUseCase{}
+	handler := NewShareHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetUserSharePage(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://www.example.com/users/1", rec.Header().Get(echo.HeaderLocation))
+
+	// 4. Teardown
+	os.Unsetenv("FRONTEND_BASE_URL")
+}
+
+func TestGetUserSharePage_error(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createShareContext("/share/users/1", "1", "Discordbot/2.0", rec)
+
+	usecase := mockShareUseCase{}
+	usecase.On("GetUserSharePage", 1).Return(nil, errors.New("error"))
+	handler := NewShareHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetUserSharePage(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestGetUserSharePage_error_notFound(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createShareContext("/share/users/1", "1", "Discordbot/2.0", rec)
+
+	mockUseCase := mockShareUseCase{}
+	mockUseCase.On("GetUserSharePage", 1).Return(nil, usecase.ErrUserNotFound)
+	handler := NewShareHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetUserSharePage(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// 4. Teardown
+}
+
+// まとめのリンクプレビュー用ページ取得テスト(ブラウザ)
+func TestGetCollectionSharePage_success_browser(t *testing.T) {
+	// 1. Setup
+	os.Setenv("FRONTEND_BASE_URL", "https://www.example.com")
+	rec := httptest.NewRecorder()
+	c := createShareContext("/share/collections/1", "1", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)", rec)
+
+	usecase := mockShareUseCase{}
+	handler := NewShareHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetCollectionSharePage(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://www.example.com/collections/1", rec.Header().Get(echo.HeaderLocation))
+
+	// 4. Teardown
+	os.Unsetenv("FRONTEND_BASE_URL")
+}
+
+func TestGetCollectionSharePage_error_notFound(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createShareContext("/share/collections/1", "1", "Twitterbot/1.0", rec)
+
+	mockUseCase := mockShareUseCase{}
+	mockUseCase.On("GetCollectionSharePage", 1).Return(nil, usecase.ErrCollectionNotFound)
+	handler := NewShareHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.GetCollectionSharePage(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// 4. Teardown
+}
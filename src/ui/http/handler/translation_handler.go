@@ -0,0 +1,132 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"golang.org/x/text/language"
+)
+
+type (
+	// TranslationHandler interface
+	TranslationHandler interface {
+		// 翻訳の登録または更新
+		SaveTranslation(c echo.Context) error
+		// 翻訳一覧取得
+		GetTranslations(c echo.Context) error
+		// 翻訳削除
+		DeleteTranslation(c echo.Context) error
+	}
+
+	// translationHandler 構造体
+	translationHandler struct {
+		TranslationUseCase usecase.TranslationUseCase
+	}
+)
+
+// NewTranslationHandler TranslationHandlerを生成。
+func NewTranslationHandler(usecase usecase.TranslationUseCase) TranslationHandler {
+	return &translationHandler{usecase}
+}
+
+// SaveTranslation 翻訳の登録または更新。ログインユーザーを翻訳者とする。
+func (handler *translationHandler) SaveTranslation(c echo.Context) error {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := new(request.SaveTranslationRequest)
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	request.PostID = postID
+	request.TranslatorID = loginUserID(c)
+	request.Language = c.Param("language")
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err = handler.TranslationUseCase.SaveTranslation(request.PostID, request.TranslatorID, request.Language, request.Title, request.Detail)
+	if err != nil {
+		return c.JSON(translationErrorStatus(err), err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetTranslations 翻訳一覧取得
+func (handler *translationHandler) GetTranslations(c echo.Context) error {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.GetTranslationsRequest{PostID: postID}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	translations, err := handler.TranslationUseCase.GetTranslations(request.PostID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"translations": translations,
+	})
+}
+
+// DeleteTranslation 翻訳削除
+func (handler *translationHandler) DeleteTranslation(c echo.Context) error {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.DeleteTranslationRequest{PostID: postID, LoginUserID: loginUserID(c), Language: c.Param("language")}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err = handler.TranslationUseCase.DeleteTranslation(request.PostID, request.LoginUserID, request.Language)
+	if err != nil {
+		return c.JSON(translationErrorStatus(err), err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// translationErrorStatus 翻訳関連のエラーに対応するHTTPステータスコード
+func translationErrorStatus(err error) int {
+	switch err {
+	case usecase.ErrPostNotFound:
+		return http.StatusNotFound
+	case usecase.ErrTranslationForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// preferredLanguages 希望言語の一覧。langが指定されている場合はlangのみ、
+// 指定されていない場合はAccept-Languageヘッダーの優先度順とする。
+func preferredLanguages(c echo.Context, lang string) []string {
+	if lang != "" {
+		return []string{lang}
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(c.Request().Header.Get("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return nil
+	}
+	languages := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		languages = append(languages, tag.String())
+	}
+	return languages
+}
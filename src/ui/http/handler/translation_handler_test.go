@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockTranslationUseCase struct {
+	mock.Mock
+}
+
+// 翻訳の登録または更新
+func (usecase *mockTranslationUseCase) SaveTranslation(postID, translatorID int, lang, title, detail string) error {
+	return usecase.Called(postID, translatorID, lang, title, detail).Error(0)
+}
+
+// 翻訳一覧取得
+func (usecase *mockTranslationUseCase) GetTranslations(postID int) ([]*model.GetPostTranslationResult, error) {
+	args := usecase.Called(postID)
+	translations, ok := args.Get(0).([]*model.GetPostTranslationResult)
+	if ok {
+		return translations, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 翻訳削除
+func (usecase *mockTranslationUseCase) DeleteTranslation(postID, loginUserID int, lang string) error {
+	return usecase.Called(postID, loginUserID, lang).Error(0)
+}
+
+// 翻訳登録テスト
+func TestSaveTranslation_success(t *testing.T) {
+	// 1. Setup
+	body := `{"title":"Never give up","detail":"detail"}`
+	rec := httptest.NewRecorder()
+	c := createContext(echo.PUT, "/posts/1/translations/en", strings.NewReader(body), rec)
+	c.SetPath("/posts/:id/translations/:language")
+	c.SetParamNames("id", "language")
+	c.SetParamValues("1", "en")
+	setLoginUser(c, 2, model.RoleUser)
+
+	usecase := mockTranslationUseCase{}
+	usecase.On("SaveTranslation", 1, 2, "en", "Never give up", "detail").Return(nil)
+	handler := NewTranslationHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.SaveTranslation(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestSaveTranslation_error_validationError(t *testing.T) {
+	cases := []struct {
+		label    string
+		id       string
+		language string
+		userID   int
+		body     string
+	}{
+		{"ID形式", "a", "en", 2, `{"title":"title"}`},
+		{"ログインユーザー必須", "1", "en", 0, `{"title":"title"}`},
+		{"言語形式", "1", "english!", 2, `{"title":"title"}`},
+		{"タイトル必須", "1", "en", 2, `{"detail":"detail"}`},
+		{"タイトル桁数", "1", "en", 2, `{"title":"` + strings.Repeat("a", 101) + `"}`},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/posts/1/translations/en", strings.NewReader(test.body), rec)
+		c.SetPath("/posts/:id/translations/:language")
+		c.SetParamNames("id", "language")
+		c.SetParamValues(test.id, test.language)
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+
+		usecase := mockTranslationUseCase{}
+		handler := NewTranslationHandler(&usecase)
+
+		// 2. Exercise
+		err := handler.SaveTranslation(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+func TestSaveTranslation_error_usecaseError(t *testing.T) {
+	cases := []struct {
+		label    string
+		err      error
+		expected int
+	}{
+		{"投稿なし", usecase.ErrPostNotFound, http.StatusNotFound},
+		{"権限なし", usecase.ErrTranslationForbidden, http.StatusForbidden},
+		{"その他", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		body := `{"title":"title"}`
+		rec := httptest.NewRecorder()
+		c := createContext(echo.PUT, "/posts/1/translations/en", strings.NewReader(body), rec)
+		c.SetPath("/posts/:id/translations/:language")
+		c.SetParamNames("id", "language")
+		c.SetParamValues("1", "en")
+		setLoginUser(c, 2, model.RoleUser)
+
+		mockUseCase := mockTranslationUseCase{}
+		mockUseCase.On("SaveTranslation", 1, 2, "en", "title", "").Return(test.err)
+		handler := NewTranslationHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.SaveTranslation(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// 翻訳一覧取得テスト
+func TestGetTranslations_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/1/translations", nil, rec)
+	c.SetPath("/posts/:id/translations")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	expected := []*model.GetPostTranslationResult{
+		{PostTranslation: model.PostTranslation{ID: 1, PostID: 1, Language: "en", Title: "title", TranslatorID: 2}, TranslatorName: "username2"},
+	}
+	usecase := mockTranslationUseCase{}
+	usecase.On("GetTranslations", 1).Return(expected, nil)
+	handler := NewTranslationHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetTranslations(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string][]*model.GetPostTranslationResult
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+	assert.Equal(t, 1, len(actual["translations"]))
+	assert.Equal(t, "en", actual["translations"][0].Language)
+	assert.Equal(t, "username2", actual["translations"][0].TranslatorName)
+
+	// 4. Teardown
+}
+
+func TestGetTranslations_error_validationError(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/a/translations", nil, rec)
+	c.SetPath("/posts/:id/translations")
+	c.SetParamNames("id")
+	c.SetParamValues("a")
+
+	usecase := mockTranslationUseCase{}
+	handler := NewTranslationHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetTranslations(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestGetTranslations_error_usecaseError(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.GET, "/posts/1/translations", nil, rec)
+	c.SetPath("/posts/:id/translations")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	usecase := mockTranslationUseCase{}
+	usecase.On("GetTranslations", 1).Return(nil, errors.New("error"))
+	handler := NewTranslationHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.GetTranslations(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// 4. Teardown
+}
+
+// 翻訳削除テスト
+func TestDeleteTranslation_success(t *testing.T) {
+	// 1. Setup
+	rec := httptest.NewRecorder()
+	c := createContext(echo.DELETE, "/posts/1/translations/en-US", nil, rec)
+	c.SetPath("/posts/:id/translations/:language")
+	c.SetParamNames("id", "language")
+	c.SetParamValues("1", "en-US")
+	setLoginUser(c, 1, model.RoleUser)
+
+	usecase := mockTranslationUseCase{}
+	usecase.On("DeleteTranslation", 1, 1, "en-US").Return(nil)
+	handler := NewTranslationHandler(&usecase)
+
+	// 2. Exercise
+	err := handler.DeleteTranslation(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 4. Teardown
+}
+
+func TestDeleteTranslation_error_usecaseError(t *testing.T) {
+	cases := []struct {
+		label    string
+		err      error
+		expected int
+	}{
+		{"投稿なし", usecase.ErrPostNotFound, http.StatusNotFound},
+		{"権限なし", usecase.ErrTranslationForbidden, http.StatusForbidden},
+		{"その他", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.DELETE, "/posts/1/translations/en", nil, rec)
+		c.SetPath("/posts/:id/translations/:language")
+		c.SetParamNames("id", "language")
+		c.SetParamValues("1", "en")
+		setLoginUser(c, 2, model.RoleUser)
+
+		mockUseCase := mockTranslationUseCase{}
+		mockUseCase.On("DeleteTranslation", 1, 2, "en").Return(test.err)
+		handler := NewTranslationHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.DeleteTranslation(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// 希望言語テスト
+func TestPreferredLanguages(t *testing.T) {
+	cases := []struct {
+		label          string
+		lang           string
+		acceptLanguage string
+		expected       []string
+	}{
+		{"lang指定", "en", "fr", []string{"en"}},
+		{"Accept-Language", "", "fr-CH, fr;q=0.9, en;q=0.8", []string{"fr-CH", "fr", "en"}},
+		{"指定なし", "", "", nil},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/posts/1", nil, rec)
+		if test.acceptLanguage != "" {
+			c.Request().Header.Set("Accept-Language", test.acceptLanguage)
+		}
+
+		// 2. Exercise
+		actual := preferredLanguages(c, test.lang)
+
+		// 3. Verify
+		assert.Equal(t, test.expected, actual, test.label)
+
+		// 4. Teardown
+	}
+}
@@ -0,0 +1,99 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// TrashHandler interface
+	TrashHandler interface {
+		// ゴミ箱の一覧取得
+		GetTrash(c echo.Context) error
+		// 投稿の復元
+		RestorePost(c echo.Context) error
+		// コメントの復元
+		RestoreComment(c echo.Context) error
+	}
+
+	// trashHandler 構造体
+	trashHandler struct {
+		TrashUseCase usecase.TrashUseCase
+	}
+)
+
+// NewTrashHandler TrashHandlerを生成。
+func NewTrashHandler(usecase usecase.TrashUseCase) TrashHandler {
+	return &trashHandler{usecase}
+}
+
+// GetTrash ゴミ箱の一覧取得。ログインユーザーが削除した投稿、コメントを返す。
+func (handler *trashHandler) GetTrash(c echo.Context) error {
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "limit：数値で入力してください。")
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "page：数値で入力してください。")
+	}
+
+	request := &request.GetTrashRequest{
+		TargetType: c.QueryParam("target_type"),
+		Limit:      limit,
+		Page:       page,
+	}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	totalCount, items, err := handler.TrashUseCase.GetTrash(loginUserID(c), request.TargetType, request.Limit, request.Page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"totalCount": totalCount,
+		"items":      items,
+	})
+}
+
+// RestorePost 投稿の復元。本人が削除した投稿が存在しない場合は404、復元できる期間を過ぎている場合は410を返す。
+func (handler *trashHandler) RestorePost(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	return restoreResponse(c, handler.TrashUseCase.RestorePost(id, loginUserID(c)))
+}
+
+// RestoreComment コメントの復元。本人が削除したコメントが存在しない場合は404、復元できる期間を過ぎている場合は410を返す。
+func (handler *trashHandler) RestoreComment(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	return restoreResponse(c, handler.TrashUseCase.RestoreComment(id, loginUserID(c)))
+}
+
+// restoreResponse 復元の結果に応じたレスポンスを返す
+func restoreResponse(c echo.Context, err error) error {
+	if err == usecase.ErrTrashItemNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err == usecase.ErrRestorePeriodExpired {
+		return c.JSON(http.StatusGone, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
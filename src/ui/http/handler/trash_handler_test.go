@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockTrashUseCase struct {
+	mock.Mock
+}
+
+// ゴミ箱の一覧取得
+func (usecase *mockTrashUseCase) GetTrash(userID int, targetType string, limit, page int) (int, []*model.TrashItem, error) {
+	args := usecase.Called(userID, targetType, limit, page)
+	items, ok := args.Get(1).([]*model.TrashItem)
+	if ok {
+		return args.Int(0), items, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// 投稿の復元
+func (usecase *mockTrashUseCase) RestorePost(id, userID int) error {
+	return usecase.Called(id, userID).Error(0)
+}
+
+// コメントの復元
+func (usecase *mockTrashUseCase) RestoreComment(id, userID int) error {
+	return usecase.Called(id, userID).Error(0)
+}
+
+// 保持期間を過ぎたデータの完全削除
+func (usecase *mockTrashUseCase) PurgeExpired() (*model.PurgeResult, error) {
+	args := usecase.Called()
+	result, ok := args.Get(0).(*model.PurgeResult)
+	if ok {
+		return result, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// ゴミ箱の一覧取得テスト
+func TestGetTrash(t *testing.T) {
+	cases := []struct {
+		label  string
+		query  string
+		status int
+	}{
+		{"成功", "?limit=10&page=1", http.StatusOK},
+		{"対象の種類で絞り込み", "?limit=10&page=1&target_type=post", http.StatusOK},
+		{"page必須", "?limit=10", http.StatusUnprocessableEntity},
+		{"対象の種類不正", "?limit=10&page=1&target_type=user", http.StatusUnprocessableEntity},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/trash"+test.query, nil, rec)
+		setLoginUser(c, 1, model.RoleUser)
+
+		mockUseCase := mockTrashUseCase{}
+		mockUseCase.On("GetTrash", 1, mock.AnythingOfType("string"), 10, 1).Return(1, []*model.TrashItem{{TargetType: "post", TargetID: 1}}, nil)
+		handler := NewTrashHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetTrash(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// 投稿、コメントの復元テスト
+func TestRestore(t *testing.T) {
+	cases := []struct {
+		label  string
+		id     string
+		err    error
+		status int
+	}{
+		{"成功", "1", nil, http.StatusOK},
+		{"ID形式", "a", nil, http.StatusUnprocessableEntity},
+		{"対象なし", "1", usecase.ErrTrashItemNotFound, http.StatusNotFound},
+		{"期限切れ", "1", usecase.ErrRestorePeriodExpired, http.StatusGone},
+		{"その他", "1", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, target := range []string{"posts", "comments"} {
+		for _, test := range cases {
+			// 1. Setup
+			rec := httptest.NewRecorder()
+			c := createContext(echo.POST, "/"+target+"/"+test.id+"/restore", nil, rec)
+			c.SetPath("/" + target + "/:id/restore")
+			c.SetParamNames("id")
+			c.SetParamValues(test.id)
+			setLoginUser(c, 2, model.RoleUser)
+
+			mockUseCase := mockTrashUseCase{}
+			mockUseCase.On("RestorePost", 1, 2).Return(test.err)
+			mockUseCase.On("RestoreComment", 1, 2).Return(test.err)
+			handler := NewTrashHandler(&mockUseCase)
+
+			// 2. Exercise
+			var err error
+			if target == "posts" {
+				err = handler.RestorePost(c)
+			} else {
+				err = handler.RestoreComment(c)
+			}
+
+			// 3. Verify
+			assert.NoError(t, err, target+" "+test.label)
+			assert.Equal(t, test.status, rec.Code, target+" "+test.label)
+
+			// 4. Teardown
+		}
+	}
+}
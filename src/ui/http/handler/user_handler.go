@@ -23,6 +23,8 @@ type (
 		GetUser(c echo.Context) error
 		UpdateUser(c echo.Context) error
 		DeleteUser(c echo.Context) error
+		// 利用停止中のユーザーを拒否するミドルウェア
+		RequireActiveUser(next echo.HandlerFunc) echo.HandlerFunc
 	}
 
 	// userHandler 構造体
@@ -92,6 +94,9 @@ func (handler *userHandler) Login(c echo.Context) error {
 	}
 
 	userID, token, err := handler.UserUseCase.Login(request.Email, request.Password)
+	if err == usecase.ErrUserSuspended {
+		return c.JSON(http.StatusForbidden, err.Error())
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
 	}
@@ -102,6 +107,30 @@ func (handler *userHandler) Login(c echo.Context) error {
 	})
 }
 
+// RequireActiveUser JWTトークンのユーザーが利用停止中、退会済みの場合にリクエストを拒否するミドルウェア。
+// ログイン時だけでなくリクエストごとに確認するため、利用停止前に発行したトークンも使用できなくなる。
+// トークンが指定されていない場合(ログイン任意の場合)は確認しない。
+func (handler *userHandler) RequireActiveUser(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID := loginUserID(c)
+		if userID == 0 {
+			return next(c)
+		}
+
+		err := handler.UserUseCase.CheckActiveUser(userID)
+		if err == usecase.ErrUserSuspended {
+			return c.JSON(http.StatusForbidden, err.Error())
+		}
+		if err == usecase.ErrUserNotFound {
+			return c.JSON(http.StatusUnauthorized, err.Error())
+		}
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err.Error())
+		}
+		return next(c)
+	}
+}
+
 // GetUser 詳細取得
 func (handler *userHandler) GetUser(c echo.Context) error {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -143,6 +172,7 @@ func (handler *userHandler) UpdateUser(c echo.Context) error {
 		request.Email,
 		request.Password,
 		request.ImageFilePath,
+		request.TimeZone,
 	)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
 	"github.com/k-kazuya0926/power-phrase2-api/validator"
 	"github.com/labstack/echo"
 	"github.com/stretchr/testify/assert"
@@ -43,8 +44,12 @@ func (usecase *mockUserUseCase) GetUser(id int) (*model.User, error) {
 	return nil, args.Error(1)
 }
 
-func (usecase *mockUserUseCase) UpdateUser(userID int, name, email, password, imageFilePath string) error {
-	return usecase.Called(userID, name, email, password, imageFilePath).Error(0)
+func (usecase *mockUserUseCase) UpdateUser(userID int, name, email, password, imageFilePath, timeZone string) error {
+	return usecase.Called(userID, name, email, password, imageFilePath, timeZone).Error(0)
+}
+
+func (usecase *mockUserUseCase) CheckActiveUser(id int) error {
+	return usecase.Called(id).Error(0)
 }
 
 func (usecase *mockUserUseCase) DeleteUser(id int) error {
@@ -243,6 +248,65 @@ func TestLogin_error_usecaseError(t *testing.T) {
 	// 4. Teardown
 }
 
+func TestLogin_error_suspended(t *testing.T) {
+	// 1. Setup
+	email := "testuser@example.com"
+	password := "testuser"
+	reader := strings.NewReader(fmt.Sprintf(`{"email": "%s", "password": "%s"}`, email, password))
+	rec := httptest.NewRecorder()
+	c := createContext(echo.POST, "/users", reader, rec)
+
+	mockUseCase := mockUserUseCase{}
+	mockUseCase.On("Login", email, password).Return(0, "", usecase.ErrUserSuspended)
+	handler := NewUserHandler(&mockUseCase)
+
+	// 2. Exercise
+	err := handler.Login(c)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// 4. Teardown
+}
+
+// 利用停止中のユーザーを拒否するミドルウェアのテスト
+func TestRequireActiveUser(t *testing.T) {
+	cases := []struct {
+		label    string
+		userID   int
+		err      error
+		expected int
+	}{
+		{"ログインしていない", 0, nil, http.StatusOK},
+		{"利用可能", 1, nil, http.StatusOK},
+		{"利用停止中", 2, usecase.ErrUserSuspended, http.StatusForbidden},
+		{"退会済み", 3, usecase.ErrUserNotFound, http.StatusUnauthorized},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/feed", nil, rec)
+		if test.userID > 0 {
+			setLoginUser(c, test.userID, model.RoleUser)
+		}
+		mockUseCase := mockUserUseCase{}
+		mockUseCase.On("CheckActiveUser", test.userID).Return(test.err)
+		handler := NewUserHandler(&mockUseCase)
+		next := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+
+		// 2. Exercise
+		err := handler.RequireActiveUser(next)(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
 // ユーザー詳細テスト
 func TestGetUser_success(t *testing.T) {
 	// 1. Setup
@@ -346,7 +410,7 @@ func TestUpdateUser_success(t *testing.T) {
 	c.SetParamValues(fmt.Sprint(1))
 
 	usecase := mockUserUseCase{}
-	usecase.On("UpdateUser", user.ID, user.Name, user.Email, user.Password, user.ImageFilePath).Return(nil)
+	usecase.On("UpdateUser", user.ID, user.Name, user.Email, user.Password, user.ImageFilePath, user.TimeZone).Return(nil)
 	handler := NewUserHandler(&usecase)
 
 	// 2. Exercise
@@ -417,7 +481,7 @@ func TestUpdateUser_error_usecaseError(t *testing.T) {
 	c.SetParamValues(fmt.Sprint(id))
 
 	usecase := mockUserUseCase{}
-	usecase.On("UpdateUser", user.ID, user.Name, user.Email, user.Password, user.ImageFilePath).Return(errors.New("error"))
+	usecase.On("UpdateUser", user.ID, user.Name, user.Email, user.Password, user.ImageFilePath, user.TimeZone).Return(errors.New("error"))
 	handler := NewUserHandler(&usecase)
 
 	// 2. Exercise
@@ -0,0 +1,155 @@
+// Package handler UI層
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+)
+
+type (
+	// WebhookHandler interface
+	WebhookHandler interface {
+		// Webhook一覧取得
+		GetWebhooks(c echo.Context) error
+		// Webhook登録
+		CreateWebhook(c echo.Context) error
+		// Webhook削除
+		DeleteWebhook(c echo.Context) error
+		// Webhookの配信履歴取得
+		GetWebhookDeliveries(c echo.Context) error
+		// Webhookの再配信
+		RedeliverWebhook(c echo.Context) error
+	}
+
+	// webhookHandler 構造体
+	webhookHandler struct {
+		WebhookUseCase usecase.WebhookUseCase
+	}
+)
+
+// NewWebhookHandler WebhookHandlerを生成。
+func NewWebhookHandler(usecase usecase.WebhookUseCase) WebhookHandler {
+	return &webhookHandler{usecase}
+}
+
+// GetWebhooks Webhook一覧取得。管理者のみ実行できる。
+func (handler *webhookHandler) GetWebhooks(c echo.Context) error {
+	webhooks, err := handler.WebhookUseCase.GetWebhooks()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"webhooks": webhooks,
+	})
+}
+
+// CreateWebhook Webhook登録。管理者のみ実行できる。署名の鍵はこの応答でのみ返す。
+func (handler *webhookHandler) CreateWebhook(c echo.Context) error {
+	request := new(request.CreateWebhookRequest)
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	webhook, err := handler.WebhookUseCase.CreateWebhook(request.URL, request.EventTypes)
+	if err == usecase.ErrInvalidWebhookURL || err == usecase.ErrInvalidWebhookEventType {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, webhook)
+}
+
+// DeleteWebhook Webhook削除。管理者のみ実行できる。
+func (handler *webhookHandler) DeleteWebhook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+
+	request := &request.DeleteWebhookRequest{ID: id}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	err = handler.WebhookUseCase.DeleteWebhook(request.ID)
+	if err == usecase.ErrWebhookNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetWebhookDeliveries Webhookの配信履歴取得。管理者のみ実行できる。
+func (handler *webhookHandler) GetWebhookDeliveries(c echo.Context) error {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "limit：数値で入力してください。")
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "page：数値で入力してください。")
+	}
+
+	request := &request.GetWebhookDeliveriesRequest{WebhookID: webhookID, Limit: limit, Page: page}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	totalCount, deliveries, err := handler.WebhookUseCase.GetWebhookDeliveries(request.WebhookID, request.Limit, request.Page)
+	if err == usecase.ErrWebhookNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"totalCount": totalCount,
+		"deliveries": deliveries,
+	})
+}
+
+// RedeliverWebhook Webhookの再配信。管理者のみ実行できる。同じ内容を新しい配信として送信し、その結果を返す。
+func (handler *webhookHandler) RedeliverWebhook(c echo.Context) error {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "ID：数値で入力してください。")
+	}
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, "DeliveryID：数値で入力してください。")
+	}
+
+	request := &request.RedeliverWebhookRequest{WebhookID: webhookID, DeliveryID: deliveryID}
+	if err := c.Validate(request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	delivery, err := handler.WebhookUseCase.RedeliverWebhook(request.WebhookID, request.DeliveryID)
+	if err == usecase.ErrWebhookNotFound {
+		return c.JSON(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, delivery)
+}
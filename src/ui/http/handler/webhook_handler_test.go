@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockWebhookUseCase struct {
+	mock.Mock
+}
+
+// Webhook一覧取得
+func (usecase *mockWebhookUseCase) GetWebhooks() ([]*model.Webhook, error) {
+	args := usecase.Called()
+	webhooks, ok := args.Get(0).([]*model.Webhook)
+	if ok {
+		return webhooks, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// Webhook登録
+func (usecase *mockWebhookUseCase) CreateWebhook(url string, eventTypes []string) (*model.Webhook, error) {
+	args := usecase.Called(url, eventTypes)
+	webhook, ok := args.Get(0).(*model.Webhook)
+	if ok {
+		return webhook, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// Webhook削除
+func (usecase *mockWebhookUseCase) DeleteWebhook(id int) error {
+	return usecase.Called(id).Error(0)
+}
+
+// Webhookの配信履歴取得
+func (usecase *mockWebhookUseCase) GetWebhookDeliveries(webhookID, limit, page int) (int, []*model.WebhookDelivery, error) {
+	args := usecase.Called(webhookID, limit, page)
+	deliveries, ok := args.Get(1).([]*model.WebhookDelivery)
+	if ok {
+		return args.Int(0), deliveries, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// Webhookの再配信
+func (usecase *mockWebhookUseCase) RedeliverWebhook(webhookID, deliveryID int) (*model.WebhookDelivery, error) {
+	args := usecase.Called(webhookID, deliveryID)
+	delivery, ok := args.Get(0).(*model.WebhookDelivery)
+	if ok {
+		return delivery, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 再送待ちのWebhookの配信の再送
+func (usecase *mockWebhookUseCase) RetryWebhookDeliveries() (int, error) {
+	args := usecase.Called()
+	return args.Int(0), args.Error(1)
+}
+
+// ドメインイベントのWebhook配信
+func (usecase *mockWebhookUseCase) HandleDomainEvent(event *model.OutboxEvent) error {
+	return usecase.Called(event).Error(0)
+}
+
+// Webhook登録テスト
+func TestCreateWebhook(t *testing.T) {
+	cases := []struct {
+		label  string
+		body   string
+		err    error
+		status int
+	}{
+		{"成功", `{"url":"https://example.com/hook","event_types":["post.created"]}`, nil, http.StatusCreated},
+		{"URL必須", `{"event_types":["post.created"]}`, nil, http.StatusUnprocessableEntity},
+		{"URL不正", `{"url":"example","event_types":["post.created"]}`, nil, http.StatusUnprocessableEntity},
+		{"イベント必須", `{"url":"https://example.com/hook","event_types":[]}`, nil, http.StatusUnprocessableEntity},
+		{"イベント不正", `{"url":"https://example.com/hook","event_types":["post.created"]}`, usecase.ErrInvalidWebhookEventType, http.StatusUnprocessableEntity},
+		{"エラー", `{"url":"https://example.com/hook","event_types":["post.created"]}`, errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.POST, "/admin/webhooks", strings.NewReader(test.body), rec)
+		setLoginUser(c, 1, model.RoleAdmin)
+
+		mockUseCase := mockWebhookUseCase{}
+		if test.err == nil {
+			mockUseCase.On("CreateWebhook", "https://example.com/hook", []string{"post.created"}).Return(&model.Webhook{ID: 1, Secret: "secret"}, nil)
+		} else {
+			mockUseCase.On("CreateWebhook", "https://example.com/hook", []string{"post.created"}).Return(nil, test.err)
+		}
+		handler := NewWebhookHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.CreateWebhook(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+		if test.status == http.StatusCreated {
+			assert.Contains(t, rec.Body.String(), `"secret":"secret"`, test.label)
+		}
+
+		// 4. Teardown
+	}
+}
+
+// Webhook削除テスト
+func TestDeleteWebhook(t *testing.T) {
+	cases := []struct {
+		label  string
+		id     string
+		err    error
+		status int
+	}{
+		{"成功", "1", nil, http.StatusOK},
+		{"ID不正", "a", nil, http.StatusUnprocessableEntity},
+		{"存在しない", "1", usecase.ErrWebhookNotFound, http.StatusNotFound},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.DELETE, "/admin/webhooks/"+test.id, nil, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+
+		mockUseCase := mockWebhookUseCase{}
+		mockUseCase.On("DeleteWebhook", 1).Return(test.err)
+		handler := NewWebhookHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.DeleteWebhook(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// Webhookの配信履歴取得テスト
+func TestGetWebhookDeliveries(t *testing.T) {
+	cases := []struct {
+		label  string
+		query  string
+		err    error
+		status int
+	}{
+		{"成功", "?limit=10&page=1", nil, http.StatusOK},
+		{"limit不正", "?limit=a&page=1", nil, http.StatusUnprocessableEntity},
+		{"page範囲外", "?limit=10&page=0", nil, http.StatusUnprocessableEntity},
+		{"存在しない", "?limit=10&page=1", usecase.ErrWebhookNotFound, http.StatusNotFound},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.GET, "/admin/webhooks/1/deliveries"+test.query, nil, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockUseCase := mockWebhookUseCase{}
+		mockUseCase.On("GetWebhookDeliveries", 1, 10, 1).Return(1, []*model.WebhookDelivery{{ID: 1, WebhookID: 1}}, test.err)
+		handler := NewWebhookHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.GetWebhookDeliveries(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+		if test.status == http.StatusOK {
+			assert.Contains(t, rec.Body.String(), `"totalCount":1`, test.label)
+		}
+
+		// 4. Teardown
+	}
+}
+
+// Webhookの再配信テスト
+func TestRedeliverWebhook(t *testing.T) {
+	cases := []struct {
+		label      string
+		deliveryID string
+		err        error
+		status     int
+	}{
+		{"成功", "5", nil, http.StatusCreated},
+		{"ID不正", "a", nil, http.StatusUnprocessableEntity},
+		{"存在しない", "5", usecase.ErrWebhookNotFound, http.StatusNotFound},
+		{"エラー", "5", errors.New("error"), http.StatusInternalServerError},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		rec := httptest.NewRecorder()
+		c := createContext(echo.POST, "/admin/webhooks/1/deliveries/"+test.deliveryID+"/redeliver", nil, rec)
+		c.SetParamNames("id", "delivery_id")
+		c.SetParamValues("1", test.deliveryID)
+
+		mockUseCase := mockWebhookUseCase{}
+		if test.err == nil {
+			mockUseCase.On("RedeliverWebhook", 1, 5).Return(&model.WebhookDelivery{ID: 6, WebhookID: 1}, nil)
+		} else {
+			mockUseCase.On("RedeliverWebhook", 1, 5).Return(nil, test.err)
+		}
+		handler := NewWebhookHandler(&mockUseCase)
+
+		// 2. Exercise
+		err := handler.RedeliverWebhook(c)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.status, rec.Code, test.label)
+
+		// 4. Teardown
+	}
+}
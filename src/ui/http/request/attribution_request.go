@@ -0,0 +1,27 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// CreateAttributionClaimRequest 出典の証拠・異議登録リクエスト
+	CreateAttributionClaimRequest struct {
+		PostID         int    `json:"post_id" validate:"required,min=1"`
+		UserID         int    `json:"user_id" validate:"required,min=1"`
+		ClaimType      string `json:"claim_type" validate:"required,oneof=evidence dispute"`
+		ProposedStatus string `json:"proposed_status" validate:"omitempty,oneof=disputed misattributed"`
+		Body           string `json:"body" validate:"required,max=1000"`
+		SourceURL      string `json:"source_url" validate:"omitempty,url,max=200"`
+	}
+
+	// GetAttributionClaimsRequest 出典の証拠・異議一覧取得リクエスト
+	GetAttributionClaimsRequest struct {
+		PostID int `json:"post_id" validate:"required,min=1"`
+	}
+
+	// RuleAttributionClaimRequest 出典の証拠・異議審査リクエスト
+	RuleAttributionClaimRequest struct {
+		ID          int    `json:"id" validate:"required,min=1"`
+		ModeratorID int    `json:"moderator_id" validate:"required,min=1"`
+		Status      string `json:"status" validate:"required,oneof=accepted rejected"`
+		Comment     string `json:"comment" validate:"max=500"`
+	}
+)
@@ -0,0 +1,11 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// AutocompleteRequest 入力補完候補取得リクエスト
+	AutocompleteRequest struct {
+		Field string `json:"field" validate:"required,oneof=speaker title"`
+		Q     string `json:"q" validate:"required,max=100"`
+		Limit int    `json:"limit" validate:"min=1,max=50"`
+	}
+)
@@ -0,0 +1,73 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// CreateCollectionRequest まとめ登録リクエスト
+	CreateCollectionRequest struct {
+		UserID      int    `validate:"required,min=1"`
+		Name        string `json:"name" validate:"required,max=50"`
+		Description string `json:"description" validate:"max=500"`
+		IsPublic    bool   `json:"is_public"`
+	}
+
+	// GetCollectionsRequest ユーザーのまとめ一覧取得リクエスト
+	GetCollectionsRequest struct {
+		UserID int `validate:"required,min=1"`
+		Limit  int `validate:"required,min=1"`
+		Page   int `validate:"required,min=1"`
+	}
+
+	// GetCollectionRequest まとめ詳細取得リクエスト
+	GetCollectionRequest struct {
+		ID int `validate:"required,min=1"`
+	}
+
+	// UpdateCollectionRequest まとめ更新リクエスト
+	UpdateCollectionRequest struct {
+		ID          int    `validate:"required,min=1"`
+		UserID      int    `validate:"required,min=1"`
+		Name        string `json:"name" validate:"required,max=50"`
+		Description string `json:"description" validate:"max=500"`
+		CoverPostID int    `json:"cover_post_id" validate:"min=0"`
+		IsPublic    bool   `json:"is_public"`
+	}
+
+	// DeleteCollectionRequest まとめ削除リクエスト
+	DeleteCollectionRequest struct {
+		ID     int `validate:"required,min=1"`
+		UserID int `validate:"required,min=1"`
+	}
+
+	// GetCollectionPostsRequest まとめの投稿一覧取得リクエスト
+	GetCollectionPostsRequest struct {
+		ID    int `validate:"required,min=1"`
+		Limit int `validate:"required,min=1"`
+		Page  int `validate:"required,min=1"`
+	}
+
+	// AddCollectionPostRequest まとめへの投稿追加リクエスト
+	AddCollectionPostRequest struct {
+		ID     int `validate:"required,min=1"`
+		UserID int `validate:"required,min=1"`
+		PostID int `json:"post_id" validate:"required,min=1"`
+	}
+
+	// DeleteCollectionPostRequest まとめからの投稿削除リクエスト
+	DeleteCollectionPostRequest struct {
+		ID     int `validate:"required,min=1"`
+		UserID int `validate:"required,min=1"`
+		PostID int `validate:"required,min=1"`
+	}
+
+	// ReorderCollectionPostsRequest まとめの投稿の並び替えリクエスト
+	ReorderCollectionPostsRequest struct {
+		ID      int   `validate:"required,min=1"`
+		UserID  int   `validate:"required,min=1"`
+		PostIDs []int `json:"post_ids" validate:"required,min=1,max=1000,dive,min=1"`
+	}
+
+	// GetPostCollectionsRequest 投稿を含むまとめ一覧取得リクエスト
+	GetPostCollectionsRequest struct {
+		PostID int `validate:"required,min=1"`
+	}
+)
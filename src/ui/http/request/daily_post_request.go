@@ -0,0 +1,16 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// GetDailyPostRequest 今日の言葉取得リクエスト
+	GetDailyPostRequest struct {
+		TimeZone    string `json:"tz" validate:"omitempty,timezone,max=64"`
+		LoginUserID int    `json:"login_user_id" validate:"min=0"`
+	}
+
+	// PinDailyPostRequest 今日の言葉指定リクエスト
+	PinDailyPostRequest struct {
+		Date   string `json:"date" validate:"required,date"`
+		PostID int    `json:"post_id" validate:"required,min=1"`
+	}
+)
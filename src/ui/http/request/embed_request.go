@@ -0,0 +1,19 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// GetOEmbedRequest oEmbed取得リクエスト
+	GetOEmbedRequest struct {
+		URL       string `json:"url" validate:"required,url,max=500"`
+		Format    string `json:"format" validate:"omitempty,oneof=json xml"`
+		MaxWidth  int    `json:"maxwidth" validate:"min=0"`
+		MaxHeight int    `json:"maxheight" validate:"min=0"`
+		Theme     string `json:"theme" validate:"required,oneof=light dark sepia"`
+	}
+
+	// GetEmbedWidgetRequest 埋め込みウィジェット取得リクエスト
+	GetEmbedWidgetRequest struct {
+		ID    int    `validate:"min=1"`
+		Theme string `json:"theme" validate:"required,oneof=light dark sepia"`
+	}
+)
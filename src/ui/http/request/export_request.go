@@ -0,0 +1,20 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// CreateExportRequest 個人データのエクスポート要求リクエスト
+	CreateExportRequest struct {
+		UserID int `validate:"required,min=1"`
+	}
+
+	// GetExportRequest エクスポートジョブ取得リクエスト
+	GetExportRequest struct {
+		ID     int `validate:"required,min=1"`
+		UserID int `validate:"required,min=1"`
+	}
+
+	// DownloadExportRequest エクスポートしたファイルのダウンロードリクエスト
+	DownloadExportRequest struct {
+		Token string `validate:"required,hexadecimal,len=64"`
+	}
+)
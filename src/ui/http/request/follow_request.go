@@ -0,0 +1,29 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// FollowRequest フォロー・フォロー解除リクエスト
+	FollowRequest struct {
+		FollowerID int `validate:"required,min=1"`
+		FolloweeID int `validate:"required,min=1"`
+	}
+
+	// GetFollowUsersRequest フォロワー、フォロー中のユーザー一覧取得リクエスト
+	GetFollowUsersRequest struct {
+		UserID int `validate:"required,min=1"`
+		Limit  int `json:"limit" validate:"required,min=1"`
+		Page   int `json:"page" validate:"required,min=1"`
+	}
+
+	// GetFollowCountsRequest フォロワー数、フォロー数取得リクエスト
+	GetFollowCountsRequest struct {
+		UserID int `validate:"required,min=1"`
+	}
+
+	// GetFeedRequest フィード取得リクエスト
+	GetFeedRequest struct {
+		UserID int `validate:"required,min=1"`
+		Cursor int `json:"cursor" validate:"min=0"`
+		Limit  int `json:"limit" validate:"min=1,max=100"`
+	}
+)
@@ -0,0 +1,20 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// ImportPostsRequest 投稿の一括登録リクエスト。ファイルはmultipart/form-dataのFileで受け取る。
+	ImportPostsRequest struct {
+		UserID int `validate:"required,min=1"`
+		// ファイル形式。空文字の場合はファイル名の拡張子から判定する。
+		Format         string `validate:"omitempty,oneof=csv json"`
+		DryRun         bool
+		Atomic         bool
+		AllowDuplicate bool
+	}
+
+	// GetImportJobRequest 一括登録ジョブ取得リクエスト
+	GetImportJobRequest struct {
+		ID     int `validate:"required,min=1"`
+		UserID int `validate:"required,min=1"`
+	}
+)
@@ -0,0 +1,30 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// GetNotificationsRequest 通知一覧取得リクエスト
+	GetNotificationsRequest struct {
+		UserID     int  `validate:"required,min=1"`
+		Limit      int  `json:"limit" validate:"required,min=1"`
+		Page       int  `json:"page" validate:"required,min=1"`
+		UnreadOnly bool `json:"unread_only"`
+	}
+
+	// NotificationUserRequest ログインユーザーの通知を対象とするリクエスト(未読件数取得、すべて既読、受け取り設定取得)
+	NotificationUserRequest struct {
+		UserID int `validate:"required,min=1"`
+	}
+
+	// MarkNotificationReadRequest 通知の既読リクエスト
+	MarkNotificationReadRequest struct {
+		ID     int `validate:"required,min=1"`
+		UserID int `validate:"required,min=1"`
+	}
+
+	// UpdateNotificationSettingRequest 通知の受け取り設定の更新リクエスト
+	UpdateNotificationSettingRequest struct {
+		UserID  int    `validate:"required,min=1"`
+		Type    string `validate:"required,max=16"`
+		Enabled bool   `json:"enabled"`
+	}
+)
@@ -9,21 +9,43 @@ type (
 		Speaker  string `validate:"required,max=100"`
 		Detail   string `validate:"max=500"`
 		MovieURL string `json:"movie_url" validate:"max=200"`
+		License  string `json:"license" validate:"omitempty,oneof=all_rights_reserved public_domain cc0 cc_by cc_by_sa cc_by_nc"`
+		Language string `json:"language" validate:"omitempty,language,max=16"`
+		// 投稿の分類。最大5件
+		Tags []string `json:"tags" validate:"max=5,dive,max=30"`
+		PostSourceRequest
+		// trueの場合は類似した投稿があっても登録する
+		AllowDuplicate bool `json:"allow_duplicate"`
+	}
+
+	// PostSourceRequest 投稿の出典。出典の種類ごとの入力チェックはValidatePostSourceで行う。
+	PostSourceRequest struct {
+		SourceType      string `json:"source_type" validate:"omitempty,oneof=book speech interview web video"`
+		SourceTitle     string `json:"source_title" validate:"max=100"`
+		SourceLocator   string `json:"source_locator" validate:"max=20"`
+		PublicationYear int    `json:"publication_year" validate:"min=0,max=9999"`
+		SourceURL       string `json:"source_url" validate:"omitempty,url,max=200"`
 	}
 
 	// GetPostsRequest 投稿一覧取得リクエスト
 	GetPostsRequest struct {
-		Limit       int    `json:"limit" validate:"required,min=1"`
-		Page        int    `json:"page" validate:"required,min=1"`
-		Keyword     string `json:"keyword" validate:"max=100"`
-		PostUserID  int    `json:"post_user_id" validate:"min=0"`
-		LoginUserID int    `json:"login_user_id" validate:"min=0"`
+		Limit        int    `json:"limit" validate:"required,min=1"`
+		Page         int    `json:"page" validate:"required,min=1"`
+		Keyword      string `json:"keyword" validate:"max=100"`
+		PostUserID   int    `json:"post_user_id" validate:"min=0"`
+		LoginUserID  int    `json:"login_user_id" validate:"min=0"`
+		VerifiedOnly bool   `json:"verified_only"`
+		// 原文または翻訳がこの言語である投稿に限定する
+		Language string `json:"language" validate:"omitempty,language,max=16"`
+		// 表示する言語
+		Lang string `json:"lang" validate:"omitempty,language,max=16"`
 	}
 
 	// GetPostRequest 投稿詳細取得リクエスト
 	GetPostRequest struct {
-		ID          int `validate:"min=1"`
-		LoginUserID int `json:"login_user_id" validate:"min=0"`
+		ID          int    `validate:"min=1"`
+		LoginUserID int    `json:"login_user_id" validate:"min=0"`
+		Lang        string `json:"lang" validate:"omitempty,language,max=16"`
 	}
 
 	// UpdatePostRequest 投稿更新リクエスト
@@ -33,6 +55,11 @@ type (
 		Speaker  string `validate:"required,max=100"`
 		Detail   string `validate:"max=500"`
 		MovieURL string `json:"movie_url" validate:"max=200"`
+		License  string `json:"license" validate:"omitempty,oneof=all_rights_reserved public_domain cc0 cc_by cc_by_sa cc_by_nc"`
+		Language string `json:"language" validate:"omitempty,language,max=16"`
+		// 投稿の分類。最大5件
+		Tags []string `json:"tags" validate:"max=5,dive,max=30"`
+		PostSourceRequest
 	}
 
 	// DeletePostRequest 投稿削除リクエスト
@@ -42,15 +69,27 @@ type (
 
 	// CreateFavoriteRequest お気に入り登録リクエスト
 	CreateFavoriteRequest struct {
-		UserID int `json:"user_id" validate:"required,min=1"`
-		PostID int `json:"post_id" validate:"required,min=1"`
+		UserID int    `json:"user_id" validate:"required,min=1"`
+		PostID int    `json:"post_id" validate:"required,min=1"`
+		Note   string `json:"note" validate:"max=1000"`
+		Tag    string `json:"tag" validate:"max=30"`
 	}
 
 	// GetFavoritesRequest お気に入り一覧取得リクエスト
 	GetFavoritesRequest struct {
-		UserID int `json:"user_id" validate:"required,min=1"`
-		Limit  int `json:"limit" validate:"required,min=1"`
-		Page   int `json:"page" validate:"required,min=1"`
+		UserID  int    `json:"user_id" validate:"required,min=1"`
+		Limit   int    `json:"limit" validate:"required,min=1"`
+		Page    int    `json:"page" validate:"required,min=1"`
+		Keyword string `json:"keyword" validate:"max=100"`
+		Tag     string `json:"tag" validate:"max=30"`
+	}
+
+	// UpdateFavoriteNoteRequest お気に入りのメモ、理由の分類の更新リクエスト
+	UpdateFavoriteNoteRequest struct {
+		UserID int    `validate:"required,min=1"`
+		PostID int    `validate:"required,min=1"`
+		Note   string `json:"note" validate:"max=1000"`
+		Tag    string `json:"tag" validate:"max=30"`
 	}
 
 	// DeleteFavoriteRequest お気に入り削除リクエスト
@@ -58,4 +97,11 @@ type (
 		UserID int `json:"user_id" validate:"required,min=1"`
 		PostID int `json:"post_id" validate:"required,min=1"`
 	}
+
+	// GetPostStatsRequest 投稿の統計取得リクエスト
+	GetPostStatsRequest struct {
+		UserID int `validate:"required,min=1"`
+		PostID int `validate:"min=0"`
+		Days   int `json:"days" validate:"required,min=1,max=365"`
+	}
 )
@@ -0,0 +1,59 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+import (
+	"regexp"
+
+	"gopkg.in/go-playground/validator.v9"
+)
+
+var (
+	// pagePattern ページ番号の形式
+	pagePattern = regexp.MustCompile(`^(pp?\.)?\d+(-\d+)?$`)
+	// timestampPattern 再生位置の形式
+	timestampPattern = regexp.MustCompile(`^(\d+:)?\d{1,2}:\d{2}$`)
+)
+
+// ValidatePostSource 出典の種類ごとに必須項目と形式をチェックする。PostSourceRequestの構造体単位のバリデーションとして登録する。
+func ValidatePostSource(sl validator.StructLevel) {
+	source := sl.Current().Interface().(PostSourceRequest)
+
+	switch source.SourceType {
+	case "":
+		// 出典の種類を指定しない場合は、出典の各項目を入力できない
+		if source.SourceTitle != "" {
+			sl.ReportError(source.SourceTitle, "SourceTitle", "source_title", "excluded", "")
+		}
+		if source.SourceLocator != "" {
+			sl.ReportError(source.SourceLocator, "SourceLocator", "source_locator", "excluded", "")
+		}
+		if source.PublicationYear != 0 {
+			sl.ReportError(source.PublicationYear, "PublicationYear", "publication_year", "excluded", "")
+		}
+		if source.SourceURL != "" {
+			sl.ReportError(source.SourceURL, "SourceURL", "source_url", "excluded", "")
+		}
+	case "book":
+		if source.SourceTitle == "" {
+			sl.ReportError(source.SourceTitle, "SourceTitle", "source_title", "required", "")
+		}
+		if source.SourceLocator != "" && !pagePattern.MatchString(source.SourceLocator) {
+			sl.ReportError(source.SourceLocator, "SourceLocator", "source_locator", "page", "")
+		}
+	case "speech", "interview":
+		if source.SourceTitle == "" {
+			sl.ReportError(source.SourceTitle, "SourceTitle", "source_title", "required", "")
+		}
+	case "web":
+		if source.SourceURL == "" {
+			sl.ReportError(source.SourceURL, "SourceURL", "source_url", "required", "")
+		}
+	case "video":
+		if source.SourceURL == "" {
+			sl.ReportError(source.SourceURL, "SourceURL", "source_url", "required", "")
+		}
+		if source.SourceLocator != "" && !timestampPattern.MatchString(source.SourceLocator) {
+			sl.ReportError(source.SourceLocator, "SourceLocator", "source_locator", "timestamp", "")
+		}
+	}
+}
@@ -0,0 +1,15 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// CreateProhibitedWordRequest 禁止語登録リクエスト
+	CreateProhibitedWordRequest struct {
+		Word   string `json:"word" validate:"required,max=100"`
+		Action string `json:"action" validate:"required,oneof=block mask review"`
+	}
+
+	// DeleteProhibitedWordRequest 禁止語削除リクエスト
+	DeleteProhibitedWordRequest struct {
+		ID int `json:"id" validate:"required,min=1"`
+	}
+)
@@ -0,0 +1,11 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// GetQuoteCardRequest 引用カード画像取得リクエスト
+	GetQuoteCardRequest struct {
+		ID    int    `validate:"min=1"`
+		Theme string `json:"theme" validate:"required,oneof=light dark sepia"`
+		Size  string `json:"size" validate:"required,oneof=ogp square story"`
+	}
+)
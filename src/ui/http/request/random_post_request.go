@@ -0,0 +1,15 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// GetRandomPostsRequest ランダム投稿取得リクエスト
+	GetRandomPostsRequest struct {
+		Count            int    `json:"count" validate:"min=1,max=50"`
+		Speaker          string `json:"speaker" validate:"max=100"`
+		Tag              string `json:"tag" validate:"max=30"`
+		SourceType       string `json:"source_type" validate:"omitempty,oneof=book speech interview web video"`
+		MinFavoriteCount int    `json:"min_favorite_count" validate:"min=0"`
+		ExcludeSeen      bool   `json:"exclude_seen"`
+		ExcludeFavorited bool   `json:"exclude_favorited"`
+	}
+)
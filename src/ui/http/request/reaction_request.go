@@ -0,0 +1,19 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// ReactionRequest リアクション登録・削除リクエスト
+	ReactionRequest struct {
+		UserID int    `validate:"required,min=1"`
+		PostID int    `validate:"required,min=1"`
+		Type   string `validate:"required,max=32"`
+	}
+
+	// GetReactionsRequest リアクションしたユーザー一覧取得リクエスト
+	GetReactionsRequest struct {
+		PostID int    `validate:"required,min=1"`
+		Type   string `json:"type" validate:"max=32"`
+		Limit  int    `json:"limit" validate:"required,min=1"`
+		Page   int    `json:"page" validate:"required,min=1"`
+	}
+)
@@ -0,0 +1,15 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// CreateStreamTicketRequest リアルタイム配信のチケット発行リクエスト
+	CreateStreamTicketRequest struct {
+		UserID int `validate:"required,min=1"`
+	}
+
+	// SubscribeRealtimeRequest リアルタイム配信の購読リクエスト
+	SubscribeRealtimeRequest struct {
+		UserID  int   `validate:"required,min=1"`
+		PostIDs []int `json:"post_id" validate:"max=20,dive,min=1"`
+	}
+)
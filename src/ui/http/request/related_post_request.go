@@ -0,0 +1,11 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// GetRelatedPostsRequest 関連する投稿一覧取得リクエスト
+	GetRelatedPostsRequest struct {
+		ID          int `validate:"required,min=1"`
+		Limit       int `json:"limit" validate:"min=1,max=20"`
+		LoginUserID int `json:"login_user_id" validate:"min=0"`
+	}
+)
@@ -0,0 +1,35 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// CreateReportRequest 通報リクエスト
+	CreateReportRequest struct {
+		ReporterID int    `json:"reporter_id" validate:"required,min=1"`
+		TargetType string `json:"target_type" validate:"required,oneof=post comment user"`
+		TargetID   int    `json:"target_id" validate:"required,min=1"`
+		ReasonCode string `json:"reason_code" validate:"required,oneof=spam harassment hate_speech misinformation copyright other"`
+		Detail     string `json:"detail" validate:"max=500"`
+	}
+
+	// GetReportQueueRequest 未対応の通報の対象一覧取得リクエスト
+	GetReportQueueRequest struct {
+		TargetType string `json:"target_type" validate:"omitempty,oneof=post comment user"`
+		Limit      int    `json:"limit" validate:"required,min=1"`
+		Page       int    `json:"page" validate:"required,min=1"`
+	}
+
+	// GetReportTargetRequest 通報の対象取得リクエスト
+	GetReportTargetRequest struct {
+		TargetType string `json:"target_type" validate:"required,oneof=post comment user"`
+		TargetID   int    `json:"target_id" validate:"required,min=1"`
+	}
+
+	// ModerateTargetRequest モデレーターの対応リクエスト
+	ModerateTargetRequest struct {
+		ModeratorID int    `json:"moderator_id" validate:"required,min=1"`
+		TargetType  string `json:"target_type" validate:"required,oneof=post comment user"`
+		TargetID    int    `json:"target_id" validate:"required,min=1"`
+		Action      string `json:"action" validate:"required,oneof=dismiss hide delete warn suspend"`
+		Reason      string `json:"reason" validate:"required,max=500"`
+	}
+)
@@ -0,0 +1,9 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// GetSharePageRequest リンクプレビュー用ページ取得リクエスト
+	GetSharePageRequest struct {
+		ID int `validate:"min=1"`
+	}
+)
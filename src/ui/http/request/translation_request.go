@@ -0,0 +1,25 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// SaveTranslationRequest 翻訳の登録または更新リクエスト
+	SaveTranslationRequest struct {
+		PostID       int    `validate:"required,min=1"`
+		TranslatorID int    `validate:"required,min=1"`
+		Language     string `validate:"required,language,max=16"`
+		Title        string `json:"title" validate:"required,max=100"`
+		Detail       string `json:"detail" validate:"max=500"`
+	}
+
+	// GetTranslationsRequest 翻訳一覧取得リクエスト
+	GetTranslationsRequest struct {
+		PostID int `validate:"required,min=1"`
+	}
+
+	// DeleteTranslationRequest 翻訳削除リクエスト
+	DeleteTranslationRequest struct {
+		PostID      int    `validate:"required,min=1"`
+		LoginUserID int    `validate:"required,min=1"`
+		Language    string `validate:"required,language,max=16"`
+	}
+)
@@ -0,0 +1,11 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// GetTrashRequest ゴミ箱の一覧取得リクエスト
+	GetTrashRequest struct {
+		TargetType string `json:"target_type" validate:"omitempty,oneof=post comment"`
+		Limit      int    `json:"limit" validate:"required,min=1"`
+		Page       int    `json:"page" validate:"required,min=1"`
+	}
+)
@@ -28,6 +28,7 @@ type (
 		Email         string `json:"email" validate:"required,email,max=100"`
 		Password      string `json:"password" validate:"max=100"`
 		ImageFilePath string `json:"image_file_path" validate:"max=100"`
+		TimeZone      string `json:"time_zone" validate:"omitempty,timezone,max=64"`
 	}
 
 	// DeleteUserRequest ユーザー削除リクエスト
@@ -0,0 +1,28 @@
+// Package request リクエストを表す構造体を定義
+package request
+
+type (
+	// CreateWebhookRequest Webhook登録リクエスト
+	CreateWebhookRequest struct {
+		URL        string   `json:"url" validate:"required,url,max=512"`
+		EventTypes []string `json:"event_types" validate:"required,min=1,dive,required"`
+	}
+
+	// DeleteWebhookRequest Webhook削除リクエスト
+	DeleteWebhookRequest struct {
+		ID int `json:"id" validate:"required,min=1"`
+	}
+
+	// GetWebhookDeliveriesRequest Webhookの配信履歴取得リクエスト
+	GetWebhookDeliveriesRequest struct {
+		WebhookID int `validate:"required,min=1"`
+		Limit     int `json:"limit" validate:"required,min=1"`
+		Page      int `json:"page" validate:"required,min=1"`
+	}
+
+	// RedeliverWebhookRequest Webhookの再配信リクエスト
+	RedeliverWebhookRequest struct {
+		WebhookID  int `validate:"required,min=1"`
+		DeliveryID int `validate:"required,min=1"`
+	}
+)
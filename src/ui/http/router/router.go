@@ -2,8 +2,11 @@
 package router
 
 import (
+	"net/http"
 	"os"
 
+	"github.com/dgrijalva/jwt-go"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
 	"github.com/k-kazuya0926/power-phrase2-api/ui/http/handler"
 	"github.com/labstack/echo"
 	"github.com/labstack/echo/middleware"
@@ -20,30 +23,169 @@ func SetRoutes(e *echo.Echo, handler handler.AppHandler) {
 
 	e.Static("/", "assets")
 
+	// リンクプレビュー用HTML(アクセス制限なし)
+	e.GET("/share/posts/:id", handler.GetPostSharePage)
+	e.GET("/share/users/:id", handler.GetUserSharePage)
+	e.GET("/share/collections/:id", handler.GetCollectionSharePage)
+
+	// 埋め込み(アクセス制限なし)
+	e.GET("/oembed", handler.GetOEmbed)
+	e.GET("/embed/posts/:id", handler.GetEmbedWidget)
+
 	// アクセス制限なし
 	unauthenticatedGroup := e.Group("/api/v1")
 	unauthenticatedGroup.POST("/users/images", handler.UploadImageFile)
 	unauthenticatedGroup.POST("/users", handler.CreateUser)
 	unauthenticatedGroup.POST("/login", handler.Login)
 	unauthenticatedGroup.GET("/posts", handler.GetPosts)
-	unauthenticatedGroup.GET("/posts/:id", handler.GetPost)
+	unauthenticatedGroup.GET("/posts/daily", handler.GetDailyPost)
 	unauthenticatedGroup.GET("/posts/:id/comments", handler.GetComments)
+	unauthenticatedGroup.GET("/posts/:id/card.png", handler.GetQuoteCard)
+	unauthenticatedGroup.GET("/autocomplete", handler.Autocomplete)
+	unauthenticatedGroup.GET("/posts/:id/attribution_claims", handler.GetAttributionClaims)
+	unauthenticatedGroup.GET("/posts/:id/translations", handler.GetTranslations)
+	unauthenticatedGroup.GET("/exports/download/:token", handler.DownloadExport)
+	unauthenticatedGroup.GET("/reaction_types", handler.GetReactionTypes)
+	unauthenticatedGroup.GET("/posts/:id/reactions", handler.GetReactions)
+	unauthenticatedGroup.GET("/posts/:id/related", handler.GetRelatedPosts)
+
+	// ログイン任意。トークンが指定された場合のみ検証し、ログインユーザーとして扱う。
+	optionalAuthenticatedGroup := e.Group("/api/v1")
+	optionalAuthenticatedGroup.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey: []byte(os.Getenv("JWT_SIGNING_KEY")),
+		Skipper: func(c echo.Context) bool {
+			return c.Request().Header.Get(echo.HeaderAuthorization) == ""
+		},
+	}))
+	optionalAuthenticatedGroup.Use(handler.RequireActiveUser)
+	optionalAuthenticatedGroup.GET("/posts/random", handler.GetRandomPosts)
+	optionalAuthenticatedGroup.GET("/posts/:id", handler.GetPost)
+	optionalAuthenticatedGroup.GET("/users/:id/collections", handler.GetCollections)
+	optionalAuthenticatedGroup.GET("/collections/:id", handler.GetCollection)
+	optionalAuthenticatedGroup.GET("/collections/:id/posts", handler.GetCollectionPosts)
+	optionalAuthenticatedGroup.GET("/posts/:id/collections", handler.GetPostCollections)
+	optionalAuthenticatedGroup.GET("/users/:id/followers", handler.GetFollowers)
+	optionalAuthenticatedGroup.GET("/users/:id/following", handler.GetFollowing)
+	optionalAuthenticatedGroup.GET("/users/:id/follow_counts", handler.GetFollowCounts)
 
 	// アクセス制限あり
 	authenticatedGroup := e.Group("/api/v1")
 	authenticatedGroup.Use(middleware.JWT([]byte(os.Getenv("JWT_SIGNING_KEY"))))
+	authenticatedGroup.Use(handler.RequireActiveUser)
 	authenticatedGroup.GET("/users/:id", handler.GetUser)
 	authenticatedGroup.PUT("/users/:id", handler.UpdateUser)
 	authenticatedGroup.DELETE("/users/:id", handler.DeleteUser)
+	authenticatedGroup.PUT("/users/:id/follow", handler.Follow)
+	authenticatedGroup.DELETE("/users/:id/follow", handler.Unfollow)
+	authenticatedGroup.GET("/feed", handler.GetFeed)
+
+	authenticatedGroup.GET("/notifications", handler.GetNotifications)
+	authenticatedGroup.GET("/notifications/unread_count", handler.GetUnreadNotificationCount)
+	authenticatedGroup.PUT("/notifications/read", handler.MarkAllNotificationsRead)
+	authenticatedGroup.PUT("/notifications/:id/read", handler.MarkNotificationRead)
+	authenticatedGroup.GET("/notification_settings", handler.GetNotificationSettings)
+	authenticatedGroup.PUT("/notification_settings/:type", handler.UpdateNotificationSetting)
 
 	authenticatedGroup.POST("/posts", handler.CreatePost)
 	authenticatedGroup.PUT("/posts/:id", handler.UpdatePost)
 	authenticatedGroup.DELETE("/posts/:id", handler.DeletePost)
+	authenticatedGroup.GET("/posts/stats", handler.GetPostStats)
+	authenticatedGroup.GET("/posts/:id/stats", handler.GetPostStats)
 
 	authenticatedGroup.POST("/posts/:id/comments", handler.CreateComment)
 	authenticatedGroup.DELETE("/comments/:id", handler.DeleteComment)
 
 	authenticatedGroup.POST("/posts/:id/favorites", handler.CreateFavorite)
 	authenticatedGroup.GET("/posts/favorites", handler.GetFavorites)
+	authenticatedGroup.PUT("/posts/:id/favorites/note", handler.UpdateFavoriteNote)
 	authenticatedGroup.DELETE("/posts/:id/favorites/:user_id", handler.DeleteFavorite)
+
+	authenticatedGroup.PUT("/posts/:id/reactions/:type", handler.CreateReaction)
+	authenticatedGroup.DELETE("/posts/:id/reactions/:type", handler.DeleteReaction)
+
+	authenticatedGroup.POST("/posts/:id/attribution_claims", handler.CreateAttributionClaim)
+
+	authenticatedGroup.PUT("/posts/:id/translations/:language", handler.SaveTranslation)
+	authenticatedGroup.DELETE("/posts/:id/translations/:language", handler.DeleteTranslation)
+
+	authenticatedGroup.POST("/collections", handler.CreateCollection)
+	authenticatedGroup.PUT("/collections/:id", handler.UpdateCollection)
+	authenticatedGroup.DELETE("/collections/:id", handler.DeleteCollection)
+	authenticatedGroup.POST("/collections/:id/posts", handler.AddCollectionPost)
+	authenticatedGroup.PUT("/collections/:id/posts/order", handler.ReorderCollectionPosts)
+	authenticatedGroup.DELETE("/collections/:id/posts/:post_id", handler.DeleteCollectionPost)
+
+	authenticatedGroup.POST("/exports", handler.CreateExport)
+	authenticatedGroup.GET("/exports/:id", handler.GetExport)
+
+	authenticatedGroup.POST("/reports", handler.CreateReport)
+
+	authenticatedGroup.GET("/trash", handler.GetTrash)
+	authenticatedGroup.POST("/posts/:id/restore", handler.RestorePost)
+	authenticatedGroup.POST("/comments/:id/restore", handler.RestoreComment)
+
+	authenticatedGroup.POST("/events/ticket", handler.CreateStreamTicket)
+
+	// リアルタイム配信(アクセス制限あり)。EventSourceはヘッダーを指定できないため、
+	// ログイン用のトークンはアクセスログに残らないようヘッダーでのみ受け付け、クエリパラメータticketでは有効期限の短いチケットを受け付ける。
+	realtimeGroup := e.Group("/api/v1")
+	realtimeGroup.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey: []byte(os.Getenv("JWT_SIGNING_KEY")),
+		Skipper: func(c echo.Context) bool {
+			return c.Request().Header.Get(echo.HeaderAuthorization) == ""
+		},
+	}))
+	realtimeGroup.Use(handler.AuthenticateStreamTicket)
+	realtimeGroup.Use(handler.RequireActiveUser)
+	realtimeGroup.GET("/events", handler.SubscribeEvents)
+
+	// モデレーターのみ
+	moderatorGroup := e.Group("/api/v1")
+	moderatorGroup.Use(middleware.JWT([]byte(os.Getenv("JWT_SIGNING_KEY"))))
+	moderatorGroup.Use(requireRole(model.RoleModerator, model.RoleAdmin))
+	moderatorGroup.Use(handler.RequireActiveUser)
+	moderatorGroup.PUT("/attribution_claims/:id/ruling", handler.RuleAttributionClaim)
+	moderatorGroup.GET("/moderation/reports", handler.GetReportQueue)
+	moderatorGroup.GET("/moderation/reports/:target_type/:target_id", handler.GetReportTarget)
+	moderatorGroup.POST("/moderation/actions", handler.ModerateTarget)
+
+	// 管理者のみ
+	adminGroup := e.Group("/api/v1/admin")
+	adminGroup.Use(middleware.JWT([]byte(os.Getenv("JWT_SIGNING_KEY"))))
+	adminGroup.Use(requireRole(model.RoleAdmin))
+	adminGroup.Use(handler.RequireActiveUser)
+	adminGroup.GET("/posts/duplicates", handler.GetDuplicateClusters)
+	adminGroup.PUT("/posts/daily/:date", handler.PinDailyPost)
+	adminGroup.POST("/posts/import", handler.ImportPosts)
+	adminGroup.GET("/posts/import/:id", handler.GetImportJob)
+	adminGroup.GET("/prohibited_words", handler.GetProhibitedWords)
+	adminGroup.POST("/prohibited_words", handler.CreateProhibitedWord)
+	adminGroup.DELETE("/prohibited_words/:id", handler.DeleteProhibitedWord)
+	adminGroup.GET("/webhooks", handler.GetWebhooks)
+	adminGroup.POST("/webhooks", handler.CreateWebhook)
+	adminGroup.DELETE("/webhooks/:id", handler.DeleteWebhook)
+	adminGroup.GET("/webhooks/:id/deliveries", handler.GetWebhookDeliveries)
+	adminGroup.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", handler.RedeliverWebhook)
+}
+
+// requireRole JWTトークンの権限がrolesのいずれかであることを確認するミドルウェア。
+func requireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token, ok := c.Get("user").(*jwt.Token)
+			if !ok {
+				return c.JSON(http.StatusForbidden, "権限がありません。")
+			}
+			claims, ok := token.Claims.(jwt.MapClaims)
+			if !ok {
+				return c.JSON(http.StatusForbidden, "権限がありません。")
+			}
+			for _, role := range roles {
+				if claims["role"] == role {
+					return next(c)
+				}
+			}
+			return c.JSON(http.StatusForbidden, "権限がありません。")
+		}
+	}
 }
@@ -0,0 +1,238 @@
+// Package importer 投稿の一括登録ファイルの読み込み。HTTP APIとコマンドラインの両方から使用する。
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
+	"github.com/labstack/echo"
+)
+
+// MaxRows 1回で一括登録できる最大行数
+const MaxRows = 1000
+
+// ファイル形式
+const (
+	// FormatCSV 1行目をヘッダー行とするCSV
+	FormatCSV = "csv"
+	// FormatJSON オブジェクトの配列
+	FormatJSON = "json"
+)
+
+// record ファイルの1行
+type record struct {
+	Title           string   `json:"title"`
+	Speaker         string   `json:"speaker"`
+	Detail          string   `json:"detail"`
+	MovieURL        string   `json:"movie_url"`
+	Tags            []string `json:"tags"`
+	License         string   `json:"license"`
+	Language        string   `json:"language"`
+	SourceType      string   `json:"source_type"`
+	SourceTitle     string   `json:"source_title"`
+	SourceLocator   string   `json:"source_locator"`
+	PublicationYear int      `json:"publication_year"`
+	SourceURL       string   `json:"source_url"`
+}
+
+// csvColumns CSVで指定できる列
+var csvColumns = []string{
+	"title", "speaker", "detail", "movie_url", "tags", "license", "language",
+	"source_type", "source_title", "source_locator", "publication_year", "source_url",
+}
+
+// FormatFromFileName ファイル名の拡張子からファイル形式を判定する。判定できない場合は空文字を返す。
+func FormatFromFileName(fileName string) string {
+	lower := strings.ToLower(fileName)
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
+		return FormatCSV
+	case strings.HasSuffix(lower, ".json"):
+		return FormatJSON
+	}
+	return ""
+}
+
+// Parse ファイルを読み込み、各行を投稿登録リクエストと同じルールで入力チェックする。
+// 入力チェックのエラーは行ごとにImportRow.Errorsに設定する。ファイル自体を読み込めない場合はエラーを返す。
+func Parse(format string, reader io.Reader, validator echo.Validator) ([]*model.ImportRow, error) {
+	var rows []*model.ImportRow
+	var err error
+	switch format {
+	case FormatCSV:
+		rows, err = parseCSV(reader)
+	case FormatJSON:
+		rows, err = parseJSON(reader)
+	default:
+		return nil, errors.New("format：csvまたはjsonを指定してください。")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("投稿が1件もありません。")
+	}
+	if len(rows) > MaxRows {
+		return nil, fmt.Errorf("一度に登録できる投稿は%d件までです。", MaxRows)
+	}
+
+	for _, row := range rows {
+		validateRow(row, validator)
+	}
+	return rows, nil
+}
+
+// parseCSV CSVを読み込む。1行目はヘッダー行とし、列の順序は問わない。
+func parseCSV(reader io.Reader) ([]*model.ImportRow, error) {
+	csvReader := csv.NewReader(reader)
+	// 列が不足している行は空文字として扱う
+	csvReader.FieldsPerRecord = -1
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, errors.New("ヘッダー行がありません。")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if !containsString(csvColumns, column) {
+			return nil, fmt.Errorf("%s：不明な列です。", column)
+		}
+		columns[column] = i
+	}
+	for _, column := range []string{"title", "speaker"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%s：列がありません。", column)
+		}
+	}
+
+	var rows []*model.ImportRow
+	for line := 2; ; line++ {
+		values, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(values) {
+				return strings.TrimSpace(values[i])
+			}
+			return ""
+		}
+
+		row := &model.ImportRow{Row: line}
+		r := record{
+			Title:         value("title"),
+			Speaker:       value("speaker"),
+			Detail:        value("detail"),
+			MovieURL:      value("movie_url"),
+			License:       value("license"),
+			Language:      value("language"),
+			SourceType:    value("source_type"),
+			SourceTitle:   value("source_title"),
+			SourceLocator: value("source_locator"),
+			SourceURL:     value("source_url"),
+		}
+		if tags := value("tags"); tags != "" {
+			r.Tags = strings.Split(tags, ",")
+		}
+		if year := value("publication_year"); year != "" {
+			if r.PublicationYear, err = strconv.Atoi(year); err != nil {
+				row.Errors = append(row.Errors, "publication_year：数値で入力してください。")
+			}
+		}
+		setRecord(row, &r)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseJSON オブジェクトの配列のJSONを読み込む。
+func parseJSON(reader io.Reader) ([]*model.ImportRow, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	var records []*record
+	if err := decoder.Decode(&records); err != nil {
+		return nil, fmt.Errorf("JSONを読み込めません。%s", err.Error())
+	}
+
+	rows := make([]*model.ImportRow, 0, len(records))
+	for i, r := range records {
+		row := &model.ImportRow{Row: i + 1}
+		if r == nil {
+			row.Errors = append(row.Errors, "オブジェクトを指定してください。")
+			r = &record{}
+		}
+		setRecord(row, r)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// setRecord 読み込んだ1行を投稿に設定する。
+func setRecord(row *model.ImportRow, r *record) {
+	row.Post = model.Post{
+		Title:    r.Title,
+		Speaker:  r.Speaker,
+		Detail:   r.Detail,
+		MovieURL: r.MovieURL,
+		License:  r.License,
+		Language: r.Language,
+		Tags:     r.Tags,
+		PostSource: model.PostSource{
+			SourceType:      r.SourceType,
+			SourceTitle:     r.SourceTitle,
+			SourceLocator:   r.SourceLocator,
+			PublicationYear: r.PublicationYear,
+			SourceURL:       r.SourceURL,
+		},
+	}
+}
+
+// validateRow 投稿登録リクエストと同じルールで入力チェックする。
+// 登録ユーザーはジョブ実行時に設定するため、ここでは仮の値でチェックする。
+func validateRow(row *model.ImportRow, validator echo.Validator) {
+	post := row.Post
+	createPostRequest := &request.CreatePostRequest{
+		UserID:   1,
+		Title:    post.Title,
+		Speaker:  post.Speaker,
+		Detail:   post.Detail,
+		MovieURL: post.MovieURL,
+		License:  post.License,
+		Language: post.Language,
+		Tags:     post.Tags,
+		PostSourceRequest: request.PostSourceRequest{
+			SourceType:      post.SourceType,
+			SourceTitle:     post.SourceTitle,
+			SourceLocator:   post.SourceLocator,
+			PublicationYear: post.PublicationYear,
+			SourceURL:       post.SourceURL,
+		},
+	}
+	if err := validator.Validate(createPostRequest); err != nil {
+		row.Errors = append(row.Errors, strings.Split(err.Error(), "\n")...)
+	}
+}
+
+// containsString valuesにvalueが含まれるか
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// CSV読み込みテスト
+func TestParse_success_csv(t *testing.T) {
+	// 1. Setup
+	file := "\ufeffspeaker,title,tags,source_type,source_title,publication_year\n" +
+		"speaker1,title1,\"tag1,tag2\",book,book1,2001\n" +
+		"speaker2,,,,,\n" +
+		"speaker3,title3,,,,year\n" +
+		"speaker4,title4\n"
+
+	// 2. Exercise
+	rows, err := Parse(FormatCSV, strings.NewReader(file), validator.NewValidator())
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Len(t, rows, 4)
+	assert.Equal(t, 2, rows[0].Row)
+	assert.Equal(t, "title1", rows[0].Post.Title)
+	assert.Equal(t, "speaker1", rows[0].Post.Speaker)
+	assert.Equal(t, "book1", rows[0].Post.SourceTitle)
+	assert.Equal(t, 2001, rows[0].Post.PublicationYear)
+	assert.Equal(t, []string{"tag1", "tag2"}, rows[0].Post.Tags)
+	assert.Empty(t, rows[0].Errors)
+	assert.Empty(t, rows[0].Warnings)
+	assert.Equal(t, []string{"Title：必須です。"}, rows[1].Errors)
+	assert.Equal(t, []string{"publication_year：数値で入力してください。"}, rows[2].Errors)
+	assert.Equal(t, 5, rows[3].Row)
+	assert.Empty(t, rows[3].Errors)
+
+	// 4. Teardown
+}
+
+// JSON読み込みテスト
+func TestParse_success_json(t *testing.T) {
+	// 1. Setup
+	file := `[
+		{"title": "title1", "speaker": "speaker1", "movie_url": "https://example.com", "tags": ["tag1"], "language": "en"},
+		{"title": "title2", "speaker": "speaker2", "source_type": "web"},
+		null
+	]`
+
+	// 2. Exercise
+	rows, err := Parse(FormatJSON, strings.NewReader(file), validator.NewValidator())
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, 1, rows[0].Row)
+	assert.Equal(t, "https://example.com", rows[0].Post.MovieURL)
+	assert.Equal(t, "en", rows[0].Post.Language)
+	assert.Equal(t, []string{"tag1"}, rows[0].Post.Tags)
+	assert.Empty(t, rows[0].Errors)
+	assert.Equal(t, []string{"SourceURL：必須です。"}, rows[1].Errors)
+	assert.Contains(t, rows[2].Errors, "オブジェクトを指定してください。")
+
+	// 4. Teardown
+}
+
+func TestParse_error(t *testing.T) {
+	// 1. Setup
+	cases := []struct {
+		label  string
+		format string
+		file   string
+	}{
+		{"unknownFormat", "xml", "<posts></posts>"},
+		{"csvEmpty", FormatCSV, ""},
+		{"csvUnknownColumn", FormatCSV, "title,speaker,author\n"},
+		{"csvMissingColumn", FormatCSV, "title,detail\ntitle1,detail1\n"},
+		{"csvNoRows", FormatCSV, "title,speaker\n"},
+		{"csvTooManyRows", FormatCSV, "title,speaker\n" + strings.Repeat("title,speaker\n", MaxRows+1)},
+		{"jsonInvalid", FormatJSON, `{"title": "title1"}`},
+		{"jsonUnknownField", FormatJSON, `[{"title": "title1", "speaker": "speaker1", "author": "author1"}]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			// 2. Exercise
+			rows, err := Parse(c.format, strings.NewReader(c.file), validator.NewValidator())
+
+			// 3. Verify
+			assert.Error(t, err)
+			assert.Nil(t, rows)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// ファイル形式判定テスト
+func TestFormatFromFileName(t *testing.T) {
+	// 1. Setup
+	cases := []struct {
+		fileName string
+		format   string
+	}{
+		{"phrases.csv", FormatCSV},
+		{"PHRASES.JSON", FormatJSON},
+		{"phrases.txt", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.fileName, func(t *testing.T) {
+			// 2. Exercise
+			format := FormatFromFileName(c.fileName)
+
+			// 3. Verify
+			assert.Equal(t, c.format, format)
+		})
+	}
+
+	// 4. Teardown
+}
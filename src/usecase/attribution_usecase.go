@@ -0,0 +1,104 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+var (
+	// ErrAttributionClaimAlreadyRuled 審査済みの証拠・異議を再審査しようとした場合のエラー
+	ErrAttributionClaimAlreadyRuled = errors.New("既に審査済みです。")
+	// ErrAttributionClaimNotFound 存在しない証拠・異議を審査しようとした場合のエラー
+	ErrAttributionClaimNotFound = errors.New("出典の証拠・異議が見つかりません。")
+)
+
+// AttributionUseCase インターフェース
+type AttributionUseCase interface {
+	// 出典の証拠・異議登録
+	CreateAttributionClaim(postID, userID int, claimType, proposedStatus, body, sourceURL string) error
+	// 出典の証拠・異議一覧取得
+	GetAttributionClaims(postID int) ([]*model.GetAttributionClaimResult, error)
+	// 出典の証拠・異議の審査
+	RuleAttributionClaim(id, moderatorID int, status, comment string) error
+}
+
+// attributionUseCase 構造体
+type attributionUseCase struct {
+	repository.AttributionRepository
+}
+
+// NewAttributionUseCase AttributionUseCaseを生成。
+func NewAttributionUseCase(repository repository.AttributionRepository) AttributionUseCase {
+	return &attributionUseCase{repository}
+}
+
+// CreateAttributionClaim 出典の証拠・異議登録。
+// 証拠の場合、採用時の検証状態は「検証済み」となる。
+// 異議の場合、proposedStatusが空文字であれば採用時の検証状態は「異議あり」となる。
+func (usecase *attributionUseCase) CreateAttributionClaim(postID, userID int, claimType, proposedStatus, body, sourceURL string) error {
+	if claimType == model.ClaimTypeEvidence {
+		proposedStatus = model.VerificationStatusVerified
+	} else if proposedStatus == "" {
+		proposedStatus = model.VerificationStatusDisputed
+	}
+
+	claim := model.AttributionClaim{
+		PostID:         postID,
+		UserID:         userID,
+		ClaimType:      claimType,
+		ProposedStatus: proposedStatus,
+		Body:           body,
+		SourceURL:      sourceURL,
+		Status:         model.ClaimStatusPending,
+	}
+	return usecase.AttributionRepository.CreateAttributionClaim(&claim)
+}
+
+// GetAttributionClaims 出典の証拠・異議一覧取得
+func (usecase *attributionUseCase) GetAttributionClaims(postID int) ([]*model.GetAttributionClaimResult, error) {
+	claims, err := usecase.AttributionRepository.FetchAttributionClaims(postID)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// RuleAttributionClaim 出典の証拠・異議の審査。
+// 採用した場合は、投稿の検証状態を証拠・異議の提案する状態に更新する。
+// 複数のモデレーターが同時に審査した場合は、先に登録した審査のみ有効とし、他はErrAttributionClaimAlreadyRuledとする。
+func (usecase *attributionUseCase) RuleAttributionClaim(id, moderatorID int, status, comment string) error {
+	claim, err := usecase.AttributionRepository.FetchAttributionClaimByID(id)
+	if err != nil {
+		return err
+	}
+	if claim == nil {
+		return ErrAttributionClaimNotFound
+	}
+	if claim.Status != model.ClaimStatusPending {
+		return ErrAttributionClaimAlreadyRuled
+	}
+
+	now := time.Now()
+	claim.Status = status
+	claim.RuledBy = moderatorID
+	claim.RulingComment = comment
+	claim.RuledAt = &now
+
+	verificationStatus := ""
+	if status == model.ClaimStatusAccepted {
+		verificationStatus = claim.ProposedStatus
+	}
+
+	ruled, err := usecase.AttributionRepository.RuleAttributionClaim(claim, verificationStatus)
+	if err != nil {
+		return err
+	}
+	if !ruled {
+		return ErrAttributionClaimAlreadyRuled
+	}
+	return nil
+}
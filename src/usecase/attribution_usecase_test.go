@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 証拠・異議を生成
+func makeAttributionClaim(id, postID int, claimType, proposedStatus string) *model.AttributionClaim {
+	return &model.AttributionClaim{
+		ID:             id,
+		PostID:         postID,
+		UserID:         id,
+		ClaimType:      claimType,
+		ProposedStatus: proposedStatus,
+		Body:           fmt.Sprintf("body%d", id),
+		Status:         model.ClaimStatusPending,
+	}
+}
+
+func makeGetAttributionClaimResult(id, postID int) *model.GetAttributionClaimResult {
+	return &model.GetAttributionClaimResult{
+		AttributionClaim:  *makeAttributionClaim(id, postID, model.ClaimTypeEvidence, model.VerificationStatusVerified),
+		UserName:          fmt.Sprintf("username%d", id),
+		UserImageFilePath: fmt.Sprintf("images/%d.png", id),
+	}
+}
+
+// 証拠・異議登録テスト
+func TestCreateAttributionClaim_success(t *testing.T) {
+	cases := []struct {
+		label          string
+		claimType      string
+		proposedStatus string
+		expected       string
+	}{
+		{"証拠", model.ClaimTypeEvidence, "", model.VerificationStatusVerified},
+		{"異議", model.ClaimTypeDispute, "", model.VerificationStatusDisputed},
+		{"異議(誤帰属)", model.ClaimTypeDispute, model.VerificationStatusMisattributed, model.VerificationStatusMisattributed},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		repository := mockPostRepository{}
+		usecase := NewAttributionUseCase(&repository)
+		repository.On("CreateAttributionClaim", mock.MatchedBy(func(claim *model.AttributionClaim) bool {
+			return claim.ProposedStatus == test.expected && claim.Status == model.ClaimStatusPending
+		})).Return(nil)
+
+		// 2. Exercise
+		err := usecase.CreateAttributionClaim(1, 1, test.claimType, test.proposedStatus, "body", "https://example.com")
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		repository.AssertExpectations(t)
+
+		// 4. Teardown
+	}
+}
+
+func TestCreateAttributionClaim_error(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewAttributionUseCase(&repository)
+	repository.On("CreateAttributionClaim", mock.AnythingOfType("*model.AttributionClaim")).Return(errors.New("error"))
+
+	// 2. Exercise
+	err := usecase.CreateAttributionClaim(1, 1, model.ClaimTypeEvidence, "", "body", "")
+
+	// 3. Verify
+	assert.Error(t, err)
+
+	// 4. Teardown
+}
+
+// 証拠・異議一覧取得テスト
+func TestGetAttributionClaims_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewAttributionUseCase(&repository)
+	postID := 1
+	expected := []*model.GetAttributionClaimResult{makeGetAttributionClaimResult(1, postID), makeGetAttributionClaimResult(2, postID)}
+	repository.On("FetchAttributionClaims", postID).Return(expected, nil)
+
+	// 2. Exercise
+	claims, err := usecase.GetAttributionClaims(postID)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, expected, claims)
+
+	// 4. Teardown
+}
+
+func TestGetAttributionClaims_error(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewAttributionUseCase(&repository)
+	repository.On("FetchAttributionClaims", 1).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	claims, err := usecase.GetAttributionClaims(1)
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, claims)
+
+	// 4. Teardown
+}
+
+// 証拠・異議審査テスト
+func TestRuleAttributionClaim_success(t *testing.T) {
+	cases := []struct {
+		label              string
+		claim              *model.AttributionClaim
+		status             string
+		verificationStatus string
+	}{
+		{"証拠を採用", makeAttributionClaim(1, 1, model.ClaimTypeEvidence, model.VerificationStatusVerified), model.ClaimStatusAccepted, model.VerificationStatusVerified},
+		{"異議を採用", makeAttributionClaim(1, 1, model.ClaimTypeDispute, model.VerificationStatusMisattributed), model.ClaimStatusAccepted, model.VerificationStatusMisattributed},
+		{"却下", makeAttributionClaim(1, 1, model.ClaimTypeDispute, model.VerificationStatusDisputed), model.ClaimStatusRejected, ""},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		repository := mockPostRepository{}
+		usecase := NewAttributionUseCase(&repository)
+		moderatorID := 2
+		repository.On("FetchAttributionClaimByID", test.claim.ID).Return(test.claim, nil)
+		repository.On("RuleAttributionClaim", mock.MatchedBy(func(claim *model.AttributionClaim) bool {
+			return claim.Status == test.status && claim.RuledBy == moderatorID && claim.RuledAt != nil
+		}), test.verificationStatus).Return(true, nil)
+
+		// 2. Exercise
+		err := usecase.RuleAttributionClaim(test.claim.ID, moderatorID, test.status, "comment")
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		repository.AssertExpectations(t)
+
+		// 4. Teardown
+	}
+}
+
+func TestRuleAttributionClaim_error_alreadyRuled(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewAttributionUseCase(&repository)
+	claim := makeAttributionClaim(1, 1, model.ClaimTypeEvidence, model.VerificationStatusVerified)
+	claim.Status = model.ClaimStatusRejected
+	repository.On("FetchAttributionClaimByID", claim.ID).Return(claim, nil)
+
+	// 2. Exercise
+	err := usecase.RuleAttributionClaim(claim.ID, 2, model.ClaimStatusAccepted, "")
+
+	// 3. Verify
+	assert.Equal(t, ErrAttributionClaimAlreadyRuled, err)
+
+	// 4. Teardown
+}
+
+func TestRuleAttributionClaim_error_ruledConcurrently(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewAttributionUseCase(&repository)
+	claim := makeAttributionClaim(1, 1, model.ClaimTypeEvidence, model.VerificationStatusVerified)
+	repository.On("FetchAttributionClaimByID", claim.ID).Return(claim, nil)
+	repository.On("RuleAttributionClaim", claim, model.VerificationStatusVerified).Return(false, nil)
+
+	// 2. Exercise
+	err := usecase.RuleAttributionClaim(claim.ID, 2, model.ClaimStatusAccepted, "")
+
+	// 3. Verify
+	assert.Equal(t, ErrAttributionClaimAlreadyRuled, err)
+
+	// 4. Teardown
+}
+
+func TestRuleAttributionClaim_error_notFound(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewAttributionUseCase(&repository)
+	repository.On("FetchAttributionClaimByID", 1).Return(nil, nil)
+
+	// 2. Exercise
+	err := usecase.RuleAttributionClaim(1, 2, model.ClaimStatusAccepted, "")
+
+	// 3. Verify
+	assert.Equal(t, ErrAttributionClaimNotFound, err)
+
+	// 4. Teardown
+}
+
+func TestRuleAttributionClaim_error(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewAttributionUseCase(&repository)
+	repository.On("FetchAttributionClaimByID", 1).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	err := usecase.RuleAttributionClaim(1, 2, model.ClaimStatusAccepted, "")
+
+	// 3. Verify
+	assert.Error(t, err)
+
+	// 4. Teardown
+}
@@ -0,0 +1,196 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// 入力補完の対象項目
+const (
+	AutocompleteFieldSpeaker = "speaker"
+	AutocompleteFieldTitle   = "title"
+)
+
+// autocompleteIndexTTL 入力補完インデックスの最大保持期間。
+// 他のAPIインスタンスでの更新を取り込むため、投稿更新がなくても一定時間で再構築する。
+const autocompleteIndexTTL = 5 * time.Minute
+
+// AutocompleteUseCase インターフェース
+type AutocompleteUseCase interface {
+	// 入力補完候補取得
+	Autocomplete(field, query string, limit int) ([]*model.AutocompleteCandidate, error)
+}
+
+// autocompleteUseCase 構造体
+type autocompleteUseCase struct {
+	repository.PostRepository
+	autocompleteIndexes *AutocompleteIndexCache
+}
+
+// NewAutocompleteUseCase AutocompleteUseCaseを生成。
+func NewAutocompleteUseCase(repository repository.PostRepository, autocompleteIndexes *AutocompleteIndexCache) AutocompleteUseCase {
+	return &autocompleteUseCase{repository, autocompleteIndexes}
+}
+
+// Autocomplete 入力補完候補取得。
+// 正規化した文字列で前方一致し、使用回数の多い順に返す。
+func (usecase *autocompleteUseCase) Autocomplete(field, query string, limit int) ([]*model.AutocompleteCandidate, error) {
+	index, err := usecase.autocompleteIndexes.get(field, usecase.PostRepository.FetchFieldCounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.search(normalizePrefix(query), limit), nil
+}
+
+// autocompleteEntry インデックスの1要素。正規化後の文字列ごとに集約される。
+type autocompleteEntry struct {
+	key   string
+	text  string
+	count int
+	// 最も多く使われている表記の使用回数
+	textCount int
+}
+
+// autocompleteIndex 正規化後の文字列でソートされた入力補完インデックス。
+type autocompleteIndex struct {
+	entries []*autocompleteEntry
+	builtAt time.Time
+}
+
+// newAutocompleteIndex 項目ごとの使用回数からインデックスを構築する。
+func newAutocompleteIndex(counts []*model.AutocompleteCandidate) *autocompleteIndex {
+	entryMap := map[string]*autocompleteEntry{}
+	for _, candidate := range counts {
+		key := normalizeText(candidate.Text)
+		if key == "" {
+			continue
+		}
+		entry, ok := entryMap[key]
+		if !ok {
+			entry = &autocompleteEntry{key: key}
+			entryMap[key] = entry
+		}
+		entry.count += candidate.Count
+		// 表記ゆれがある場合は最も多く使われている表記を候補とする
+		if candidate.Count > entry.textCount || (candidate.Count == entry.textCount && candidate.Text < entry.text) {
+			entry.text = candidate.Text
+			entry.textCount = candidate.Count
+		}
+	}
+
+	entries := make([]*autocompleteEntry, 0, len(entryMap))
+	for _, entry := range entryMap {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	return &autocompleteIndex{entries: entries, builtAt: time.Now()}
+}
+
+// search 前方一致する候補を使用回数の多い順に最大limit件返す。
+func (index *autocompleteIndex) search(prefix string, limit int) []*model.AutocompleteCandidate {
+	start := sort.Search(len(index.entries), func(i int) bool {
+		return index.entries[i].key >= prefix
+	})
+
+	var matched []*autocompleteEntry
+	for i := start; i < len(index.entries) && strings.HasPrefix(index.entries[i].key, prefix); i++ {
+		matched = append(matched, index.entries[i])
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].count != matched[j].count {
+			return matched[i].count > matched[j].count
+		}
+		return matched[i].text < matched[j].text
+	})
+
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	candidates := make([]*model.AutocompleteCandidate, 0, len(matched))
+	for _, entry := range matched {
+		candidates = append(candidates, &model.AutocompleteCandidate{Text: entry.text, Count: entry.count})
+	}
+	return candidates
+}
+
+// AutocompleteIndexCache 項目ごとの入力補完インデックスのキャッシュ。
+// 全APIリクエストで共有し、投稿を登録・更新・削除するユースケースが破棄する。
+type AutocompleteIndexCache struct {
+	mutex   sync.Mutex
+	indexes map[string]*autocompleteIndex
+	// 構築中のインデックス。同じ項目の構築は1回にまとめ、他のリクエストは完了を待つ
+	builds map[string]*autocompleteIndexBuild
+	// 破棄した回数。破棄する前に取得を始めた使用回数でキャッシュを上書きしないために使う
+	generation int
+}
+
+// autocompleteIndexBuild 構築中のインデックス。doneが閉じられた後にindex、errを参照できる。
+type autocompleteIndexBuild struct {
+	done  chan struct{}
+	index *autocompleteIndex
+	err   error
+}
+
+// NewAutocompleteIndexCache AutocompleteIndexCacheを生成。
+func NewAutocompleteIndexCache() *AutocompleteIndexCache {
+	return &AutocompleteIndexCache{indexes: map[string]*autocompleteIndex{}, builds: map[string]*autocompleteIndexBuild{}}
+}
+
+// get インデックスを取得する。未構築または期限切れの場合はfetchで取得した使用回数から構築する。
+// 取得中はロックを解放するため、他の項目の取得を待たせない。
+func (cache *AutocompleteIndexCache) get(field string, fetch func(field string) ([]*model.AutocompleteCandidate, error)) (*autocompleteIndex, error) {
+	cache.mutex.Lock()
+	index, ok := cache.indexes[field]
+	if ok && time.Since(index.builtAt) < autocompleteIndexTTL {
+		cache.mutex.Unlock()
+		return index, nil
+	}
+	if build, ok := cache.builds[field]; ok {
+		cache.mutex.Unlock()
+		<-build.done
+		return build.index, build.err
+	}
+	build := &autocompleteIndexBuild{done: make(chan struct{})}
+	cache.builds[field] = build
+	generation := cache.generation
+	cache.mutex.Unlock()
+
+	counts, err := fetch(field)
+	if err == nil {
+		build.index = newAutocompleteIndex(counts)
+	}
+	build.err = err
+
+	cache.mutex.Lock()
+	if cache.builds[field] == build {
+		delete(cache.builds, field)
+	}
+	if err == nil && generation == cache.generation {
+		cache.indexes[field] = build.index
+	}
+	cache.mutex.Unlock()
+	close(build.done)
+
+	return build.index, build.err
+}
+
+// invalidate キャッシュを破棄する。投稿の登録・更新・削除時に呼び出す。
+func (cache *AutocompleteIndexCache) invalidate() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.indexes = map[string]*autocompleteIndex{}
+	// 構築中のインデックスは破棄前の投稿から構築しているため、以降のリクエストは待たずに構築し直す
+	cache.builds = map[string]*autocompleteIndexBuild{}
+	cache.generation++
+}
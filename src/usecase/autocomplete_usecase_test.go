@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// 正規化テスト
+func TestNormalizeText(t *testing.T) {
+	cases := []struct {
+		label    string
+		text     string
+		expected string
+	}{
+		{"ひらがな", "まつしたこうのすけ", "まつしたこうのすけ"},
+		{"カタカナ", "マツシタ コウノスケ", "まつしたこうのすけ"},
+		{"半角カタカナ", "ﾏﾂｼﾀ", "まつした"},
+		{"ローマ字", "Matsushita Kounosuke", "まつしたこうのすけ"},
+		{"全角英字", "ＭＡＴＳＵＳＨＩＴＡ", "まつした"},
+		{"撥音", "konnichiwa", "こんにちわ"},
+		{"語末の撥音", "ken", "けん"},
+		{"促音", "kitte", "きって"},
+		{"記号", "スティーブ・ジョブズ", "すてぃーぶじょぶず"},
+	}
+
+	for _, test := range cases {
+		assert.Equal(t, test.expected, normalizeText(test.text), test.label)
+	}
+}
+
+// 前方一致クエリの正規化テスト
+func TestNormalizePrefix(t *testing.T) {
+	cases := []struct {
+		label    string
+		text     string
+		expected string
+	}{
+		{"入力途中のローマ字", "matsus", "まつ"},
+		{"語末のn", "kan", "か"},
+		{"ローマ字のみ", "s", "s"},
+	}
+
+	for _, test := range cases {
+		assert.Equal(t, test.expected, normalizePrefix(test.text), test.label)
+	}
+}
+
+// 入力補完テスト
+func TestAutocomplete_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewAutocompleteUseCase(&repository, NewAutocompleteIndexCache())
+	repository.On("FetchFieldCounts", AutocompleteFieldSpeaker).Return([]*model.AutocompleteCandidate{
+		{Text: "松下幸之助", Count: 1},
+		{Text: "マツダ", Count: 2},
+		{Text: "まつだ", Count: 3},
+		{Text: "マツコ", Count: 4},
+		{Text: "イチロー", Count: 10},
+	}, nil).Once()
+
+	// 2. Exercise
+	candidates, err := usecase.Autocomplete(AutocompleteFieldSpeaker, "matsu", 10)
+	cached, cachedErr := usecase.Autocomplete(AutocompleteFieldSpeaker, "マツダ", 10)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.AutocompleteCandidate{
+		{Text: "まつだ", Count: 5},
+		{Text: "マツコ", Count: 4},
+	}, candidates)
+	assert.NoError(t, cachedErr)
+	assert.Equal(t, []*model.AutocompleteCandidate{{Text: "まつだ", Count: 5}}, cached)
+	repository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestAutocomplete_success_limit(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewAutocompleteUseCase(&repository, NewAutocompleteIndexCache())
+	repository.On("FetchFieldCounts", AutocompleteFieldTitle).Return([]*model.AutocompleteCandidate{
+		{Text: "あきらめたらそこで試合終了", Count: 1},
+		{Text: "明日やろうは馬鹿野郎", Count: 2},
+		{Text: "あいうえお", Count: 3},
+	}, nil)
+
+	// 2. Exercise
+	candidates, err := usecase.Autocomplete(AutocompleteFieldTitle, "あ", 2)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.AutocompleteCandidate{
+		{Text: "あいうえお", Count: 3},
+		{Text: "あきらめたらそこで試合終了", Count: 1},
+	}, candidates)
+
+	// 4. Teardown
+}
+
+func TestAutocomplete_error(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewAutocompleteUseCase(&repository, NewAutocompleteIndexCache())
+	repository.On("FetchFieldCounts", AutocompleteFieldSpeaker).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	candidates, err := usecase.Autocomplete(AutocompleteFieldSpeaker, "a", 10)
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, candidates)
+
+	// 4. Teardown
+}
+
+// 構築中は同じ項目の取得を1回にまとめ、他の項目の取得を待たせない
+func TestAutocompleteIndexCache_concurrent(t *testing.T) {
+	// 1. Setup
+	cache := NewAutocompleteIndexCache()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var speakerFetches int32
+	fetch := func(field string) ([]*model.AutocompleteCandidate, error) {
+		if field == AutocompleteFieldSpeaker {
+			atomic.AddInt32(&speakerFetches, 1)
+			close(started)
+			<-release
+		}
+		return []*model.AutocompleteCandidate{{Text: field, Count: 1}}, nil
+	}
+
+	// 2. Exercise
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache.get(AutocompleteFieldSpeaker, fetch)
+		}()
+	}
+	<-started
+	titleIndex, titleErr := cache.get(AutocompleteFieldTitle, fetch)
+	close(release)
+	wg.Wait()
+	speakerIndex, speakerErr := cache.get(AutocompleteFieldSpeaker, fetch)
+
+	// 3. Verify
+	assert.NoError(t, titleErr)
+	assert.Len(t, titleIndex.entries, 1)
+	assert.NoError(t, speakerErr)
+	assert.Len(t, speakerIndex.entries, 1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&speakerFetches))
+
+	// 4. Teardown
+}
+
+// 構築中に破棄された場合は、破棄前に取得した使用回数をキャッシュしない
+func TestAutocompleteIndexCache_invalidateWhileBuilding(t *testing.T) {
+	// 1. Setup
+	cache := NewAutocompleteIndexCache()
+	fetches := 0
+	fetch := func(field string) ([]*model.AutocompleteCandidate, error) {
+		fetches++
+		if fetches == 1 {
+			cache.invalidate()
+		}
+		return []*model.AutocompleteCandidate{{Text: field, Count: fetches}}, nil
+	}
+
+	// 2. Exercise
+	cache.get(AutocompleteFieldSpeaker, fetch)
+	index, err := cache.get(AutocompleteFieldSpeaker, fetch)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+	assert.Equal(t, 2, index.entries[0].count)
+
+	// 4. Teardown
+}
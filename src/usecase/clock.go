@@ -0,0 +1,6 @@
+package usecase
+
+import "time"
+
+// Clock 現在時刻を返す。ユースケースごとに保持し、テストでは固定した時刻を返すものに差し替える。
+type Clock func() time.Time
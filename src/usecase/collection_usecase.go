@@ -0,0 +1,306 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// ErrCollectionNotFound まとめが存在しない場合、または非公開のまとめを作成者以外が参照した場合のエラー
+var ErrCollectionNotFound = errors.New("まとめが見つかりません。")
+
+// ErrCollectionForbidden まとめを作成者以外が変更しようとした場合のエラー
+var ErrCollectionForbidden = errors.New("まとめを変更する権限がありません。")
+
+// ErrCollectionPostNotIncluded まとめに含まれない投稿を指定した場合のエラー
+var ErrCollectionPostNotIncluded = errors.New("まとめに含まれない投稿が指定されています。")
+
+// ErrCollectionOrderMismatch 並び替えで指定された投稿がまとめの投稿と一致しない場合のエラー
+var ErrCollectionOrderMismatch = errors.New("まとめの全ての投稿を重複なく指定してください。")
+
+// CollectionUseCase インターフェース
+type CollectionUseCase interface {
+	// まとめ登録
+	CreateCollection(userID int, name, description string, isPublic bool) (*model.Collection, error)
+	// ユーザーのまとめ一覧取得
+	GetCollections(userID, loginUserID, limit, page int) (totalCount int, collections []*model.GetCollectionResult, err error)
+	// まとめ詳細取得
+	GetCollection(id, loginUserID int) (*model.GetCollectionResult, error)
+	// まとめ更新
+	UpdateCollection(id, loginUserID int, name, description string, coverPostID int, isPublic bool) error
+	// まとめ削除
+	DeleteCollection(id, loginUserID int) error
+
+	// まとめの投稿一覧取得
+	GetCollectionPosts(id, loginUserID, limit, page int) (totalCount int, posts []*model.GetPostResult, err error)
+	// まとめへの投稿追加
+	AddCollectionPost(id, loginUserID, postID int) error
+	// まとめからの投稿削除
+	DeleteCollectionPost(id, loginUserID, postID int) error
+	// まとめの投稿の並び替え
+	ReorderCollectionPosts(id, loginUserID int, postIDs []int) error
+
+	// 投稿を含むまとめ一覧取得
+	GetPostCollections(postID, loginUserID int) ([]*model.GetCollectionResult, error)
+}
+
+// collectionUseCase 構造体
+type collectionUseCase struct {
+	repository.PostRepository
+	repository.CollectionRepository
+}
+
+// NewCollectionUseCase CollectionUseCaseを生成。
+func NewCollectionUseCase(postRepository repository.PostRepository, collectionRepository repository.CollectionRepository) CollectionUseCase {
+	return &collectionUseCase{postRepository, collectionRepository}
+}
+
+// CreateCollection まとめ登録
+func (usecase *collectionUseCase) CreateCollection(userID int, name, description string, isPublic bool) (*model.Collection, error) {
+	collection := &model.Collection{
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		IsPublic:    isPublic,
+	}
+	if err := usecase.CollectionRepository.CreateCollection(collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// GetCollections ユーザーのまとめ一覧取得。本人以外には公開されたまとめのみ返す。
+func (usecase *collectionUseCase) GetCollections(userID, loginUserID, limit, page int) (totalCount int, collections []*model.GetCollectionResult, err error) {
+	totalCount, collections, err = usecase.CollectionRepository.FetchCollections(userID, userID == loginUserID, limit, page)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err = usecase.completeCollections(collections, loginUserID); err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, collections, nil
+}
+
+// GetCollection まとめ詳細取得。非公開のまとめは作成者のみ取得できる。
+func (usecase *collectionUseCase) GetCollection(id, loginUserID int) (*model.GetCollectionResult, error) {
+	collection, err := usecase.fetchVisibleCollection(id, loginUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.completeCollections([]*model.GetCollectionResult{collection}, loginUserID); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// UpdateCollection まとめ更新。表紙を解除する場合はcoverPostIDに0を指定する。
+func (usecase *collectionUseCase) UpdateCollection(id, loginUserID int, name, description string, coverPostID int, isPublic bool) error {
+	if _, err := usecase.fetchOwnCollection(id, loginUserID); err != nil {
+		return err
+	}
+
+	if coverPostID > 0 {
+		postIDs, err := usecase.CollectionRepository.FetchCollectionPostIDs(id)
+		if err != nil {
+			return err
+		}
+		if !containsInt(postIDs, coverPostID) {
+			return ErrCollectionPostNotIncluded
+		}
+	}
+
+	collection := &model.Collection{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		CoverPostID: coverPostID,
+		IsPublic:    isPublic,
+	}
+	return usecase.CollectionRepository.UpdateCollection(collection)
+}
+
+// DeleteCollection まとめ削除
+func (usecase *collectionUseCase) DeleteCollection(id, loginUserID int) error {
+	if _, err := usecase.fetchOwnCollection(id, loginUserID); err != nil {
+		return err
+	}
+	return usecase.CollectionRepository.DeleteCollection(id)
+}
+
+// GetCollectionPosts まとめの投稿一覧取得。並び順に返す。
+func (usecase *collectionUseCase) GetCollectionPosts(id, loginUserID, limit, page int) (totalCount int, posts []*model.GetPostResult, err error) {
+	if _, err = usecase.fetchVisibleCollection(id, loginUserID); err != nil {
+		return 0, nil, err
+	}
+
+	totalCount, posts, err = usecase.CollectionRepository.FetchCollectionPosts(id, loginUserID, limit, page)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// 動画URL加工、引用表記生成
+	for _, post := range posts {
+		post.EmbedMovieURL = makeEmbedMovieURL(post.MovieURL)
+		post.Citation = makeCitation(post.Speaker, &post.PostSource)
+	}
+
+	return totalCount, posts, nil
+}
+
+// AddCollectionPost まとめへの投稿追加。末尾に追加する。
+func (usecase *collectionUseCase) AddCollectionPost(id, loginUserID, postID int) error {
+	if _, err := usecase.fetchOwnCollection(id, loginUserID); err != nil {
+		return err
+	}
+	if _, err := usecase.PostRepository.FetchByID(postID, 0); err != nil {
+		return err
+	}
+	return usecase.CollectionRepository.AddCollectionItem(id, postID)
+}
+
+// DeleteCollectionPost まとめからの投稿削除。表紙の投稿を削除した場合は表紙を解除する。
+func (usecase *collectionUseCase) DeleteCollectionPost(id, loginUserID, postID int) error {
+	collection, err := usecase.fetchOwnCollection(id, loginUserID)
+	if err != nil {
+		return err
+	}
+	if err = usecase.CollectionRepository.DeleteCollectionItem(id, postID); err != nil {
+		return err
+	}
+
+	if collection.CoverPostID != postID {
+		return nil
+	}
+	collection.CoverPostID = 0
+	return usecase.CollectionRepository.UpdateCollection(&collection.Collection)
+}
+
+// ReorderCollectionPosts まとめの投稿の並び替え。postIDsにはまとめの全ての投稿を新しい順序で指定する。
+func (usecase *collectionUseCase) ReorderCollectionPosts(id, loginUserID int, postIDs []int) error {
+	if _, err := usecase.fetchOwnCollection(id, loginUserID); err != nil {
+		return err
+	}
+
+	current, err := usecase.CollectionRepository.FetchCollectionPostIDs(id)
+	if err != nil {
+		return err
+	}
+	if !isPermutation(current, postIDs) {
+		return ErrCollectionOrderMismatch
+	}
+
+	return usecase.CollectionRepository.ReorderCollectionItems(id, postIDs)
+}
+
+// GetPostCollections 投稿を含むまとめ一覧取得。公開されたまとめとログインユーザーのまとめを返す。
+func (usecase *collectionUseCase) GetPostCollections(postID, loginUserID int) ([]*model.GetCollectionResult, error) {
+	collections, err := usecase.CollectionRepository.FetchCollectionsByPostID(postID, loginUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err = usecase.completeCollections(collections, loginUserID); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// fetchVisibleCollection ログインユーザーが参照できるまとめを取得する。
+// 存在しない場合や、非公開のまとめを作成者以外が参照した場合はErrCollectionNotFoundを返す。
+func (usecase *collectionUseCase) fetchVisibleCollection(id, loginUserID int) (*model.GetCollectionResult, error) {
+	collection, err := usecase.CollectionRepository.FetchCollectionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if collection == nil || (!collection.IsPublic && collection.UserID != loginUserID) {
+		return nil, ErrCollectionNotFound
+	}
+	return collection, nil
+}
+
+// fetchOwnCollection ログインユーザーが作成したまとめを取得する。
+// 作成者以外の場合、公開されたまとめはErrCollectionForbidden、非公開のまとめはErrCollectionNotFoundを返す。
+func (usecase *collectionUseCase) fetchOwnCollection(id, loginUserID int) (*model.GetCollectionResult, error) {
+	collection, err := usecase.fetchVisibleCollection(id, loginUserID)
+	if err != nil {
+		return nil, err
+	}
+	if collection.UserID != loginUserID {
+		return nil, ErrCollectionForbidden
+	}
+	return collection, nil
+}
+
+// completeCollections 表紙の投稿と共有用URLを設定する。
+// 表紙が指定されていない場合や、表紙の投稿が削除された場合は先頭の投稿を表紙とする。
+func (usecase *collectionUseCase) completeCollections(collections []*model.GetCollectionResult, loginUserID int) error {
+	coverPostIDs := []int{}
+	for _, collection := range collections {
+		collection.ShareURL = CollectionShareURL(collection.ID)
+		for _, id := range []int{collection.CoverPostID, collection.FirstPostID} {
+			if id > 0 && !containsInt(coverPostIDs, id) {
+				coverPostIDs = append(coverPostIDs, id)
+			}
+		}
+	}
+	if len(coverPostIDs) == 0 {
+		return nil
+	}
+
+	posts, err := usecase.PostRepository.FetchByIDs(coverPostIDs, loginUserID)
+	if err != nil {
+		return err
+	}
+	postMap := map[int]*model.GetPostResult{}
+	for _, post := range posts {
+		post.EmbedMovieURL = makeEmbedMovieURL(post.MovieURL)
+		post.Citation = makeCitation(post.Speaker, &post.PostSource)
+		postMap[post.ID] = post
+	}
+	for _, collection := range collections {
+		if post, ok := postMap[collection.CoverPostID]; ok {
+			collection.CoverPost = post
+		} else {
+			collection.CoverPost = postMap[collection.FirstPostID]
+		}
+	}
+	return nil
+}
+
+// CollectionShareURL まとめの共有用URL。リンクプレビュー用ページを経由してフロントエンドのまとめページを表示する。
+func CollectionShareURL(collectionID int) string {
+	return apiURL(fmt.Sprintf("/share/collections/%d", collectionID))
+}
+
+// containsInt valuesにvalueが含まれるかどうか
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// isPermutation bがaの並び替えであるかどうか
+func isPermutation(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := map[int]int{}
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		counts[v]--
+		if counts[v] < 0 {
+			return false
+		}
+	}
+	return true
+}
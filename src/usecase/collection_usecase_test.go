@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// まとめを生成
+func makeGetCollectionResult(id, userID int, isPublic bool) *model.GetCollectionResult {
+	return &model.GetCollectionResult{
+		Collection: model.Collection{
+			ID:       id,
+			UserID:   userID,
+			Name:     fmt.Sprintf("name%d", id),
+			IsPublic: isPublic,
+		},
+		UserName: fmt.Sprintf("username%d", userID),
+	}
+}
+
+// まとめ登録テスト
+func TestCreateCollection_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewCollectionUseCase(&repository, &repository)
+	repository.On("CreateCollection", mock.MatchedBy(func(collection *model.Collection) bool {
+		return collection.UserID == 1 && collection.Name == "朝読む言葉" && collection.IsPublic
+	})).Return(nil)
+
+	// 2. Exercise
+	collection, err := usecase.CreateCollection(1, "朝読む言葉", "description", true)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, "description", collection.Description)
+
+	// 4. Teardown
+}
+
+// まとめ一覧テスト
+func TestGetCollections_visibility(t *testing.T) {
+	cases := []struct {
+		label          string
+		loginUserID    int
+		includePrivate bool
+	}{
+		{"本人", 1, true},
+		{"本人以外", 2, false},
+		{"未ログイン", 0, false},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		repository := mockPostRepository{}
+		usecase := NewCollectionUseCase(&repository, &repository)
+		repository.On("FetchCollections", 1, test.includePrivate, 10, 1).Return(0, []*model.GetCollectionResult{}, nil)
+
+		// 2. Exercise
+		_, _, err := usecase.GetCollections(1, test.loginUserID, 10, 1)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		repository.AssertExpectations(t)
+
+		// 4. Teardown
+	}
+}
+
+// まとめ詳細テスト
+func TestGetCollection_success_cover(t *testing.T) {
+	cases := []struct {
+		label       string
+		coverPostID int
+		firstPostID int
+		posts       []*model.GetPostResult
+		expected    int
+	}{
+		{"表紙指定", 2, 1, []*model.GetPostResult{makeGetPostResult(1), makeGetPostResult(2)}, 2},
+		{"表紙未指定", 0, 1, []*model.GetPostResult{makeGetPostResult(1)}, 1},
+		{"表紙の投稿が削除済み", 2, 1, []*model.GetPostResult{makeGetPostResult(1)}, 1},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		repository := mockPostRepository{}
+		usecase := NewCollectionUseCase(&repository, &repository)
+		collection := makeGetCollectionResult(1, 1, true)
+		collection.CoverPostID = test.coverPostID
+		collection.FirstPostID = test.firstPostID
+		repository.On("FetchCollectionByID", 1).Return(collection, nil)
+		repository.On("FetchByIDs", mock.Anything, 2).Return(test.posts, nil)
+
+		// 2. Exercise
+		actual, err := usecase.GetCollection(1, 2)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, test.expected, actual.CoverPost.ID, test.label)
+		assert.Equal(t, CollectionShareURL(1), actual.ShareURL, test.label)
+
+		// 4. Teardown
+	}
+}
+
+func TestGetCollection_error_notFound(t *testing.T) {
+	cases := []struct {
+		label       string
+		collection  *model.GetCollectionResult
+		loginUserID int
+	}{
+		{"存在しない", nil, 1},
+		{"非公開", makeGetCollectionResult(1, 1, false), 2},
+		{"非公開・未ログイン", makeGetCollectionResult(1, 1, false), 0},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		repository := mockPostRepository{}
+		usecase := NewCollectionUseCase(&repository, &repository)
+		repository.On("FetchCollectionByID", 1).Return(test.collection, nil)
+
+		// 2. Exercise
+		_, err := usecase.GetCollection(1, test.loginUserID)
+
+		// 3. Verify
+		assert.Equal(t, ErrCollectionNotFound, err, test.label)
+
+		// 4. Teardown
+	}
+}
+
+// まとめ更新テスト
+func TestUpdateCollection_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewCollectionUseCase(&repository, &repository)
+	repository.On("FetchCollectionByID", 1).Return(makeGetCollectionResult(1, 1, true), nil)
+	repository.On("FetchCollectionPostIDs", 1).Return([]int{3, 5}, nil)
+	repository.On("UpdateCollection", mock.MatchedBy(func(collection *model.Collection) bool {
+		return collection.ID == 1 && collection.CoverPostID == 5 && !collection.IsPublic
+	})).Return(nil)
+
+	// 2. Exercise
+	err := usecase.UpdateCollection(1, 1, "試合前", "", 5, false)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestUpdateCollection_error(t *testing.T) {
+	cases := []struct {
+		label       string
+		loginUserID int
+		coverPostID int
+		expected    error
+	}{
+		{"作成者以外", 2, 0, ErrCollectionForbidden},
+		{"含まれない表紙", 1, 4, ErrCollectionPostNotIncluded},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		repository := mockPostRepository{}
+		usecase := NewCollectionUseCase(&repository, &repository)
+		repository.On("FetchCollectionByID", 1).Return(makeGetCollectionResult(1, 1, true), nil)
+		repository.On("FetchCollectionPostIDs", 1).Return([]int{3, 5}, nil)
+
+		// 2. Exercise
+		err := usecase.UpdateCollection(1, test.loginUserID, "name", "", test.coverPostID, true)
+
+		// 3. Verify
+		assert.Equal(t, test.expected, err, test.label)
+		repository.AssertNotCalled(t, "UpdateCollection", mock.Anything)
+
+		// 4. Teardown
+	}
+}
+
+// まとめの投稿一覧テスト
+func TestGetCollectionPosts_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewCollectionUseCase(&repository, &repository)
+	post := makeGetPostResult(1)
+	post.MovieURL = "https://youtu.be/A1"
+	repository.On("FetchCollectionByID", 1).Return(makeGetCollectionResult(1, 1, false), nil)
+	repository.On("FetchCollectionPosts", 1, 1, 10, 1).Return(1, []*model.GetPostResult{post}, nil)
+
+	// 2. Exercise
+	totalCount, posts, err := usecase.GetCollectionPosts(1, 1, 10, 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 1, totalCount)
+	assert.Equal(t, "https://www.youtube.com/embed/A1", posts[0].EmbedMovieURL)
+
+	// 4. Teardown
+}
+
+// まとめへの投稿追加テスト
+func TestAddCollectionPost_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewCollectionUseCase(&repository, &repository)
+	repository.On("FetchCollectionByID", 1).Return(makeGetCollectionResult(1, 1, true), nil)
+	repository.On("FetchByID", 3, 0).Return(makeGetPostResult(3), nil)
+	repository.On("AddCollectionItem", 1, 3).Return(nil)
+
+	// 2. Exercise
+	err := usecase.AddCollectionPost(1, 1, 3)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestAddCollectionPost_error_postNotFound(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewCollectionUseCase(&repository, &repository)
+	repository.On("FetchCollectionByID", 1).Return(makeGetCollectionResult(1, 1, true), nil)
+	repository.On("FetchByID", 3, 0).Return(nil, errors.New("record not found"))
+
+	// 2. Exercise
+	err := usecase.AddCollectionPost(1, 1, 3)
+
+	// 3. Verify
+	assert.Error(t, err)
+	repository.AssertNotCalled(t, "AddCollectionItem", mock.Anything, mock.Anything)
+
+	// 4. Teardown
+}
+
+// まとめからの投稿削除テスト
+func TestDeleteCollectionPost_success_cover(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewCollectionUseCase(&repository, &repository)
+	collection := makeGetCollectionResult(1, 1, true)
+	collection.CoverPostID = 3
+	repository.On("FetchCollectionByID", 1).Return(collection, nil)
+	repository.On("DeleteCollectionItem", 1, 3).Return(nil)
+	repository.On("UpdateCollection", mock.MatchedBy(func(collection *model.Collection) bool {
+		return collection.ID == 1 && collection.CoverPostID == 0 && collection.IsPublic
+	})).Return(nil)
+
+	// 2. Exercise
+	err := usecase.DeleteCollectionPost(1, 1, 3)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+// まとめの投稿の並び替えテスト
+func TestReorderCollectionPosts(t *testing.T) {
+	cases := []struct {
+		label    string
+		postIDs  []int
+		expected error
+	}{
+		{"並び替え", []int{5, 3, 4}, nil},
+		{"不足", []int{5, 3}, ErrCollectionOrderMismatch},
+		{"重複", []int{5, 3, 3}, ErrCollectionOrderMismatch},
+		{"含まれない投稿", []int{5, 3, 6}, ErrCollectionOrderMismatch},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		repository := mockPostRepository{}
+		usecase := NewCollectionUseCase(&repository, &repository)
+		repository.On("FetchCollectionByID", 1).Return(makeGetCollectionResult(1, 1, true), nil)
+		repository.On("FetchCollectionPostIDs", 1).Return([]int{3, 4, 5}, nil)
+		repository.On("ReorderCollectionItems", 1, test.postIDs).Return(nil)
+
+		// 2. Exercise
+		err := usecase.ReorderCollectionPosts(1, 1, test.postIDs)
+
+		// 3. Verify
+		assert.Equal(t, test.expected, err, test.label)
+		if test.expected != nil {
+			repository.AssertNotCalled(t, "ReorderCollectionItems", mock.Anything, mock.Anything)
+		}
+
+		// 4. Teardown
+	}
+}
+
+// 投稿を含むまとめ一覧テスト
+func TestGetPostCollections_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewCollectionUseCase(&repository, &repository)
+	collections := []*model.GetCollectionResult{makeGetCollectionResult(1, 1, true), makeGetCollectionResult(2, 2, false)}
+	repository.On("FetchCollectionsByPostID", 3, 2).Return(collections, nil)
+
+	// 2. Exercise
+	actual, err := usecase.GetPostCollections(3, 2)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(actual))
+	assert.Equal(t, CollectionShareURL(2), actual[1].ShareURL)
+
+	// 4. Teardown
+}
@@ -16,23 +16,42 @@ type CommentUseCase interface {
 // commentUseCase 構造体
 type commentUseCase struct {
 	repository.PostRepository
+	repository.ProhibitedWordRepository
+	repository.ReportRepository
 }
 
 // NewCommentUseCase CommentUseCaseを生成。
-func NewCommentUseCase(repository repository.PostRepository) CommentUseCase {
-	return &commentUseCase{repository}
+func NewCommentUseCase(postRepository repository.PostRepository, prohibitedWordRepository repository.ProhibitedWordRepository, reportRepository repository.ReportRepository) CommentUseCase {
+	return &commentUseCase{postRepository, prohibitedWordRepository, reportRepository}
 }
 
-// CreateComment 登録
+// CreateComment 登録。投稿した本人への通知、コメントの配信は、ドメインイベントのサブスクライバーが行う。
+// 登録を拒否する禁止語を含む場合はProhibitedWordErrorを返す。モデレーターの確認待ちにする禁止語を含む場合は非表示で登録する。
 func (usecase *commentUseCase) CreateComment(postID, userID int, body string) (err error) {
+	reviewWords, err := applyWordFilter(usecase.ProhibitedWordRepository, &filteredField{"Body", &body})
+	if err != nil {
+		return err
+	}
 	comment := model.Comment{
-		PostID: postID,
-		UserID: userID,
-		Body:   body,
+		PostID:   postID,
+		UserID:   userID,
+		Body:     body,
+		IsHidden: len(reviewWords) > 0,
+	}
+	// 確認待ちのコメントは公開されていないため、ドメインイベントを発行しない
+	var events []*model.DomainEvent
+	if !comment.IsHidden {
+		events = append(events, model.NewDomainEvent(model.DomainEventCommentCreated, &comment))
 	}
-	err = usecase.PostRepository.CreateComment(&comment)
+	if err = usecase.PostRepository.CreateComment(&comment, events); err != nil {
+		return err
+	}
+	wakeDomainEventDispatcher()
 
-	return err
+	if comment.IsHidden {
+		return holdForReview(usecase.ReportRepository, model.ReportTargetComment, comment.ID, reviewWords)
+	}
+	return nil
 }
 
 // GetComments 一覧取得
@@ -47,8 +66,9 @@ func (usecase *commentUseCase) GetComments(postID, limit, page int) (totalCount
 
 // DeleteComment 削除
 func (usecase *commentUseCase) DeleteComment(id int) error {
-	if err := usecase.PostRepository.DeleteComment(id); err != nil {
+	if err := usecase.PostRepository.DeleteComment(id, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventCommentDeleted, &model.DomainEventTarget{ID: id})}); err != nil {
 		return err
 	}
+	wakeDomainEventDispatcher()
 	return nil
 }
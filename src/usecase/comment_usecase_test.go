@@ -43,7 +43,9 @@ func makeGetCommentResult(id, postID, userID int) *model.GetCommentResult {
 func TestCreateComment_success(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewCommentUseCase(&repository)
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewCommentUseCase(&repository, &prohibitedWordRepository, &mockReportRepository{})
+	setProhibitedWords(t, &prohibitedWordRepository)
 	id := 1
 	postID := 1
 	userID := 1
@@ -55,6 +57,10 @@ func TestCreateComment_success(t *testing.T) {
 
 	// 3. Verify
 	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+	prohibitedWordRepository.AssertExpectations(t)
+	assert.Len(t, repository.domainEvents, 1)
+	assert.Equal(t, model.DomainEventCommentCreated, repository.domainEvents[0].Type)
 
 	// 4. Teardown
 }
@@ -63,7 +69,9 @@ func TestCreateComment_success(t *testing.T) {
 func TestCreateComment_error(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewCommentUseCase(&repository)
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewCommentUseCase(&repository, &prohibitedWordRepository, &mockReportRepository{})
+	setProhibitedWords(t, &prohibitedWordRepository)
 	id := 1
 	postID := 1
 	userID := 1
@@ -83,7 +91,7 @@ func TestCreateComment_error(t *testing.T) {
 func TestGetComments_success(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewCommentUseCase(&repository)
+	usecase := NewCommentUseCase(&repository, &mockProhibitedWordRepository{}, &mockReportRepository{})
 	limit := 3
 	page := 1
 	postID := 1
@@ -107,7 +115,7 @@ func TestGetComments_success(t *testing.T) {
 func TestGetComments_error(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewCommentUseCase(&repository)
+	usecase := NewCommentUseCase(&repository, &mockProhibitedWordRepository{}, &mockReportRepository{})
 	limit := 3
 	page := 1
 	postID := 1
@@ -128,7 +136,7 @@ func TestGetComments_error(t *testing.T) {
 func TestDeleteComment_success(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewCommentUseCase(&repository)
+	usecase := NewCommentUseCase(&repository, &mockProhibitedWordRepository{}, &mockReportRepository{})
 	id := 1
 	repository.On("DeleteComment", id).Return(nil)
 
@@ -137,13 +145,14 @@ func TestDeleteComment_success(t *testing.T) {
 
 	// 3. Verify
 	assert.NoError(t, err)
+	assert.Equal(t, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventCommentDeleted, &model.DomainEventTarget{ID: id})}, repository.domainEvents)
 
 	// 4. Teardown
 }
 
 func TestDeleteComment_error(t *testing.T) {
 	repository := mockPostRepository{}
-	usecase := NewCommentUseCase(&repository)
+	usecase := NewCommentUseCase(&repository, &mockProhibitedWordRepository{}, &mockReportRepository{})
 	id := 1
 	repository.On("DeleteComment", id).Return(errors.New("error"))
 
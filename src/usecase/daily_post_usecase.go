@@ -0,0 +1,194 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// defaultDailyPostTimeZone タイムゾーンの指定がない場合に使用するタイムゾーン
+const defaultDailyPostTimeZone = "Asia/Tokyo"
+
+// defaultDailyPostRepeatWindow 同じ投稿を再度選ばない日数の既定値
+const defaultDailyPostRepeatWindow = 30
+
+// dailyPostDateFormat 日付の形式
+const dailyPostDateFormat = "2006-01-02"
+
+// ErrNoDailyPostCandidate 今日の言葉の候補となる投稿がない場合のエラー
+var ErrNoDailyPostCandidate = errors.New("投稿がありません。")
+
+// DailyPostUseCase インターフェース
+type DailyPostUseCase interface {
+	// 今日の言葉取得
+	GetDailyPost(timeZone string, loginUserID int) (date string, post *model.GetPostResult, err error)
+	// 今日の言葉指定
+	PinDailyPost(date string, postID int) error
+}
+
+// dailyPostUseCase 構造体
+type dailyPostUseCase struct {
+	repository.PostRepository
+	repository.DailyPostRepository
+	repository.ModerationRepository
+	repository.UserRepository
+	clock Clock
+}
+
+// NewDailyPostUseCase DailyPostUseCaseを生成。
+func NewDailyPostUseCase(postRepository repository.PostRepository, dailyPostRepository repository.DailyPostRepository, moderationRepository repository.ModerationRepository, userRepository repository.UserRepository) DailyPostUseCase {
+	return &dailyPostUseCase{postRepository, dailyPostRepository, moderationRepository, userRepository, time.Now}
+}
+
+// GetDailyPost 今日の言葉取得。
+// タイムゾーンの指定がない場合は、ログインユーザーの設定、既定のタイムゾーンの順に使用する。
+// 日付ごとに1件を決定的に選び、以降は同じ投稿を返す。
+// 選んだ投稿が削除または非表示にされた場合は、選び直す。
+func (usecase *dailyPostUseCase) GetDailyPost(timeZone string, loginUserID int) (date string, post *model.GetPostResult, err error) {
+	if timeZone == "" && loginUserID > 0 {
+		user, err := usecase.UserRepository.FetchByID(loginUserID)
+		if err != nil {
+			return "", nil, err
+		}
+		timeZone = user.TimeZone
+	}
+	if timeZone == "" {
+		timeZone = defaultDailyPostTimeZone
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return "", nil, err
+	}
+	today := usecase.clock().In(location)
+	date = today.Format(dailyPostDateFormat)
+
+	dailyPost, err := usecase.DailyPostRepository.FetchDailyPost(date)
+	if err != nil {
+		return "", nil, err
+	}
+	replace := false
+	if dailyPost != nil {
+		available, err := usecase.isAvailablePost(dailyPost.PostID)
+		if err != nil {
+			return "", nil, err
+		}
+		if !available {
+			dailyPost = nil
+			replace = true
+		}
+	}
+	if dailyPost == nil {
+		if dailyPost, err = usecase.selectDailyPost(today, replace); err != nil {
+			return "", nil, err
+		}
+	}
+
+	post, err = usecase.PostRepository.FetchByID(dailyPost.PostID, loginUserID)
+	if err != nil {
+		return "", nil, err
+	}
+	post.EmbedMovieURL = makeEmbedMovieURL(post.MovieURL)
+	post.Citation = makeCitation(post.Speaker, &post.PostSource)
+
+	return date, post, nil
+}
+
+// isAvailablePost 投稿が削除、非表示にされておらず、今日の言葉として表示できる場合はtrueを返す。
+func (usecase *dailyPostUseCase) isAvailablePost(postID int) (bool, error) {
+	post, err := usecase.ModerationRepository.FetchPostForModeration(postID)
+	if err != nil {
+		return false, err
+	}
+	return post != nil && !post.IsHidden, nil
+}
+
+// selectDailyPost 指定日の今日の言葉を選んで登録する。replaceがtrueの場合は登録済みの今日の言葉を置き換える。
+// 直近に選ばれた投稿を除き、お気に入り数が多いほど選ばれやすくなるよう日付をシードとして抽選する。
+func (usecase *dailyPostUseCase) selectDailyPost(day time.Time, replace bool) (*model.DailyPost, error) {
+	date := day.Format(dailyPostDateFormat)
+	since := day.AddDate(0, 0, -dailyPostRepeatWindow()).Format(dailyPostDateFormat)
+
+	candidates, err := usecase.DailyPostRepository.FetchDailyPostCandidates(since)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		// 全ての投稿が直近に選ばれている場合は除外しない
+		if candidates, err = usecase.DailyPostRepository.FetchDailyPostCandidates(""); err != nil {
+			return nil, err
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoDailyPostCandidate
+	}
+
+	dailyPost := &model.DailyPost{Date: date, PostID: pickDailyPostCandidate(date, candidates)}
+	if replace {
+		if err := usecase.DailyPostRepository.SaveDailyPost(dailyPost); err != nil {
+			return nil, err
+		}
+		return dailyPost, nil
+	}
+	if err := usecase.DailyPostRepository.CreateDailyPost(dailyPost); err != nil {
+		// 同時に他のリクエストが登録した場合は、登録済みのものを使用する
+		registered, fetchErr := usecase.DailyPostRepository.FetchDailyPost(date)
+		if fetchErr != nil || registered == nil {
+			return nil, err
+		}
+		return registered, nil
+	}
+
+	return dailyPost, nil
+}
+
+// pickDailyPostCandidate 日付をシードとして、お気に入り数で重み付けした抽選を行う。
+func pickDailyPostCandidate(date string, candidates []*model.DailyPostCandidate) int {
+	hash := fnv.New64a()
+	hash.Write([]byte(date))
+	random := rand.New(rand.NewSource(int64(hash.Sum64())))
+
+	totalWeight := 0
+	for _, candidate := range candidates {
+		totalWeight += candidate.FavoriteCount + 1
+	}
+
+	point := random.Intn(totalWeight)
+	for _, candidate := range candidates {
+		point -= candidate.FavoriteCount + 1
+		if point < 0 {
+			return candidate.PostID
+		}
+	}
+	return candidates[len(candidates)-1].PostID
+}
+
+// dailyPostRepeatWindow 同じ投稿を再度選ばない日数。環境変数DAILY_POST_REPEAT_WINDOWで変更できる。
+func dailyPostRepeatWindow() int {
+	window, err := strconv.Atoi(os.Getenv("DAILY_POST_REPEAT_WINDOW"))
+	if err != nil || window < 0 {
+		return defaultDailyPostRepeatWindow
+	}
+	return window
+}
+
+// PinDailyPost 今日の言葉指定。指定日の今日の言葉を指定した投稿に固定する。
+// 存在しない投稿、非表示の投稿の場合はErrPostNotFoundを返す。
+func (usecase *dailyPostUseCase) PinDailyPost(date string, postID int) error {
+	available, err := usecase.isAvailablePost(postID)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return ErrPostNotFound
+	}
+
+	dailyPost := &model.DailyPost{Date: date, PostID: postID, Pinned: true}
+	return usecase.DailyPostRepository.SaveDailyPost(dailyPost)
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 固定した時刻を返すClock
+func fixedClock(t time.Time) Clock {
+	return func() time.Time { return t }
+}
+
+// 今日の言葉取得テスト(登録済み)
+func TestGetDailyPost_success_registered(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := &dailyPostUseCase{&postRepository, &postRepository, &postRepository, &userRepository, fixedClock(time.Date(2020, 12, 31, 16, 0, 0, 0, time.UTC))}
+	expected := makeGetPostResult(3)
+	// 日本時間では2021-01-01
+	postRepository.On("FetchDailyPost", "2021-01-01").Return(&model.DailyPost{Date: "2021-01-01", PostID: 3}, nil)
+	postRepository.On("FetchPostForModeration", 3).Return(&model.Post{ID: 3}, nil)
+	postRepository.On("FetchByID", 3, 0).Return(expected, nil)
+
+	// 2. Exercise
+	date, post, err := usecase.GetDailyPost("", 0)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-01-01", date)
+	assert.Equal(t, expected.ID, post.ID)
+
+	// 4. Teardown
+}
+
+// 今日の言葉取得テスト(ユーザーのタイムゾーン設定を使用)
+func TestGetDailyPost_success_userTimeZone(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := &dailyPostUseCase{&postRepository, &postRepository, &postRepository, &userRepository, fixedClock(time.Date(2020, 12, 31, 16, 0, 0, 0, time.UTC))}
+	user := makeUserForRead(1)
+	user.TimeZone = "America/New_York"
+	userRepository.On("FetchByID", 1).Return(user, nil)
+	postRepository.On("FetchDailyPost", "2020-12-31").Return(&model.DailyPost{Date: "2020-12-31", PostID: 2}, nil)
+	postRepository.On("FetchPostForModeration", 2).Return(&model.Post{ID: 2}, nil)
+	postRepository.On("FetchByID", 2, 1).Return(makeGetPostResult(2), nil)
+
+	// 2. Exercise
+	date, _, err := usecase.GetDailyPost("", 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, "2020-12-31", date)
+
+	// 4. Teardown
+}
+
+// 今日の言葉取得テスト(未登録の場合は抽選して登録)
+func TestGetDailyPost_success_select(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := &dailyPostUseCase{&postRepository, &postRepository, &postRepository, &userRepository, fixedClock(time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC))}
+	candidates := []*model.DailyPostCandidate{{PostID: 5, FavoriteCount: 0}}
+	postRepository.On("FetchDailyPost", "2021-01-31").Return(nil, nil)
+	postRepository.On("FetchDailyPostCandidates", "2021-01-01").Return(candidates, nil)
+	postRepository.On("CreateDailyPost", &model.DailyPost{Date: "2021-01-31", PostID: 5}).Return(nil)
+	postRepository.On("FetchByID", 5, 0).Return(makeGetPostResult(5), nil)
+
+	// 2. Exercise
+	date, post, err := usecase.GetDailyPost("UTC", 0)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-01-31", date)
+	assert.Equal(t, 5, post.ID)
+	postRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+// 今日の言葉取得テスト(登録済みの投稿が削除、非表示にされた場合は選び直す)
+func TestGetDailyPost_success_reselect(t *testing.T) {
+	cases := []struct {
+		label string
+		post  *model.Post
+	}{
+		{"削除", nil},
+		{"非表示", &model.Post{ID: 3, IsHidden: true}},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		postRepository := mockPostRepository{}
+		userRepository := mockUserRepository{}
+		usecase := &dailyPostUseCase{&postRepository, &postRepository, &postRepository, &userRepository, fixedClock(time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC))}
+		postRepository.On("FetchDailyPost", "2021-01-31").Return(&model.DailyPost{Date: "2021-01-31", PostID: 3}, nil)
+		postRepository.On("FetchPostForModeration", 3).Return(test.post, nil)
+		postRepository.On("FetchDailyPostCandidates", "2021-01-01").Return([]*model.DailyPostCandidate{{PostID: 5}}, nil)
+		postRepository.On("SaveDailyPost", &model.DailyPost{Date: "2021-01-31", PostID: 5}).Return(nil)
+		postRepository.On("FetchByID", 5, 0).Return(makeGetPostResult(5), nil)
+
+		// 2. Exercise
+		_, post, err := usecase.GetDailyPost("UTC", 0)
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, 5, post.ID, test.label)
+		postRepository.AssertExpectations(t)
+
+		// 4. Teardown
+	}
+}
+
+// 今日の言葉取得テスト(候補なし)
+func TestGetDailyPost_error_noCandidate(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := &dailyPostUseCase{&postRepository, &postRepository, &postRepository, &userRepository, fixedClock(time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC))}
+	postRepository.On("FetchDailyPost", "2021-01-31").Return(nil, nil)
+	postRepository.On("FetchDailyPostCandidates", mock.Anything).Return([]*model.DailyPostCandidate{}, nil)
+
+	// 2. Exercise
+	_, post, err := usecase.GetDailyPost("UTC", 0)
+
+	// 3. Verify
+	assert.Equal(t, ErrNoDailyPostCandidate, err)
+	assert.Nil(t, post)
+
+	// 4. Teardown
+}
+
+func TestGetDailyPost_error(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewDailyPostUseCase(&postRepository, &postRepository, &postRepository, &userRepository)
+	postRepository.On("FetchDailyPost", mock.Anything).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	_, post, err := usecase.GetDailyPost("UTC", 0)
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, post)
+
+	// 4. Teardown
+}
+
+// 抽選テスト
+func TestPickDailyPostCandidate(t *testing.T) {
+	candidates := []*model.DailyPostCandidate{
+		{PostID: 1, FavoriteCount: 0},
+		{PostID: 2, FavoriteCount: 100},
+		{PostID: 3, FavoriteCount: 0},
+	}
+
+	// 同じ日付では同じ投稿が選ばれる
+	assert.Equal(t, pickDailyPostCandidate("2021-01-01", candidates), pickDailyPostCandidate("2021-01-01", candidates))
+
+	// お気に入り数が多い投稿が選ばれやすい
+	counts := map[int]int{}
+	day := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		counts[pickDailyPostCandidate(day.AddDate(0, 0, i).Format(dailyPostDateFormat), candidates)]++
+	}
+	assert.True(t, counts[2] > counts[1]+counts[3])
+}
+
+// 今日の言葉指定テスト
+func TestPinDailyPost_success(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewDailyPostUseCase(&postRepository, &postRepository, &postRepository, &userRepository)
+	postRepository.On("FetchPostForModeration", 1).Return(&model.Post{ID: 1}, nil)
+	postRepository.On("SaveDailyPost", &model.DailyPost{Date: "2021-01-01", PostID: 1, Pinned: true}).Return(nil)
+
+	// 2. Exercise
+	err := usecase.PinDailyPost("2021-01-01", 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	postRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestPinDailyPost_error_notFound(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewDailyPostUseCase(&postRepository, &postRepository, &postRepository, &userRepository)
+	postRepository.On("FetchPostForModeration", 1).Return(nil, nil)
+
+	// 2. Exercise
+	err := usecase.PinDailyPost("2021-01-01", 1)
+
+	// 3. Verify
+	assert.Equal(t, ErrPostNotFound, err)
+
+	// 4. Teardown
+}
+
+func TestPinDailyPost_error(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewDailyPostUseCase(&postRepository, &postRepository, &postRepository, &userRepository)
+	postRepository.On("FetchPostForModeration", 1).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	err := usecase.PinDailyPost("2021-01-01", 1)
+
+	// 3. Verify
+	assert.Error(t, err)
+
+	// 4. Teardown
+}
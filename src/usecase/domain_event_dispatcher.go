@@ -0,0 +1,166 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+const (
+	// outboxBatchSize 1回の処理で送信箱から取り出すイベントの件数
+	outboxBatchSize = 100
+	// outboxPollInterval 送信箱を確認する間隔。他のAPIサーバーで保存されたイベント、再処理待ちのイベントはこの間隔で処理する
+	outboxPollInterval = 5 * time.Second
+	// outboxLease 処理中のイベントを他のAPIサーバーが処理しない期間。過ぎても処理が終わらない場合は再処理する
+	outboxLease = time.Minute
+	// outboxMaxAttempts 1つのイベントを処理する回数の上限(初回を含む)
+	outboxMaxAttempts = 10
+	// outboxRetryInterval 最初の再処理までの間隔。以降は再処理ごとに2倍にする
+	outboxRetryInterval = 10 * time.Second
+)
+
+// DomainEventHandler ドメインイベントを処理するサブスクライバー。
+// イベントは少なくとも1回届き、失敗した場合やいずれかのサブスクライバーが失敗した場合は再び届くため、同じイベントを複数回処理しても問題ないようにする。
+type DomainEventHandler func(event *model.OutboxEvent) error
+
+// DomainEventDispatcher インターフェース
+type DomainEventDispatcher interface {
+	// サブスクライバーの登録
+	Subscribe(eventType string, handler DomainEventHandler)
+	// 未処理のイベントの処理
+	DispatchPending() (int, error)
+	// 未処理のイベントの処理を繰り返す
+	Run(stop <-chan struct{})
+}
+
+// domainEventDispatcher 構造体
+type domainEventDispatcher struct {
+	repository.OutboxRepository
+	mutex    sync.RWMutex
+	handlers map[string][]DomainEventHandler
+	clock    Clock
+}
+
+// domainEventWakeup イベントを保存したことをディスパッチャーに知らせ、確認の間隔を待たずに処理させる
+var domainEventWakeup = make(chan struct{}, 1)
+
+// NewDomainEventDispatcher DomainEventDispatcherを生成。
+func NewDomainEventDispatcher(repository repository.OutboxRepository) DomainEventDispatcher {
+	return &domainEventDispatcher{OutboxRepository: repository, handlers: map[string][]DomainEventHandler{}, clock: time.Now}
+}
+
+// wakeDomainEventDispatcher ディスパッチャーに未処理のイベントの処理を促す。既に促している場合は何もしない。
+func wakeDomainEventDispatcher() {
+	select {
+	case domainEventWakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Subscribe サブスクライバーの登録。イベントの種類ごとに、登録した順に呼び出す。
+func (dispatcher *domainEventDispatcher) Subscribe(eventType string, handler DomainEventHandler) {
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+
+	dispatcher.handlers[eventType] = append(dispatcher.handlers[eventType], handler)
+}
+
+// DispatchPending 処理する日時を過ぎた未処理のイベントをサブスクライバーに届ける。処理した件数を返す。
+// すべてのサブスクライバーが成功した場合は処理済みとし、失敗した場合は上限の回数に達するまで間隔を2倍ずつ空けて再処理する。
+func (dispatcher *domainEventDispatcher) DispatchPending() (int, error) {
+	events, err := dispatcher.OutboxRepository.FetchDueOutboxEvents(dispatcher.clock(), outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, event := range events {
+		startedAt := dispatcher.clock()
+		claimed, err := dispatcher.OutboxRepository.ClaimOutboxEvent(event.ID, startedAt, startedAt.Add(outboxLease))
+		if err != nil {
+			return count, err
+		}
+		if !claimed {
+			continue
+		}
+
+		event.Attempts++
+		if err := dispatcher.dispatch(event); err != nil {
+			event.Error = truncateRunes(err.Error(), 256)
+			if event.Attempts >= outboxMaxAttempts {
+				event.Status = model.OutboxEventStatusFailed
+				event.NextAttemptAt = nil
+				log.Printf("ドメインイベントの処理に失敗しました(ID：%d)：%v", event.ID, err)
+			} else {
+				nextAttemptAt := startedAt.Add(outboxRetryInterval << uint(event.Attempts-1))
+				event.NextAttemptAt = &nextAttemptAt
+			}
+		} else {
+			dispatchedAt := dispatcher.clock()
+			event.Status = model.OutboxEventStatusDispatched
+			event.Error = ""
+			event.NextAttemptAt = nil
+			event.DispatchedAt = &dispatchedAt
+		}
+		if err := dispatcher.OutboxRepository.UpdateOutboxEvent(event); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// dispatch イベントの種類のサブスクライバーを順に呼び出す。失敗したサブスクライバーがあっても残りを呼び出し、最初のエラーを返す。
+func (dispatcher *domainEventDispatcher) dispatch(event *model.OutboxEvent) (err error) {
+	dispatcher.mutex.RLock()
+	handlers := dispatcher.handlers[event.EventType]
+	dispatcher.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		if handlerErr := callDomainEventHandler(handler, event); handlerErr != nil && err == nil {
+			err = handlerErr
+		}
+	}
+	return err
+}
+
+// callDomainEventHandler サブスクライバーを呼び出す。パニックした場合もエラーとして扱う。
+func callDomainEventHandler(handler DomainEventHandler, event *model.OutboxEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return handler(event)
+}
+
+// Run 未処理のイベントの処理を、stopが閉じられるまで繰り返す。
+// 一定の間隔のほか、このAPIサーバーでイベントを保存した直後にも処理する。
+func (dispatcher *domainEventDispatcher) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			count, err := dispatcher.DispatchPending()
+			if err != nil {
+				log.Printf("ドメインイベントの処理に失敗しました：%v", err)
+			}
+			if err != nil || count < outboxBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-domainEventWakeup:
+		}
+	}
+}
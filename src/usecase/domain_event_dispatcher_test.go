@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockOutboxRepository struct {
+	mock.Mock
+}
+
+func (repository *mockOutboxRepository) FetchDueOutboxEvents(before time.Time, limit int) ([]*model.OutboxEvent, error) {
+	args := repository.Called(before, limit)
+	events, ok := args.Get(0).([]*model.OutboxEvent)
+	if ok {
+		return events, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (repository *mockOutboxRepository) ClaimOutboxEvent(id int, before, until time.Time) (bool, error) {
+	args := repository.Called(id, before, until)
+	return args.Bool(0), args.Error(1)
+}
+
+func (repository *mockOutboxRepository) UpdateOutboxEvent(event *model.OutboxEvent) error {
+	return repository.Called(event).Error(0)
+}
+
+// ドメインイベントの処理テスト
+func TestDispatchPending_success(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 16, 0, 0, 0, time.UTC)
+	repository := mockOutboxRepository{}
+	dispatcher := &domainEventDispatcher{OutboxRepository: &repository, handlers: map[string][]DomainEventHandler{}, clock: fixedClock(current)}
+	events := []*model.OutboxEvent{
+		{ID: 1, EventType: model.DomainEventPostCreated, Payload: `{"id":1}`, Status: model.OutboxEventStatusPending},
+		{ID: 2, EventType: model.DomainEventUserCreated, Payload: `{"id":2}`, Status: model.OutboxEventStatusPending},
+		{ID: 3, EventType: model.DomainEventPostCreated, Payload: `{"id":3}`, Status: model.OutboxEventStatusPending},
+	}
+	repository.On("FetchDueOutboxEvents", current, outboxBatchSize).Return(events, nil)
+	repository.On("ClaimOutboxEvent", 1, current, current.Add(outboxLease)).Return(true, nil)
+	repository.On("ClaimOutboxEvent", 2, current, current.Add(outboxLease)).Return(true, nil)
+	repository.On("ClaimOutboxEvent", 3, current, current.Add(outboxLease)).Return(false, nil)
+	repository.On("UpdateOutboxEvent", mock.AnythingOfType("*model.OutboxEvent")).Return(nil)
+
+	received := []string{}
+	dispatcher.Subscribe(model.DomainEventPostCreated, func(event *model.OutboxEvent) error {
+		received = append(received, "first:"+event.Payload)
+		return nil
+	})
+	dispatcher.Subscribe(model.DomainEventPostCreated, func(event *model.OutboxEvent) error {
+		received = append(received, "second:"+event.Payload)
+		return nil
+	})
+
+	// 2. Exercise
+	count, err := dispatcher.DispatchPending()
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{`first:{"id":1}`, `second:{"id":1}`}, received)
+	for _, event := range events[:2] {
+		assert.Equal(t, model.OutboxEventStatusDispatched, event.Status)
+		assert.Equal(t, 1, event.Attempts)
+		assert.Equal(t, current, *event.DispatchedAt)
+		assert.Nil(t, event.NextAttemptAt)
+	}
+	assert.Equal(t, model.OutboxEventStatusPending, events[2].Status)
+	assert.Equal(t, 0, events[2].Attempts)
+	repository.AssertNumberOfCalls(t, "UpdateOutboxEvent", 2)
+
+	// 4. Teardown
+}
+
+func TestDispatchPending_retry(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 16, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		label         string
+		attempts      int
+		handler       DomainEventHandler
+		status        string
+		nextAttemptAt *time.Time
+		err           string
+	}{
+		{"初回", 0, func(*model.OutboxEvent) error { return errors.New("error") }, model.OutboxEventStatusPending, timePtr(current.Add(outboxRetryInterval)), "error"},
+		{"3回目", 2, func(*model.OutboxEvent) error { return errors.New("error") }, model.OutboxEventStatusPending, timePtr(current.Add(4 * outboxRetryInterval)), "error"},
+		{"パニック", 0, func(*model.OutboxEvent) error { panic("panic") }, model.OutboxEventStatusPending, timePtr(current.Add(outboxRetryInterval)), "panic"},
+		{"上限", outboxMaxAttempts - 1, func(*model.OutboxEvent) error { return errors.New("error") }, model.OutboxEventStatusFailed, nil, "error"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			repository := mockOutboxRepository{}
+			dispatcher := &domainEventDispatcher{OutboxRepository: &repository, handlers: map[string][]DomainEventHandler{}, clock: fixedClock(current)}
+			event := &model.OutboxEvent{ID: 1, EventType: model.DomainEventPostCreated, Status: model.OutboxEventStatusPending, Attempts: c.attempts}
+			repository.On("FetchDueOutboxEvents", current, outboxBatchSize).Return([]*model.OutboxEvent{event}, nil)
+			repository.On("ClaimOutboxEvent", 1, current, current.Add(outboxLease)).Return(true, nil)
+			repository.On("UpdateOutboxEvent", event).Return(nil)
+
+			secondCalled := false
+			dispatcher.Subscribe(model.DomainEventPostCreated, c.handler)
+			dispatcher.Subscribe(model.DomainEventPostCreated, func(*model.OutboxEvent) error {
+				secondCalled = true
+				return nil
+			})
+
+			// 2. Exercise
+			count, err := dispatcher.DispatchPending()
+
+			// 3. Verify
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count)
+			assert.True(t, secondCalled)
+			assert.Equal(t, c.status, event.Status)
+			assert.Equal(t, c.attempts+1, event.Attempts)
+			assert.Equal(t, c.nextAttemptAt, event.NextAttemptAt)
+			assert.Equal(t, c.err, event.Error)
+			assert.Nil(t, event.DispatchedAt)
+		})
+	}
+
+	// 4. Teardown
+}
+
+func TestDispatchPending_error(t *testing.T) {
+	// 1. Setup
+	repository := mockOutboxRepository{}
+	dispatcher := NewDomainEventDispatcher(&repository)
+	repository.On("FetchDueOutboxEvents", mock.Anything, outboxBatchSize).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	count, err := dispatcher.DispatchPending()
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+
+	// 4. Teardown
+}
+
+// イベント保存直後の処理テスト
+func TestDomainEventDispatcher_Run(t *testing.T) {
+	// 1. Setup
+	repository := mockOutboxRepository{}
+	dispatcher := NewDomainEventDispatcher(&repository)
+	dispatched := make(chan struct{}, 10)
+	repository.On("FetchDueOutboxEvents", mock.Anything, outboxBatchSize).Return(nil, nil).Run(func(mock.Arguments) {
+		dispatched <- struct{}{}
+	})
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	// 2. Exercise
+	go func() {
+		dispatcher.Run(stop)
+		close(done)
+	}()
+	<-dispatched
+	wakeDomainEventDispatcher()
+
+	// 3. Verify
+	select {
+	case <-dispatched:
+	case <-time.After(outboxPollInterval / 2):
+		t.Fatal("イベント保存直後に処理されませんでした")
+	}
+
+	// 4. Teardown
+	close(stop)
+	<-done
+}
@@ -0,0 +1,211 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// duplicateSimilarityThreshold 重複とみなすタイトルの類似度の下限
+const duplicateSimilarityThreshold = 0.8
+
+// DuplicatePostError 重複の可能性がある投稿が存在する場合のエラー
+type DuplicatePostError struct {
+	Candidates []*model.DuplicateCandidate
+}
+
+// Error エラーメッセージ
+func (err *DuplicatePostError) Error() string {
+	return fmt.Sprintf("同じ発言者による類似した投稿が%d件あります。", len(err.Candidates))
+}
+
+// DuplicatePostUseCase インターフェース
+type DuplicatePostUseCase interface {
+	// 重複の可能性がある投稿のまとまり一覧取得
+	GetDuplicateClusters() ([]*model.DuplicateCluster, error)
+}
+
+// duplicatePostUseCase 構造体
+type duplicatePostUseCase struct {
+	repository.PostRepository
+}
+
+// NewDuplicatePostUseCase DuplicatePostUseCaseを生成。
+func NewDuplicatePostUseCase(repository repository.PostRepository) DuplicatePostUseCase {
+	return &duplicatePostUseCase{repository}
+}
+
+// GetDuplicateClusters 重複の可能性がある投稿のまとまり一覧取得。
+// 発言者ごとに、類似度が閾値以上の投稿同士を同じまとまりとする。
+func (usecase *duplicatePostUseCase) GetDuplicateClusters() ([]*model.DuplicateCluster, error) {
+	posts, err := usecase.PostRepository.FetchAllTitles()
+	if err != nil {
+		return nil, err
+	}
+
+	// 発言者ごとに分類
+	speakerKeys := []string{}
+	postsBySpeaker := map[string][]*model.Post{}
+	for _, post := range posts {
+		fillNormalizedFields(post)
+		if _, ok := postsBySpeaker[post.NormalizedSpeaker]; !ok {
+			speakerKeys = append(speakerKeys, post.NormalizedSpeaker)
+		}
+		postsBySpeaker[post.NormalizedSpeaker] = append(postsBySpeaker[post.NormalizedSpeaker], post)
+	}
+
+	clusters := []*model.DuplicateCluster{}
+	for _, key := range speakerKeys {
+		clusters = append(clusters, clusterPosts(postsBySpeaker[key])...)
+	}
+
+	// 件数の多いまとまりから順に並べる
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i].Posts) > len(clusters[j].Posts)
+	})
+
+	return clusters, nil
+}
+
+// clusterPosts 同じ発言者の投稿を類似度でまとめる。2件以上のまとまりのみ返す。
+func clusterPosts(posts []*model.Post) []*model.DuplicateCluster {
+	// Union-Find
+	parents := make([]int, len(posts))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+
+	similarities := make([]float64, len(posts))
+	for i := 0; i < len(posts); i++ {
+		for j := i + 1; j < len(posts); j++ {
+			similarity := titleSimilarity(posts[i].NormalizedTitle, posts[j].NormalizedTitle)
+			if similarity < duplicateSimilarityThreshold {
+				continue
+			}
+			parents[find(j)] = find(i)
+			if similarity > similarities[i] {
+				similarities[i] = similarity
+			}
+			if similarity > similarities[j] {
+				similarities[j] = similarity
+			}
+		}
+	}
+
+	roots := []int{}
+	members := map[int][]int{}
+	for i := range posts {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], i)
+	}
+
+	clusters := []*model.DuplicateCluster{}
+	for _, root := range roots {
+		if len(members[root]) < 2 {
+			continue
+		}
+		cluster := &model.DuplicateCluster{Speaker: posts[root].Speaker}
+		for _, i := range members[root] {
+			cluster.Posts = append(cluster.Posts, makeDuplicateCandidate(posts[i], similarities[i]))
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+// findDuplicatePosts postsの中からtitleと類似した投稿を類似度の高い順に返す。
+func findDuplicatePosts(title string, posts []*model.Post) []*model.DuplicateCandidate {
+	normalizedTitle := normalizeText(title)
+
+	candidates := []*model.DuplicateCandidate{}
+	for _, post := range posts {
+		fillNormalizedFields(post)
+		similarity := titleSimilarity(normalizedTitle, post.NormalizedTitle)
+		if similarity >= duplicateSimilarityThreshold {
+			candidates = append(candidates, makeDuplicateCandidate(post, similarity))
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Similarity > candidates[j].Similarity
+	})
+	return candidates
+}
+
+// fillNormalizedFields 正規化済みのタイトル、発言者が未設定の場合に設定する。
+func fillNormalizedFields(post *model.Post) {
+	if post.NormalizedTitle == "" {
+		post.NormalizedTitle = normalizeText(post.Title)
+	}
+	if post.NormalizedSpeaker == "" {
+		post.NormalizedSpeaker = normalizeText(post.Speaker)
+	}
+}
+
+// makeDuplicateCandidate 投稿から重複候補を生成する。
+func makeDuplicateCandidate(post *model.Post, similarity float64) *model.DuplicateCandidate {
+	return &model.DuplicateCandidate{
+		PostID:     post.ID,
+		UserID:     post.UserID,
+		Title:      post.Title,
+		Speaker:    post.Speaker,
+		Similarity: similarity,
+	}
+}
+
+// titleSimilarity 正規化済みタイトルの類似度(0〜1)。編集距離を長い方の文字数で割った値を1から引く。
+func titleSimilarity(a, b string) float64 {
+	runesA, runesB := []rune(a), []rune(b)
+	maxLength := len(runesA)
+	if len(runesB) > maxLength {
+		maxLength = len(runesB)
+	}
+	if maxLength == 0 {
+		return 0
+	}
+	return 1 - float64(levenshteinDistance(runesA, runesB))/float64(maxLength)
+}
+
+// levenshteinDistance 編集距離
+func levenshteinDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// minInt 最小値
+func minInt(values ...int) int {
+	min := values[0]
+	for _, value := range values[1:] {
+		if value < min {
+			min = value
+		}
+	}
+	return min
+}
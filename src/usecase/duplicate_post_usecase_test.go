@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// 類似度テスト
+func TestTitleSimilarity(t *testing.T) {
+	cases := []struct {
+		label    string
+		a        string
+		b        string
+		expected float64
+	}{
+		{"一致", "あいうえお", "あいうえお", 1},
+		{"1文字違い", "あいうえお", "あいうえか", 0.8},
+		{"不一致", "あいう", "かきく", 0},
+		{"空文字", "", "", 0},
+	}
+
+	for _, test := range cases {
+		assert.InDelta(t, test.expected, titleSimilarity(test.a, test.b), 0.0001, test.label)
+	}
+}
+
+// 重複まとまり一覧取得テスト
+func TestGetDuplicateClusters_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewDuplicatePostUseCase(&repository)
+	repository.On("FetchAllTitles").Return([]*model.Post{
+		{ID: 1, Title: "明日やろうは馬鹿野郎", Speaker: "島耕作"},
+		{ID: 2, Title: "明日やろうは、馬鹿野郎！", Speaker: "島 耕作"},
+		{ID: 3, Title: "明日やろうは馬鹿野郎", Speaker: "別人"},
+		{ID: 4, Title: "全く違う言葉です", Speaker: "島耕作"},
+		{ID: 5, Title: "ハングリーであれ、愚かであれ", Speaker: "ジョブズ"},
+		{ID: 6, Title: "Stay hungry, stay foolish", Speaker: "ジョブズ"},
+		{ID: 7, Title: "はんぐりーであれ。おろかであれ。", Speaker: "ｼﾞｮﾌﾞｽﾞ"},
+	}, nil)
+
+	// 2. Exercise
+	clusters, err := usecase.GetDuplicateClusters()
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(clusters))
+	assert.Equal(t, "島耕作", clusters[0].Speaker)
+	assert.Equal(t, 1, clusters[0].Posts[0].PostID)
+	assert.Equal(t, 2, clusters[0].Posts[1].PostID)
+	assert.Equal(t, "ジョブズ", clusters[1].Speaker)
+	assert.Equal(t, 5, clusters[1].Posts[0].PostID)
+	assert.Equal(t, 7, clusters[1].Posts[1].PostID)
+
+	// 4. Teardown
+}
+
+func TestGetDuplicateClusters_error(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewDuplicatePostUseCase(&repository)
+	repository.On("FetchAllTitles").Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	clusters, err := usecase.GetDuplicateClusters()
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, clusters)
+
+	// 4. Teardown
+}
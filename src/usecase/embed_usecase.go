@@ -0,0 +1,173 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"image/color"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// 埋め込みウィジェットの大きさ
+const (
+	embedDefaultWidth  = 550
+	embedDefaultHeight = 250
+	embedMinWidth      = 200
+	embedMinHeight     = 120
+)
+
+// embedCacheAge oEmbedのレスポンスをキャッシュしてよい秒数
+const embedCacheAge = 3600
+
+// ErrOEmbedURLNotSupported oEmbedの対象外のURLが指定された場合のエラー
+var ErrOEmbedURLNotSupported = errors.New("埋め込みに対応していないURLです。")
+
+// ErrOEmbedSizeNotSupported 指定された最大幅・最大高さに収まらない場合のエラー
+var ErrOEmbedSizeNotSupported = errors.New("指定された大きさでは埋め込みできません。")
+
+// embedPostPathPattern 埋め込み対象の投稿URLのパス
+var embedPostPathPattern = regexp.MustCompile(`^/(?:share/)?posts/(\d+)/?$`)
+
+// EmbedUseCase インターフェース
+type EmbedUseCase interface {
+	// oEmbed取得
+	GetOEmbed(rawURL string, maxWidth, maxHeight int, theme string) (*model.OEmbed, error)
+	// 埋め込みウィジェット取得
+	GetEmbedWidget(postID int, theme string) (*model.EmbedWidget, error)
+}
+
+// embedUseCase 構造体
+type embedUseCase struct {
+	repository.PostRepository
+}
+
+// NewEmbedUseCase EmbedUseCaseを生成。
+func NewEmbedUseCase(repository repository.PostRepository) EmbedUseCase {
+	return &embedUseCase{repository}
+}
+
+// GetOEmbed oEmbed取得。
+// フロントエンドの投稿詳細ページ、またはリンクプレビュー用ページのURLに対応する。
+// 最大幅・最大高さは0の場合は指定なしとして扱う。存在しない投稿、非表示の投稿はErrPostNotFoundを返す。
+func (usecase *embedUseCase) GetOEmbed(rawURL string, maxWidth, maxHeight int, theme string) (*model.OEmbed, error) {
+	postID, ok := parseEmbedPostURL(rawURL)
+	if !ok {
+		return nil, ErrOEmbedURLNotSupported
+	}
+
+	width, height, ok := embedSize(maxWidth, maxHeight)
+	if !ok {
+		return nil, ErrOEmbedSizeNotSupported
+	}
+
+	post, err := usecase.PostRepository.FetchByID(postID, 0)
+	if err == repository.ErrNotFound {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	widgetURL := apiURL(fmt.Sprintf("/embed/posts/%d?theme=%s", post.ID, url.QueryEscape(theme)))
+	iframe := fmt.Sprintf(
+		`<iframe src="%s" width="%d" height="%d" title="%s" style="border:none;max-width:100%%;" sandbox="allow-popups allow-popups-to-escape-sandbox" loading="lazy"></iframe>`,
+		html.EscapeString(widgetURL), width, height, html.EscapeString(post.Title),
+	)
+	thumbnailSize := quoteCardSizes[QuoteCardSizeOGP]
+
+	return &model.OEmbed{
+		Type:            "rich",
+		Version:         "1.0",
+		Title:           post.Title,
+		AuthorName:      post.UserName,
+		AuthorURL:       UserPageURL(post.UserID),
+		ProviderName:    siteName,
+		ProviderURL:     frontendURL("/"),
+		CacheAge:        embedCacheAge,
+		ThumbnailURL:    apiURL(fmt.Sprintf("/api/v1/posts/%d/card.png", post.ID)),
+		ThumbnailWidth:  thumbnailSize.X,
+		ThumbnailHeight: thumbnailSize.Y,
+		HTML:            iframe,
+		Width:           width,
+		Height:          height,
+	}, nil
+}
+
+// GetEmbedWidget 埋め込みウィジェット取得。配色は引用カードのテーマと共通。
+// 存在しない投稿、非表示の投稿はErrPostNotFoundを返す。
+func (usecase *embedUseCase) GetEmbedWidget(postID int, theme string) (*model.EmbedWidget, error) {
+	post, err := usecase.PostRepository.FetchByID(postID, 0)
+	if err == repository.ErrNotFound {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	colors := quoteCardThemes[theme]
+	return &model.EmbedWidget{
+		Title:      post.Title,
+		Speaker:    post.Speaker,
+		PostURL:    PostPageURL(post.ID),
+		Background: hexColor(colors.background),
+		Text:       hexColor(colors.text),
+		Sub:        hexColor(colors.sub),
+		Accent:     hexColor(colors.accent),
+	}, nil
+}
+
+// parseEmbedPostURL 埋め込み対象のURLから投稿IDを取得する。
+func parseEmbedPostURL(rawURL string) (int, bool) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, false
+	}
+
+	for _, base := range []string{frontendURL(""), apiURL("")} {
+		baseURL, err := url.Parse(base)
+		if err != nil || baseURL.Host == "" {
+			continue
+		}
+		basePath := strings.TrimRight(baseURL.Path, "/")
+		if !strings.EqualFold(target.Host, baseURL.Host) || !strings.HasPrefix(target.Path, basePath) {
+			continue
+		}
+		matches := embedPostPathPattern.FindStringSubmatch(strings.TrimPrefix(target.Path, basePath))
+		if matches == nil {
+			continue
+		}
+		id, err := strconv.Atoi(matches[1])
+		if err != nil || id < 1 {
+			continue
+		}
+		return id, true
+	}
+	return 0, false
+}
+
+// embedSize 最大幅・最大高さに収まる埋め込みの大きさ。最小の大きさに満たない場合はfalseを返す。
+func embedSize(maxWidth, maxHeight int) (width, height int, ok bool) {
+	width, height = embedDefaultWidth, embedDefaultHeight
+	if maxWidth > 0 && maxWidth < width {
+		width = maxWidth
+	}
+	if maxHeight > 0 && maxHeight < height {
+		height = maxHeight
+	}
+	if width < embedMinWidth || height < embedMinHeight {
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// hexColor 色を#rrggbb形式の文字列にする。
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	domainRepository "github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// oEmbed取得テスト
+func TestGetOEmbed_success(t *testing.T) {
+	// 1. Setup
+	os.Setenv("API_BASE_URL", "https://api.example.com")
+	os.Setenv("FRONTEND_BASE_URL", "https://www.example.com")
+	repository := mockPostRepository{}
+	usecase := NewEmbedUseCase(&repository)
+	post := makeGetPostResult(1)
+	post.Title = `"title"`
+	repository.On("FetchByID", 1, 0).Return(post, nil)
+
+	// 2. Exercise
+	oembed, err := usecase.GetOEmbed("https://www.example.com/posts/1", 400, 0, QuoteCardThemeDark)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, "rich", oembed.Type)
+	assert.Equal(t, "1.0", oembed.Version)
+	assert.Equal(t, 400, oembed.Width)
+	assert.Equal(t, embedDefaultHeight, oembed.Height)
+	assert.Equal(t, "https://api.example.com/api/v1/posts/1/card.png", oembed.ThumbnailURL)
+	assert.Equal(t, `<iframe src="https://api.example.com/embed/posts/1?theme=dark" width="400" height="250" title="&#34;title&#34;" style="border:none;max-width:100%;" sandbox="allow-popups allow-popups-to-escape-sandbox" loading="lazy"></iframe>`, oembed.HTML)
+
+	// 4. Teardown
+	os.Unsetenv("API_BASE_URL")
+	os.Unsetenv("FRONTEND_BASE_URL")
+}
+
+func TestGetOEmbed_error(t *testing.T) {
+	os.Setenv("API_BASE_URL", "https://api.example.com")
+	os.Setenv("FRONTEND_BASE_URL", "https://www.example.com")
+
+	cases := []struct {
+		label     string
+		url       string
+		maxWidth  int
+		maxHeight int
+		expected  error
+	}{
+		{"他サイトのURL", "https://evil.example.net/posts/1", 0, 0, ErrOEmbedURLNotSupported},
+		{"投稿以外のURL", "https://www.example.com/users/1", 0, 0, ErrOEmbedURLNotSupported},
+		{"最大幅が小さい", "https://www.example.com/posts/1", 100, 0, ErrOEmbedSizeNotSupported},
+		{"最大高さが小さい", "https://api.example.com/share/posts/1", 0, 100, ErrOEmbedSizeNotSupported},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		repository := mockPostRepository{}
+		usecase := NewEmbedUseCase(&repository)
+
+		// 2. Exercise
+		oembed, err := usecase.GetOEmbed(test.url, test.maxWidth, test.maxHeight, QuoteCardThemeLight)
+
+		// 3. Verify
+		assert.Equal(t, test.expected, err, test.label)
+		assert.Nil(t, oembed, test.label)
+
+		// 4. Teardown
+	}
+
+	os.Unsetenv("API_BASE_URL")
+	os.Unsetenv("FRONTEND_BASE_URL")
+}
+
+// 埋め込みウィジェット取得テスト
+func TestGetEmbedWidget_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewEmbedUseCase(&repository)
+	post := makeGetPostResult(1)
+	repository.On("FetchByID", 1, 0).Return(post, nil)
+
+	// 2. Exercise
+	widget, err := usecase.GetEmbedWidget(1, QuoteCardThemeLight)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, post.Title, widget.Title)
+	assert.Equal(t, post.Speaker, widget.Speaker)
+	assert.Equal(t, "#ffffff", widget.Background)
+
+	// 4. Teardown
+}
+
+func TestGetEmbedWidget_error(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewEmbedUseCase(&repository)
+	repository.On("FetchByID", 1, 0).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	widget, err := usecase.GetEmbedWidget(1, QuoteCardThemeLight)
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, widget)
+
+	// 4. Teardown
+}
+
+func TestGetEmbedWidget_error_notFound(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewEmbedUseCase(&repository)
+	repository.On("FetchByID", 1, 0).Return(nil, domainRepository.ErrNotFound)
+
+	// 2. Exercise
+	widget, err := usecase.GetEmbedWidget(1, QuoteCardThemeLight)
+
+	// 3. Verify
+	assert.Equal(t, ErrPostNotFound, err)
+	assert.Nil(t, widget)
+
+	// 4. Teardown
+}
+
+// 投稿URL解析テスト
+func TestParseEmbedPostURL(t *testing.T) {
+	os.Setenv("API_BASE_URL", "https://api.example.com")
+	os.Setenv("FRONTEND_BASE_URL", "https://www.example.com/app")
+
+	cases := []struct {
+		url      string
+		expected int
+		ok       bool
+	}{
+		{"https://www.example.com/app/posts/12", 12, true},
+		{"https://WWW.example.com/app/posts/12/", 12, true},
+		{"https://api.example.com/share/posts/3?utm_source=x", 3, true},
+		{"https://www.example.com/posts/12", 0, false},
+		{"https://www.example.com/app/posts/0", 0, false},
+		{"https://www.example.com/app/posts/abc", 0, false},
+		{"not a url", 0, false},
+	}
+
+	for _, test := range cases {
+		id, ok := parseEmbedPostURL(test.url)
+		assert.Equal(t, test.expected, id, test.url)
+		assert.Equal(t, test.ok, ok, test.url)
+	}
+
+	os.Unsetenv("API_BASE_URL")
+	os.Unsetenv("FRONTEND_BASE_URL")
+}
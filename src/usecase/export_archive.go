@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// assetsDir プロフィール画像を保存しているディレクトリ。テストで差し替えられるよう変数にしている。
+var assetsDir = "assets"
+
+// uploadedImagePath プロフィール画像のファイルのパスを返す。
+// 画像のパスはユーザーが指定できるため、アップロードした画像のディレクトリ外の場合はokにfalseを返す。
+func uploadedImagePath(imageFilePath string) (imagePath string, ok bool) {
+	imagePath = path.Clean("/" + imageFilePath)[1:]
+	if !strings.HasPrefix(imagePath, "images/") {
+		return "", false
+	}
+	return imagePath, true
+}
+
+// exportData エクスポートする個人データ。
+// 投稿の変更履歴は保存していないため含まない。
+type exportData struct {
+	User       *model.User
+	Posts      []*model.Post
+	Comments   []*model.Comment
+	Favorites  []*model.Favorite
+	ExportedAt time.Time
+	// ZIPファイル内のプロフィール画像のパス。画像がない場合は空文字。
+	ImagePath string
+}
+
+// exportIndexTemplate ZIPファイルに含める、個人データを一覧できるHTML。
+var exportIndexTemplate = template.Must(template.New("export").Funcs(template.FuncMap{
+	"postPageURL": PostPageURL,
+	"formatTime": func(t time.Time) string {
+		return t.Format("2006-01-02 15:04")
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<title>Power Phrase 個人データ</title>
+</head>
+<body>
+<h1>Power Phrase 個人データ</h1>
+<p>{{formatTime .ExportedAt}} 時点のデータです。同じ内容をJSON形式(profile.json、posts.json、comments.json、favorites.json)でも収録しています。</p>
+
+<h2>プロフィール</h2>
+<dl>
+<dt>ユーザー名</dt><dd>{{.User.Name}}</dd>
+<dt>メールアドレス</dt><dd>{{.User.Email}}</dd>
+<dt>タイムゾーン</dt><dd>{{.User.TimeZone}}</dd>
+<dt>登録日時</dt><dd>{{formatTime .User.CreatedAt}}</dd>
+{{if .ImagePath}}<dt>プロフィール画像</dt><dd><img src="{{.ImagePath}}" alt="プロフィール画像" width="150"></dd>{{end}}
+</dl>
+
+<h2>投稿({{len .Posts}}件)</h2>
+<ul>
+{{range .Posts}}<li><a href="{{postPageURL .ID}}">{{.Title}}</a> ― {{.Speaker}}({{formatTime .CreatedAt}}){{if .Detail}}<br>{{.Detail}}{{end}}</li>
+{{end}}</ul>
+
+<h2>コメント({{len .Comments}}件)</h2>
+<ul>
+{{range .Comments}}<li><a href="{{postPageURL .PostID}}">投稿{{.PostID}}</a>へのコメント({{formatTime .CreatedAt}})<br>{{.Body}}</li>
+{{end}}</ul>
+
+<h2>お気に入り({{len .Favorites}}件)</h2>
+<ul>
+{{range .Favorites}}<li><a href="{{postPageURL .PostID}}">投稿{{.PostID}}</a>({{formatTime .CreatedAt}}){{if .Tag}} [{{.Tag}}]{{end}}{{if .Note}}<br>{{.Note}}{{end}}</li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+// writeExportArchive 個人データのZIPファイルを作成する。
+// パスワードのハッシュ値は含めない。プロフィール画像のファイルがない場合は画像を含めない。
+func writeExportArchive(data *exportData) ([]byte, error) {
+	user := *data.User
+	user.Password = ""
+	data.User = &user
+	// 0件の場合もJSONでnullではなく空の配列とする
+	if data.Posts == nil {
+		data.Posts = []*model.Post{}
+	}
+	if data.Comments == nil {
+		data.Comments = []*model.Comment{}
+	}
+	if data.Favorites == nil {
+		data.Favorites = []*model.Favorite{}
+	}
+
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+
+	if imagePath, ok := uploadedImagePath(user.ImageFilePath); ok {
+		image, err := ioutil.ReadFile(filepath.Join(assetsDir, filepath.FromSlash(imagePath)))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			data.ImagePath = "images/" + path.Base(imagePath)
+			if err := writeExportFile(writer, data.ImagePath, data.ExportedAt, image); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, file := range []struct {
+		name  string
+		value interface{}
+	}{
+		{"profile.json", data.User},
+		{"posts.json", data.Posts},
+		{"comments.json", data.Comments},
+		{"favorites.json", data.Favorites},
+	} {
+		content, err := json.MarshalIndent(file.value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := writeExportFile(writer, file.name, data.ExportedAt, content); err != nil {
+			return nil, err
+		}
+	}
+
+	index := &bytes.Buffer{}
+	if err := exportIndexTemplate.Execute(index, data); err != nil {
+		return nil, err
+	}
+	if err := writeExportFile(writer, "index.html", data.ExportedAt, index.Bytes()); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// writeExportFile ZIPファイルにファイルを追加する。
+func writeExportFile(writer *zip.Writer, name string, modified time.Time, content []byte) error {
+	file, err := writer.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(content)
+	return err
+}
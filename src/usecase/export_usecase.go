@@ -0,0 +1,255 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// ErrExportNotFound エクスポートが存在しない、またはダウンロード期限が切れた場合のエラー
+var ErrExportNotFound = errors.New("エクスポートが見つかりません。")
+
+const (
+	// exportRequestInterval 同じユーザーがエクスポートを要求できる間隔
+	exportRequestInterval = time.Hour
+	// exportDownloadTTL エクスポートしたファイルをダウンロードできる期間
+	exportDownloadTTL = 24 * time.Hour
+	// exportJobHeartbeatTimeout 状態の更新がこの期間ないジョブは、サーバーの停止などで中断したものとして扱う
+	exportJobHeartbeatTimeout = 30 * time.Minute
+)
+
+// exportJobInterruptedError 中断したジョブのエラー
+const exportJobInterruptedError = "サーバーの停止などにより中断しました。もう一度要求してください。"
+
+// ExportRateLimitError エクスポートの要求が多すぎる場合のエラー
+type ExportRateLimitError struct {
+	RetryAfter time.Duration
+}
+
+// Error エラーメッセージ
+func (err *ExportRateLimitError) Error() string {
+	return fmt.Sprintf("エクスポートは%d分後に要求できます。", int(math.Ceil(err.RetryAfter.Minutes())))
+}
+
+// ExportUseCase インターフェース
+type ExportUseCase interface {
+	// 個人データのエクスポート要求
+	CreateExport(userID int) (*model.ExportJob, error)
+	// エクスポートジョブ取得
+	GetExport(id, userID int) (*model.ExportJob, error)
+	// エクスポートしたファイルのダウンロード
+	DownloadExport(token string) (fileName string, archive io.ReadCloser, err error)
+	// ダウンロード期限を過ぎたエクスポートの削除
+	PurgeExpiredExports() (int, error)
+	// 中断したジョブを失敗にする
+	RecoverExportJobs() (int, error)
+}
+
+// exportUseCase 構造体
+type exportUseCase struct {
+	repository.PostRepository
+	repository.UserRepository
+	repository.ExportJobRepository
+	repository.ExportArchiveStorage
+	runJob JobRunner
+	clock  Clock
+}
+
+// NewExportUseCase ExportUseCaseを生成。
+func NewExportUseCase(postRepository repository.PostRepository, userRepository repository.UserRepository, exportJobRepository repository.ExportJobRepository, archiveStorage repository.ExportArchiveStorage, runJob JobRunner) ExportUseCase {
+	return &exportUseCase{postRepository, userRepository, exportJobRepository, archiveStorage, runJob, time.Now}
+}
+
+// CreateExport 個人データのエクスポート要求。
+// ジョブを登録して非同期に実行し、実行待ちのジョブを返す。進捗とダウンロードURLはGetExportで取得する。
+// 失敗したジョブを除き、前回の要求からexportRequestIntervalが経過していない場合はExportRateLimitErrorを返す。
+func (usecase *exportUseCase) CreateExport(userID int) (*model.ExportJob, error) {
+	token, err := newExportToken()
+	if err != nil {
+		return nil, err
+	}
+	job := &model.ExportJob{
+		UserID:    userID,
+		Status:    model.ExportJobStatusPending,
+		Token:     token,
+		CreatedAt: usecase.clock(),
+	}
+	job.HeartbeatAt = job.CreatedAt
+	latest, err := usecase.ExportJobRepository.CreateExportJob(job, job.CreatedAt.Add(-exportRequestInterval))
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		return nil, &ExportRateLimitError{RetryAfter: latest.CreatedAt.Add(exportRequestInterval).Sub(job.CreatedAt)}
+	}
+
+	running := *job
+	usecase.runJob(func() {
+		if _, err := usecase.PurgeExpiredExports(); err != nil {
+			log.Printf("期限切れのエクスポートの削除に失敗しました：%v", err)
+		}
+		usecase.runExportJob(&running)
+	})
+	return job, nil
+}
+
+// GetExport エクスポートジョブ取得。他のユーザーのジョブはErrExportNotFoundとする。
+// 中断したジョブの場合は、失敗にしてから返す。
+func (usecase *exportUseCase) GetExport(id, userID int) (*model.ExportJob, error) {
+	job, err := usecase.ExportJobRepository.FetchExportJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil || job.UserID != userID {
+		return nil, ErrExportNotFound
+	}
+	if job.FinishedAt == nil && !job.HeartbeatAt.After(usecase.clock().Add(-exportJobHeartbeatTimeout)) {
+		if _, err := usecase.RecoverExportJobs(); err != nil {
+			return nil, err
+		}
+		if job, err = usecase.ExportJobRepository.FetchExportJob(id); err != nil {
+			return nil, err
+		}
+		if job == nil {
+			return nil, ErrExportNotFound
+		}
+	}
+	if job.Status == model.ExportJobStatusCompleted {
+		job.DownloadURL = exportDownloadURL(job.Token)
+	}
+	return job, nil
+}
+
+// DownloadExport エクスポートしたファイルのダウンロード。
+// ダウンロードURLを知っていればログインせずにダウンロードできる。期限切れの場合はErrExportNotFoundを返す。
+// archiveは呼び出し元で閉じる。
+func (usecase *exportUseCase) DownloadExport(token string) (fileName string, archive io.ReadCloser, err error) {
+	job, err := usecase.ExportJobRepository.FetchCompletedExportJobByToken(token)
+	if err != nil {
+		return "", nil, err
+	}
+	if job == nil || !usecase.clock().Before(*job.ExpiresAt) {
+		return "", nil, ErrExportNotFound
+	}
+	archive, err = usecase.ExportArchiveStorage.Open(job.Token)
+	if err != nil {
+		return "", nil, err
+	}
+	if archive == nil {
+		return "", nil, ErrExportNotFound
+	}
+	return fmt.Sprintf("power-phrase-export-%d-%s.zip", job.UserID, job.FinishedAt.Format("20060102150405")), archive, nil
+}
+
+// runExportJob エクスポートジョブを実行し、結果をジョブに反映する。
+// 作成したファイルはジョブのトークンをキーに保存する。
+func (usecase *exportUseCase) runExportJob(job *model.ExportJob) {
+	job.Status = model.ExportJobStatusRunning
+	usecase.updateExportJob(job)
+
+	archive, err := usecase.buildExportArchive(job.UserID)
+	if err == nil {
+		err = usecase.ExportArchiveStorage.Save(job.Token, archive)
+	}
+	finishedAt := usecase.clock()
+	job.FinishedAt = &finishedAt
+	if err != nil {
+		job.Status = model.ExportJobStatusFailed
+		job.Error = truncateRunes(err.Error(), 256)
+		usecase.updateExportJob(job)
+		return
+	}
+	expiresAt := finishedAt.Add(exportDownloadTTL)
+	job.Status = model.ExportJobStatusCompleted
+	job.ExpiresAt = &expiresAt
+	usecase.updateExportJob(job)
+}
+
+// updateExportJob ジョブの状態を保存する。エラーはログに出力する。
+func (usecase *exportUseCase) updateExportJob(job *model.ExportJob) {
+	job.HeartbeatAt = usecase.clock()
+	if err := usecase.ExportJobRepository.UpdateExportJob(job); err != nil {
+		log.Printf("エクスポートジョブの更新に失敗しました：%v", err)
+	}
+}
+
+// PurgeExpiredExports ダウンロード期限を過ぎたジョブとファイルを削除し、削除したジョブの件数を返す。
+// 完了しなかったジョブは登録からexportDownloadTTLが経過したものを削除する。
+func (usecase *exportUseCase) PurgeExpiredExports() (int, error) {
+	current := usecase.clock()
+	jobs, err := usecase.ExportJobRepository.FetchExpiredExportJobs(current, current.Add(-exportDownloadTTL))
+	if err != nil {
+		return 0, err
+	}
+	for i, job := range jobs {
+		if err := usecase.ExportArchiveStorage.Delete(job.Token); err != nil {
+			return i, err
+		}
+		if err := usecase.ExportJobRepository.DeleteExportJob(job.ID); err != nil {
+			return i, err
+		}
+	}
+	return len(jobs), nil
+}
+
+// RecoverExportJobs 実行待ち、実行中のまま状態の更新が一定時間ないジョブを、中断したものとして失敗にする。失敗にした件数を返す。
+// ジョブはAPIサーバーのgoroutineで実行するため、サーバーが停止した場合は実行中のまま残る。
+// 失敗にしたジョブは要求の間隔の制限に数えないため、すぐに再度要求できる。起動時と、中断したジョブを取得した際に実行する。
+func (usecase *exportUseCase) RecoverExportJobs() (int, error) {
+	current := usecase.clock()
+	return usecase.ExportJobRepository.FailStaleExportJobs(current.Add(-exportJobHeartbeatTimeout), current, exportJobInterruptedError)
+}
+
+// buildExportArchive ユーザーの個人データを収集してZIPファイルを作成する。
+func (usecase *exportUseCase) buildExportArchive(userID int) ([]byte, error) {
+	user, err := usecase.UserRepository.FetchByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("ユーザーが見つかりません。")
+	}
+	posts, err := usecase.PostRepository.FetchPostsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := usecase.PostRepository.FetchCommentsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	favorites, err := usecase.PostRepository.FetchFavoritesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeExportArchive(&exportData{
+		User:       user,
+		Posts:      posts,
+		Comments:   comments,
+		Favorites:  favorites,
+		ExportedAt: usecase.clock(),
+	})
+}
+
+// newExportToken ダウンロードURLに含める推測できないトークンを生成する。
+func newExportToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// exportDownloadURL ダウンロードURL
+func exportDownloadURL(token string) string {
+	return apiURL("/api/v1/exports/download/" + token)
+}
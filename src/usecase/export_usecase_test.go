@@ -0,0 +1,397 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockExportJobRepository struct {
+	mock.Mock
+}
+
+func (repository *mockExportJobRepository) CreateExportJob(job *model.ExportJob, since time.Time) (*model.ExportJob, error) {
+	args := repository.Called(job, since)
+	latest, _ := args.Get(0).(*model.ExportJob)
+	return latest, args.Error(1)
+}
+
+func (repository *mockExportJobRepository) FetchExportJob(id int) (*model.ExportJob, error) {
+	args := repository.Called(id)
+	job, _ := args.Get(0).(*model.ExportJob)
+	return job, args.Error(1)
+}
+
+func (repository *mockExportJobRepository) FetchCompletedExportJobByToken(token string) (*model.ExportJob, error) {
+	args := repository.Called(token)
+	job, _ := args.Get(0).(*model.ExportJob)
+	return job, args.Error(1)
+}
+
+func (repository *mockExportJobRepository) UpdateExportJob(job *model.ExportJob) error {
+	return repository.Called(job).Error(0)
+}
+
+func (repository *mockExportJobRepository) FetchExpiredExportJobs(before, createdBefore time.Time) ([]*model.ExportJob, error) {
+	args := repository.Called(before, createdBefore)
+	jobs, _ := args.Get(0).([]*model.ExportJob)
+	return jobs, args.Error(1)
+}
+
+func (repository *mockExportJobRepository) DeleteExportJob(id int) error {
+	return repository.Called(id).Error(0)
+}
+
+func (repository *mockExportJobRepository) FailStaleExportJobs(heartbeatBefore, finishedAt time.Time, message string) (int, error) {
+	args := repository.Called(heartbeatBefore, finishedAt, message)
+	return args.Int(0), args.Error(1)
+}
+
+// Mock。保存したファイルをメモリ内に保持する。
+type mockExportArchiveStorage struct {
+	archives map[string][]byte
+}
+
+func (storage *mockExportArchiveStorage) Save(key string, archive []byte) error {
+	storage.archives[key] = archive
+	return nil
+}
+
+func (storage *mockExportArchiveStorage) Open(key string) (io.ReadCloser, error) {
+	archive, ok := storage.archives[key]
+	if !ok {
+		return nil, nil
+	}
+	return ioutil.NopCloser(bytes.NewReader(archive)), nil
+}
+
+func (storage *mockExportArchiveStorage) Delete(key string) error {
+	delete(storage.archives, key)
+	return nil
+}
+
+// プロフィール画像を保存するディレクトリを一時ディレクトリに差し替える
+func setAssetsDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := assetsDir
+	assetsDir = dir
+	t.Cleanup(func() {
+		assetsDir = original
+		os.RemoveAll(dir)
+	})
+}
+
+// ZIPファイルの内容を読み込む
+func readExportArchive(t *testing.T, archive []byte) map[string]string {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, file := range reader.File {
+		r, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[file.Name] = string(content)
+	}
+	return files
+}
+
+// エクスポート要求テスト
+func TestCreateExport_success(t *testing.T) {
+	// 1. Setup
+	setAssetsDir(t)
+	os.MkdirAll(filepath.Join(assetsDir, "images"), 0755)
+	ioutil.WriteFile(filepath.Join(assetsDir, "images", "101.png"), []byte("png"), 0644)
+
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	jobRepository := mockExportJobRepository{}
+	storage := &mockExportArchiveStorage{archives: map[string][]byte{}}
+	usecase := &exportUseCase{&postRepository, &userRepository, &jobRepository, storage, runSynchronously, fixedClock(current)}
+	user := makeUserForRead(101)
+	user.ImageFilePath = "images/101.png"
+	userRepository.On("FetchByID", 101).Return(user, nil)
+	postRepository.On("FetchPostsByUserID", 101).Return([]*model.Post{{ID: 1, UserID: 101, Title: "<b>title1</b>", Speaker: "speaker1"}}, nil)
+	postRepository.On("FetchCommentsByUserID", 101).Return(nil, nil)
+	postRepository.On("FetchFavoritesByUserID", 101).Return([]*model.Favorite{{ID: 1, UserID: 101, PostID: 2, Note: "note1", Tag: "tag1"}}, nil)
+	jobRepository.On("CreateExportJob", mock.AnythingOfType("*model.ExportJob"), current.Add(-exportRequestInterval)).Return(nil, nil)
+	jobRepository.On("FetchExpiredExportJobs", current, current.Add(-exportDownloadTTL)).Return(nil, nil)
+	jobRepository.On("UpdateExportJob", mock.AnythingOfType("*model.ExportJob")).Return(nil)
+
+	// 2. Exercise
+	job, err := usecase.CreateExport(101)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, model.ExportJobStatusPending, job.Status)
+	assert.Len(t, job.Token, 64)
+	assert.Empty(t, job.DownloadURL)
+
+	jobRepository.AssertNumberOfCalls(t, "UpdateExportJob", 2)
+	finished := jobRepository.Calls[len(jobRepository.Calls)-1].Arguments.Get(0).(*model.ExportJob)
+	assert.Equal(t, model.ExportJobStatusCompleted, finished.Status)
+	assert.Equal(t, current.Add(exportDownloadTTL), *finished.ExpiresAt)
+
+	files := readExportArchive(t, storage.archives[job.Token])
+	assert.Equal(t, "png", files["images/101.png"])
+	assert.Equal(t, "[]", files["comments.json"])
+	profile := model.User{}
+	assert.NoError(t, json.Unmarshal([]byte(files["profile.json"]), &profile))
+	assert.Equal(t, user.Email, profile.Email)
+	assert.Empty(t, profile.Password)
+	assert.Contains(t, files["posts.json"], "title1")
+	assert.Contains(t, files["favorites.json"], "note1")
+	assert.Contains(t, files["index.html"], "&lt;b&gt;title1&lt;/b&gt;")
+	assert.Contains(t, files["index.html"], `src="images/101.png"`)
+
+	// 4. Teardown
+}
+
+func TestCreateExport_error_rateLimit(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	jobRepository := mockExportJobRepository{}
+	usecase := &exportUseCase{&mockPostRepository{}, &mockUserRepository{}, &jobRepository, &mockExportArchiveStorage{}, runSynchronously, fixedClock(current)}
+	latest := &model.ExportJob{ID: 1, UserID: 102, Status: model.ExportJobStatusCompleted, CreatedAt: current.Add(-10 * time.Minute)}
+	jobRepository.On("CreateExportJob", mock.AnythingOfType("*model.ExportJob"), current.Add(-exportRequestInterval)).Return(latest, nil)
+
+	// 2. Exercise
+	job, err := usecase.CreateExport(102)
+
+	// 3. Verify
+	assert.Nil(t, job)
+	rateLimitErr, ok := err.(*ExportRateLimitError)
+	assert.True(t, ok)
+	assert.Equal(t, 50*time.Minute, rateLimitErr.RetryAfter)
+	jobRepository.AssertNotCalled(t, "UpdateExportJob", mock.Anything)
+
+	// 4. Teardown
+}
+
+func TestCreateExport_success_jobFailed(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	userRepository := mockUserRepository{}
+	jobRepository := mockExportJobRepository{}
+	storage := &mockExportArchiveStorage{archives: map[string][]byte{}}
+	usecase := &exportUseCase{&mockPostRepository{}, &userRepository, &jobRepository, storage, runSynchronously, fixedClock(current)}
+	userRepository.On("FetchByID", 103).Return(nil, errors.New("error"))
+	jobRepository.On("CreateExportJob", mock.AnythingOfType("*model.ExportJob"), current.Add(-exportRequestInterval)).Return(nil, nil)
+	jobRepository.On("FetchExpiredExportJobs", current, current.Add(-exportDownloadTTL)).Return(nil, nil)
+	jobRepository.On("UpdateExportJob", mock.AnythingOfType("*model.ExportJob")).Return(nil)
+
+	// 2. Exercise
+	job, err := usecase.CreateExport(103)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, model.ExportJobStatusPending, job.Status)
+	finished := jobRepository.Calls[len(jobRepository.Calls)-1].Arguments.Get(0).(*model.ExportJob)
+	assert.Equal(t, model.ExportJobStatusFailed, finished.Status)
+	assert.Equal(t, "error", finished.Error)
+	assert.Nil(t, finished.ExpiresAt)
+	assert.Empty(t, storage.archives)
+
+	// 4. Teardown
+}
+
+// エクスポートジョブ取得テスト
+func TestGetExport(t *testing.T) {
+	// 1. Setup
+	jobRepository := mockExportJobRepository{}
+	usecase := &exportUseCase{&mockPostRepository{}, &mockUserRepository{}, &jobRepository, &mockExportArchiveStorage{}, runSynchronously, time.Now}
+	jobRepository.On("FetchExportJob", 1).Return(&model.ExportJob{ID: 1, UserID: 104, Status: model.ExportJobStatusRunning, Token: "token1", HeartbeatAt: time.Now()}, nil)
+	jobRepository.On("FetchExportJob", 2).Return(&model.ExportJob{ID: 2, UserID: 104, Status: model.ExportJobStatusCompleted, Token: "token2", HeartbeatAt: time.Now()}, nil)
+	jobRepository.On("FetchExportJob", 3).Return(nil, nil)
+
+	cases := []struct {
+		label       string
+		id          int
+		userID      int
+		err         error
+		downloadURL string
+	}{
+		{"running", 1, 104, nil, ""},
+		{"completed", 2, 104, nil, exportDownloadURL("token2")},
+		{"otherUser", 1, 105, ErrExportNotFound, ""},
+		{"jobNotFound", 3, 104, ErrExportNotFound, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			// 2. Exercise
+			result, err := usecase.GetExport(c.id, c.userID)
+
+			// 3. Verify
+			assert.Equal(t, c.err, err)
+			if c.err == nil {
+				assert.Equal(t, c.downloadURL, result.DownloadURL)
+			}
+		})
+	}
+
+	// 4. Teardown
+}
+
+// 中断したエクスポートジョブの取得テスト
+func TestGetExport_interrupted(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	jobRepository := mockExportJobRepository{}
+	usecase := &exportUseCase{&mockPostRepository{}, &mockUserRepository{}, &jobRepository, &mockExportArchiveStorage{}, runSynchronously, fixedClock(current)}
+	running := &model.ExportJob{ID: 1, UserID: 104, Status: model.ExportJobStatusRunning, HeartbeatAt: current.Add(-exportJobHeartbeatTimeout)}
+	failed := &model.ExportJob{ID: 1, UserID: 104, Status: model.ExportJobStatusFailed, Error: exportJobInterruptedError, HeartbeatAt: running.HeartbeatAt, FinishedAt: &current}
+	jobRepository.On("FetchExportJob", 1).Return(running, nil).Once()
+	jobRepository.On("FailStaleExportJobs", current.Add(-exportJobHeartbeatTimeout), current, exportJobInterruptedError).Return(1, nil)
+	jobRepository.On("FetchExportJob", 1).Return(failed, nil).Once()
+
+	// 2. Exercise
+	result, err := usecase.GetExport(1, 104)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, model.ExportJobStatusFailed, result.Status)
+	assert.Equal(t, exportJobInterruptedError, result.Error)
+	jobRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+// ダウンロードテスト
+func TestDownloadExport_success(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	jobRepository := mockExportJobRepository{}
+	storage := &mockExportArchiveStorage{archives: map[string][]byte{"token106": []byte("zip")}}
+	usecase := &exportUseCase{&mockPostRepository{}, &mockUserRepository{}, &jobRepository, storage, runSynchronously, fixedClock(current)}
+	finishedAt := current.Add(-time.Hour)
+	expiresAt := finishedAt.Add(exportDownloadTTL)
+	jobRepository.On("FetchCompletedExportJobByToken", "token106").Return(&model.ExportJob{ID: 1, UserID: 106, Status: model.ExportJobStatusCompleted, Token: "token106", FinishedAt: &finishedAt, ExpiresAt: &expiresAt}, nil)
+
+	// 2. Exercise
+	fileName, archive, err := usecase.DownloadExport("token106")
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, "power-phrase-export-106-20200601110000.zip", fileName)
+	content, _ := ioutil.ReadAll(archive)
+	assert.Equal(t, "zip", string(content))
+
+	// 4. Teardown
+	archive.Close()
+}
+
+func TestDownloadExport_error(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	jobRepository := mockExportJobRepository{}
+	storage := &mockExportArchiveStorage{archives: map[string][]byte{"token107": []byte("zip")}}
+	finishedAt := current.Add(-time.Hour)
+	expiresAt := finishedAt.Add(exportDownloadTTL)
+	jobRepository.On("FetchCompletedExportJobByToken", "token000").Return(nil, nil)
+	jobRepository.On("FetchCompletedExportJobByToken", "token107").Return(&model.ExportJob{ID: 1, UserID: 107, Token: "token107", FinishedAt: &finishedAt, ExpiresAt: &expiresAt}, nil)
+	jobRepository.On("FetchCompletedExportJobByToken", "token108").Return(&model.ExportJob{ID: 2, UserID: 108, Token: "token108", FinishedAt: &finishedAt, ExpiresAt: &expiresAt}, nil)
+
+	cases := []struct {
+		label string
+		token string
+		now   time.Time
+	}{
+		{"tokenNotFound", "token000", current},
+		{"expired", "token107", expiresAt},
+		{"archiveNotFound", "token108", current},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			usecase := &exportUseCase{&mockPostRepository{}, &mockUserRepository{}, &jobRepository, storage, runSynchronously, fixedClock(c.now)}
+
+			// 2. Exercise
+			_, archive, err := usecase.DownloadExport(c.token)
+
+			// 3. Verify
+			assert.Equal(t, ErrExportNotFound, err)
+			assert.Nil(t, archive)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// ダウンロード期限を過ぎたエクスポートの削除テスト
+func TestPurgeExpiredExports(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	jobRepository := mockExportJobRepository{}
+	storage := &mockExportArchiveStorage{archives: map[string][]byte{"token1": []byte("zip"), "token3": []byte("zip")}}
+	usecase := &exportUseCase{&mockPostRepository{}, &mockUserRepository{}, &jobRepository, storage, runSynchronously, fixedClock(current)}
+	jobRepository.On("FetchExpiredExportJobs", current, current.Add(-exportDownloadTTL)).Return([]*model.ExportJob{{ID: 1, Token: "token1"}, {ID: 2, Token: "token2"}}, nil)
+	jobRepository.On("DeleteExportJob", 1).Return(nil)
+	jobRepository.On("DeleteExportJob", 2).Return(nil)
+
+	// 2. Exercise
+	count, err := usecase.PurgeExpiredExports()
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, map[string][]byte{"token3": []byte("zip")}, storage.archives)
+	jobRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+// 個人データのZIPファイル作成テスト
+func TestWriteExportArchive_imagePath(t *testing.T) {
+	// 1. Setup
+	cases := []struct {
+		label         string
+		imageFilePath string
+	}{
+		{"画像なし", ""},
+		{"ファイルなし", "images/notfound.png"},
+		{"画像ディレクトリ外", "../conf/database.go"},
+		{"画像ディレクトリ外(images経由)", "images/../../conf/database.go"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			user := makeUserForRead(1)
+			user.ImageFilePath = c.imageFilePath
+
+			// 2. Exercise
+			archive, err := writeExportArchive(&exportData{User: user, ExportedAt: time.Now()})
+
+			// 3. Verify
+			assert.NoError(t, err)
+			files := readExportArchive(t, archive)
+			assert.Len(t, files, 5)
+			assert.NotContains(t, files["index.html"], "<img")
+		})
+	}
+
+	// 4. Teardown
+}
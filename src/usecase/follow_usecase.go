@@ -0,0 +1,169 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+const (
+	// defaultFeedPopularDays フィードに含める人気の投稿の対象期間(日数)のデフォルト値
+	defaultFeedPopularDays = 7
+	// defaultFeedPopularMinFavorites フィードに含める人気の投稿のお気に入り数の下限のデフォルト値
+	defaultFeedPopularMinFavorites = 3
+)
+
+var (
+	// ErrUserNotFound 存在しないユーザー、退会済みのユーザーを指定した場合のエラー
+	ErrUserNotFound = errors.New("ユーザーが見つかりません。")
+	// ErrFollowSelf 自分自身をフォローしようとした場合のエラー
+	ErrFollowSelf = errors.New("自分自身はフォローできません。")
+)
+
+// FollowUseCase インターフェース
+type FollowUseCase interface {
+	// フォロー
+	Follow(followerID, followeeID int) error
+	// フォロー解除
+	Unfollow(followerID, followeeID int) error
+	// フォロワー一覧取得
+	GetFollowers(userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error)
+	// フォロー中のユーザー一覧取得
+	GetFollowing(userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error)
+	// フォロワー数、フォロー数取得
+	GetFollowCounts(userID, loginUserID int) (*model.FollowCounts, error)
+	// フィード取得
+	GetFeed(userID, cursor, limit int) (posts []*model.GetPostResult, nextCursor int, err error)
+}
+
+// followUseCase 構造体
+type followUseCase struct {
+	repository.PostRepository
+	repository.UserRepository
+	repository.FeedRepository
+	repository.ReactionRepository
+	clock Clock
+}
+
+// NewFollowUseCase FollowUseCaseを生成。
+func NewFollowUseCase(postRepository repository.PostRepository, userRepository repository.UserRepository, feedRepository repository.FeedRepository, reactionRepository repository.ReactionRepository) FollowUseCase {
+	return &followUseCase{postRepository, userRepository, feedRepository, reactionRepository, time.Now}
+}
+
+// feedPopularDays フィードに含める人気の投稿の対象期間(日数)。環境変数FEED_POPULAR_DAYSで変更できる。
+func feedPopularDays() int {
+	days, err := strconv.Atoi(os.Getenv("FEED_POPULAR_DAYS"))
+	if err != nil || days <= 0 {
+		return defaultFeedPopularDays
+	}
+	return days
+}
+
+// feedPopularMinFavorites フィードに含める人気の投稿のお気に入り数の下限。環境変数FEED_POPULAR_MIN_FAVORITESで変更できる。
+func feedPopularMinFavorites() int {
+	count, err := strconv.Atoi(os.Getenv("FEED_POPULAR_MIN_FAVORITES"))
+	if err != nil || count <= 0 {
+		return defaultFeedPopularMinFavorites
+	}
+	return count
+}
+
+// Follow フォロー。既にフォローしている場合はフォローを登録しない。
+// フォローされたユーザーへの通知は、ドメインイベントのサブスクライバーが行う。
+func (usecase *followUseCase) Follow(followerID, followeeID int) error {
+	if followerID == followeeID {
+		return ErrFollowSelf
+	}
+	// 存在しないユーザー、退会済みのユーザーはフォローできない
+	if _, err := usecase.UserRepository.FetchByID(followeeID); err != nil {
+		return ErrUserNotFound
+	}
+
+	follow := model.Follow{FollowerID: followerID, FolloweeID: followeeID}
+	if err := usecase.UserRepository.Follow(&follow, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventFollowCreated, &follow)}); err != nil {
+		return err
+	}
+	wakeDomainEventDispatcher()
+	return nil
+}
+
+// Unfollow フォロー解除。フォローしていない場合は何もしない。
+func (usecase *followUseCase) Unfollow(followerID, followeeID int) error {
+	return usecase.UserRepository.Unfollow(followerID, followeeID)
+}
+
+// GetFollowers フォロワー一覧取得。フォローされた日時の新しい順に返す。
+func (usecase *followUseCase) GetFollowers(userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error) {
+	return usecase.UserRepository.FetchFollowers(userID, limit, page)
+}
+
+// GetFollowing フォロー中のユーザー一覧取得。フォローした日時の新しい順に返す。
+func (usecase *followUseCase) GetFollowing(userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error) {
+	return usecase.UserRepository.FetchFollowing(userID, limit, page)
+}
+
+// GetFollowCounts フォロワー数、フォロー数取得。ログインユーザーがフォローしているかも返す。
+func (usecase *followUseCase) GetFollowCounts(userID, loginUserID int) (*model.FollowCounts, error) {
+	return usecase.UserRepository.FetchFollowCounts(userID, loginUserID)
+}
+
+// GetFeed フィード取得。フォロー中のユーザーの投稿と、最近の人気の投稿(自分の投稿を除く)を新しい順に合わせて返す。
+// cursorに前回のnextCursorを指定すると続きを取得する。続きがない場合、nextCursorは0となる。
+// 投稿の読み込み時に振り分けず、取得のたびにcursorより前の投稿をlimit件ずつ取得して合わせる。
+func (usecase *followUseCase) GetFeed(userID, cursor, limit int) (posts []*model.GetPostResult, nextCursor int, err error) {
+	followingIDs, err := usecase.FeedRepository.FetchFollowingPostIDs(userID, cursor, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	since := usecase.clock().Add(-time.Duration(feedPopularDays()) * 24 * time.Hour)
+	popularIDs, err := usecase.FeedRepository.FetchPopularPostIDs(since, feedPopularMinFavorites(), userID, cursor, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := mergeFeedPostIDs(followingIDs, popularIDs)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	if len(ids) == limit {
+		nextCursor = ids[len(ids)-1]
+	}
+
+	posts, err = usecase.PostRepository.FetchByIDs(ids, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 動画URL加工、引用表記生成
+	for _, post := range posts {
+		post.EmbedMovieURL = makeEmbedMovieURL(post.MovieURL)
+		post.Citation = makeCitation(post.Speaker, &post.PostSource)
+	}
+	if err := applyReactions(usecase.ReactionRepository, posts, userID); err != nil {
+		return nil, 0, err
+	}
+
+	return posts, nextCursor, nil
+}
+
+// mergeFeedPostIDs 投稿のID一覧を重複を除いて合わせ、新しい順(IDの降順)に並べる。
+func mergeFeedPostIDs(idLists ...[]int) []int {
+	seen := map[int]bool{}
+	ids := []int{}
+	for _, list := range idLists {
+		for _, id := range list {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(ids)))
+	return ids
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockFeedRepository struct {
+	mock.Mock
+}
+
+func (repository *mockFeedRepository) FetchFollowingPostIDs(userID, beforeID, limit int) ([]int, error) {
+	args := repository.Called(userID, beforeID, limit)
+	ids, ok := args.Get(0).([]int)
+	if ok {
+		return ids, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (repository *mockFeedRepository) FetchPopularPostIDs(since time.Time, minFavoriteCount, excludeUserID, beforeID, limit int) ([]int, error) {
+	args := repository.Called(since, minFavoriteCount, excludeUserID, beforeID, limit)
+	ids, ok := args.Get(0).([]int)
+	if ok {
+		return ids, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// フォローテスト
+func TestFollow_success(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewFollowUseCase(&postRepository, &userRepository, &mockFeedRepository{}, &mockReactionRepository{})
+	userRepository.On("FetchByID", 2).Return(makeUserForRead(2), nil)
+	userRepository.On("Follow", &model.Follow{FollowerID: 1, FolloweeID: 2}).Return(nil)
+
+	// 2. Exercise
+	err := usecase.Follow(1, 2)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	userRepository.AssertExpectations(t)
+	postRepository.AssertExpectations(t)
+	assert.Len(t, userRepository.domainEvents, 1)
+	assert.Equal(t, model.DomainEventFollowCreated, userRepository.domainEvents[0].Type)
+
+	// 4. Teardown
+}
+
+func TestFollow_error(t *testing.T) {
+	cases := []struct {
+		label      string
+		followeeID int
+		err        error
+	}{
+		{"自分自身", 1, ErrFollowSelf},
+		{"ユーザーなし", 3, ErrUserNotFound},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			// 1. Setup
+			postRepository := mockPostRepository{}
+			userRepository := mockUserRepository{}
+			usecase := NewFollowUseCase(&postRepository, &userRepository, &mockFeedRepository{}, &mockReactionRepository{})
+			userRepository.On("FetchByID", 3).Return(nil, errors.New("record not found"))
+
+			// 2. Exercise
+			err := usecase.Follow(1, c.followeeID)
+
+			// 3. Verify
+			assert.Equal(t, c.err, err)
+			userRepository.AssertNotCalled(t, "Follow", mock.Anything)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// フォロワー数、フォロー数取得テスト
+func TestGetFollowCounts(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewFollowUseCase(&postRepository, &userRepository, &mockFeedRepository{}, &mockReactionRepository{})
+	expected := &model.FollowCounts{FollowerCount: 3, FollowingCount: 2, IsFollowing: true}
+	userRepository.On("FetchFollowCounts", 1, 2).Return(expected, nil)
+
+	// 2. Exercise
+	counts, err := usecase.GetFollowCounts(1, 2)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, expected, counts)
+
+	// 4. Teardown
+}
+
+// フィード取得テスト
+func TestGetFeed_success(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	feedRepository := mockFeedRepository{}
+	reactionRepository := mockReactionRepository{}
+	userRepository := mockUserRepository{}
+	usecase := &followUseCase{&postRepository, &userRepository, &feedRepository, &reactionRepository, fixedClock(time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC))}
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	feedRepository.On("FetchFollowingPostIDs", 1, 100, 3).Return([]int{90, 70, 50}, nil)
+	feedRepository.On("FetchPopularPostIDs", since, defaultFeedPopularMinFavorites, 1, 100, 3).Return([]int{80, 70, 60}, nil)
+	postRepository.On("FetchByIDs", []int{90, 80, 70}, 1).Return([]*model.GetPostResult{makeGetPostResult(90), makeGetPostResult(80), makeGetPostResult(70)}, nil)
+	reactionRepository.On("FetchReactionCounts", []int{90, 80, 70}, 1).Return([]*model.PostReactionCount{}, nil)
+
+	// 2. Exercise
+	posts, nextCursor, err := usecase.GetFeed(1, 100, 3)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Len(t, posts, 3)
+	assert.NotEmpty(t, posts[0].EmbedMovieURL)
+	assert.NotNil(t, posts[0].Reactions)
+	assert.Equal(t, 70, nextCursor)
+
+	// 4. Teardown
+}
+
+func TestGetFeed_success_lastPage(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	feedRepository := mockFeedRepository{}
+	reactionRepository := mockReactionRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewFollowUseCase(&postRepository, &userRepository, &feedRepository, &reactionRepository)
+	feedRepository.On("FetchFollowingPostIDs", 1, 0, 3).Return([]int{2}, nil)
+	feedRepository.On("FetchPopularPostIDs", mock.Anything, mock.Anything, 1, 0, 3).Return([]int{}, nil)
+	postRepository.On("FetchByIDs", []int{2}, 1).Return([]*model.GetPostResult{makeGetPostResult(2)}, nil)
+	reactionRepository.On("FetchReactionCounts", []int{2}, 1).Return([]*model.PostReactionCount{}, nil)
+
+	// 2. Exercise
+	posts, nextCursor, err := usecase.GetFeed(1, 0, 3)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, 0, nextCursor)
+
+	// 4. Teardown
+}
+
+func TestGetFeed_error(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	feedRepository := mockFeedRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewFollowUseCase(&postRepository, &userRepository, &feedRepository, &mockReactionRepository{})
+	feedRepository.On("FetchFollowingPostIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	posts, nextCursor, err := usecase.GetFeed(1, 0, 3)
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, posts)
+	assert.Equal(t, 0, nextCursor)
+
+	// 4. Teardown
+}
+
+// フィードの投稿IDの結合テスト
+func TestMergeFeedPostIDs(t *testing.T) {
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, mergeFeedPostIDs([]int{5, 3, 1}, []int{4, 3, 2}))
+	assert.Equal(t, []int{}, mergeFeedPostIDs(nil, nil))
+}
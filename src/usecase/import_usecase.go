@@ -0,0 +1,290 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// ErrImportJobNotFound 一括登録ジョブが存在しない場合のエラー
+var ErrImportJobNotFound = errors.New("一括登録ジョブが見つかりません。")
+
+// importJobRetention 一括登録ジョブを保持する期間。登録からこの期間が過ぎたジョブは削除する
+const importJobRetention = 24 * time.Hour
+
+// importProgressRows 進捗をジョブに反映する行数の間隔
+const importProgressRows = 100
+
+// importReviewWarning モデレーターの確認待ちにする禁止語を含む行の注意事項
+const importReviewWarning = "モデレーターの確認待ちにする禁止語を含むため、非表示で登録します。"
+
+// importJobHeartbeatTimeout 進捗の反映がこの期間ないジョブは、サーバーの停止などで中断したものとして扱う
+const importJobHeartbeatTimeout = 10 * time.Minute
+
+// importJobInterruptedError 中断したジョブのエラー
+const importJobInterruptedError = "サーバーの停止などにより中断しました。登録された行を確認して、もう一度実行してください。"
+
+// ImportUseCase インターフェース
+type ImportUseCase interface {
+	// 投稿の一括登録
+	ImportPosts(userID int, rows []*model.ImportRow, dryRun, atomic, allowDuplicate bool) (*model.ImportJob, error)
+	// 一括登録ジョブ取得
+	GetImportJob(id, userID int) (*model.ImportJob, error)
+	// 中断したジョブを失敗にする
+	RecoverImportJobs() (int, error)
+}
+
+// importUseCase 構造体
+type importUseCase struct {
+	repository.PostRepository
+	repository.ImportJobRepository
+	repository.ProhibitedWordRepository
+	repository.ReportRepository
+	autocompleteIndexes *AutocompleteIndexCache
+	runJob              JobRunner
+	clock               Clock
+}
+
+// NewImportUseCase ImportUseCaseを生成。
+func NewImportUseCase(postRepository repository.PostRepository, importJobRepository repository.ImportJobRepository, prohibitedWordRepository repository.ProhibitedWordRepository, reportRepository repository.ReportRepository, autocompleteIndexes *AutocompleteIndexCache, runJob JobRunner) ImportUseCase {
+	return &importUseCase{postRepository, importJobRepository, prohibitedWordRepository, reportRepository, autocompleteIndexes, runJob, time.Now}
+}
+
+// ImportPosts 投稿の一括登録。
+// dryRunがtrueの場合は登録せずに、各行の入力チェックと重複チェックの結果を返す。
+// dryRunがfalseの場合はジョブを登録して非同期に実行し、実行待ちのジョブを返す。進捗はGetImportJobで取得する。
+// atomicがtrueの場合は1行でもエラーがあれば何も登録しない。falseの場合はエラーのない行のみ登録する。
+// allowDuplicateがfalseの場合、同じ発言者による類似した投稿が既にある行、またはファイル内で先にある行はエラーとする。
+// 禁止語の扱いは投稿登録と同じ。登録を拒否する禁止語を含む行はエラーとし、モデレーターの確認待ちにする禁止語を含む行は非表示で登録する。
+func (usecase *importUseCase) ImportPosts(userID int, rows []*model.ImportRow, dryRun, atomic, allowDuplicate bool) (*model.ImportJob, error) {
+	job := &model.ImportJob{
+		UserID:         userID,
+		Status:         model.ImportJobStatusPending,
+		DryRun:         dryRun,
+		Atomic:         atomic,
+		AllowDuplicate: allowDuplicate,
+		TotalCount:     len(rows),
+		Results:        make([]*model.ImportRowResult, 0, len(rows)),
+		CreatedAt:      usecase.clock(),
+	}
+	job.HeartbeatAt = job.CreatedAt
+
+	if dryRun {
+		usecase.runImportJob(job, rows)
+		return job, nil
+	}
+
+	if err := usecase.ImportJobRepository.DeleteImportJobs(job.CreatedAt.Add(-importJobRetention)); err != nil {
+		return nil, err
+	}
+	if err := usecase.ImportJobRepository.CreateImportJob(job); err != nil {
+		return nil, err
+	}
+	created := copyImportJob(job)
+	usecase.runJob(func() { usecase.runImportJob(job, rows) })
+	return created, nil
+}
+
+// GetImportJob 一括登録ジョブ取得。他のユーザーのジョブはErrImportJobNotFoundとする。
+// 中断したジョブの場合は、失敗にしてから返す。
+func (usecase *importUseCase) GetImportJob(id, userID int) (*model.ImportJob, error) {
+	job, err := usecase.ImportJobRepository.FetchImportJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil || job.UserID != userID {
+		return nil, ErrImportJobNotFound
+	}
+	if job.FinishedAt == nil && !job.HeartbeatAt.After(usecase.clock().Add(-importJobHeartbeatTimeout)) {
+		if _, err := usecase.RecoverImportJobs(); err != nil {
+			return nil, err
+		}
+		if job, err = usecase.ImportJobRepository.FetchImportJob(id); err != nil {
+			return nil, err
+		}
+		if job == nil {
+			return nil, ErrImportJobNotFound
+		}
+	}
+	return job, nil
+}
+
+// RecoverImportJobs 実行待ち、実行中のまま進捗の反映が一定時間ないジョブを、中断したものとして失敗にする。失敗にした件数を返す。
+// ジョブはAPIサーバーのgoroutineで実行するため、サーバーが停止した場合は実行中のまま残る。
+// 起動時と、中断したジョブを取得した際に実行する。
+func (usecase *importUseCase) RecoverImportJobs() (int, error) {
+	current := usecase.clock()
+	return usecase.ImportJobRepository.FailStaleImportJobs(current.Add(-importJobHeartbeatTimeout), current, importJobInterruptedError)
+}
+
+// runImportJob 一括登録ジョブを実行する。
+// 進捗はimportProgressRows行処理するごとにジョブに反映する。ドライランの場合はジョブを保存しないので反映しない。
+// 反映に失敗しても登録は続け、エラーはログに出力する。
+func (usecase *importUseCase) runImportJob(job *model.ImportJob, rows []*model.ImportRow) {
+	publish := func() {
+		if job.DryRun {
+			return
+		}
+		job.HeartbeatAt = usecase.clock()
+		if err := usecase.ImportJobRepository.UpdateImportJob(job); err != nil {
+			log.Printf("一括登録ジョブの更新に失敗しました：%v", err)
+		}
+	}
+	finish := func(status string) {
+		finishedAt := usecase.clock()
+		job.Status = status
+		job.FinishedAt = &finishedAt
+		publish()
+	}
+
+	job.Status = model.ImportJobStatusRunning
+	publish()
+
+	// 発言者ごとの、ファイル内で登録予定の投稿
+	importedPosts := map[string][]*model.Post{}
+	var posts []*model.Post
+	var results []*model.ImportRowResult
+	// 登録予定の投稿ごとの、モデレーターの確認待ちにする禁止語
+	var postReviewWords [][]string
+	for _, row := range rows {
+		result := &model.ImportRowResult{
+			Row:      row.Row,
+			Errors:   append([]string{}, row.Errors...),
+			Warnings: append([]string{}, row.Warnings...),
+		}
+		job.Results = append(job.Results, result)
+
+		var post *model.Post
+		var reviewWords []string
+		if len(result.Errors) == 0 {
+			var err error
+			post, reviewWords, err = usecase.checkImportRow(job, row, importedPosts)
+			if err != nil {
+				result.Errors = append(result.Errors, strings.Split(err.Error(), "\n")...)
+			} else if len(reviewWords) > 0 {
+				result.Warnings = append(result.Warnings, importReviewWarning)
+			}
+		}
+
+		if len(result.Errors) == 0 && !job.DryRun && !job.Atomic {
+			if err := usecase.PostRepository.Create(post, postCreatedEvents(post)); err != nil {
+				result.Errors = append(result.Errors, err.Error())
+			} else {
+				result.PostID = post.ID
+				usecase.holdImportedPost(post, reviewWords)
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			job.FailedCount++
+		} else {
+			importedPosts[post.NormalizedSpeaker] = append(importedPosts[post.NormalizedSpeaker], post)
+			posts = append(posts, post)
+			results = append(results, result)
+			postReviewWords = append(postReviewWords, reviewWords)
+			if !job.Atomic || job.DryRun {
+				job.SucceededCount++
+			}
+		}
+		job.ProcessedCount++
+		if job.ProcessedCount%importProgressRows == 0 {
+			publish()
+		}
+	}
+
+	if job.DryRun {
+		finish(model.ImportJobStatusCompleted)
+		return
+	}
+
+	if job.Atomic {
+		if job.FailedCount > 0 {
+			job.Error = fmt.Sprintf("エラーのある行が%d行あるため、登録しませんでした。", job.FailedCount)
+			finish(model.ImportJobStatusFailed)
+			return
+		}
+		if len(posts) > 0 {
+			if err := usecase.PostRepository.CreatePosts(posts, postCreatedEvents(posts...)); err != nil {
+				job.Error = truncateRunes(err.Error(), 256)
+				finish(model.ImportJobStatusFailed)
+				return
+			}
+		}
+		for i, post := range posts {
+			results[i].PostID = post.ID
+			usecase.holdImportedPost(post, postReviewWords[i])
+		}
+		job.SucceededCount = len(posts)
+	}
+
+	if job.SucceededCount > 0 {
+		wakeDomainEventDispatcher()
+		usecase.autocompleteIndexes.invalidate()
+	}
+	finish(model.ImportJobStatusCompleted)
+}
+
+// checkImportRow 登録する投稿を生成し、禁止語のチェックと重複チェックを行う。
+// 言語が空文字の場合は既定の言語とする。
+// モデレーターの確認待ちにする禁止語を含む場合は、投稿を非表示にして検出した禁止語を返す。
+func (usecase *importUseCase) checkImportRow(job *model.ImportJob, row *model.ImportRow, importedPosts map[string][]*model.Post) (*model.Post, []string, error) {
+	post := row.Post
+	post.UserID = job.UserID
+	if post.Language == "" {
+		post.Language = model.DefaultLanguage
+	}
+	post.Language = canonicalLanguage(post.Language)
+	post.Tags = normalizeTags(post.Tags)
+	reviewWords, err := applyWordFilter(usecase.ProhibitedWordRepository, append([]*filteredField{
+		{"Title", &post.Title},
+		{"Speaker", &post.Speaker},
+		{"Detail", &post.Detail},
+	}, tagFields(post.Tags)...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	post.IsHidden = len(reviewWords) > 0
+	post.NormalizedTitle = normalizeText(post.Title)
+	post.NormalizedSpeaker = normalizeText(post.Speaker)
+
+	if !job.AllowDuplicate {
+		posts, err := usecase.PostRepository.FetchBySpeaker(post.NormalizedSpeaker, post.Speaker)
+		if err != nil {
+			return nil, nil, err
+		}
+		posts = append(posts, importedPosts[post.NormalizedSpeaker]...)
+		if candidates := findDuplicatePosts(post.Title, posts); len(candidates) > 0 {
+			return nil, nil, &DuplicatePostError{Candidates: candidates}
+		}
+	}
+
+	return &post, reviewWords, nil
+}
+
+// holdImportedPost 禁止語を含むため非表示で登録した投稿を、モデレーターの確認待ちにする。
+// 投稿は登録済みのため、失敗した場合は記録のみ行う。
+func (usecase *importUseCase) holdImportedPost(post *model.Post, reviewWords []string) {
+	if len(reviewWords) == 0 {
+		return
+	}
+	if err := holdForReview(usecase.ReportRepository, model.ReportTargetPost, post.ID, reviewWords); err != nil {
+		log.Printf("一括登録した投稿の確認待ちの登録に失敗しました(ID：%d)：%v", post.ID, err)
+	}
+}
+
+// copyImportJob 実行中のジョブと共有しないようにジョブを複製する。
+func copyImportJob(job *model.ImportJob) *model.ImportJob {
+	copied := *job
+	copied.Results = make([]*model.ImportRowResult, len(job.Results))
+	for i, result := range job.Results {
+		copiedResult := *result
+		copied.Results[i] = &copiedResult
+	}
+	return &copied
+}
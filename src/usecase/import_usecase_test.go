@@ -0,0 +1,385 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 一括登録する行を生成
+func makeImportRow(row int, title, speaker string) *model.ImportRow {
+	return &model.ImportRow{
+		Row:  row,
+		Post: model.Post{Title: title, Speaker: speaker},
+	}
+}
+
+// 非同期に実行するジョブを同期的に実行する
+func runSynchronously(job func()) {
+	job()
+}
+
+// Mock。登録したジョブをメモリ内に保持する。
+type mockImportJobRepository struct {
+	nextID int
+	jobs   map[int]*model.ImportJob
+}
+
+func newMockImportJobRepository() *mockImportJobRepository {
+	return &mockImportJobRepository{nextID: 1, jobs: map[int]*model.ImportJob{}}
+}
+
+func (repository *mockImportJobRepository) CreateImportJob(job *model.ImportJob) error {
+	job.ID = repository.nextID
+	repository.nextID++
+	repository.jobs[job.ID] = copyImportJob(job)
+	return nil
+}
+
+func (repository *mockImportJobRepository) UpdateImportJob(job *model.ImportJob) error {
+	repository.jobs[job.ID] = copyImportJob(job)
+	return nil
+}
+
+func (repository *mockImportJobRepository) FetchImportJob(id int) (*model.ImportJob, error) {
+	job, ok := repository.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyImportJob(job), nil
+}
+
+func (repository *mockImportJobRepository) DeleteImportJobs(before time.Time) error {
+	for id, job := range repository.jobs {
+		if !job.CreatedAt.After(before) {
+			delete(repository.jobs, id)
+		}
+	}
+	return nil
+}
+
+func (repository *mockImportJobRepository) FailStaleImportJobs(heartbeatBefore, finishedAt time.Time, message string) (int, error) {
+	count := 0
+	for _, job := range repository.jobs {
+		if (job.Status == model.ImportJobStatusPending || job.Status == model.ImportJobStatusRunning) && !job.HeartbeatAt.After(heartbeatBefore) {
+			job.Status = model.ImportJobStatusFailed
+			job.Error = message
+			job.FinishedAt = &finishedAt
+			count++
+		}
+	}
+	return count, nil
+}
+
+// 一括登録テスト
+func TestImportPosts_success_dryRun(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	jobRepository := newMockImportJobRepository()
+	usecase := NewImportUseCase(&repository, jobRepository, &prohibitedWordRepository, &mockReportRepository{}, NewAutocompleteIndexCache(), runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
+	invalidRow := makeImportRow(2, "", "speaker1")
+	invalidRow.Errors = []string{"Title：必須です。"}
+	duplicateRow := makeImportRow(3, "title1", "speaker2")
+	rows := []*model.ImportRow{makeImportRow(1, "title1", "speaker1"), invalidRow, duplicateRow, makeImportRow(4, "タイトル１", "speaker1")}
+	rows[0].Warnings = []string{"tags"}
+	repository.On("FetchBySpeaker", normalizeText("speaker1"), "speaker1").Return([]*model.Post{}, nil)
+	repository.On("FetchBySpeaker", normalizeText("speaker2"), "speaker2").Return([]*model.Post{{ID: 5, Title: "title1", Speaker: "speaker2"}}, nil)
+
+	// 2. Exercise
+	job, err := usecase.ImportPosts(1, rows, true, false, false)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportJobStatusCompleted, job.Status)
+	assert.Equal(t, 4, job.TotalCount)
+	assert.Equal(t, 4, job.ProcessedCount)
+	assert.Equal(t, 2, job.SucceededCount)
+	assert.Equal(t, 2, job.FailedCount)
+	assert.Equal(t, []string{"tags"}, job.Results[0].Warnings)
+	assert.Empty(t, job.Results[0].Errors)
+	assert.Equal(t, []string{"Title：必須です。"}, job.Results[1].Errors)
+	assert.Equal(t, []string{"同じ発言者による類似した投稿が1件あります。"}, job.Results[2].Errors)
+	assert.Empty(t, job.Results[3].Errors)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Empty(t, jobRepository.jobs)
+	repository.AssertNotCalled(t, "Create", mock.Anything)
+	repository.AssertNotCalled(t, "CreatePosts", mock.Anything)
+
+	// 4. Teardown
+}
+
+func TestImportPosts_success_duplicateInFile(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	jobRepository := newMockImportJobRepository()
+	usecase := NewImportUseCase(&repository, jobRepository, &prohibitedWordRepository, &mockReportRepository{}, NewAutocompleteIndexCache(), runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
+	rows := []*model.ImportRow{makeImportRow(1, "title1", "speaker1"), makeImportRow(2, "title1", "speaker1")}
+	repository.On("FetchBySpeaker", normalizeText("speaker1"), "speaker1").Return([]*model.Post{}, nil)
+
+	// 2. Exercise
+	job, err := usecase.ImportPosts(1, rows, true, false, false)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Empty(t, job.Results[0].Errors)
+	assert.Len(t, job.Results[1].Errors, 1)
+
+	// 4. Teardown
+}
+
+func TestImportPosts_success_perRow(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	jobRepository := newMockImportJobRepository()
+	usecase := NewImportUseCase(&repository, jobRepository, &prohibitedWordRepository, &mockReportRepository{}, NewAutocompleteIndexCache(), runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
+	rows := []*model.ImportRow{makeImportRow(1, "title1", "speaker1"), makeImportRow(2, "title2", "speaker2")}
+	repository.On("Create", mock.MatchedBy(func(post *model.Post) bool {
+		return post.Title == "title1"
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.Post).ID = 10
+	}).Return(nil)
+	repository.On("Create", mock.MatchedBy(func(post *model.Post) bool {
+		return post.Title == "title2"
+	})).Return(errors.New("error"))
+
+	// 2. Exercise
+	job, err := usecase.ImportPosts(1, rows, false, false, true)
+	job, _ = jobRepository.FetchImportJob(job.ID)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.NotZero(t, job.ID)
+	assert.Equal(t, model.ImportJobStatusCompleted, job.Status)
+	assert.Equal(t, 1, job.SucceededCount)
+	assert.Equal(t, 1, job.FailedCount)
+	assert.Equal(t, 10, job.Results[0].PostID)
+	assert.Equal(t, []string{"error"}, job.Results[1].Errors)
+	repository.AssertNotCalled(t, "FetchBySpeaker", mock.Anything, mock.Anything)
+	assert.Len(t, repository.domainEvents, 1)
+	assert.Equal(t, model.DomainEventPostCreated, repository.domainEvents[0].Type)
+
+	// 4. Teardown
+}
+
+func TestImportPosts_success_prohibitedWords(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	reportRepository := mockReportRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	jobRepository := newMockImportJobRepository()
+	usecase := NewImportUseCase(&repository, jobRepository, &prohibitedWordRepository, &reportRepository, NewAutocompleteIndexCache(), runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository,
+		&model.ProhibitedWord{Word: "禁止", Action: model.ProhibitedWordActionBlock},
+		&model.ProhibitedWord{Word: "要確認", Action: model.ProhibitedWordActionReview},
+	)
+	rows := []*model.ImportRow{makeImportRow(1, "禁止の言葉", "speaker1"), makeImportRow(2, "要確認の言葉", "speaker2")}
+	repository.On("Create", mock.MatchedBy(func(post *model.Post) bool {
+		return post.Title == "要確認の言葉" && post.IsHidden
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.Post).ID = 10
+	}).Return(nil)
+	reportRepository.On("SaveReport", mock.MatchedBy(func(report *model.Report) bool {
+		return report.TargetType == model.ReportTargetPost && report.TargetID == 10 && report.Detail == "禁止語：要確認"
+	})).Return(true, nil)
+
+	// 2. Exercise
+	job, err := usecase.ImportPosts(1, rows, false, false, true)
+	job, _ = jobRepository.FetchImportJob(job.ID)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 1, job.SucceededCount)
+	assert.Equal(t, 1, job.FailedCount)
+	assert.Equal(t, []string{"Title：使用できない語句が含まれています。"}, job.Results[0].Errors)
+	assert.Equal(t, []string{importReviewWarning}, job.Results[1].Warnings)
+	assert.Equal(t, 10, job.Results[1].PostID)
+	repository.AssertExpectations(t)
+	reportRepository.AssertExpectations(t)
+	prohibitedWordRepository.AssertExpectations(t)
+	// 確認待ちの投稿は公開されていないため、発行しない
+	assert.Empty(t, repository.domainEvents)
+
+	// 4. Teardown
+}
+
+func TestImportPosts_success_atomic(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	jobRepository := newMockImportJobRepository()
+	usecase := NewImportUseCase(&repository, jobRepository, &prohibitedWordRepository, &mockReportRepository{}, NewAutocompleteIndexCache(), runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
+	rows := []*model.ImportRow{makeImportRow(1, "title1", "speaker1"), makeImportRow(2, "title2", "speaker2")}
+	repository.On("CreatePosts", mock.MatchedBy(func(posts []*model.Post) bool {
+		return len(posts) == 2 && posts[0].UserID == 1 && posts[0].Language == model.DefaultLanguage && posts[1].NormalizedSpeaker == normalizeText("speaker2")
+	})).Run(func(args mock.Arguments) {
+		for i, post := range args.Get(0).([]*model.Post) {
+			post.ID = 10 + i
+		}
+	}).Return(nil)
+
+	// 2. Exercise
+	job, err := usecase.ImportPosts(1, rows, false, true, true)
+	job, _ = jobRepository.FetchImportJob(job.ID)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportJobStatusCompleted, job.Status)
+	assert.Equal(t, 2, job.SucceededCount)
+	assert.Equal(t, 10, job.Results[0].PostID)
+	assert.Equal(t, 11, job.Results[1].PostID)
+	repository.AssertNotCalled(t, "Create", mock.Anything)
+	assert.Len(t, repository.domainEvents, 2)
+	assert.Equal(t, model.DomainEventPostCreated, repository.domainEvents[1].Type)
+
+	// 4. Teardown
+}
+
+func TestImportPosts_error_atomic(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	jobRepository := newMockImportJobRepository()
+	usecase := NewImportUseCase(&repository, jobRepository, &prohibitedWordRepository, &mockReportRepository{}, NewAutocompleteIndexCache(), runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
+	invalidRow := makeImportRow(2, "", "speaker2")
+	invalidRow.Errors = []string{"Title：必須です。"}
+	rows := []*model.ImportRow{makeImportRow(1, "title1", "speaker1"), invalidRow}
+
+	// 2. Exercise
+	job, err := usecase.ImportPosts(1, rows, false, true, true)
+	job, _ = jobRepository.FetchImportJob(job.ID)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportJobStatusFailed, job.Status)
+	assert.Equal(t, 0, job.SucceededCount)
+	assert.Equal(t, 1, job.FailedCount)
+	assert.NotEmpty(t, job.Error)
+	repository.AssertNotCalled(t, "CreatePosts", mock.Anything)
+
+	// 4. Teardown
+}
+
+// 一括登録ジョブ取得テスト
+func TestGetImportJob(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	jobRepository := newMockImportJobRepository()
+	usecase := NewImportUseCase(&repository, jobRepository, &mockProhibitedWordRepository{}, &mockReportRepository{}, NewAutocompleteIndexCache(), runSynchronously)
+	job := &model.ImportJob{UserID: 1, Status: model.ImportJobStatusRunning, Results: []*model.ImportRowResult{{Row: 1}}, HeartbeatAt: time.Now()}
+	jobRepository.CreateImportJob(job)
+
+	cases := []struct {
+		label  string
+		id     int
+		userID int
+		err    error
+	}{
+		{"success", job.ID, 1, nil},
+		{"otherUser", job.ID, 2, ErrImportJobNotFound},
+		{"jobNotFound", job.ID + 1000, 1, ErrImportJobNotFound},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			// 2. Exercise
+			result, err := usecase.GetImportJob(c.id, c.userID)
+
+			// 3. Verify
+			assert.Equal(t, c.err, err)
+			if c.err == nil {
+				assert.Equal(t, model.ImportJobStatusRunning, result.Status)
+				assert.Equal(t, 1, result.Results[0].Row)
+			}
+		})
+	}
+
+	// 4. Teardown
+}
+
+// 中断した一括登録ジョブの取得テスト
+func TestGetImportJob_interrupted(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	jobRepository := newMockImportJobRepository()
+	usecase := &importUseCase{&mockPostRepository{}, jobRepository, &mockProhibitedWordRepository{}, &mockReportRepository{}, NewAutocompleteIndexCache(), runSynchronously, fixedClock(current)}
+	job := &model.ImportJob{UserID: 1, Status: model.ImportJobStatusRunning, HeartbeatAt: current.Add(-importJobHeartbeatTimeout)}
+	jobRepository.CreateImportJob(job)
+
+	// 2. Exercise
+	result, err := usecase.GetImportJob(job.ID, 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportJobStatusFailed, result.Status)
+	assert.Equal(t, importJobInterruptedError, result.Error)
+	assert.Equal(t, current, *result.FinishedAt)
+
+	// 4. Teardown
+}
+
+// 中断した一括登録ジョブの回復テスト
+func TestRecoverImportJobs(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	jobRepository := newMockImportJobRepository()
+	usecase := &importUseCase{&mockPostRepository{}, jobRepository, &mockProhibitedWordRepository{}, &mockReportRepository{}, NewAutocompleteIndexCache(), runSynchronously, fixedClock(current)}
+	interrupted := &model.ImportJob{UserID: 1, Status: model.ImportJobStatusRunning, HeartbeatAt: current.Add(-time.Hour)}
+	pending := &model.ImportJob{UserID: 1, Status: model.ImportJobStatusPending, HeartbeatAt: current.Add(-time.Hour)}
+	running := &model.ImportJob{UserID: 1, Status: model.ImportJobStatusRunning, HeartbeatAt: current.Add(-time.Minute)}
+	completed := &model.ImportJob{UserID: 1, Status: model.ImportJobStatusCompleted, HeartbeatAt: current.Add(-time.Hour)}
+	for _, job := range []*model.ImportJob{interrupted, pending, running, completed} {
+		jobRepository.CreateImportJob(job)
+	}
+
+	// 2. Exercise
+	count, err := usecase.RecoverImportJobs()
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, model.ImportJobStatusFailed, jobRepository.jobs[interrupted.ID].Status)
+	assert.Equal(t, model.ImportJobStatusFailed, jobRepository.jobs[pending.ID].Status)
+	assert.Equal(t, model.ImportJobStatusRunning, jobRepository.jobs[running.ID].Status)
+	assert.Equal(t, model.ImportJobStatusCompleted, jobRepository.jobs[completed.ID].Status)
+
+	// 4. Teardown
+}
+
+// 保持期間を過ぎた一括登録ジョブの削除テスト
+func TestImportPosts_success_deleteExpiredJobs(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	jobRepository := newMockImportJobRepository()
+	usecase := &importUseCase{&repository, jobRepository, &prohibitedWordRepository, &mockReportRepository{}, NewAutocompleteIndexCache(), runSynchronously, fixedClock(current)}
+	setProhibitedWords(t, &prohibitedWordRepository)
+	expired := &model.ImportJob{UserID: 1, CreatedAt: current.Add(-importJobRetention - time.Minute)}
+	jobRepository.CreateImportJob(expired)
+	retained := &model.ImportJob{UserID: 1, CreatedAt: current.Add(-importJobRetention + time.Minute)}
+	jobRepository.CreateImportJob(retained)
+
+	// 2. Exercise
+	job, err := usecase.ImportPosts(1, []*model.ImportRow{}, false, false, true)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportJobStatusPending, job.Status)
+	assert.Len(t, jobRepository.jobs, 2)
+	assert.NotNil(t, jobRepository.jobs[retained.ID])
+	assert.Equal(t, model.ImportJobStatusCompleted, jobRepository.jobs[job.ID].Status)
+
+	// 4. Teardown
+}
@@ -0,0 +1,12 @@
+// Package usecase Application Service層。
+package usecase
+
+// JobRunner 時間のかかる処理をリクエストとは別に非同期に実行する。
+// ユースケースの生成時に渡し、テストでは同期的に実行するものに差し替える。
+type JobRunner func(job func())
+
+// RunInBackground 処理をgoroutineで実行するJobRunner。
+// 実行中にサーバーが停止した場合、処理は中断される。中断したジョブの扱いは各ユースケースで行う。
+func RunInBackground(job func()) {
+	go job()
+}
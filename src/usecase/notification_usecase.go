@@ -0,0 +1,207 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+var (
+	// ErrNotificationNotFound 存在しない通知、他のユーザーの通知を指定した場合のエラー
+	ErrNotificationNotFound = errors.New("通知が見つかりません。")
+	// ErrInvalidNotificationType 通知の種類が正しくない場合のエラー
+	ErrInvalidNotificationType = errors.New("Type：通知の種類が正しくありません。")
+)
+
+// NotificationUseCase インターフェース
+type NotificationUseCase interface {
+	// 通知一覧取得
+	GetNotifications(userID int, unreadOnly bool, limit, page int) (totalCount, unreadCount int, notifications []*model.GetNotificationResult, err error)
+	// 未読の通知の件数取得
+	GetUnreadCount(userID int) (int, error)
+	// 通知を既読にする
+	MarkAsRead(id, userID int) error
+	// すべての通知を既読にする
+	MarkAllAsRead(userID int) error
+	// 通知の受け取り設定取得
+	GetSettings(userID int) ([]*model.NotificationSetting, error)
+	// 通知の受け取り設定の更新
+	UpdateSetting(userID int, notificationType string, enabled bool) error
+	// ドメインイベントの通知(サブスクライバー)
+	HandleDomainEvent(event *model.OutboxEvent) error
+}
+
+// notificationUseCase 構造体
+type notificationUseCase struct {
+	repository.ModerationRepository
+	repository.NotificationRepository
+	clock Clock
+}
+
+// NewNotificationUseCase NotificationUseCaseを生成。
+func NewNotificationUseCase(moderationRepository repository.ModerationRepository, notificationRepository repository.NotificationRepository) NotificationUseCase {
+	return &notificationUseCase{moderationRepository, notificationRepository, time.Now}
+}
+
+// GetNotifications 通知一覧取得。新しい順に返す。未読の通知の件数も返す。
+func (usecase *notificationUseCase) GetNotifications(userID int, unreadOnly bool, limit, page int) (totalCount, unreadCount int, notifications []*model.GetNotificationResult, err error) {
+	totalCount, notifications, err = usecase.NotificationRepository.FetchNotifications(userID, unreadOnly, limit, page)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	unreadCount, err = usecase.NotificationRepository.CountUnreadNotifications(userID)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	for _, notification := range notifications {
+		notification.Message = notificationMessage(notification)
+	}
+	return totalCount, unreadCount, notifications, nil
+}
+
+// notificationMessage 通知の表示用のメッセージを生成する。
+// 1人の場合はユーザー名、複数人の場合は人数を表示する(例：3人があなたの投稿「…」をお気に入りしました。)。
+func notificationMessage(notification *model.GetNotificationResult) string {
+	actor := notification.ActorName + "さん"
+	if notification.ActorCount > 1 {
+		actor = fmt.Sprintf("%d人", notification.ActorCount)
+	}
+
+	switch notification.Type {
+	case model.NotificationTypeComment:
+		return fmt.Sprintf("%sがあなたの投稿「%s」にコメントしました。", actor, notification.PostTitle)
+	case model.NotificationTypeFavorite:
+		return fmt.Sprintf("%sがあなたの投稿「%s」をお気に入りしました。", actor, notification.PostTitle)
+	case model.NotificationTypeFollow:
+		return fmt.Sprintf("%sがあなたをフォローしました。", actor)
+	}
+	return ""
+}
+
+// GetUnreadCount 未読の通知の件数取得
+func (usecase *notificationUseCase) GetUnreadCount(userID int) (int, error) {
+	return usecase.NotificationRepository.CountUnreadNotifications(userID)
+}
+
+// MarkAsRead 通知を既読にする。本人の通知のみ既読にできる。
+func (usecase *notificationUseCase) MarkAsRead(id, userID int) error {
+	found, err := usecase.NotificationRepository.MarkNotificationRead(id, userID, usecase.clock())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllAsRead すべての通知を既読にする
+func (usecase *notificationUseCase) MarkAllAsRead(userID int) error {
+	return usecase.NotificationRepository.MarkAllNotificationsRead(userID, usecase.clock())
+}
+
+// GetSettings 通知の受け取り設定取得。すべての種類の設定を返し、変更していない種類は受け取る設定とする。
+func (usecase *notificationUseCase) GetSettings(userID int) ([]*model.NotificationSetting, error) {
+	saved, err := usecase.NotificationRepository.FetchNotificationSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	enabled := map[string]bool{}
+	for _, setting := range saved {
+		enabled[setting.Type] = setting.Enabled
+	}
+
+	settings := make([]*model.NotificationSetting, 0, len(model.NotificationTypes))
+	for _, notificationType := range model.NotificationTypes {
+		setting := &model.NotificationSetting{UserID: userID, Type: notificationType, Enabled: true}
+		if value, ok := enabled[notificationType]; ok {
+			setting.Enabled = value
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+// UpdateSetting 通知の受け取り設定の更新
+func (usecase *notificationUseCase) UpdateSetting(userID int, notificationType string, enabled bool) error {
+	if !isNotificationType(notificationType) {
+		return ErrInvalidNotificationType
+	}
+	return usecase.NotificationRepository.SaveNotificationSetting(&model.NotificationSetting{UserID: userID, Type: notificationType, Enabled: enabled})
+}
+
+// isNotificationType 通知の種類の場合はtrueを返す
+func isNotificationType(notificationType string) bool {
+	for _, t := range model.NotificationTypes {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleDomainEvent コメント、お気に入りの登録を投稿した本人に、フォローをフォローされたユーザーに通知する(サブスクライバー)。
+// 同じイベントが再び届いても、未読の通知は行ったユーザーごとにまとめるため重複しない。
+func (usecase *notificationUseCase) HandleDomainEvent(event *model.OutboxEvent) error {
+	switch event.EventType {
+	case model.DomainEventCommentCreated:
+		var comment model.Comment
+		if err := json.Unmarshal([]byte(event.Payload), &comment); err != nil {
+			return err
+		}
+		return usecase.notifyPostAuthor(model.NotificationTypeComment, comment.PostID, comment.UserID)
+	case model.DomainEventFavoriteCreated:
+		var favorite model.Favorite
+		if err := json.Unmarshal([]byte(event.Payload), &favorite); err != nil {
+			return err
+		}
+		return usecase.notifyPostAuthor(model.NotificationTypeFavorite, favorite.PostID, favorite.UserID)
+	case model.DomainEventFollowCreated:
+		var follow model.Follow
+		if err := json.Unmarshal([]byte(event.Payload), &follow); err != nil {
+			return err
+		}
+		return saveNotification(usecase.NotificationRepository, &model.Notification{UserID: follow.FolloweeID, Type: model.NotificationTypeFollow, ActorID: follow.FollowerID})
+	}
+	return nil
+}
+
+// notifyPostAuthor 投稿した本人に通知する。投稿が存在しない場合は通知しない。
+func (usecase *notificationUseCase) notifyPostAuthor(notificationType string, postID, actorID int) error {
+	post, err := usecase.ModerationRepository.FetchPostForModeration(postID)
+	if err != nil {
+		return err
+	}
+	if post == nil {
+		return nil
+	}
+	return saveNotification(usecase.NotificationRepository, &model.Notification{UserID: post.UserID, Type: notificationType, PostID: postID, ActorID: actorID})
+}
+
+// saveNotification 通知を登録する。本人が行った場合、受け取らない設定の場合は登録しない。
+func saveNotification(notificationRepository repository.NotificationRepository, notification *model.Notification) error {
+	if notification.UserID == notification.ActorID {
+		return nil
+	}
+	settings, err := notificationRepository.FetchNotificationSettings(notification.UserID)
+	if err != nil {
+		return err
+	}
+	for _, setting := range settings {
+		if setting.Type == notification.Type && !setting.Enabled {
+			return nil
+		}
+	}
+
+	if err := notificationRepository.SaveNotification(notification); err != nil {
+		return err
+	}
+	realtimeHub.publish(model.RealtimeEventNotification, 0, notification.UserID, notification)
+	return nil
+}
@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockNotificationRepository struct {
+	mock.Mock
+}
+
+func (repository *mockNotificationRepository) SaveNotification(notification *model.Notification) error {
+	return repository.Called(notification).Error(0)
+}
+
+func (repository *mockNotificationRepository) FetchNotifications(userID int, unreadOnly bool, limit, page int) (totalCount int, notifications []*model.GetNotificationResult, err error) {
+	args := repository.Called(userID, unreadOnly, limit, page)
+	notifications, ok := args.Get(1).([]*model.GetNotificationResult)
+	if ok {
+		return args.Int(0), notifications, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+func (repository *mockNotificationRepository) CountUnreadNotifications(userID int) (int, error) {
+	args := repository.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (repository *mockNotificationRepository) MarkNotificationRead(id, userID int, readAt time.Time) (bool, error) {
+	args := repository.Called(id, userID, readAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (repository *mockNotificationRepository) MarkAllNotificationsRead(userID int, readAt time.Time) error {
+	return repository.Called(userID, readAt).Error(0)
+}
+
+func (repository *mockNotificationRepository) FetchNotificationSettings(userID int) ([]*model.NotificationSetting, error) {
+	args := repository.Called(userID)
+	settings, ok := args.Get(0).([]*model.NotificationSetting)
+	if ok {
+		return settings, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (repository *mockNotificationRepository) SaveNotificationSetting(setting *model.NotificationSetting) error {
+	return repository.Called(setting).Error(0)
+}
+
+// 通知一覧取得テスト
+func TestGetNotifications_success(t *testing.T) {
+	// 1. Setup
+	repository := mockNotificationRepository{}
+	usecase := NewNotificationUseCase(&mockPostRepository{}, &repository)
+	repository.On("FetchNotifications", 1, true, 10, 1).Return(3, []*model.GetNotificationResult{
+		{Notification: model.Notification{Type: model.NotificationTypeFavorite, ActorCount: 3}, ActorName: "user2", PostTitle: "title1"},
+		{Notification: model.Notification{Type: model.NotificationTypeComment, ActorCount: 1}, ActorName: "user3", PostTitle: "title2"},
+		{Notification: model.Notification{Type: model.NotificationTypeFollow, ActorCount: 1}, ActorName: "user4"},
+	}, nil)
+	repository.On("CountUnreadNotifications", 1).Return(5, nil)
+
+	// 2. Exercise
+	totalCount, unreadCount, notifications, err := usecase.GetNotifications(1, true, 10, 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 3, totalCount)
+	assert.Equal(t, 5, unreadCount)
+	assert.Equal(t, "3人があなたの投稿「title1」をお気に入りしました。", notifications[0].Message)
+	assert.Equal(t, "user3さんがあなたの投稿「title2」にコメントしました。", notifications[1].Message)
+	assert.Equal(t, "user4さんがあなたをフォローしました。", notifications[2].Message)
+
+	// 4. Teardown
+}
+
+func TestGetNotifications_error(t *testing.T) {
+	// 1. Setup
+	repository := mockNotificationRepository{}
+	usecase := NewNotificationUseCase(&mockPostRepository{}, &repository)
+	repository.On("FetchNotifications", 1, false, 10, 1).Return(0, nil, errors.New("error"))
+
+	// 2. Exercise
+	_, _, notifications, err := usecase.GetNotifications(1, false, 10, 1)
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, notifications)
+
+	// 4. Teardown
+}
+
+// 既読テスト
+func TestMarkAsRead(t *testing.T) {
+	cases := []struct {
+		label string
+		found bool
+		err   error
+	}{
+		{"成功", true, nil},
+		{"通知なし", false, ErrNotificationNotFound},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			// 1. Setup
+			readAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+			repository := mockNotificationRepository{}
+			usecase := &notificationUseCase{&mockPostRepository{}, &repository, fixedClock(readAt)}
+			repository.On("MarkNotificationRead", 1, 2, readAt).Return(c.found, nil)
+
+			// 2. Exercise
+			err := usecase.MarkAsRead(1, 2)
+
+			// 3. Verify
+			assert.Equal(t, c.err, err)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// 通知の受け取り設定取得テスト
+func TestGetNotificationSettings(t *testing.T) {
+	// 1. Setup
+	repository := mockNotificationRepository{}
+	usecase := NewNotificationUseCase(&mockPostRepository{}, &repository)
+	repository.On("FetchNotificationSettings", 1).Return([]*model.NotificationSetting{
+		{UserID: 1, Type: model.NotificationTypeFavorite, Enabled: false},
+	}, nil)
+
+	// 2. Exercise
+	settings, err := usecase.GetSettings(1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.NotificationSetting{
+		{UserID: 1, Type: model.NotificationTypeComment, Enabled: true},
+		{UserID: 1, Type: model.NotificationTypeFavorite, Enabled: false},
+		{UserID: 1, Type: model.NotificationTypeFollow, Enabled: true},
+	}, settings)
+
+	// 4. Teardown
+}
+
+// 通知の受け取り設定の更新テスト
+func TestUpdateNotificationSetting(t *testing.T) {
+	// 1. Setup
+	repository := mockNotificationRepository{}
+	usecase := NewNotificationUseCase(&mockPostRepository{}, &repository)
+	repository.On("SaveNotificationSetting", &model.NotificationSetting{UserID: 1, Type: model.NotificationTypeFollow, Enabled: false}).Return(nil)
+
+	// 2. Exercise
+	err := usecase.UpdateSetting(1, model.NotificationTypeFollow, false)
+	invalidErr := usecase.UpdateSetting(1, "unknown", false)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, ErrInvalidNotificationType, invalidErr)
+	repository.AssertNumberOfCalls(t, "SaveNotificationSetting", 1)
+
+	// 4. Teardown
+}
+
+// 通知登録テスト
+func TestSaveNotification(t *testing.T) {
+	cases := []struct {
+		label    string
+		userID   int
+		settings []*model.NotificationSetting
+		saved    bool
+	}{
+		{"登録", 2, nil, true},
+		{"本人", 1, nil, false},
+		{"受け取らない設定", 2, []*model.NotificationSetting{{Type: model.NotificationTypeComment, Enabled: false}}, false},
+		{"他の種類を受け取らない設定", 2, []*model.NotificationSetting{{Type: model.NotificationTypeFollow, Enabled: false}}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			// 1. Setup
+			repository := mockNotificationRepository{}
+			repository.On("FetchNotificationSettings", 2).Return(c.settings, nil)
+			repository.On("SaveNotification", mock.Anything).Return(nil)
+
+			// 2. Exercise
+			err := saveNotification(&repository, &model.Notification{UserID: c.userID, Type: model.NotificationTypeComment, PostID: 1, ActorID: 1})
+
+			// 3. Verify
+			assert.NoError(t, err)
+			if c.saved {
+				repository.AssertCalled(t, "SaveNotification", mock.Anything)
+			} else {
+				repository.AssertNotCalled(t, "SaveNotification", mock.Anything)
+			}
+		})
+	}
+
+	// 4. Teardown
+}
+
+// ドメインイベントによる通知テスト
+func TestNotificationHandleDomainEvent_success(t *testing.T) {
+	cases := []struct {
+		label        string
+		event        *model.OutboxEvent
+		notification *model.Notification
+	}{
+		{"コメント", &model.OutboxEvent{EventType: model.DomainEventCommentCreated, Payload: `{"id":3,"post_id":1,"user_id":2,"body":"body"}`}, &model.Notification{UserID: 5, Type: model.NotificationTypeComment, PostID: 1, ActorID: 2}},
+		{"お気に入り", &model.OutboxEvent{EventType: model.DomainEventFavoriteCreated, Payload: `{"user_id":2,"post_id":1}`}, &model.Notification{UserID: 5, Type: model.NotificationTypeFavorite, PostID: 1, ActorID: 2}},
+		{"フォロー", &model.OutboxEvent{EventType: model.DomainEventFollowCreated, Payload: `{"id":4,"follower_id":2,"followee_id":3}`}, &model.Notification{UserID: 3, Type: model.NotificationTypeFollow, ActorID: 2}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			// 1. Setup
+			postRepository := mockPostRepository{}
+			repository := mockNotificationRepository{}
+			usecase := NewNotificationUseCase(&postRepository, &repository)
+			postRepository.On("FetchPostForModeration", 1).Return(&model.Post{ID: 1, UserID: 5}, nil)
+			repository.On("FetchNotificationSettings", c.notification.UserID).Return(nil, nil)
+			repository.On("SaveNotification", c.notification).Return(nil)
+
+			// 2. Exercise
+			err := usecase.HandleDomainEvent(c.event)
+
+			// 3. Verify
+			assert.NoError(t, err)
+			repository.AssertExpectations(t)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// ドメインイベントによる通知で、投稿が存在しない場合は登録しない
+func TestNotificationHandleDomainEvent_postNotFound(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	repository := mockNotificationRepository{}
+	usecase := NewNotificationUseCase(&postRepository, &repository)
+	postRepository.On("FetchPostForModeration", 1).Return(nil, nil)
+
+	// 2. Exercise
+	err := usecase.HandleDomainEvent(&model.OutboxEvent{EventType: model.DomainEventFavoriteCreated, Payload: `{"user_id":2,"post_id":1}`})
+
+	// 3. Verify
+	assert.NoError(t, err)
+	repository.AssertNotCalled(t, "SaveNotification", mock.Anything)
+
+	// 4. Teardown
+}
+
+// ドメインイベントによる通知で、投稿の取得に失敗した場合はエラーを返し、再送させる
+func TestNotificationHandleDomainEvent_error(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	repository := mockNotificationRepository{}
+	usecase := NewNotificationUseCase(&postRepository, &repository)
+	postRepository.On("FetchPostForModeration", 1).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	err := usecase.HandleDomainEvent(&model.OutboxEvent{EventType: model.DomainEventCommentCreated, Payload: `{"id":3,"post_id":1,"user_id":2,"body":"body"}`})
+
+	// 3. Verify
+	assert.Error(t, err)
+	repository.AssertNotCalled(t, "SaveNotification", mock.Anything)
+
+	// 4. Teardown
+}
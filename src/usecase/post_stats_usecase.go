@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"strconv"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+)
+
+// RecordView 閲覧の記録。ログインしていない場合はIPアドレスで閲覧者を識別する。
+// ログインしている場合は閲覧済みの投稿としても記録する。
+// データベースへの反映は非同期に行うため、閲覧数にすぐには反映されない。
+func (usecase *postUseCase) RecordView(postID, loginUserID int, ipAddress string) {
+	viewer := "ip:" + ipAddress
+	if loginUserID > 0 {
+		viewer = "user:" + strconv.Itoa(loginUserID)
+	}
+	usecase.viewCounter.record(postID, viewer, loginUserID, usecase.clock())
+}
+
+// GetPostStats 投稿の統計取得。今日までのdays日間の日付ごとの閲覧数、お気に入り数、コメント数を返す。
+// postIDが0の場合は投稿者の全ての投稿の合計を返す。投稿者以外は取得できない(ErrPostNotFoundを返す)。
+func (usecase *postUseCase) GetPostStats(userID, postID, days int) (*model.PostStats, error) {
+	if postID > 0 {
+		post, err := usecase.ModerationRepository.FetchPostForModeration(postID)
+		if err != nil {
+			return nil, err
+		}
+		if post == nil || post.UserID != userID {
+			return nil, ErrPostNotFound
+		}
+	}
+
+	today := usecase.clock()
+	from := today.AddDate(0, 0, -(days - 1))
+	stats := &model.PostStats{
+		PostID: postID,
+		From:   from.Format(dailyPostDateFormat),
+		To:     today.Format(dailyPostDateFormat),
+		Daily:  make([]*model.PostDailyStat, 0, days),
+	}
+
+	dailyStats, err := usecase.PostViewRepository.FetchPostDailyStats(userID, postID, stats.From, stats.To)
+	if err != nil {
+		return nil, err
+	}
+	statsByDate := map[string]*model.PostDailyStat{}
+	for _, stat := range dailyStats {
+		statsByDate[stat.Date] = stat
+	}
+
+	// 件数が0の日も含める
+	for date := from; len(stats.Daily) < days; date = date.AddDate(0, 0, 1) {
+		stat, ok := statsByDate[date.Format(dailyPostDateFormat)]
+		if !ok {
+			stat = &model.PostDailyStat{Date: date.Format(dailyPostDateFormat)}
+		}
+		stats.Daily = append(stats.Daily, stat)
+		stats.Views += stat.Views
+		stats.Favorites += stat.Favorites
+		stats.Comments += stat.Comments
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockPostViewRepository struct {
+	mock.Mock
+}
+
+// 閲覧の記録
+func (repository *mockPostViewRepository) SavePostViews(views []*model.PostView) error {
+	return repository.Called(views).Error(0)
+}
+
+// 期間を過ぎた閲覧の記録の削除
+func (repository *mockPostViewRepository) DeletePostViews(before time.Time) error {
+	return repository.Called(before).Error(0)
+}
+
+// 閲覧済みの投稿の記録
+func (repository *mockPostViewRepository) SaveSeenPosts(userID int, postIDs []int, seenAt time.Time) error {
+	return repository.Called(userID, postIDs, seenAt).Error(0)
+}
+
+// 日付ごとの閲覧数、お気に入り数、コメント数取得
+func (repository *mockPostViewRepository) FetchPostDailyStats(userID, postID int, from, to string) ([]*model.PostDailyStat, error) {
+	args := repository.Called(userID, postID, from, to)
+	stats, ok := args.Get(0).([]*model.PostDailyStat)
+	if ok {
+		return stats, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 閲覧の記録テスト
+func TestRecordView(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 12, 0, 0, 0, time.Local)
+	windowStart := current.Truncate(viewDedupWindow())
+	repository := mockPostRepository{}
+	postViewRepository := mockPostViewRepository{}
+	usecase := &postUseCase{&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &postViewRepository, NewAutocompleteIndexCache(), NewViewCounter(&postViewRepository, runSynchronously), runSynchronously, fixedClock(current)}
+	postViewRepository.On("SavePostViews", []*model.PostView{{CreatedAt: current, PostID: 1, Viewer: "ip:192.0.2.1", WindowStart: windowStart, Date: "2020-12-31"}}).Return(nil)
+	// ログインしている場合はユーザーで閲覧者を識別し、閲覧済みの投稿としても記録する
+	postViewRepository.On("SavePostViews", []*model.PostView{{CreatedAt: current, PostID: 1, Viewer: "user:2", WindowStart: windowStart, UserID: 2, Date: "2020-12-31"}}).Return(nil)
+
+	// 2. Exercise
+	usecase.RecordView(1, 0, "192.0.2.1")
+	usecase.RecordView(1, 2, "192.0.2.1")
+
+	// 3. Verify
+	postViewRepository.AssertExpectations(t)
+	assert.Empty(t, usecase.viewCounter.pending)
+
+	// 4. Teardown
+}
+
+// データベースへの反映に失敗した閲覧の再反映テスト
+func TestViewCounterFlush_retry(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 12, 0, 0, 0, time.Local)
+	postViewRepository := mockPostViewRepository{}
+	counter := NewViewCounter(&postViewRepository, runSynchronously)
+	postViewRepository.On("SavePostViews", mock.AnythingOfType("[]*model.PostView")).Return(errors.New("error")).Once()
+	postViewRepository.On("SavePostViews", mock.AnythingOfType("[]*model.PostView")).Return(nil).Once()
+
+	// 2. Exercise
+	counter.record(1, "ip:192.0.2.1", 0, current)
+	// 失敗した閲覧は反映していない閲覧に戻す
+	pending := len(counter.pending)
+	err := counter.Flush()
+
+	// 3. Verify
+	assert.Equal(t, 1, pending)
+	assert.NoError(t, err)
+	assert.Empty(t, counter.pending)
+	postViewRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+// 期間を過ぎた閲覧の記録の削除テスト
+func TestViewCounterExpire(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 12, 0, 0, 0, time.Local)
+	postViewRepository := mockPostViewRepository{}
+	counter := NewViewCounter(&postViewRepository, runSynchronously)
+	// 直前の期間の記録は残す
+	postViewRepository.On("DeletePostViews", current.Truncate(viewDedupWindow()).Add(-viewDedupWindow())).Return(nil)
+
+	// 2. Exercise
+	err := counter.expire(current)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	postViewRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+// 投稿の統計取得テスト
+func TestGetPostStats_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	postViewRepository := mockPostViewRepository{}
+	usecase := &postUseCase{&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &postViewRepository, NewAutocompleteIndexCache(), nil, runSynchronously, fixedClock(time.Date(2020, 12, 31, 12, 0, 0, 0, time.Local))}
+	repository.On("FetchPostForModeration", 2).Return(&model.Post{ID: 2, UserID: 1}, nil)
+	postViewRepository.On("FetchPostDailyStats", 1, 2, "2020-12-29", "2020-12-31").Return([]*model.PostDailyStat{
+		{Date: "2020-12-29", Views: 10, Favorites: 1},
+		{Date: "2020-12-31", Views: 5, Comments: 2},
+	}, nil)
+
+	// 2. Exercise
+	stats, err := usecase.GetPostStats(1, 2, 3)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, &model.PostStats{
+		PostID:    2,
+		From:      "2020-12-29",
+		To:        "2020-12-31",
+		Views:     15,
+		Favorites: 1,
+		Comments:  2,
+		Daily: []*model.PostDailyStat{
+			{Date: "2020-12-29", Views: 10, Favorites: 1},
+			{Date: "2020-12-30"},
+			{Date: "2020-12-31", Views: 5, Comments: 2},
+		},
+	}, stats)
+
+	// 4. Teardown
+}
+
+func TestGetPostStats_error_notOwner(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	postViewRepository := mockPostViewRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &postViewRepository, NewAutocompleteIndexCache(), nil, runSynchronously)
+	repository.On("FetchPostForModeration", 2).Return(&model.Post{ID: 2, UserID: 3}, nil)
+	repository.On("FetchPostForModeration", 4).Return(nil, nil)
+
+	for _, postID := range []int{2, 4} {
+		// 2. Exercise
+		_, err := usecase.GetPostStats(1, postID, 30)
+
+		// 3. Verify
+		assert.Equal(t, ErrPostNotFound, err)
+	}
+	postViewRepository.AssertNotCalled(t, "FetchPostDailyStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// 4. Teardown
+}
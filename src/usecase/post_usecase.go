@@ -2,70 +2,186 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
 	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
 )
 
+// ErrFavoriteNotFound お気に入りが存在しない場合のエラー
+var ErrFavoriteNotFound = errors.New("お気に入りが見つかりません。")
+
+// ErrPostNotFound 投稿が存在しない場合のエラー
+var ErrPostNotFound = errors.New("投稿が見つかりません。")
+
 // PostUseCase インターフェース
 type PostUseCase interface {
 	// 投稿登録
-	CreatePost(userID int, title, speaker, detail, movieURL string) (err error)
+	CreatePost(userID int, title, speaker, detail, movieURL string, source model.PostSource, license, language string, tags []string, allowDuplicate bool) (err error)
 	// 投稿一覧取得
-	GetPosts(limit, offset int, keyword string, postUserID, loginUserID int) (totalCount int, posts []*model.GetPostResult, err error)
+	GetPosts(limit, offset int, keyword string, postUserID, loginUserID int, verifiedOnly bool, language string, preferredLanguages []string) (totalCount int, posts []*model.GetPostResult, err error)
 	// 投稿詳細取得
-	GetPost(id, loginUserID int) (*model.GetPostResult, error)
+	GetPost(id, loginUserID int, preferredLanguages []string) (*model.GetPostResult, error)
 	// 投稿更新
-	UpdatePost(ID int, title, speaker, detail, movieURL string) error
+	UpdatePost(ID int, title, speaker, detail, movieURL string, source model.PostSource, license, language string, tags []string) error
 	// 投稿削除
 	DeletePost(id int) error
 
 	// お気に入り登録
-	CreateFavorite(postID, userID int) (err error)
+	CreateFavorite(userID, postID int, note, tag string) (err error)
 	// お気に入り一覧取得
-	GetFavorites(userID, limit, offset int) (totalCount int, posts []*model.GetPostResult, err error)
+	GetFavorites(userID, loginUserID, limit, offset int, keyword, tag string) (totalCount int, posts []*model.GetPostResult, err error)
+	// お気に入りのメモ、理由の分類の更新
+	UpdateFavoriteNote(userID, postID int, note, tag string) error
 	// お気に入り削除
 	DeleteFavorite(userID, postID int) error
+
+	// 閲覧の記録
+	RecordView(postID, loginUserID int, ipAddress string)
+	// 投稿の統計取得
+	GetPostStats(userID, postID, days int) (*model.PostStats, error)
 }
 
 // postUseCase 構造体
 type postUseCase struct {
 	repository.PostRepository
+	repository.TranslationRepository
+	repository.AttributionRepository
+	repository.ModerationRepository
+	repository.ProhibitedWordRepository
+	repository.ReportRepository
+	repository.ReactionRepository
+	repository.PostViewRepository
+	autocompleteIndexes *AutocompleteIndexCache
+	viewCounter         *ViewCounter
+	runJob              JobRunner
+	clock               Clock
 }
 
 // NewPostUseCase PostUseCaseを生成。
-func NewPostUseCase(repository repository.PostRepository) PostUseCase {
-	return &postUseCase{repository}
+func NewPostUseCase(postRepository repository.PostRepository, translationRepository repository.TranslationRepository, attributionRepository repository.AttributionRepository, moderationRepository repository.ModerationRepository, prohibitedWordRepository repository.ProhibitedWordRepository, reportRepository repository.ReportRepository, reactionRepository repository.ReactionRepository, postViewRepository repository.PostViewRepository, autocompleteIndexes *AutocompleteIndexCache, viewCounter *ViewCounter, runJob JobRunner) PostUseCase {
+	return &postUseCase{postRepository, translationRepository, attributionRepository, moderationRepository, prohibitedWordRepository, reportRepository, reactionRepository, postViewRepository, autocompleteIndexes, viewCounter, runJob, time.Now}
 }
 
-// CreatePost 投稿登録
-func (usecase *postUseCase) CreatePost(userID int, title, speaker, detail, movieURL string) (err error) {
+// CreatePost 投稿登録。
+// 言語が空文字の場合は既定の言語とする。タグは前後の空白を除き、空のタグと重複したタグは除く。
+// 登録を拒否する禁止語を含む場合はProhibitedWordErrorを返す。モデレーターの確認待ちにする禁止語を含む場合は非表示で登録する。
+// allowDuplicateがfalseの場合、同じ発言者による類似した投稿があればDuplicatePostErrorを返す。
+func (usecase *postUseCase) CreatePost(userID int, title, speaker, detail, movieURL string, source model.PostSource, license, language string, tags []string, allowDuplicate bool) (err error) {
+	if language == "" {
+		language = model.DefaultLanguage
+	}
+	tags = normalizeTags(tags)
+	reviewWords, err := applyWordFilter(usecase.ProhibitedWordRepository, append([]*filteredField{
+		{"Title", &title},
+		{"Speaker", &speaker},
+		{"Detail", &detail},
+	}, tagFields(tags)...)...)
+	if err != nil {
+		return err
+	}
 	post := model.Post{
-		UserID:   userID,
-		Title:    title,
-		Speaker:  speaker,
-		Detail:   detail,
-		MovieURL: movieURL,
+		UserID:            userID,
+		Title:             title,
+		Speaker:           speaker,
+		Detail:            detail,
+		MovieURL:          movieURL,
+		License:           license,
+		Language:          canonicalLanguage(language),
+		PostSource:        source,
+		Tags:              tags,
+		NormalizedTitle:   normalizeText(title),
+		NormalizedSpeaker: normalizeText(speaker),
+		IsHidden:          len(reviewWords) > 0,
 	}
-	err = usecase.PostRepository.Create(&post)
 
-	return err
+	if !allowDuplicate {
+		posts, err := usecase.PostRepository.FetchBySpeaker(post.NormalizedSpeaker, speaker)
+		if err != nil {
+			return err
+		}
+		if candidates := findDuplicatePosts(title, posts); len(candidates) > 0 {
+			return &DuplicatePostError{Candidates: candidates}
+		}
+	}
+	if err = usecase.PostRepository.Create(&post, postCreatedEvents(&post)); err != nil {
+		return err
+	}
+	wakeDomainEventDispatcher()
+	usecase.autocompleteIndexes.invalidate()
+
+	if post.IsHidden {
+		return holdForReview(usecase.ReportRepository, model.ReportTargetPost, post.ID, reviewWords)
+	}
+	return nil
+}
+
+// postCreatedEvents 投稿登録のドメインイベント。確認待ちの投稿は公開されていないため、発行しない。
+func postCreatedEvents(posts ...*model.Post) []*model.DomainEvent {
+	var events []*model.DomainEvent
+	for _, post := range posts {
+		if !post.IsHidden {
+			events = append(events, model.NewDomainEvent(model.DomainEventPostCreated, post))
+		}
+	}
+	return events
+}
+
+// normalizeTags タグの前後の空白を除き、空のタグと重複したタグを除く。
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// tagFields タグを禁止語のチェック対象にする。
+func tagFields(tags []string) []*filteredField {
+	fields := make([]*filteredField, 0, len(tags))
+	for i := range tags {
+		fields = append(fields, &filteredField{"Tags", &tags[i]})
+	}
+	return fields
 }
 
 // GetPosts 一覧取得。
 // キーワード検索を行わない場合はkeywordに空文字を指定する。
 // 投稿ユーザーを限定しない場合はpostUserIDに0を指定する。
 // ログインユーザーを限定しない場合はloginUserIDに0を指定する。
-func (usecase *postUseCase) GetPosts(limit, page int, keyword string, postUserID, loginUserID int) (totalCount int, posts []*model.GetPostResult, err error) {
-	totalCount, posts, err = usecase.PostRepository.Fetch(limit, page, keyword, postUserID, loginUserID)
+// verifiedOnlyがtrueの場合は出典が検証済みの投稿のみ取得する。
+// 言語を限定しない場合はlanguageに空文字を指定する。指定した場合は地域などのサブタグを除いた言語(例：en-USの場合はen)で絞り込む。
+// preferredLanguagesを指定した場合は、希望言語に最も合う翻訳を設定する。
+// リアクションの種類ごとの件数と、ログインユーザーがリアクションしている種類を設定する。
+func (usecase *postUseCase) GetPosts(limit, page int, keyword string, postUserID, loginUserID int, verifiedOnly bool, language string, preferredLanguages []string) (totalCount int, posts []*model.GetPostResult, err error) {
+	if language != "" {
+		language = baseLanguage(language)
+	}
+	totalCount, posts, err = usecase.PostRepository.Fetch(limit, page, keyword, postUserID, loginUserID, verifiedOnly, language)
 	if err != nil {
 		return 0, nil, err
 	}
+	if err = applyTranslations(usecase.TranslationRepository, posts, preferredLanguages); err != nil {
+		return 0, nil, err
+	}
+	if err = applyReactions(usecase.ReactionRepository, posts, loginUserID); err != nil {
+		return 0, nil, err
+	}
 
-	// 動画URL加工
+	// 動画URL加工、引用表記生成
 	for _, post := range posts {
 		post.EmbedMovieURL = makeEmbedMovieURL(post.MovieURL)
+		post.Citation = makeCitation(post.Speaker, &post.PostSource)
 	}
 
 	return totalCount, posts, nil
@@ -104,72 +220,189 @@ func makeEmbedMovieURL(movieURL string) string {
 	return ""
 }
 
-// GetPost 投稿詳細取得
-func (usecase *postUseCase) GetPost(id, loginUserID int) (*model.GetPostResult, error) {
+// makeCitation 出典情報から表示用の引用表記を生成する。
+// 例：松下幸之助『道をひらく』(1968年) p.12
+func makeCitation(speaker string, source *model.PostSource) string {
+	if source.SourceType == "" {
+		return ""
+	}
+
+	parts := []string{}
+
+	work := ""
+	if source.SourceTitle != "" {
+		if source.SourceType == model.SourceTypeBook {
+			work = "『" + source.SourceTitle + "』"
+		} else {
+			work = "「" + source.SourceTitle + "」"
+		}
+	}
+	switch source.SourceType {
+	case model.SourceTypeSpeech:
+		work += "スピーチ"
+	case model.SourceTypeInterview:
+		work += "インタビュー"
+	}
+	parts = append(parts, speaker+work)
+
+	if source.PublicationYear > 0 {
+		parts = append(parts, fmt.Sprintf("(%d年)", source.PublicationYear))
+	}
+	if source.SourceLocator != "" {
+		if source.SourceType == model.SourceTypeBook && !strings.HasPrefix(source.SourceLocator, "p") {
+			parts = append(parts, "p."+source.SourceLocator)
+		} else {
+			parts = append(parts, source.SourceLocator)
+		}
+	}
+	if source.SourceURL != "" {
+		parts = append(parts, source.SourceURL)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// GetPost 投稿詳細取得。
+// preferredLanguagesを指定した場合は、希望言語に最も合う翻訳を設定する。
+// リアクションの種類ごとの件数と、ログインユーザーがリアクションしている種類を設定する。
+func (usecase *postUseCase) GetPost(id, loginUserID int, preferredLanguages []string) (*model.GetPostResult, error) {
 	post, err := usecase.PostRepository.FetchByID(id, loginUserID)
 	if err != nil {
 		return nil, err
 	}
+	if err = applyTranslations(usecase.TranslationRepository, []*model.GetPostResult{post}, preferredLanguages); err != nil {
+		return nil, err
+	}
+	if err = applyReactions(usecase.ReactionRepository, []*model.GetPostResult{post}, loginUserID); err != nil {
+		return nil, err
+	}
 
-	// 動画URL加工
+	// 動画URL加工、引用表記生成
 	post.EmbedMovieURL = makeEmbedMovieURL(post.MovieURL)
+	post.Citation = makeCitation(post.Speaker, &post.PostSource)
+
+	// 出典の証拠・異議の履歴
+	post.AttributionClaims, err = usecase.AttributionRepository.FetchAttributionClaims(id)
+	if err != nil {
+		return nil, err
+	}
 
 	return post, nil
 }
 
-// UpdatePost 投稿更新
-func (usecase *postUseCase) UpdatePost(ID int, title, speaker, detail, movieURL string) error {
+// UpdatePost 投稿更新。言語が空文字の場合は変更しない。タグは指定したタグで置き換える。
+// 禁止語の扱いは投稿登録と同じ。モデレーターの確認待ちにする禁止語を含む場合は、更新内容が公開されないよう同じ更新で非表示にする。
+func (usecase *postUseCase) UpdatePost(ID int, title, speaker, detail, movieURL string, source model.PostSource, license, language string, tags []string) error {
+	if language != "" {
+		language = canonicalLanguage(language)
+	}
+	tags = normalizeTags(tags)
+	reviewWords, err := applyWordFilter(usecase.ProhibitedWordRepository, append([]*filteredField{
+		{"Title", &title},
+		{"Speaker", &speaker},
+		{"Detail", &detail},
+	}, tagFields(tags)...)...)
+	if err != nil {
+		return err
+	}
 	post := model.Post{
-		ID:       ID,
-		Title:    title,
-		Speaker:  speaker,
-		Detail:   detail,
-		MovieURL: movieURL,
+		ID:                ID,
+		Title:             title,
+		Speaker:           speaker,
+		Detail:            detail,
+		MovieURL:          movieURL,
+		License:           license,
+		Language:          language,
+		PostSource:        source,
+		Tags:              tags,
+		NormalizedTitle:   normalizeText(title),
+		NormalizedSpeaker: normalizeText(speaker),
+		IsHidden:          len(reviewWords) > 0,
 	}
-	if err := usecase.PostRepository.Update(&post); err != nil {
+	if err := usecase.PostRepository.Update(&post, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventPostUpdated, &model.DomainEventTarget{ID: ID})}); err != nil {
 		return err
 	}
+	wakeDomainEventDispatcher()
+	if len(reviewWords) > 0 {
+		if err := holdForReview(usecase.ReportRepository, model.ReportTargetPost, ID, reviewWords); err != nil {
+			return err
+		}
+	}
+	usecase.autocompleteIndexes.invalidate()
+	quoteCards.invalidate(ID)
 	return nil
 }
 
 // DeletePost 投稿削除
 func (usecase *postUseCase) DeletePost(id int) error {
-	if err := usecase.PostRepository.Delete(id); err != nil {
+	if err := usecase.PostRepository.Delete(id, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventPostDeleted, &model.DomainEventTarget{ID: id})}); err != nil {
 		return err
 	}
+	wakeDomainEventDispatcher()
+	usecase.autocompleteIndexes.invalidate()
+	quoteCards.invalidate(id)
 	return nil
 }
 
-// CreateFavorite お気に入り登録
-func (usecase *postUseCase) CreateFavorite(userID, postID int) (err error) {
+// CreateFavorite お気に入り登録。メモ、理由の分類は空文字でもよい。
+// 投稿した本人への通知、お気に入り数の配信は、ドメインイベントのサブスクライバーが行う。
+func (usecase *postUseCase) CreateFavorite(userID, postID int, note, tag string) (err error) {
 	favorite := model.Favorite{
 		UserID: userID,
 		PostID: postID,
+		Note:   note,
+		Tag:    tag,
 	}
-	err = usecase.PostRepository.CreateFavorite(&favorite)
-
-	return err
+	if err = usecase.PostRepository.CreateFavorite(&favorite, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventFavoriteCreated, (*model.FavoriteEventPayload)(&favorite))}); err != nil {
+		return err
+	}
+	wakeDomainEventDispatcher()
+	return nil
 }
 
-// GetFavorites お気に入り一覧取得
-func (usecase *postUseCase) GetFavorites(userID, limit, page int) (totalCount int, posts []*model.GetPostResult, err error) {
-	totalCount, posts, err = usecase.PostRepository.FetchFavorites(userID, limit, page)
+// GetFavorites お気に入り一覧取得。
+// キーワード検索を行わない場合はkeywordに、理由の分類で絞り込まない場合はtagに空文字を指定する。
+// メモ、理由の分類は本人(userIDとloginUserIDが一致する場合)のみ取得、検索できる。
+func (usecase *postUseCase) GetFavorites(userID, loginUserID, limit, page int, keyword, tag string) (totalCount int, posts []*model.GetPostResult, err error) {
+	includeNote := userID == loginUserID
+	if !includeNote {
+		tag = ""
+	}
+	totalCount, posts, err = usecase.PostRepository.FetchFavorites(userID, limit, page, keyword, tag, includeNote)
 	if err != nil {
 		return 0, nil, err
 	}
 
-	// 動画URL加工
+	// 動画URL加工、引用表記生成
 	for _, post := range posts {
 		post.EmbedMovieURL = makeEmbedMovieURL(post.MovieURL)
+		post.Citation = makeCitation(post.Speaker, &post.PostSource)
 	}
 
 	return totalCount, posts, nil
 }
 
+// UpdateFavoriteNote お気に入りのメモ、理由の分類の更新。空文字を指定した場合は削除する。
+func (usecase *postUseCase) UpdateFavoriteNote(userID, postID int, note, tag string) error {
+	favorite, err := usecase.PostRepository.FetchFavorite(userID, postID)
+	if err != nil {
+		return err
+	}
+	if favorite == nil {
+		return ErrFavoriteNotFound
+	}
+
+	favorite.Note = note
+	favorite.Tag = tag
+	return usecase.PostRepository.UpdateFavoriteNote(favorite)
+}
+
 // DeleteFavorite お気に入り削除
 func (usecase *postUseCase) DeleteFavorite(userID, postID int) error {
-	if err := usecase.PostRepository.DeleteFavorite(userID, postID); err != nil {
+	favorite := &model.Favorite{UserID: userID, PostID: postID}
+	if err := usecase.PostRepository.DeleteFavorite(userID, postID, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventFavoriteDeleted, (*model.FavoriteEventPayload)(favorite))}); err != nil {
 		return err
 	}
+	wakeDomainEventDispatcher()
 	return nil
 }
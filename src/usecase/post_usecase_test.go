@@ -14,16 +14,31 @@ import (
 // Mock
 type mockPostRepository struct {
 	mock.Mock
+	// 送信箱に保存されたドメインイベント
+	domainEvents []*model.DomainEvent
 }
 
 // 投稿登録
-func (repository *mockPostRepository) Create(post *model.Post) error {
-	return repository.Called(post).Error(0)
+func (repository *mockPostRepository) Create(post *model.Post, events []*model.DomainEvent) error {
+	args := repository.Called(post)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
+}
+
+// 投稿の一括登録
+func (repository *mockPostRepository) CreatePosts(posts []*model.Post, events []*model.DomainEvent) error {
+	args := repository.Called(posts)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
 }
 
 // 投稿一覧取得
-func (repository *mockPostRepository) Fetch(limit, page int, keyword string, postUserID, loginUserID int) (int, []*model.GetPostResult, error) {
-	args := repository.Called(limit, page, keyword, postUserID, loginUserID)
+func (repository *mockPostRepository) Fetch(limit, page int, keyword string, postUserID, loginUserID int, verifiedOnly bool, language string) (int, []*model.GetPostResult, error) {
+	args := repository.Called(limit, page, keyword, postUserID, loginUserID, verifiedOnly, language)
 	posts, ok := args.Get(1).([]*model.GetPostResult)
 	if ok {
 		return args.Int(0), posts, args.Error(2)
@@ -44,18 +59,203 @@ func (repository *mockPostRepository) FetchByID(id, loginUserID int) (*model.Get
 }
 
 // 投稿更新
-func (repository *mockPostRepository) Update(post *model.Post) error {
-	return repository.Called(post).Error(0)
+func (repository *mockPostRepository) Update(post *model.Post, events []*model.DomainEvent) error {
+	args := repository.Called(post)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
 }
 
 // 投稿削除
-func (repository *mockPostRepository) Delete(id int) error {
+func (repository *mockPostRepository) Delete(id int, events []*model.DomainEvent) error {
+	args := repository.Called(id)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
+}
+
+// 発言者が一致する投稿一覧取得
+func (repository *mockPostRepository) FetchBySpeaker(normalizedSpeaker, speaker string) ([]*model.Post, error) {
+	args := repository.Called(normalizedSpeaker, speaker)
+	posts, ok := args.Get(0).([]*model.Post)
+	if ok {
+		return posts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 全投稿のタイトル、発言者取得
+func (repository *mockPostRepository) FetchAllTitles() ([]*model.Post, error) {
+	args := repository.Called()
+	posts, ok := args.Get(0).([]*model.Post)
+	if ok {
+		return posts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 項目の値ごとの使用回数取得
+func (repository *mockPostRepository) FetchFieldCounts(field string) ([]*model.AutocompleteCandidate, error) {
+	args := repository.Called(field)
+	candidates, ok := args.Get(0).([]*model.AutocompleteCandidate)
+	if ok {
+		return candidates, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// ランダム取得用の投稿IDの最小値、最大値取得
+func (repository *mockPostRepository) FetchRandomCandidateIDRange(condition *model.RandomPostCondition) (minID, maxID int, err error) {
+	args := repository.Called(condition)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+// ランダム取得用の投稿ID取得
+func (repository *mockPostRepository) FetchRandomCandidateID(condition *model.RandomPostCondition, fromID int, excludeIDs []int) (int, error) {
+	args := repository.Called(condition, fromID, excludeIDs)
+	return args.Int(0), args.Error(1)
+}
+
+// 投稿ID指定の一覧取得
+func (repository *mockPostRepository) FetchByIDs(ids []int, loginUserID int) ([]*model.GetPostResult, error) {
+	args := repository.Called(ids, loginUserID)
+	posts, ok := args.Get(0).([]*model.GetPostResult)
+	if ok {
+		return posts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// ユーザーの全投稿取得
+func (repository *mockPostRepository) FetchPostsByUserID(userID int) ([]*model.Post, error) {
+	args := repository.Called(userID)
+	posts, ok := args.Get(0).([]*model.Post)
+	if ok {
+		return posts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 翻訳の登録または更新
+func (repository *mockPostRepository) SaveTranslation(translation *model.PostTranslation) error {
+	return repository.Called(translation).Error(0)
+}
+
+// 翻訳一覧取得
+func (repository *mockPostRepository) FetchTranslations(postIDs []int) ([]*model.GetPostTranslationResult, error) {
+	args := repository.Called(postIDs)
+	translations, ok := args.Get(0).([]*model.GetPostTranslationResult)
+	if ok {
+		return translations, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 翻訳削除
+func (repository *mockPostRepository) DeleteTranslation(postID int, language string) error {
+	return repository.Called(postID, language).Error(0)
+}
+
+// まとめ登録
+func (repository *mockPostRepository) CreateCollection(collection *model.Collection) error {
+	return repository.Called(collection).Error(0)
+}
+
+// ユーザーのまとめ一覧取得
+func (repository *mockPostRepository) FetchCollections(userID int, includePrivate bool, limit, page int) (int, []*model.GetCollectionResult, error) {
+	args := repository.Called(userID, includePrivate, limit, page)
+	collections, ok := args.Get(1).([]*model.GetCollectionResult)
+	if ok {
+		return args.Int(0), collections, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// まとめ1件取得
+func (repository *mockPostRepository) FetchCollectionByID(id int) (*model.GetCollectionResult, error) {
+	args := repository.Called(id)
+	collection, ok := args.Get(0).(*model.GetCollectionResult)
+	if ok {
+		return collection, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 投稿を含むまとめ一覧取得
+func (repository *mockPostRepository) FetchCollectionsByPostID(postID, loginUserID int) ([]*model.GetCollectionResult, error) {
+	args := repository.Called(postID, loginUserID)
+	collections, ok := args.Get(0).([]*model.GetCollectionResult)
+	if ok {
+		return collections, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// まとめ更新
+func (repository *mockPostRepository) UpdateCollection(collection *model.Collection) error {
+	return repository.Called(collection).Error(0)
+}
+
+// まとめ削除
+func (repository *mockPostRepository) DeleteCollection(id int) error {
 	return repository.Called(id).Error(0)
 }
 
+// まとめの投稿一覧取得
+func (repository *mockPostRepository) FetchCollectionPosts(collectionID, loginUserID, limit, page int) (int, []*model.GetPostResult, error) {
+	args := repository.Called(collectionID, loginUserID, limit, page)
+	posts, ok := args.Get(1).([]*model.GetPostResult)
+	if ok {
+		return args.Int(0), posts, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// まとめの投稿ID一覧取得
+func (repository *mockPostRepository) FetchCollectionPostIDs(collectionID int) ([]int, error) {
+	args := repository.Called(collectionID)
+	postIDs, ok := args.Get(0).([]int)
+	if ok {
+		return postIDs, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// まとめへの投稿追加
+func (repository *mockPostRepository) AddCollectionItem(collectionID, postID int) error {
+	return repository.Called(collectionID, postID).Error(0)
+}
+
+// まとめからの投稿削除
+func (repository *mockPostRepository) DeleteCollectionItem(collectionID, postID int) error {
+	return repository.Called(collectionID, postID).Error(0)
+}
+
+// まとめの投稿の並び替え
+func (repository *mockPostRepository) ReorderCollectionItems(collectionID int, postIDs []int) error {
+	return repository.Called(collectionID, postIDs).Error(0)
+}
+
 // コメント登録
-func (repository *mockPostRepository) CreateComment(comment *model.Comment) error {
-	return repository.Called(comment).Error(0)
+func (repository *mockPostRepository) CreateComment(comment *model.Comment, events []*model.DomainEvent) error {
+	args := repository.Called(comment)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
 }
 
 // コメント一覧取得
@@ -70,18 +270,37 @@ func (repository *mockPostRepository) FetchComments(postID, limit, page int) (in
 }
 
 // コメント削除
-func (repository *mockPostRepository) DeleteComment(id int) error {
-	return repository.Called(id).Error(0)
+func (repository *mockPostRepository) DeleteComment(id int, events []*model.DomainEvent) error {
+	args := repository.Called(id)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
+}
+
+// ユーザーの全コメント取得
+func (repository *mockPostRepository) FetchCommentsByUserID(userID int) ([]*model.Comment, error) {
+	args := repository.Called(userID)
+	comments, ok := args.Get(0).([]*model.Comment)
+	if ok {
+		return comments, args.Error(1)
+	}
+
+	return nil, args.Error(1)
 }
 
 // お気に入り登録
-func (repository *mockPostRepository) CreateFavorite(favorite *model.Favorite) error {
-	return repository.Called(favorite).Error(0)
+func (repository *mockPostRepository) CreateFavorite(favorite *model.Favorite, events []*model.DomainEvent) error {
+	args := repository.Called(favorite)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
 }
 
 // お気に入り一覧取得
-func (repository *mockPostRepository) FetchFavorites(userID, limit, page int) (int, []*model.GetPostResult, error) {
-	args := repository.Called(userID, limit, page)
+func (repository *mockPostRepository) FetchFavorites(userID, limit, page int, keyword, tag string, includeNote bool) (int, []*model.GetPostResult, error) {
+	args := repository.Called(userID, limit, page, keyword, tag, includeNote)
 	comments, ok := args.Get(1).([]*model.GetPostResult)
 	if ok {
 		return args.Int(0), comments, args.Error(2)
@@ -90,9 +309,201 @@ func (repository *mockPostRepository) FetchFavorites(userID, limit, page int) (i
 	return args.Int(0), nil, args.Error(2)
 }
 
+// お気に入り1件取得
+func (repository *mockPostRepository) FetchFavorite(userID, postID int) (*model.Favorite, error) {
+	args := repository.Called(userID, postID)
+	favorite, ok := args.Get(0).(*model.Favorite)
+	if ok {
+		return favorite, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// お気に入りのメモ、理由の分類の更新
+func (repository *mockPostRepository) UpdateFavoriteNote(favorite *model.Favorite) error {
+	return repository.Called(favorite).Error(0)
+}
+
 // お気に入り削除
-func (repository *mockPostRepository) DeleteFavorite(userID, postID int) error {
-	return repository.Called(userID, postID).Error(0)
+func (repository *mockPostRepository) DeleteFavorite(userID, postID int, events []*model.DomainEvent) error {
+	args := repository.Called(userID, postID)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
+}
+
+// ユーザーの全お気に入り取得
+func (repository *mockPostRepository) FetchFavoritesByUserID(userID int) ([]*model.Favorite, error) {
+	args := repository.Called(userID)
+	favorites, ok := args.Get(0).([]*model.Favorite)
+	if ok {
+		return favorites, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 今日の言葉取得
+func (repository *mockPostRepository) FetchDailyPost(date string) (*model.DailyPost, error) {
+	args := repository.Called(date)
+	dailyPost, ok := args.Get(0).(*model.DailyPost)
+	if ok {
+		return dailyPost, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 今日の言葉の候補一覧取得
+func (repository *mockPostRepository) FetchDailyPostCandidates(since string) ([]*model.DailyPostCandidate, error) {
+	args := repository.Called(since)
+	candidates, ok := args.Get(0).([]*model.DailyPostCandidate)
+	if ok {
+		return candidates, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 今日の言葉登録
+func (repository *mockPostRepository) CreateDailyPost(dailyPost *model.DailyPost) error {
+	return repository.Called(dailyPost).Error(0)
+}
+
+// 今日の言葉の登録または更新
+func (repository *mockPostRepository) SaveDailyPost(dailyPost *model.DailyPost) error {
+	return repository.Called(dailyPost).Error(0)
+}
+
+// 出典の証拠・異議登録
+func (repository *mockPostRepository) CreateAttributionClaim(claim *model.AttributionClaim) error {
+	return repository.Called(claim).Error(0)
+}
+
+// 出典の証拠・異議一覧取得
+func (repository *mockPostRepository) FetchAttributionClaims(postID int) ([]*model.GetAttributionClaimResult, error) {
+	args := repository.Called(postID)
+	claims, ok := args.Get(0).([]*model.GetAttributionClaimResult)
+	if ok {
+		return claims, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 出典の証拠・異議1件取得
+func (repository *mockPostRepository) FetchAttributionClaimByID(id int) (*model.AttributionClaim, error) {
+	args := repository.Called(id)
+	claim, ok := args.Get(0).(*model.AttributionClaim)
+	if ok {
+		return claim, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 出典の証拠・異議の審査結果登録
+func (repository *mockPostRepository) RuleAttributionClaim(claim *model.AttributionClaim, verificationStatus string) (bool, error) {
+	args := repository.Called(claim, verificationStatus)
+	return args.Bool(0), args.Error(1)
+}
+
+// モデレーター向け投稿取得
+func (repository *mockPostRepository) FetchPostForModeration(id int) (*model.Post, error) {
+	args := repository.Called(id)
+	post, ok := args.Get(0).(*model.Post)
+	if ok {
+		return post, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// コメント1件取得
+func (repository *mockPostRepository) FetchCommentByID(id int) (*model.Comment, error) {
+	args := repository.Called(id)
+	comment, ok := args.Get(0).(*model.Comment)
+	if ok {
+		return comment, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 削除済み投稿一覧取得
+func (repository *mockPostRepository) FetchDeletedPosts(userID int, since time.Time, limit, page int) (totalCount int, posts []*model.Post, err error) {
+	args := repository.Called(userID, since, limit, page)
+	posts, ok := args.Get(1).([]*model.Post)
+	if ok {
+		return args.Int(0), posts, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// 削除済みコメント一覧取得
+func (repository *mockPostRepository) FetchDeletedComments(userID int, since time.Time, limit, page int) (totalCount int, comments []*model.Comment, err error) {
+	args := repository.Called(userID, since, limit, page)
+	comments, ok := args.Get(1).([]*model.Comment)
+	if ok {
+		return args.Int(0), comments, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// 削除済み投稿1件取得
+func (repository *mockPostRepository) FetchDeletedPostByID(id int) (*model.Post, error) {
+	args := repository.Called(id)
+	post, ok := args.Get(0).(*model.Post)
+	if ok {
+		return post, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 削除済みコメント1件取得
+func (repository *mockPostRepository) FetchDeletedCommentByID(id int) (*model.Comment, error) {
+	args := repository.Called(id)
+	comment, ok := args.Get(0).(*model.Comment)
+	if ok {
+		return comment, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 削除済み投稿の復元
+func (repository *mockPostRepository) RestorePost(id int, events []*model.DomainEvent) error {
+	args := repository.Called(id)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
+}
+
+// 削除済みコメントの復元
+func (repository *mockPostRepository) RestoreComment(id int, events []*model.DomainEvent) error {
+	args := repository.Called(id)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
+}
+
+// 削除済み投稿の完全削除
+func (repository *mockPostRepository) PurgePosts(before time.Time) (int, error) {
+	args := repository.Called(before)
+	return args.Int(0), args.Error(1)
+}
+
+// 削除済みコメントの完全削除
+func (repository *mockPostRepository) PurgeComments(before time.Time) (int, error) {
+	args := repository.Called(before)
+	return args.Int(0), args.Error(1)
 }
 
 // 入力用投稿を生成
@@ -137,16 +548,88 @@ func makeFavorite(userID, postID int) *model.Favorite {
 func TestCreatePost_success(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
 	id := 1
 	post := makePostForInput(id)
+	repository.On("FetchBySpeaker", normalizeText(post.Speaker), post.Speaker).Return([]*model.Post{}, nil)
 	repository.On("Create", mock.AnythingOfType("*model.Post")).Return(nil)
 
 	// 2. Exercise
-	err := usecase.CreatePost(post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL)
+	err := usecase.CreatePost(post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, "", nil, false)
 
 	// 3. Verify
 	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+	prohibitedWordRepository.AssertExpectations(t)
+	assert.Len(t, repository.domainEvents, 1)
+	assert.Equal(t, model.DomainEventPostCreated, repository.domainEvents[0].Type)
+	assert.Equal(t, post.Title, repository.domainEvents[0].Data.(*model.Post).Title)
+
+	// 4. Teardown
+}
+
+func TestCreatePost_success_tags(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
+	post := makePostForInput(1)
+	repository.On("Create", mock.MatchedBy(func(created *model.Post) bool {
+		return assert.ObjectsAreEqual([]string{"励まし", "仕事"}, created.Tags)
+	})).Return(nil)
+
+	// 2. Exercise
+	err := usecase.CreatePost(post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, "", []string{" 励まし ", "", "仕事", "励まし"}, true)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+	prohibitedWordRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestCreatePost_error_duplicate(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
+	existing := &model.Post{ID: 10, UserID: 2, Title: "あきらめたら、そこで試合終了ですよ", Speaker: "安西先生"}
+	repository.On("FetchBySpeaker", "安西先生", "安西 先生").Return([]*model.Post{existing}, nil)
+
+	// 2. Exercise
+	err := usecase.CreatePost(1, "あきらめたらそこで試合終了ですよ…？", "安西 先生", "", "", model.PostSource{}, "", "", nil, false)
+
+	// 3. Verify
+	duplicateErr, ok := err.(*DuplicatePostError)
+	assert.True(t, ok)
+	assert.Equal(t, 1, len(duplicateErr.Candidates))
+	assert.Equal(t, existing.ID, duplicateErr.Candidates[0].PostID)
+	repository.AssertNotCalled(t, "Create", mock.Anything)
+
+	// 4. Teardown
+}
+
+func TestCreatePost_success_allowDuplicate(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
+	repository.On("Create", mock.MatchedBy(func(post *model.Post) bool {
+		return post.NormalizedTitle == "あきらめたらそこでしあいしゅうりょう" && post.NormalizedSpeaker == "あんざいせんせい"
+	})).Return(nil)
+
+	// 2. Exercise
+	err := usecase.CreatePost(1, "アキラメたら、そこでshiai shuuryou", "アンザイ センセイ", "", "", model.PostSource{}, "", "", nil, true)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	repository.AssertNotCalled(t, "FetchBySpeaker", mock.Anything, mock.Anything)
 
 	// 4. Teardown
 }
@@ -154,13 +637,16 @@ func TestCreatePost_success(t *testing.T) {
 func TestCreatePost_error(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
 	id := 1
 	post := makePostForInput(id)
+	repository.On("FetchBySpeaker", normalizeText(post.Speaker), post.Speaker).Return([]*model.Post{}, nil)
 	repository.On("Create", mock.AnythingOfType("*model.Post")).Return(errors.New("error"))
 
 	// 2. Exercise
-	err := usecase.CreatePost(post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL)
+	err := usecase.CreatePost(post.UserID, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, "", nil, false)
 
 	// 3. Verify
 	assert.Error(t, err)
@@ -172,7 +658,8 @@ func TestCreatePost_error(t *testing.T) {
 func TestGetPosts_success(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	reactionRepository := mockReactionRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &reactionRepository, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
 	limit := 3
 	page := 1
 	keyword := ""
@@ -180,10 +667,11 @@ func TestGetPosts_success(t *testing.T) {
 	loginUserID := 0 // TODO ログインユーザーID指定がある場合
 	expectedTotalCount := 2
 	expectedPosts := []*model.GetPostResult{makeGetPostResult(1), makeGetPostResult(2)}
-	repository.On("Fetch", limit, page, keyword, postUserID, loginUserID).Return(expectedTotalCount, expectedPosts, nil)
+	repository.On("Fetch", limit, page, keyword, postUserID, loginUserID, false, "").Return(expectedTotalCount, expectedPosts, nil)
+	reactionRepository.On("FetchReactionCounts", []int{1, 2}, loginUserID).Return([]*model.PostReactionCount{}, nil)
 
 	// 2. Exercise
-	totalCount, posts, err := usecase.GetPosts(limit, page, keyword, postUserID, loginUserID)
+	totalCount, posts, err := usecase.GetPosts(limit, page, keyword, postUserID, loginUserID, false, "", nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -195,19 +683,35 @@ func TestGetPosts_success(t *testing.T) {
 	// 4. Teardown
 }
 
+func TestGetPosts_success_language(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	repository.On("Fetch", 3, 1, "", 0, 0, false, "en").Return(0, []*model.GetPostResult{}, nil)
+
+	// 2. Exercise
+	_, _, err := usecase.GetPosts(3, 1, "", 0, 0, false, "en-us", nil)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
 func TestGetPosts_error(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
 	limit := 3
 	page := 1
 	keyword := ""
 	postUserID := 0  // TODO 投稿ユーザーID指定がある場合
 	loginUserID := 0 // TODO ログインユーザーID指定がある場合
-	repository.On("Fetch", limit, page, keyword, postUserID, loginUserID).Return(0, nil, errors.New("error"))
+	repository.On("Fetch", limit, page, keyword, postUserID, loginUserID, false, "").Return(0, nil, errors.New("error"))
 
 	// 2. Execise
-	totalCount, posts, err := usecase.GetPosts(limit, page, keyword, postUserID, loginUserID)
+	totalCount, posts, err := usecase.GetPosts(limit, page, keyword, postUserID, loginUserID, false, "", nil)
 
 	// 3. Verify
 	assert.Error(t, err)
@@ -252,14 +756,18 @@ func TestMakeEmbedMovieURL(t *testing.T) {
 func TestGetPost_success(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	reactionRepository := mockReactionRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &reactionRepository, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
 	id := 1
 	loginUserID := 1
 	expected := makeGetPostResult(id)
+	expectedClaims := []*model.GetAttributionClaimResult{makeGetAttributionClaimResult(1, id)}
 	repository.On("FetchByID", id, loginUserID).Return(expected, nil)
+	reactionRepository.On("FetchReactionCounts", []int{id}, loginUserID).Return([]*model.PostReactionCount{}, nil)
+	repository.On("FetchAttributionClaims", id).Return(expectedClaims, nil)
 
 	// 2. Exercise
-	post, err := usecase.GetPost(id, loginUserID)
+	post, err := usecase.GetPost(id, loginUserID, nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -273,6 +781,7 @@ func TestGetPost_success(t *testing.T) {
 	assert.Equal(t, expected.Detail, post.Detail)
 	assert.Equal(t, expected.MovieURL, post.MovieURL)
 	assert.Equal(t, expected.UserName, post.UserName)
+	assert.Equal(t, expectedClaims, post.AttributionClaims)
 
 	// 4. Teardown
 }
@@ -280,13 +789,13 @@ func TestGetPost_success(t *testing.T) {
 func TestGetPost_error(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
 	id := 1
 	loginUserID := 1
 	repository.On("FetchByID", id, loginUserID).Return(nil, errors.New("error"))
 
 	// 2. Execise
-	post, err := usecase.GetPost(id, loginUserID)
+	post, err := usecase.GetPost(id, loginUserID, nil)
 
 	// 3. Verify
 	assert.Error(t, err)
@@ -299,13 +808,15 @@ func TestGetPost_error(t *testing.T) {
 func TestUpdatePost_success(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
 	id := 1
 	post := makePostForInput(id)
 	repository.On("Update", mock.AnythingOfType("*model.Post")).Return(nil)
 
 	// 2. Exercise
-	err := usecase.UpdatePost(id, post.Title, post.Speaker, post.Detail, post.MovieURL)
+	err := usecase.UpdatePost(id, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, "", nil)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -315,13 +826,15 @@ func TestUpdatePost_success(t *testing.T) {
 
 func TestUpdatePost_error(t *testing.T) {
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
 	id := 1
 	post := makePostForInput(id)
 	repository.On("Update", mock.AnythingOfType("*model.Post")).Return(errors.New("error"))
 
 	// 2. Exercise
-	err := usecase.UpdatePost(id, post.Title, post.Speaker, post.Detail, post.MovieURL)
+	err := usecase.UpdatePost(id, post.Title, post.Speaker, post.Detail, post.MovieURL, post.PostSource, post.License, "", nil)
 
 	// 3. Verify
 	assert.Error(t, err)
@@ -333,7 +846,7 @@ func TestUpdatePost_error(t *testing.T) {
 func TestDeletePost_success(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
 	id := 1
 	repository.On("Delete", id).Return(nil)
 
@@ -342,13 +855,14 @@ func TestDeletePost_success(t *testing.T) {
 
 	// 3. Verify
 	assert.NoError(t, err)
+	assert.Equal(t, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventPostDeleted, &model.DomainEventTarget{ID: id})}, repository.domainEvents)
 
 	// 4. Teardown
 }
 
 func TestDeletePost_error(t *testing.T) {
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
 	id := 1
 	repository.On("Delete", id).Return(errors.New("error"))
 
@@ -365,17 +879,20 @@ func TestDeletePost_error(t *testing.T) {
 func TestCreateFavorite_success(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
 	userID := 1
 	postID := 1
 	favorite := makeFavorite(userID, postID)
 	repository.On("CreateFavorite", mock.AnythingOfType("*model.Favorite")).Return(nil)
 
 	// 2. Exercise
-	err := usecase.CreateFavorite(favorite.UserID, favorite.PostID)
+	err := usecase.CreateFavorite(favorite.UserID, favorite.PostID, "", "")
 
 	// 3. Verify
 	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+	assert.Len(t, repository.domainEvents, 1)
+	assert.Equal(t, model.DomainEventFavoriteCreated, repository.domainEvents[0].Type)
 
 	// 4. Teardown
 }
@@ -384,14 +901,14 @@ func TestCreateFavorite_success(t *testing.T) {
 func TestCreateFavorite_error(t *testing.T) {
 	// 1. Setup
 	repository := mockPostRepository{}
-	usecase := NewPostUseCase(&repository)
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
 	userID := 1
 	postID := 1
 	favorite := makeFavorite(userID, postID)
 	repository.On("CreateFavorite", mock.AnythingOfType("*model.Favorite")).Return(errors.New("error"))
 
 	// 2. Exercise
-	err := usecase.CreateFavorite(favorite.UserID, favorite.PostID)
+	err := usecase.CreateFavorite(favorite.UserID, favorite.PostID, "", "")
 
 	// 3. Verify
 	assert.Error(t, err)
@@ -402,3 +919,96 @@ func TestCreateFavorite_error(t *testing.T) {
 // TODO お気に入り一覧取得
 
 // TODO お気に入り削除
+
+// 引用表記生成テスト
+func TestMakeCitation(t *testing.T) {
+	cases := []struct {
+		label    string
+		speaker  string
+		source   model.PostSource
+		expected string
+	}{
+		{"出典なし", "speaker1", model.PostSource{}, ""},
+		{"書籍", "松下幸之助", model.PostSource{SourceType: model.SourceTypeBook, SourceTitle: "道をひらく", SourceLocator: "12", PublicationYear: 1968}, "松下幸之助『道をひらく』 (1968年) p.12"},
+		{"スピーチ", "スティーブ・ジョブズ", model.PostSource{SourceType: model.SourceTypeSpeech, SourceTitle: "スタンフォード大学卒業式", PublicationYear: 2005}, "スティーブ・ジョブズ「スタンフォード大学卒業式」スピーチ (2005年)"},
+		{"動画", "speaker1", model.PostSource{SourceType: model.SourceTypeVideo, SourceLocator: "1:23", SourceURL: "https://youtu.be/a"}, "speaker1 1:23 https://youtu.be/a"},
+	}
+
+	for _, test := range cases {
+		assert.Equal(t, test.expected, makeCitation(test.speaker, &test.source), test.label)
+	}
+}
+
+// お気に入り一覧テスト
+func TestGetFavorites_note(t *testing.T) {
+	cases := []struct {
+		label       string
+		loginUserID int
+		tag         string
+		includeNote bool
+	}{
+		{"本人", 1, "励まし", true},
+		{"本人以外", 2, "", false},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		repository := mockPostRepository{}
+		usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+		repository.On("FetchFavorites", 1, 10, 1, "keyword", test.tag, test.includeNote).Return(1, []*model.GetPostResult{makeGetPostResult(1)}, nil)
+
+		// 2. Exercise
+		totalCount, posts, err := usecase.GetFavorites(1, test.loginUserID, 10, 1, "keyword", "励まし")
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		assert.Equal(t, 1, totalCount, test.label)
+		assert.Equal(t, 1, len(posts), test.label)
+		repository.AssertExpectations(t)
+
+		// 4. Teardown
+	}
+}
+
+// お気に入りのメモ更新テスト
+func TestUpdateFavoriteNote_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	favorite := makeFavorite(1, 2)
+	favorite.ID = 3
+	repository.On("FetchFavorite", 1, 2).Return(favorite, nil)
+	repository.On("UpdateFavoriteNote", mock.MatchedBy(func(favorite *model.Favorite) bool {
+		return favorite.ID == 3 && favorite.Note == "試合前に読む" && favorite.Tag == "励まし"
+	})).Return(nil)
+
+	// 2. Exercise
+	err := usecase.UpdateFavoriteNote(1, 2, "試合前に読む", "励まし")
+
+	// 3. Verify
+	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestUpdateFavoriteNote_error_notFound(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	repository.On("FetchFavorite", 1, 2).Return(nil, nil)
+
+	// 2. Exercise
+	err := usecase.UpdateFavoriteNote(1, 2, "note", "")
+
+	// 3. Verify
+	assert.Equal(t, ErrFavoriteNotFound, err)
+	repository.AssertNotCalled(t, "UpdateFavoriteNote", mock.Anything)
+
+	// 4. Teardown
+}
+
+func (repository *mockPostRepository) CountFavorites(postID int) (int, error) {
+	args := repository.Called(postID)
+	return args.Int(0), args.Error(1)
+}
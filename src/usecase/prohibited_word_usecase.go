@@ -0,0 +1,85 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+var (
+	// ErrInvalidProhibitedWord 正規化すると空文字になる(空白・記号のみの)禁止語を登録しようとした場合のエラー
+	ErrInvalidProhibitedWord = errors.New("Word：文字を含む語句を入力してください。")
+	// ErrProhibitedWordAlreadyExists 正規化すると同じになる禁止語が登録済みの場合のエラー
+	ErrProhibitedWordAlreadyExists = errors.New("既に登録されています。")
+)
+
+// ProhibitedWordUseCase インターフェース
+type ProhibitedWordUseCase interface {
+	// 禁止語一覧取得
+	GetProhibitedWords() ([]*model.ProhibitedWord, error)
+	// 禁止語登録
+	CreateProhibitedWord(word, action string) (*model.ProhibitedWord, error)
+	// 禁止語削除
+	DeleteProhibitedWord(id int) error
+}
+
+// prohibitedWordUseCase 構造体
+type prohibitedWordUseCase struct {
+	repository.ProhibitedWordRepository
+}
+
+// NewProhibitedWordUseCase ProhibitedWordUseCaseを生成。
+func NewProhibitedWordUseCase(prohibitedWordRepository repository.ProhibitedWordRepository) ProhibitedWordUseCase {
+	return &prohibitedWordUseCase{prohibitedWordRepository}
+}
+
+// GetProhibitedWords 禁止語一覧取得
+func (usecase *prohibitedWordUseCase) GetProhibitedWords() ([]*model.ProhibitedWord, error) {
+	words, err := usecase.ProhibitedWordRepository.FetchProhibitedWords()
+	if err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// CreateProhibitedWord 禁止語登録。
+// 全角半角、カタカナとひらがな、空白・記号の有無の違いは同じ語句として扱う。
+func (usecase *prohibitedWordUseCase) CreateProhibitedWord(word, action string) (*model.ProhibitedWord, error) {
+	normalizedWord := normalizeProhibitedWord(word)
+	if normalizedWord == "" {
+		return nil, ErrInvalidProhibitedWord
+	}
+
+	words, err := usecase.ProhibitedWordRepository.FetchProhibitedWords()
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range words {
+		if w.NormalizedWord == normalizedWord {
+			return nil, ErrProhibitedWordAlreadyExists
+		}
+	}
+
+	prohibitedWord := &model.ProhibitedWord{
+		Word:           word,
+		NormalizedWord: normalizedWord,
+		Action:         action,
+	}
+	if err := usecase.ProhibitedWordRepository.CreateProhibitedWord(prohibitedWord); err != nil {
+		return nil, err
+	}
+	wordFilters.invalidate()
+
+	return prohibitedWord, nil
+}
+
+// DeleteProhibitedWord 禁止語削除
+func (usecase *prohibitedWordUseCase) DeleteProhibitedWord(id int) error {
+	if err := usecase.ProhibitedWordRepository.DeleteProhibitedWord(id); err != nil {
+		return err
+	}
+	wordFilters.invalidate()
+	return nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockProhibitedWordRepository struct {
+	mock.Mock
+}
+
+// 禁止語一覧取得
+func (repository *mockProhibitedWordRepository) FetchProhibitedWords() ([]*model.ProhibitedWord, error) {
+	args := repository.Called()
+	words, ok := args.Get(0).([]*model.ProhibitedWord)
+	if ok {
+		return words, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 禁止語登録
+func (repository *mockProhibitedWordRepository) CreateProhibitedWord(word *model.ProhibitedWord) error {
+	return repository.Called(word).Error(0)
+}
+
+// 禁止語削除
+func (repository *mockProhibitedWordRepository) DeleteProhibitedWord(id int) error {
+	return repository.Called(id).Error(0)
+}
+
+// 禁止語登録テスト
+func TestCreateProhibitedWord_success(t *testing.T) {
+	// 1. Setup
+	repository := mockProhibitedWordRepository{}
+	usecase := NewProhibitedWordUseCase(&repository)
+	repository.On("FetchProhibitedWords").Return([]*model.ProhibitedWord{{ID: 1, Word: "禁止", NormalizedWord: "禁止"}}, nil)
+	repository.On("CreateProhibitedWord", mock.MatchedBy(func(word *model.ProhibitedWord) bool {
+		return word.Word == "バ カ" && word.NormalizedWord == "ばか" && word.Action == model.ProhibitedWordActionMask
+	})).Return(nil)
+	wordFilters.filter = newWordFilter(nil)
+
+	// 2. Exercise
+	word, err := usecase.CreateProhibitedWord("バ カ", model.ProhibitedWordActionMask)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, "ばか", word.NormalizedWord)
+	assert.Nil(t, wordFilters.filter)
+
+	// 4. Teardown
+}
+
+func TestCreateProhibitedWord_error(t *testing.T) {
+	// 1. Setup
+	cases := []struct {
+		label string
+		word  string
+		err   error
+	}{
+		{"記号のみ", "！？", ErrInvalidProhibitedWord},
+		{"登録済み", "ｷﾝｼ", ErrProhibitedWordAlreadyExists},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			repository := mockProhibitedWordRepository{}
+			usecase := NewProhibitedWordUseCase(&repository)
+			repository.On("FetchProhibitedWords").Return([]*model.ProhibitedWord{{ID: 1, Word: "キンシ", NormalizedWord: "きんし"}}, nil)
+
+			// 2. Exercise
+			word, err := usecase.CreateProhibitedWord(c.word, model.ProhibitedWordActionBlock)
+
+			// 3. Verify
+			assert.Nil(t, word)
+			assert.Equal(t, c.err, err)
+			repository.AssertNotCalled(t, "CreateProhibitedWord", mock.Anything)
+		})
+	}
+
+	// 4. Teardown
+}
@@ -0,0 +1,377 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// 引用カードのテーマ
+const (
+	QuoteCardThemeLight = "light"
+	QuoteCardThemeDark  = "dark"
+	QuoteCardThemeSepia = "sepia"
+)
+
+// 引用カードのサイズ
+const (
+	QuoteCardSizeOGP    = "ogp"
+	QuoteCardSizeSquare = "square"
+	QuoteCardSizeStory  = "story"
+)
+
+// quoteCardBrand 引用カードに表示するサイト名
+const quoteCardBrand = siteName
+
+// quoteCardTheme 引用カードの配色
+type quoteCardTheme struct {
+	background color.RGBA
+	text       color.RGBA
+	sub        color.RGBA
+	accent     color.RGBA
+}
+
+// quoteCardThemes テーマごとの配色
+var quoteCardThemes = map[string]*quoteCardTheme{
+	QuoteCardThemeLight: {
+		background: color.RGBA{0xff, 0xff, 0xff, 0xff},
+		text:       color.RGBA{0x33, 0x33, 0x33, 0xff},
+		sub:        color.RGBA{0x75, 0x75, 0x75, 0xff},
+		accent:     color.RGBA{0xff, 0x98, 0x00, 0xff},
+	},
+	QuoteCardThemeDark: {
+		background: color.RGBA{0x21, 0x21, 0x21, 0xff},
+		text:       color.RGBA{0xf5, 0xf5, 0xf5, 0xff},
+		sub:        color.RGBA{0xbd, 0xbd, 0xbd, 0xff},
+		accent:     color.RGBA{0xff, 0xb7, 0x4d, 0xff},
+	},
+	QuoteCardThemeSepia: {
+		background: color.RGBA{0xf4, 0xec, 0xd8, 0xff},
+		text:       color.RGBA{0x5b, 0x46, 0x36, 0xff},
+		sub:        color.RGBA{0x8d, 0x6e, 0x63, 0xff},
+		accent:     color.RGBA{0xa1, 0x88, 0x7f, 0xff},
+	},
+}
+
+// quoteCardSizes サイズごとの画像の大きさ
+var quoteCardSizes = map[string]image.Point{
+	QuoteCardSizeOGP:    {1200, 630},
+	QuoteCardSizeSquare: {1080, 1080},
+	QuoteCardSizeStory:  {1080, 1920},
+}
+
+// 行頭禁則文字
+const lineStartProhibited = "、。，．,.)）]］｝〕〉》」』】〙〗〟’”｠»ゝゞーァィゥェォッャュョヮヵヶぁぃぅぇぉっゃゅょゎゕゖ…‥・：；？！!?:;"
+
+// 行末禁則文字
+const lineEndProhibited = "(（[［｛〔〈《「『【〘〖〝‘“｟«"
+
+// quoteCardRequiredGlyph 日本語を描画できるかの確認に使用する文字
+const quoteCardRequiredGlyph = '言'
+
+// quoteCardEmbeddedFont 埋め込みの日本語フォント。Noto Sans JPのサブセットをgo generateで生成する(fonts/README.md参照)
+const quoteCardEmbeddedFont = "fonts/quote_card_ja.ttf"
+
+//go:generate sh -c "pyftsubset \"$QUOTE_CARD_SOURCE_FONT\" --text-file=fonts/quote_card_chars.txt --output-file=fonts/quote_card_ja.ttf"
+
+// quoteCardFontFiles 埋め込みのフォント
+//
+//go:embed fonts
+var quoteCardFontFiles embed.FS
+
+// quoteCardFonts 描画に使用するフォント。日本語のフォントを優先し、グリフがない文字は埋め込みのGoフォントで描画する。
+var quoteCardFonts struct {
+	once  sync.Once
+	fonts []*sfnt.Font
+	// 日本語のフォントを読み込めなかった理由
+	err error
+}
+
+// loadQuoteCardFonts フォントを読み込む。読み込みは初回のみ行う。
+// 日本語のフォントを読み込めない場合も、Goフォントのみで描画できるよう読み込んだフォントを返す。
+func loadQuoteCardFonts() []*sfnt.Font {
+	quoteCardFonts.once.Do(func() {
+		f, err := loadJapaneseFont()
+		if err != nil {
+			quoteCardFonts.err = err
+		} else {
+			quoteCardFonts.fonts = append(quoteCardFonts.fonts, f)
+		}
+
+		f, err = opentype.Parse(goregular.TTF)
+		if err != nil {
+			panic(err)
+		}
+		quoteCardFonts.fonts = append(quoteCardFonts.fonts, f)
+	})
+
+	return quoteCardFonts.fonts
+}
+
+// loadJapaneseFont 日本語のフォントを読み込む。環境変数QUOTE_CARD_FONT_PATHで指定したフォントを埋め込みのフォントより優先する。
+func loadJapaneseFont() (*sfnt.Font, error) {
+	name := quoteCardEmbeddedFont
+	var data []byte
+	var err error
+	if path := os.Getenv("QUOTE_CARD_FONT_PATH"); path != "" {
+		name = path
+		data, err = ioutil.ReadFile(path)
+	} else {
+		data, err = quoteCardFontFiles.ReadFile(quoteCardEmbeddedFont)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := parseFont(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s：%v", name, err)
+	}
+	index, err := f.GlyphIndex(&sfnt.Buffer{}, quoteCardRequiredGlyph)
+	if err != nil {
+		return nil, fmt.Errorf("%s：%v", name, err)
+	}
+	if index == 0 {
+		return nil, fmt.Errorf("フォントに日本語のグリフがありません：%s", name)
+	}
+	return f, nil
+}
+
+// CheckQuoteCardFont 日本語のフォントを読み込めるか確認する。起動時に呼び出し、エラーの場合は警告をログに出力する。
+// 読み込めない場合も引用カードはGoフォントで描画するため、APIの起動は妨げない。
+func CheckQuoteCardFont() error {
+	loadQuoteCardFonts()
+	return quoteCardFonts.err
+}
+
+// parseFont TrueType、OpenTypeのフォントを読み込む。フォントコレクションの場合は先頭のフォントを使用する。
+func parseFont(data []byte) (*sfnt.Font, error) {
+	if bytes.HasPrefix(data, []byte("ttcf")) {
+		collection, err := opentype.ParseCollection(data)
+		if err != nil {
+			return nil, err
+		}
+		return collection.Font(0)
+	}
+	return opentype.Parse(data)
+}
+
+// fontSet 複数のフォントを組み合わせた書体。文字ごとにグリフを持つ最初のフォントを使用する。
+type fontSet []font.Face
+
+// newFontSet 指定サイズの書体を生成する。
+func newFontSet(fonts []*sfnt.Font, size float64) (fontSet, error) {
+	faces := fontSet{}
+	for _, f := range fonts {
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, err
+		}
+		faces = append(faces, face)
+	}
+	return faces, nil
+}
+
+// faceFor 文字を描画するフォント
+func (faces fontSet) faceFor(r rune) (font.Face, fixed.Int26_6) {
+	for _, face := range faces {
+		if advance, ok := face.GlyphAdvance(r); ok {
+			return face, advance
+		}
+	}
+	advance, _ := faces[len(faces)-1].GlyphAdvance(r)
+	return faces[len(faces)-1], advance
+}
+
+// measure 文字列の幅
+func (faces fontSet) measure(text string) fixed.Int26_6 {
+	width := fixed.Int26_6(0)
+	for _, r := range text {
+		_, advance := faces.faceFor(r)
+		width += advance
+	}
+	return width
+}
+
+// draw 文字列を描画する。yはベースラインの位置。
+func (faces fontSet) draw(dst draw.Image, text string, x, y fixed.Int26_6, c color.Color) {
+	drawer := &font.Drawer{Dst: dst, Src: image.NewUniform(c), Dot: fixed.Point26_6{X: x, Y: y}}
+	for _, r := range text {
+		drawer.Face, _ = faces.faceFor(r)
+		drawer.DrawString(string(r))
+	}
+}
+
+// close 書体を破棄する。
+func (faces fontSet) close() {
+	for _, face := range faces {
+		face.Close()
+	}
+}
+
+// renderQuoteCard 引用カードのPNG画像を生成する。
+func renderQuoteCard(title, speaker, theme, size string) ([]byte, error) {
+	fonts := loadQuoteCardFonts()
+	colors := quoteCardThemes[theme]
+	bounds := quoteCardSizes[size]
+	width, height := bounds.X, bounds.Y
+	padding := width / 12
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colors.background), image.Point{}, draw.Src)
+	// 左端のアクセントライン
+	draw.Draw(img, image.Rect(0, 0, padding/4, height), image.NewUniform(colors.accent), image.Point{}, draw.Src)
+
+	// フッター(発言者、サイト名)
+	footerSize := float64(height) / 22
+	if footerSize > float64(width)/24 {
+		footerSize = float64(width) / 24
+	}
+	footerFaces, err := newFontSet(fonts, footerSize)
+	if err != nil {
+		return nil, err
+	}
+	defer footerFaces.close()
+	footerY := fixed.I(height - padding)
+	footerFaces.draw(img, quoteCardBrand, fixed.I(padding), footerY, colors.accent)
+	speakerText := "― " + speaker
+	speakerX := fixed.I(width-padding) - footerFaces.measure(speakerText)
+	footerFaces.draw(img, speakerText, speakerX, footerY-fixed.I(int(footerSize*1.8)), colors.sub)
+
+	// 本文。領域に収まるまで文字サイズを小さくする。
+	areaWidth := fixed.I(width - padding*2)
+	areaHeight := float64(height-padding*2) - footerSize*3.5
+	fontSize := float64(height) / 8
+	if fontSize > float64(width)/10 {
+		fontSize = float64(width) / 10
+	}
+	minFontSize := footerSize
+	var faces fontSet
+	var lines []string
+	for {
+		if faces != nil {
+			faces.close()
+		}
+		if faces, err = newFontSet(fonts, fontSize); err != nil {
+			return nil, err
+		}
+		lines = wrapText(title, areaWidth, faces.measure)
+		if float64(len(lines))*fontSize*1.5 <= areaHeight || fontSize <= minFontSize {
+			break
+		}
+		fontSize *= 0.9
+	}
+	defer faces.close()
+
+	lineHeight := fontSize * 1.5
+	top := float64(padding) + (areaHeight-float64(len(lines))*lineHeight)/2
+	for i, line := range lines {
+		baseline := top + lineHeight*float64(i) + fontSize*1.1
+		if baseline > float64(height-padding)-footerSize*3 {
+			break
+		}
+		faces.draw(img, line, fixed.I(padding), fixed.Int26_6(baseline*64), colors.text)
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := png.Encode(buffer, img); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// wrapText 日本語の禁則処理を行いながら、幅に収まるよう文字列を折り返す。
+// 英数字の連続は単語として扱い、幅を超える場合を除き途中で折り返さない。
+func wrapText(text string, width fixed.Int26_6, measure func(string) fixed.Int26_6) []string {
+	lines := []string{}
+	for _, paragraph := range strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n") {
+		lines = append(lines, wrapParagraph(paragraph, width, measure)...)
+	}
+	return lines
+}
+
+// wrapParagraph 改行を含まない文字列を折り返す。
+func wrapParagraph(text string, width fixed.Int26_6, measure func(string) fixed.Int26_6) []string {
+	lines := []string{}
+	line := ""
+	for _, chunk := range splitBreakableChunks(text) {
+		if line == "" {
+			chunk = strings.TrimLeftFunc(chunk, unicode.IsSpace)
+		}
+		if measure(line+chunk) <= width {
+			line += chunk
+			continue
+		}
+		if line != "" {
+			lines = append(lines, strings.TrimRightFunc(line, unicode.IsSpace))
+			line = strings.TrimLeftFunc(chunk, unicode.IsSpace)
+		} else {
+			line = chunk
+		}
+		// 1行に収まらない塊は文字単位で折り返す
+		for measure(line) > width && len([]rune(line)) > 1 {
+			runes := []rune(line)
+			n := len(runes) - 1
+			for n > 1 && measure(string(runes[:n])) > width {
+				n--
+			}
+			lines = append(lines, string(runes[:n]))
+			line = string(runes[n:])
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+	return lines
+}
+
+// splitBreakableChunks 文字列を改行可能な位置で分割する。
+// 行頭禁則文字は直前の塊に、行末禁則文字は直後の塊に連結する。
+func splitBreakableChunks(text string) []string {
+	units := []string{}
+	word := ""
+	for _, r := range text {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'' || r == '-') {
+			word += string(r)
+			continue
+		}
+		if word != "" {
+			units = append(units, word)
+			word = ""
+		}
+		units = append(units, string(r))
+	}
+	if word != "" {
+		units = append(units, word)
+	}
+
+	chunks := []string{}
+	glueNext := false
+	for _, unit := range units {
+		r := []rune(unit)[0]
+		switch {
+		case glueNext || (len(chunks) > 0 && strings.ContainsRune(lineStartProhibited, r)):
+			chunks[len(chunks)-1] += unit
+		default:
+			chunks = append(chunks, unit)
+		}
+		glueNext = len([]rune(unit)) == 1 && strings.ContainsRune(lineEndProhibited, r)
+	}
+	return chunks
+}
@@ -0,0 +1,108 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// quoteCardRendererVersion 描画処理のバージョン。描画内容を変更した場合に上げ、キャッシュを無効にする。
+const quoteCardRendererVersion = "1"
+
+// maxQuoteCardCachePosts 引用カードをキャッシュする投稿数の上限
+const maxQuoteCardCachePosts = 500
+
+// QuoteCardUseCase インターフェース
+type QuoteCardUseCase interface {
+	// 引用カード画像取得
+	GetQuoteCard(postID int, theme, size string) (png []byte, hash string, err error)
+}
+
+// quoteCardUseCase 構造体
+type quoteCardUseCase struct {
+	repository.PostRepository
+}
+
+// NewQuoteCardUseCase QuoteCardUseCaseを生成。
+func NewQuoteCardUseCase(repository repository.PostRepository) QuoteCardUseCase {
+	return &quoteCardUseCase{repository}
+}
+
+// GetQuoteCard 引用カード画像取得。
+// 投稿内容のハッシュ値をキーとしてキャッシュし、内容が変わらない限り再描画しない。
+func (usecase *quoteCardUseCase) GetQuoteCard(postID int, theme, size string) (png []byte, hash string, err error) {
+	post, err := usecase.PostRepository.FetchByID(postID, 0)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hash = quoteCardHash(post.Title, post.Speaker, theme, size)
+	if png, ok := quoteCards.get(postID, hash); ok {
+		return png, hash, nil
+	}
+
+	png, err = renderQuoteCard(post.Title, post.Speaker, theme, size)
+	if err != nil {
+		return nil, "", err
+	}
+	quoteCards.set(postID, hash, png)
+
+	return png, hash, nil
+}
+
+// quoteCardHash 引用カードの内容のハッシュ値
+func quoteCardHash(title, speaker, theme, size string) string {
+	sum := sha256.New()
+	for _, value := range []string{quoteCardRendererVersion, title, speaker, theme, size} {
+		sum.Write([]byte(value))
+		sum.Write([]byte{0})
+	}
+	return hex.EncodeToString(sum.Sum(nil)[:16])
+}
+
+// quoteCardCache 投稿ごとの引用カードのキャッシュ。
+type quoteCardCache struct {
+	mutex sync.Mutex
+	// 投稿ID→ハッシュ値→PNG画像
+	cards map[int]map[string][]byte
+}
+
+// quoteCards 全APIリクエストで共有する引用カードのキャッシュ。
+var quoteCards = &quoteCardCache{cards: map[int]map[string][]byte{}}
+
+// get キャッシュ済みの引用カードを取得する。
+func (cache *quoteCardCache) get(postID int, hash string) ([]byte, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	png, ok := cache.cards[postID][hash]
+	return png, ok
+}
+
+// set 引用カードをキャッシュする。上限を超える場合は任意の投稿のキャッシュを破棄する。
+func (cache *quoteCardCache) set(postID int, hash string, png []byte) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if _, ok := cache.cards[postID]; !ok {
+		if len(cache.cards) >= maxQuoteCardCachePosts {
+			for id := range cache.cards {
+				delete(cache.cards, id)
+				break
+			}
+		}
+		cache.cards[postID] = map[string][]byte{}
+	}
+	cache.cards[postID][hash] = png
+}
+
+// invalidate 投稿の引用カードのキャッシュを破棄する。投稿の更新・削除時に呼び出す。
+func (cache *quoteCardCache) invalidate(postID int) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	delete(cache.cards, postID)
+}
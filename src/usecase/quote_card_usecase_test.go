@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+// 引用カード画像取得テスト
+func TestGetQuoteCard_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewQuoteCardUseCase(&repository)
+	post := makeGetPostResult(1)
+	post.Title = "失敗したところでやめてしまうから失敗になる。成功するところまで続ければ、それは成功になる。"
+	repository.On("FetchByID", 1, 0).Return(post, nil)
+
+	// 2. Exercise
+	data, hash, err := usecase.GetQuoteCard(1, QuoteCardThemeLight, QuoteCardSizeSquare)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, quoteCardHash(post.Title, post.Speaker, QuoteCardThemeLight, QuoteCardSizeSquare), hash)
+	img, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 1080, img.Bounds().Dx())
+	assert.Equal(t, 1080, img.Bounds().Dy())
+
+	// キャッシュされている
+	cached, ok := quoteCards.get(1, hash)
+	assert.True(t, ok)
+	assert.Equal(t, data, cached)
+
+	// 4. Teardown
+	quoteCards.invalidate(1)
+}
+
+func TestGetQuoteCard_error(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewQuoteCardUseCase(&repository)
+	repository.On("FetchByID", 1, 0).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	data, hash, err := usecase.GetQuoteCard(1, QuoteCardThemeLight, QuoteCardSizeOGP)
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, data)
+	assert.Empty(t, hash)
+
+	// 4. Teardown
+}
+
+// 投稿更新時のキャッシュ破棄テスト
+func TestUpdatePost_invalidateQuoteCard(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository)
+	repository.On("Update", mock.AnythingOfType("*model.Post")).Return(nil)
+	quoteCards.set(1, "hash", []byte("png"))
+
+	// 2. Exercise
+	err := usecase.UpdatePost(1, "title", "speaker", "detail", "", model.PostSource{}, "", "", nil)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	_, ok := quoteCards.get(1, "hash")
+	assert.False(t, ok)
+
+	// 4. Teardown
+}
+
+// 日本語のフォント読み込みテスト
+func TestLoadJapaneseFont_error(t *testing.T) {
+	// 1. Setup
+	latinFont := filepath.Join(t.TempDir(), "latin.ttf")
+	ioutil.WriteFile(latinFont, goregular.TTF, 0644)
+
+	cases := []struct {
+		label string
+		path  string
+		err   string
+	}{
+		{"ファイルなし", filepath.Join(t.TempDir(), "none.ttf"), "no such file or directory"},
+		{"日本語のグリフなし", latinFont, "フォントに日本語のグリフがありません：" + latinFont},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			t.Setenv("QUOTE_CARD_FONT_PATH", c.path)
+
+			// 2. Exercise
+			f, err := loadJapaneseFont()
+
+			// 3. Verify
+			assert.Nil(t, f)
+			assert.Contains(t, err.Error(), c.err)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// 内容のハッシュ値テスト
+func TestQuoteCardHash(t *testing.T) {
+	hash := quoteCardHash("title", "speaker", QuoteCardThemeLight, QuoteCardSizeOGP)
+	assert.Equal(t, hash, quoteCardHash("title", "speaker", QuoteCardThemeLight, QuoteCardSizeOGP))
+	assert.NotEqual(t, hash, quoteCardHash("title2", "speaker", QuoteCardThemeLight, QuoteCardSizeOGP))
+	assert.NotEqual(t, hash, quoteCardHash("title", "speaker", QuoteCardThemeDark, QuoteCardSizeOGP))
+	// 区切りが異なる場合は別の値
+	assert.NotEqual(t, quoteCardHash("ab", "c", QuoteCardThemeLight, QuoteCardSizeOGP), quoteCardHash("a", "bc", QuoteCardThemeLight, QuoteCardSizeOGP))
+}
+
+// 折り返しテスト
+func TestWrapText(t *testing.T) {
+	// 1文字を幅1とする
+	measure := func(text string) fixed.Int26_6 {
+		return fixed.I(len([]rune(text)))
+	}
+
+	cases := []struct {
+		label    string
+		text     string
+		width    int
+		expected []string
+	}{
+		{"折り返しなし", "あいうえお", 5, []string{"あいうえお"}},
+		{"文字単位で折り返し", "あいうえおかきく", 5, []string{"あいうえお", "かきく"}},
+		{"行頭禁則", "あいうえお。かき", 5, []string{"あいうえ", "お。かき"}},
+		{"行頭禁則(小書き文字)", "あいうえおっと", 5, []string{"あいうえ", "おっと"}},
+		{"行末禁則", "あいう「えお」", 4, []string{"あいう", "「えお」"}},
+		{"単語は途中で折り返さない", "go is fun", 6, []string{"go is", "fun"}},
+		{"幅を超える単語は折り返す", "abcdefgh", 5, []string{"abcde", "fgh"}},
+		{"改行", "あい\nうえ", 5, []string{"あい", "うえ"}},
+		{"空文字", "", 5, []string{""}},
+	}
+
+	for _, test := range cases {
+		assert.Equal(t, test.expected, wrapText(test.text, fixed.I(test.width), measure), test.label)
+	}
+}
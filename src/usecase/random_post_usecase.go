@@ -0,0 +1,103 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// RandomPostUseCase インターフェース
+type RandomPostUseCase interface {
+	// ランダム投稿取得
+	GetRandomPosts(count int, condition *model.RandomPostCondition) ([]*model.GetPostResult, error)
+}
+
+// randomPostUseCase 構造体
+type randomPostUseCase struct {
+	repository.PostRepository
+	repository.PostViewRepository
+	runJob JobRunner
+	clock  Clock
+}
+
+// NewRandomPostUseCase RandomPostUseCaseを生成。
+func NewRandomPostUseCase(postRepository repository.PostRepository, postViewRepository repository.PostViewRepository, runJob JobRunner) RandomPostUseCase {
+	return &randomPostUseCase{postRepository, postViewRepository, runJob, time.Now}
+}
+
+// GetRandomPosts ランダム投稿取得。
+// 条件に一致する投稿のIDの範囲から無作為に選んだID以上で最小のIDの投稿を、count件になるまで重複なく抽選し、抽選した順に返す。
+// ログインしている場合は、返した投稿を閲覧済みとして記録する。
+func (usecase *randomPostUseCase) GetRandomPosts(count int, condition *model.RandomPostCondition) ([]*model.GetPostResult, error) {
+	ids, err := usecase.sampleIDs(count, condition)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := usecase.PostRepository.FetchByIDs(ids, condition.LoginUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 動画URL加工、引用表記生成
+	postIDs := make([]int, 0, len(posts))
+	for _, post := range posts {
+		post.EmbedMovieURL = makeEmbedMovieURL(post.MovieURL)
+		post.Citation = makeCitation(post.Speaker, &post.PostSource)
+		postIDs = append(postIDs, post.ID)
+	}
+
+	if condition.LoginUserID > 0 {
+		saveSeenPosts(usecase.runJob, usecase.PostViewRepository, condition.LoginUserID, postIDs, usecase.clock())
+	}
+
+	return posts, nil
+}
+
+// sampleIDs 条件に一致する投稿のIDを最大count件、重複なく無作為に選ぶ。
+// IDの範囲から無作為に選んだ値以上で最小のIDを選ぶため、IDの間隔に偏りがある場合は完全な一様分布にはならない。
+func (usecase *randomPostUseCase) sampleIDs(count int, condition *model.RandomPostCondition) ([]int, error) {
+	minID, maxID, err := usecase.PostRepository.FetchRandomCandidateIDRange(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	if maxID == 0 {
+		return ids, nil
+	}
+	for len(ids) < count {
+		id, err := usecase.PostRepository.FetchRandomCandidateID(condition, minID+rand.Intn(maxID-minID+1), ids)
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			// 選んだ値以上に候補がない場合は先頭から探す
+			if id, err = usecase.PostRepository.FetchRandomCandidateID(condition, minID, ids); err != nil {
+				return nil, err
+			}
+		}
+		if id == 0 { // 候補を全て選んだ
+			break
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// saveSeenPosts ログインユーザーが閲覧した投稿を非同期に記録する。記録に失敗した場合は破棄する。
+func saveSeenPosts(runJob JobRunner, repository repository.PostViewRepository, userID int, postIDs []int, seenAt time.Time) {
+	if len(postIDs) == 0 {
+		return
+	}
+	runJob(func() {
+		if err := repository.SaveSeenPosts(userID, postIDs, seenAt); err != nil {
+			log.Printf("閲覧済みの投稿の記録に失敗しました：%v", err)
+		}
+	})
+}
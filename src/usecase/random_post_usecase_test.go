@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ランダム投稿取得テスト
+func TestGetRandomPosts_success(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 12, 0, 0, 0, time.Local)
+	repository := mockPostRepository{}
+	postViewRepository := mockPostViewRepository{}
+	usecase := &randomPostUseCase{&repository, &postViewRepository, runSynchronously, fixedClock(current)}
+	condition := &model.RandomPostCondition{Speaker: "speaker1", Tag: "tag1", LoginUserID: 1, ExcludeSeen: true}
+	repository.On("FetchRandomCandidateIDRange", condition).Return(1, 3, nil)
+	repository.On("FetchRandomCandidateID", condition, mock.AnythingOfType("int"), []int{}).Return(3, nil)
+	repository.On("FetchRandomCandidateID", condition, mock.AnythingOfType("int"), []int{3}).Return(1, nil)
+	repository.On("FetchByIDs", []int{3, 1}, 1).Return([]*model.GetPostResult{makeGetPostResult(3), makeGetPostResult(1)}, nil)
+	postViewRepository.On("SaveSeenPosts", 1, []int{3, 1}, current).Return(nil)
+
+	// 2. Exercise
+	posts, err := usecase.GetRandomPosts(2, condition)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+	assert.NotEmpty(t, posts[0].EmbedMovieURL)
+	postViewRepository.AssertCalled(t, "SaveSeenPosts", 1, []int{3, 1}, current)
+
+	// 4. Teardown
+}
+
+func TestGetRandomPosts_success_exhausted(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	postViewRepository := mockPostViewRepository{}
+	usecase := NewRandomPostUseCase(&repository, &postViewRepository, runSynchronously)
+	condition := &model.RandomPostCondition{}
+	repository.On("FetchRandomCandidateIDRange", condition).Return(2, 2, nil)
+	repository.On("FetchRandomCandidateID", condition, 2, []int{}).Return(2, nil)
+	// 候補を全て選んだ場合は先頭から探しても見つからない
+	repository.On("FetchRandomCandidateID", condition, 2, []int{2}).Return(0, nil)
+	repository.On("FetchByIDs", []int{2}, 0).Return([]*model.GetPostResult{makeGetPostResult(2)}, nil)
+
+	// 2. Exercise
+	posts, err := usecase.GetRandomPosts(3, condition)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	repository.AssertNumberOfCalls(t, "FetchRandomCandidateID", 3)
+	postViewRepository.AssertNotCalled(t, "SaveSeenPosts", mock.Anything, mock.Anything, mock.Anything)
+
+	// 4. Teardown
+}
+
+func TestGetRandomPosts_success_noCandidates(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewRandomPostUseCase(&repository, &mockPostViewRepository{}, runSynchronously)
+	condition := &model.RandomPostCondition{MinFavoriteCount: 10}
+	repository.On("FetchRandomCandidateIDRange", condition).Return(0, 0, nil)
+	repository.On("FetchByIDs", []int{}, 0).Return([]*model.GetPostResult{}, nil)
+
+	// 2. Exercise
+	posts, err := usecase.GetRandomPosts(1, condition)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Empty(t, posts)
+	repository.AssertNotCalled(t, "FetchRandomCandidateID", mock.Anything, mock.Anything, mock.Anything)
+
+	// 4. Teardown
+}
+
+func TestGetRandomPosts_error(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	usecase := NewRandomPostUseCase(&repository, &mockPostViewRepository{}, runSynchronously)
+	repository.On("FetchRandomCandidateIDRange", mock.Anything).Return(0, 0, errors.New("error"))
+
+	// 2. Exercise
+	posts, err := usecase.GetRandomPosts(1, &model.RandomPostCondition{})
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, posts)
+
+	// 4. Teardown
+}
@@ -0,0 +1,150 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// defaultReactionTypes リアクションの種類のデフォルト値
+const defaultReactionTypes = "moved:感動,encouraged:励まされた,laughed:笑った"
+
+// ErrInvalidReactionType 設定されていないリアクションの種類を指定した場合のエラー
+var ErrInvalidReactionType = errors.New("Type：リアクションの種類が正しくありません。")
+
+// ReactionUseCase インターフェース
+type ReactionUseCase interface {
+	// リアクションの種類一覧取得
+	GetReactionTypes() []*model.ReactionType
+	// リアクション登録
+	CreateReaction(userID, postID int, reactionType string) error
+	// リアクション削除
+	DeleteReaction(userID, postID int, reactionType string) error
+	// リアクションしたユーザー一覧取得
+	GetReactions(postID int, reactionType string, limit, page int) (totalCount int, reactions []*model.GetReactionResult, err error)
+}
+
+// reactionUseCase 構造体
+type reactionUseCase struct {
+	repository.ModerationRepository
+	repository.ReactionRepository
+}
+
+// NewReactionUseCase ReactionUseCaseを生成。
+func NewReactionUseCase(moderationRepository repository.ModerationRepository, reactionRepository repository.ReactionRepository) ReactionUseCase {
+	return &reactionUseCase{moderationRepository, reactionRepository}
+}
+
+// reactionTypes リアクションの種類一覧。
+// 環境変数REACTION_TYPESに「種類:表示名」をカンマ区切りで指定して変更できる(例：moved:感動,laughed:笑った)。
+// 設定から外した種類のリアクションは削除されないが、件数には含めない。
+func reactionTypes() []*model.ReactionType {
+	setting := os.Getenv("REACTION_TYPES")
+	if strings.TrimSpace(setting) == "" {
+		setting = defaultReactionTypes
+	}
+
+	types := []*model.ReactionType{}
+	for _, item := range strings.Split(setting, ",") {
+		parts := strings.SplitN(item, ":", 2)
+		reactionType := strings.TrimSpace(parts[0])
+		if reactionType == "" {
+			continue
+		}
+		label := reactionType
+		if len(parts) == 2 && strings.TrimSpace(parts[1]) != "" {
+			label = strings.TrimSpace(parts[1])
+		}
+		types = append(types, &model.ReactionType{Type: reactionType, Label: label})
+	}
+	return types
+}
+
+// isReactionType 設定されているリアクションの種類の場合はtrueを返す
+func isReactionType(reactionType string) bool {
+	for _, t := range reactionTypes() {
+		if t.Type == reactionType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetReactionTypes リアクションの種類一覧取得
+func (usecase *reactionUseCase) GetReactionTypes() []*model.ReactionType {
+	return reactionTypes()
+}
+
+// CreateReaction リアクション登録。種類ごとに1回のみリアクションでき、既にリアクションしている場合は何もしない。
+// 存在しない投稿、非表示の投稿の場合はErrPostNotFoundを返す。
+func (usecase *reactionUseCase) CreateReaction(userID, postID int, reactionType string) error {
+	if !isReactionType(reactionType) {
+		return ErrInvalidReactionType
+	}
+	post, err := usecase.ModerationRepository.FetchPostForModeration(postID)
+	if err != nil {
+		return err
+	}
+	if post == nil || post.IsHidden {
+		return ErrPostNotFound
+	}
+
+	return usecase.ReactionRepository.CreateReaction(&model.Reaction{UserID: userID, PostID: postID, Type: reactionType})
+}
+
+// DeleteReaction リアクション削除。設定から外した種類のリアクションも削除できる。
+func (usecase *reactionUseCase) DeleteReaction(userID, postID int, reactionType string) error {
+	return usecase.ReactionRepository.DeleteReaction(userID, postID, reactionType)
+}
+
+// GetReactions リアクションしたユーザー一覧取得。新しい順に返す。種類を限定しない場合はreactionTypeに空文字を指定する。
+func (usecase *reactionUseCase) GetReactions(postID int, reactionType string, limit, page int) (totalCount int, reactions []*model.GetReactionResult, err error) {
+	if reactionType != "" && !isReactionType(reactionType) {
+		return 0, nil, ErrInvalidReactionType
+	}
+	return usecase.ReactionRepository.FetchReactions(postID, reactionType, limit, page)
+}
+
+// applyReactions 各投稿にリアクションの種類ごとの件数と、ログインユーザーがリアクションしている種類を設定する。
+func applyReactions(reactionRepository repository.ReactionRepository, posts []*model.GetPostResult, loginUserID int) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]int, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	counts, err := reactionRepository.FetchReactionCounts(postIDs, loginUserID)
+	if err != nil {
+		return err
+	}
+	countsByPostID := map[int]map[string]*model.PostReactionCount{}
+	for _, count := range counts {
+		if countsByPostID[count.PostID] == nil {
+			countsByPostID[count.PostID] = map[string]*model.PostReactionCount{}
+		}
+		countsByPostID[count.PostID][count.Type] = count
+	}
+
+	types := reactionTypes()
+	for _, post := range posts {
+		post.Reactions = make([]*model.ReactionCount, 0, len(types))
+		post.MyReactions = []string{}
+		for _, t := range types {
+			reactionCount := &model.ReactionCount{Type: t.Type, Label: t.Label}
+			if count, ok := countsByPostID[post.ID][t.Type]; ok {
+				reactionCount.Count = count.Count
+				if count.Reacted && loginUserID > 0 {
+					post.MyReactions = append(post.MyReactions, t.Type)
+				}
+			}
+			post.Reactions = append(post.Reactions, reactionCount)
+		}
+	}
+	return nil
+}
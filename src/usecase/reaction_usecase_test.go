@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"os"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockReactionRepository struct {
+	mock.Mock
+}
+
+// リアクション登録
+func (repository *mockReactionRepository) CreateReaction(reaction *model.Reaction) error {
+	return repository.Called(reaction).Error(0)
+}
+
+// リアクション削除
+func (repository *mockReactionRepository) DeleteReaction(userID, postID int, reactionType string) error {
+	return repository.Called(userID, postID, reactionType).Error(0)
+}
+
+// リアクションの種類ごとの件数取得
+func (repository *mockReactionRepository) FetchReactionCounts(postIDs []int, loginUserID int) ([]*model.PostReactionCount, error) {
+	args := repository.Called(postIDs, loginUserID)
+	counts, ok := args.Get(0).([]*model.PostReactionCount)
+	if ok {
+		return counts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// リアクションしたユーザー一覧取得
+func (repository *mockReactionRepository) FetchReactions(postID int, reactionType string, limit, page int) (totalCount int, reactions []*model.GetReactionResult, err error) {
+	args := repository.Called(postID, reactionType, limit, page)
+	reactions, ok := args.Get(1).([]*model.GetReactionResult)
+	if ok {
+		return args.Int(0), reactions, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// リアクションの種類一覧テスト
+func TestReactionTypes(t *testing.T) {
+	cases := []struct {
+		label    string
+		setting  string
+		expected []*model.ReactionType
+	}{
+		{"デフォルト", "", []*model.ReactionType{{Type: "moved", Label: "感動"}, {Type: "encouraged", Label: "励まされた"}, {Type: "laughed", Label: "笑った"}}},
+		{"表示名省略", " like , moved:感動 ,", []*model.ReactionType{{Type: "like", Label: "like"}, {Type: "moved", Label: "感動"}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			// 1. Setup
+			os.Setenv("REACTION_TYPES", c.setting)
+			defer os.Unsetenv("REACTION_TYPES")
+
+			// 2. Exercise
+			actual := reactionTypes()
+
+			// 3. Verify
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// リアクション登録テスト
+func TestCreateReaction(t *testing.T) {
+	// 1. Setup
+	cases := []struct {
+		label        string
+		postID       int
+		reactionType string
+		err          error
+	}{
+		{"成功", 1, "moved", nil},
+		{"種類不正", 1, "angry", ErrInvalidReactionType},
+		{"投稿なし", 2, "moved", ErrPostNotFound},
+		{"非表示", 3, "moved", ErrPostNotFound},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			repository := mockPostRepository{}
+			reactionRepository := mockReactionRepository{}
+			usecase := NewReactionUseCase(&repository, &reactionRepository)
+			repository.On("FetchPostForModeration", 1).Return(&model.Post{ID: 1}, nil)
+			repository.On("FetchPostForModeration", 2).Return(nil, nil)
+			repository.On("FetchPostForModeration", 3).Return(&model.Post{ID: 3, IsHidden: true}, nil)
+			reactionRepository.On("CreateReaction", &model.Reaction{UserID: 5, PostID: 1, Type: "moved"}).Return(nil)
+
+			// 2. Exercise
+			err := usecase.CreateReaction(5, c.postID, c.reactionType)
+
+			// 3. Verify
+			assert.Equal(t, c.err, err)
+			if c.err != nil {
+				reactionRepository.AssertNotCalled(t, "CreateReaction", mock.Anything)
+			}
+		})
+	}
+
+	// 4. Teardown
+}
+
+// リアクションの件数設定テスト
+func TestApplyReactions(t *testing.T) {
+	// 1. Setup
+	repository := mockReactionRepository{}
+	posts := []*model.GetPostResult{makeGetPostResult(1), makeGetPostResult(2)}
+	repository.On("FetchReactionCounts", []int{1, 2}, 5).Return([]*model.PostReactionCount{
+		{PostID: 1, Type: "moved", Count: 3, Reacted: true},
+		{PostID: 1, Type: "laughed", Count: 1},
+		// 設定から外した種類は含めない
+		{PostID: 2, Type: "angry", Count: 2, Reacted: true},
+	}, nil)
+
+	// 2. Exercise
+	err := applyReactions(&repository, posts, 5)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.ReactionCount{
+		{Type: "moved", Label: "感動", Count: 3},
+		{Type: "encouraged", Label: "励まされた", Count: 0},
+		{Type: "laughed", Label: "笑った", Count: 1},
+	}, posts[0].Reactions)
+	assert.Equal(t, []string{"moved"}, posts[0].MyReactions)
+	assert.Equal(t, 0, posts[1].Reactions[0].Count)
+	assert.Empty(t, posts[1].MyReactions)
+
+	// 4. Teardown
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// realtimeEventBufferSize 接続ごとに配信を待てるイベントの件数。超えた分は配信しない。
+const realtimeEventBufferSize = 32
+
+// realtimeSubscription リアルタイム配信の接続ごとの購読。
+type realtimeSubscription struct {
+	userID  int
+	postIDs map[int]bool
+	events  chan *model.RealtimeEvent
+}
+
+// matches 購読の対象のイベントの場合はtrueを返す。
+// ユーザーが指定されたイベントはそのユーザーに、投稿が指定されたイベントはその投稿を購読している接続に配信する。
+func (subscription *realtimeSubscription) matches(event *model.RealtimeEvent) bool {
+	if event.UserID > 0 {
+		return event.UserID == subscription.userID
+	}
+	return subscription.postIDs[event.PostID]
+}
+
+// realtimeEventHub リアルタイム配信の接続を管理し、イベントを振り分ける。
+// イベントはブローカーを通してすべてのAPIサーバーのハブに届き、各ハブが自サーバーの接続に配信する。
+type realtimeEventHub struct {
+	mutex         sync.Mutex
+	broker        repository.RealtimeBroker
+	subscriptions map[*realtimeSubscription]bool
+}
+
+// realtimeHub 全APIリクエストで共有するハブ。投稿、コメントなどのユースケースからイベントを発行するため、1つのみとする。
+var realtimeHub = newRealtimeHub()
+
+// newRealtimeHub realtimeEventHubを生成する。
+func newRealtimeHub() *realtimeEventHub {
+	return &realtimeEventHub{subscriptions: map[*realtimeSubscription]bool{}}
+}
+
+// connect ブローカーに接続する。接続するまではイベントを発行しない。
+func (hub *realtimeEventHub) connect(broker repository.RealtimeBroker) {
+	hub.mutex.Lock()
+	hub.broker = broker
+	hub.mutex.Unlock()
+
+	broker.Subscribe(hub.dispatch)
+}
+
+// subscribe 購読を開始する。
+func (hub *realtimeEventHub) subscribe(userID int, postIDs []int) *realtimeSubscription {
+	subscription := &realtimeSubscription{
+		userID:  userID,
+		postIDs: map[int]bool{},
+		events:  make(chan *model.RealtimeEvent, realtimeEventBufferSize),
+	}
+	for _, postID := range postIDs {
+		subscription.postIDs[postID] = true
+	}
+
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	hub.subscriptions[subscription] = true
+	return subscription
+}
+
+// unsubscribe 購読を終了する。
+func (hub *realtimeEventHub) unsubscribe(subscription *realtimeSubscription) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	if hub.subscriptions[subscription] {
+		delete(hub.subscriptions, subscription)
+		close(subscription.events)
+	}
+}
+
+// publish イベントを発行する。内容はJSONにしてブローカーに渡す。失敗した場合はログに出力する。
+func (hub *realtimeEventHub) publish(eventType string, postID, userID int, data interface{}) {
+	hub.mutex.Lock()
+	broker := hub.broker
+	hub.mutex.Unlock()
+	if broker == nil {
+		return
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("リアルタイム配信に失敗しました：%v", err)
+		return
+	}
+	if err := broker.Publish(&model.RealtimeEvent{Type: eventType, PostID: postID, UserID: userID, Data: encoded}); err != nil {
+		log.Printf("リアルタイム配信に失敗しました：%v", err)
+	}
+}
+
+// dispatch ブローカーから届いたイベントを、購読している接続に配信する。
+// 受信が追いつかず待ちが溜まっている接続には配信しない。
+func (hub *realtimeEventHub) dispatch(event *model.RealtimeEvent) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	for subscription := range hub.subscriptions {
+		if !subscription.matches(event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+		}
+	}
+}
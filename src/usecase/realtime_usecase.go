@@ -0,0 +1,147 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// streamTicketLifetime リアルタイム配信のチケットの有効期限。接続を開始するまでの時間だけあればよい
+const streamTicketLifetime = 30 * time.Second
+
+// streamTicketType リアルタイム配信のチケットであることを表すクレームの値
+const streamTicketType = "stream"
+
+// ErrInvalidStreamTicket リアルタイム配信のチケットが正しくないか、有効期限が切れているか、使用済みの場合のエラー
+var ErrInvalidStreamTicket = errors.New("チケットが正しくないか、有効期限が切れているか、使用済みです。")
+
+// RealtimeUseCase インターフェース
+type RealtimeUseCase interface {
+	// リアルタイム配信の購読
+	Subscribe(userID int, postIDs []int) (events <-chan *model.RealtimeEvent, unsubscribe func())
+	// リアルタイム配信に接続するためのチケット発行
+	CreateStreamTicket(userID int) (*model.StreamTicket, error)
+	// リアルタイム配信のチケットの検証
+	VerifyStreamTicket(ticket string) (userID int, err error)
+	// ドメインイベントの配信(サブスクライバー)
+	HandleDomainEvent(event *model.OutboxEvent) error
+}
+
+// realtimeUseCase 構造体
+type realtimeUseCase struct {
+	repository.FavoriteCountRepository
+	repository.StreamTicketRepository
+	clock Clock
+}
+
+// NewRealtimeUseCase RealtimeUseCaseを生成。共有のハブをbrokerに接続する。
+func NewRealtimeUseCase(broker repository.RealtimeBroker, favoriteCountRepository repository.FavoriteCountRepository, streamTicketRepository repository.StreamTicketRepository) RealtimeUseCase {
+	realtimeHub.connect(broker)
+	return &realtimeUseCase{favoriteCountRepository, streamTicketRepository, time.Now}
+}
+
+// Subscribe リアルタイム配信の購読。
+// ユーザーへの通知と、postIDsの投稿へのコメント、お気に入り数の変化を配信する。
+// 購読を終了する場合はunsubscribeを呼び出す。eventsは閉じられる。
+func (usecase *realtimeUseCase) Subscribe(userID int, postIDs []int) (events <-chan *model.RealtimeEvent, unsubscribe func()) {
+	subscription := realtimeHub.subscribe(userID, postIDs)
+	return subscription.events, func() { realtimeHub.unsubscribe(subscription) }
+}
+
+// CreateStreamTicket リアルタイム配信に接続するためのチケット発行。
+// ログイン用のトークンと取り違えて使えないよう、別の鍵で署名し、種類を表すクレームを付ける。
+// 1回しか使用できないよう、チケットごとに推測できないIDを付ける。
+func (usecase *realtimeUseCase) CreateStreamTicket(userID int) (*model.StreamTicket, error) {
+	expiresAt := usecase.clock().Add(streamTicketLifetime)
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, err
+	}
+
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["sub"] = userID
+	claims["typ"] = streamTicketType
+	claims["jti"] = hex.EncodeToString(bytes)
+	claims["exp"] = expiresAt.Unix()
+
+	ticket, err := token.SignedString(streamTicketSigningKey())
+	if err != nil {
+		return nil, err
+	}
+	return &model.StreamTicket{Ticket: ticket, ExpiresAt: expiresAt}, nil
+}
+
+// VerifyStreamTicket リアルタイム配信のチケットの検証。チケットを発行したユーザーのIDを返す。
+// 検証したチケットは使用済みとして記録し、有効期限内でも再度使用できないようにする。
+func (usecase *realtimeUseCase) VerifyStreamTicket(ticket string) (int, error) {
+	parser := &jwt.Parser{ValidMethods: []string{jwt.SigningMethodHS256.Alg()}, SkipClaimsValidation: true}
+	token, err := parser.Parse(ticket, func(*jwt.Token) (interface{}, error) {
+		return streamTicketSigningKey(), nil
+	})
+	if err != nil || !token.Valid {
+		return 0, ErrInvalidStreamTicket
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["typ"] != streamTicketType || !claims.VerifyExpiresAt(usecase.clock().Unix(), true) {
+		return 0, ErrInvalidStreamTicket
+	}
+	sub, ok := claims["sub"].(float64)
+	if !ok || sub < 1 {
+		return 0, ErrInvalidStreamTicket
+	}
+	jti, ok := claims["jti"].(string)
+	if !ok || jti == "" {
+		return 0, ErrInvalidStreamTicket
+	}
+
+	exp, _ := claims["exp"].(float64)
+	saved, err := usecase.StreamTicketRepository.SaveUsedStreamTicket(&model.UsedStreamTicket{TicketID: jti, ExpiresAt: time.Unix(int64(exp), 0)}, usecase.clock())
+	if err != nil {
+		return 0, err
+	}
+	if !saved {
+		return 0, ErrInvalidStreamTicket
+	}
+	return int(sub), nil
+}
+
+// HandleDomainEvent 投稿へのコメント、お気に入り数の変化を配信する(サブスクライバー)。
+func (usecase *realtimeUseCase) HandleDomainEvent(event *model.OutboxEvent) error {
+	switch event.EventType {
+	case model.DomainEventCommentCreated:
+		var comment model.Comment
+		if err := json.Unmarshal([]byte(event.Payload), &comment); err != nil {
+			return err
+		}
+		realtimeHub.publish(model.RealtimeEventComment, comment.PostID, 0, &comment)
+	case model.DomainEventFavoriteCreated, model.DomainEventFavoriteDeleted:
+		var favorite model.Favorite
+		if err := json.Unmarshal([]byte(event.Payload), &favorite); err != nil {
+			return err
+		}
+		count, err := usecase.FavoriteCountRepository.CountFavorites(favorite.PostID)
+		if err != nil {
+			return err
+		}
+		realtimeHub.publish(model.RealtimeEventFavoriteCount, favorite.PostID, 0, &model.FavoriteCount{PostID: favorite.PostID, FavoriteCount: count})
+	}
+	return nil
+}
+
+// streamTicketSigningKey リアルタイム配信のチケットに署名する鍵。JWTトークンの鍵から導出する
+func streamTicketSigningKey() []byte {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("JWT_SIGNING_KEY")))
+	mac.Write([]byte("stream_ticket"))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock。発行されたイベントをそのまま購読している関数に渡す。
+type mockRealtimeBroker struct {
+	receive func(event *model.RealtimeEvent)
+}
+
+// イベントの発行
+func (broker *mockRealtimeBroker) Publish(event *model.RealtimeEvent) error {
+	broker.receive(event)
+	return nil
+}
+
+// イベントの購読
+func (broker *mockRealtimeBroker) Subscribe(receive func(event *model.RealtimeEvent)) {
+	broker.receive = receive
+}
+
+// Mock
+type mockStreamTicketRepository struct {
+	mock.Mock
+}
+
+// 使用済みのチケットの登録
+func (repository *mockStreamTicketRepository) SaveUsedStreamTicket(ticket *model.UsedStreamTicket, expiredBefore time.Time) (bool, error) {
+	args := repository.Called(ticket, expiredBefore)
+	return args.Bool(0), args.Error(1)
+}
+
+// connectRealtimeHub 共有のハブを新しいハブに差し替え、ブローカーのモックに接続する
+func connectRealtimeHub(t *testing.T, postRepository *mockPostRepository) RealtimeUseCase {
+	original := realtimeHub
+	realtimeHub = newRealtimeHub()
+	t.Cleanup(func() { realtimeHub = original })
+	return NewRealtimeUseCase(&mockRealtimeBroker{}, postRepository, &mockStreamTicketRepository{})
+}
+
+// 受信済みのイベントの種類一覧
+func receivedEventTypes(events <-chan *model.RealtimeEvent) []string {
+	types := []string{}
+	for {
+		select {
+		case event := <-events:
+			types = append(types, event.Type)
+		default:
+			return types
+		}
+	}
+}
+
+// リアルタイム配信の購読テスト
+func TestRealtimeSubscribe(t *testing.T) {
+	// 1. Setup
+	usecase := connectRealtimeHub(t, &mockPostRepository{})
+	events, unsubscribe := usecase.Subscribe(1, []int{10})
+	defer unsubscribe()
+	otherEvents, otherUnsubscribe := usecase.Subscribe(2, nil)
+	defer otherUnsubscribe()
+
+	// 2. Exercise
+	realtimeHub.publish(model.RealtimeEventComment, 10, 0, &model.Comment{ID: 1, PostID: 10, Body: "body"})
+	realtimeHub.publish(model.RealtimeEventComment, 11, 0, &model.Comment{ID: 2, PostID: 11, Body: "body"})
+	realtimeHub.publish(model.RealtimeEventNotification, 0, 1, &model.Notification{ID: 1, UserID: 1})
+	realtimeHub.publish(model.RealtimeEventNotification, 0, 2, &model.Notification{ID: 2, UserID: 2})
+
+	// 3. Verify
+	assert.Equal(t, []string{model.RealtimeEventComment, model.RealtimeEventNotification}, receivedEventTypes(events))
+	assert.Equal(t, []string{model.RealtimeEventNotification}, receivedEventTypes(otherEvents))
+
+	// 4. Teardown
+}
+
+func TestRealtimeSubscribe_unsubscribe(t *testing.T) {
+	// 1. Setup
+	usecase := connectRealtimeHub(t, &mockPostRepository{})
+	events, unsubscribe := usecase.Subscribe(1, []int{10})
+
+	// 2. Exercise
+	unsubscribe()
+	unsubscribe()
+	realtimeHub.publish(model.RealtimeEventComment, 10, 0, &model.Comment{ID: 1, PostID: 10})
+
+	// 3. Verify
+	_, ok := <-events
+	assert.False(t, ok)
+
+	// 4. Teardown
+}
+
+func TestRealtimeSubscribe_slowClient(t *testing.T) {
+	// 1. Setup
+	usecase := connectRealtimeHub(t, &mockPostRepository{})
+	events, unsubscribe := usecase.Subscribe(1, nil)
+	defer unsubscribe()
+
+	// 2. Exercise
+	for i := 0; i < realtimeEventBufferSize+10; i++ {
+		realtimeHub.publish(model.RealtimeEventNotification, 0, 1, &model.Notification{ID: i + 1, UserID: 1})
+	}
+
+	// 3. Verify
+	assert.Len(t, receivedEventTypes(events), realtimeEventBufferSize)
+
+	// 4. Teardown
+}
+
+// リアルタイム配信のチケット発行・検証テスト
+func TestStreamTicket(t *testing.T) {
+	// 1. Setup
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	streamTicketRepository := mockStreamTicketRepository{}
+	usecase := &realtimeUseCase{&mockPostRepository{}, &streamTicketRepository, fixedClock(now)}
+	var usedTicketIDs []string
+	streamTicketRepository.On("SaveUsedStreamTicket", mock.MatchedBy(func(ticket *model.UsedStreamTicket) bool {
+		return ticket.TicketID != "" && ticket.ExpiresAt.Equal(now.Add(streamTicketLifetime))
+	}), now).Return(true, nil).Run(func(args mock.Arguments) {
+		usedTicketIDs = append(usedTicketIDs, args.Get(0).(*model.UsedStreamTicket).TicketID)
+	})
+
+	// 2. Exercise
+	ticket, err := usecase.CreateStreamTicket(3)
+	userID, verifyErr := usecase.VerifyStreamTicket(ticket.Ticket)
+	otherTicket, _ := usecase.CreateStreamTicket(3)
+	usecase.VerifyStreamTicket(otherTicket.Ticket)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(streamTicketLifetime), ticket.ExpiresAt)
+	assert.NoError(t, verifyErr)
+	assert.Equal(t, 3, userID)
+	// チケットごとに別のIDを付ける
+	assert.Len(t, usedTicketIDs, 2)
+	assert.NotEqual(t, usedTicketIDs[0], usedTicketIDs[1])
+
+	// 4. Teardown
+}
+
+func TestVerifyStreamTicket_error_used(t *testing.T) {
+	// 1. Setup
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	streamTicketRepository := mockStreamTicketRepository{}
+	usecase := &realtimeUseCase{&mockPostRepository{}, &streamTicketRepository, fixedClock(now)}
+	ticket, _ := usecase.CreateStreamTicket(3)
+	streamTicketRepository.On("SaveUsedStreamTicket", mock.AnythingOfType("*model.UsedStreamTicket"), now).Return(false, nil)
+
+	// 2. Exercise
+	userID, err := usecase.VerifyStreamTicket(ticket.Ticket)
+
+	// 3. Verify
+	assert.Equal(t, ErrInvalidStreamTicket, err)
+	assert.Equal(t, 0, userID)
+
+	// 4. Teardown
+}
+
+func TestVerifyStreamTicket_error(t *testing.T) {
+	// 1. Setup
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	ticket, _ := (&realtimeUseCase{&mockPostRepository{}, &mockStreamTicketRepository{}, fixedClock(now)}).CreateStreamTicket(3)
+	loginToken, _ := createToken(&model.User{ID: 3, Name: "user", Role: model.RoleUser})
+	cases := []struct {
+		label  string
+		now    time.Time
+		ticket string
+	}{
+		{"有効期限切れ", now.Add(streamTicketLifetime + time.Second), ticket.Ticket},
+		{"ログイン用のトークン", now, loginToken},
+		{"改ざん", now, ticket.Ticket + "a"},
+		{"空", now, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			streamTicketRepository := mockStreamTicketRepository{}
+			usecase := &realtimeUseCase{&mockPostRepository{}, &streamTicketRepository, fixedClock(c.now)}
+
+			// 2. Exercise
+			userID, err := usecase.VerifyStreamTicket(c.ticket)
+
+			// 3. Verify
+			assert.Equal(t, ErrInvalidStreamTicket, err)
+			assert.Equal(t, 0, userID)
+			streamTicketRepository.AssertNotCalled(t, "SaveUsedStreamTicket", mock.Anything, mock.Anything)
+
+			// 4. Teardown
+		})
+	}
+}
+
+// ドメインイベントの配信テスト
+func TestRealtimeHandleDomainEvent(t *testing.T) {
+	cases := []struct {
+		label     string
+		event     *model.OutboxEvent
+		eventType string
+		data      string
+	}{
+		{"コメント", &model.OutboxEvent{EventType: model.DomainEventCommentCreated, Payload: `{"id":3,"post_id":2,"user_id":1,"body":"body"}`}, model.RealtimeEventComment, `"post_id":2`},
+		{"お気に入り登録", &model.OutboxEvent{EventType: model.DomainEventFavoriteCreated, Payload: `{"user_id":1,"post_id":2}`}, model.RealtimeEventFavoriteCount, `{"post_id":2,"favorite_count":5}`},
+		{"お気に入り削除", &model.OutboxEvent{EventType: model.DomainEventFavoriteDeleted, Payload: `{"user_id":1,"post_id":2}`}, model.RealtimeEventFavoriteCount, `{"post_id":2,"favorite_count":5}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			// 1. Setup
+			repository := mockPostRepository{}
+			usecase := connectRealtimeHub(t, &repository)
+			events, unsubscribe := usecase.Subscribe(1, []int{2})
+			defer unsubscribe()
+			repository.On("CountFavorites", 2).Return(5, nil)
+
+			// 2. Exercise
+			err := usecase.HandleDomainEvent(c.event)
+
+			// 3. Verify
+			assert.NoError(t, err)
+			event := <-events
+			assert.Equal(t, c.eventType, event.Type)
+			assert.Contains(t, string(event.Data), c.data)
+		})
+	}
+
+	// 4. Teardown
+}
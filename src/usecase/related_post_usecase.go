@@ -0,0 +1,238 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// 関連度の計算に使用する各要素の重み
+const (
+	relatedSpeakerWeight    = 0.3
+	relatedTagWeight        = 0.2
+	relatedTextWeight       = 0.3
+	relatedCoFavoriteWeight = 0.2
+)
+
+// relatedPostsPerPost 投稿ごとに登録する関連する投稿の件数
+const relatedPostsPerPost = 20
+
+// defaultRelatedPostsLimit 取得件数の指定がない場合の件数
+const defaultRelatedPostsLimit = 5
+
+// RelatedPostUseCase インターフェース
+type RelatedPostUseCase interface {
+	// 関連する投稿一覧取得
+	GetRelatedPosts(postID, loginUserID, limit int) ([]*model.GetPostResult, error)
+	// 関連する投稿の再計算
+	ComputeRelatedPosts() (int, error)
+}
+
+// relatedPostUseCase 構造体
+type relatedPostUseCase struct {
+	repository.PostRepository
+	repository.RelatedPostRepository
+}
+
+// NewRelatedPostUseCase RelatedPostUseCaseを生成。
+func NewRelatedPostUseCase(postRepository repository.PostRepository, relatedPostRepository repository.RelatedPostRepository) RelatedPostUseCase {
+	return &relatedPostUseCase{postRepository, relatedPostRepository}
+}
+
+// GetRelatedPosts 関連する投稿一覧取得。関連度の高い順に返す。
+// ログインユーザーの投稿は除く。limitに0を指定した場合は既定の件数を返す。
+func (usecase *relatedPostUseCase) GetRelatedPosts(postID, loginUserID, limit int) ([]*model.GetPostResult, error) {
+	if limit <= 0 {
+		limit = defaultRelatedPostsLimit
+	}
+	ids, err := usecase.RelatedPostRepository.FetchRelatedPostIDs(postID, loginUserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := usecase.PostRepository.FetchByIDs(ids, loginUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 動画URL加工、引用表記生成
+	for _, post := range posts {
+		post.EmbedMovieURL = makeEmbedMovieURL(post.MovieURL)
+		post.Citation = makeCitation(post.Speaker, &post.PostSource)
+	}
+
+	return posts, nil
+}
+
+// ComputeRelatedPosts 全投稿の関連する投稿を計算し、置き換える。登録した件数を返す。
+// 同じ発言者、お気に入りの理由の分類の共通度、タイトル・詳細のTF-IDFによる類似度、同じユーザーによるお気に入りの共通度を重み付けして合計する。
+// 投稿ごとに関連度の高い順に一定件数のみ登録する。
+func (usecase *relatedPostUseCase) ComputeRelatedPosts() (int, error) {
+	posts, err := usecase.RelatedPostRepository.FetchRelatedPostSources()
+	if err != nil {
+		return 0, err
+	}
+	favorites, err := usecase.RelatedPostRepository.FetchAllFavorites()
+	if err != nil {
+		return 0, err
+	}
+
+	relatedPosts := computeRelatedPosts(posts, favorites)
+	if err := usecase.RelatedPostRepository.ReplaceRelatedPosts(relatedPosts); err != nil {
+		return 0, err
+	}
+	return len(relatedPosts), nil
+}
+
+// computeRelatedPosts 投稿ごとの関連する投稿を計算する。
+func computeRelatedPosts(posts []*model.Post, favorites []*model.Favorite) []*model.RelatedPost {
+	scores := map[int]map[int]float64{}
+	addScores := func(similarities map[int]map[int]float64, weight float64) {
+		for postID, related := range similarities {
+			if scores[postID] == nil {
+				scores[postID] = map[int]float64{}
+			}
+			for relatedPostID, similarity := range related {
+				scores[postID][relatedPostID] += weight * similarity
+			}
+		}
+	}
+
+	// 同じ発言者
+	speakers := map[int][]string{}
+	for _, post := range posts {
+		fillNormalizedFields(post)
+		if post.NormalizedSpeaker != "" {
+			speakers[post.ID] = []string{post.NormalizedSpeaker}
+		}
+	}
+	addScores(cosineSimilarities(binaryVectors(speakers)), relatedSpeakerWeight)
+
+	// お気に入りの理由の分類、同じユーザーによるお気に入り
+	postIDs := map[int]bool{}
+	for _, post := range posts {
+		postIDs[post.ID] = true
+	}
+	tags := map[int][]string{}
+	favoriteUsers := map[int][]string{}
+	for _, favorite := range favorites {
+		if !postIDs[favorite.PostID] {
+			continue
+		}
+		if tag := strings.ToLower(strings.TrimSpace(favorite.Tag)); tag != "" {
+			tags[favorite.PostID] = append(tags[favorite.PostID], tag)
+		}
+		favoriteUsers[favorite.PostID] = append(favoriteUsers[favorite.PostID], strconv.Itoa(favorite.UserID))
+	}
+	addScores(cosineSimilarities(binaryVectors(tags)), relatedTagWeight)
+	addScores(cosineSimilarities(binaryVectors(favoriteUsers)), relatedCoFavoriteWeight)
+
+	// タイトル・詳細のTF-IDF
+	addScores(cosineSimilarities(tfidfVectors(posts)), relatedTextWeight)
+
+	relatedPosts := []*model.RelatedPost{}
+	for _, post := range posts {
+		candidates := []*model.RelatedPost{}
+		for relatedPostID, score := range scores[post.ID] {
+			if relatedPostID == post.ID || score <= 0 {
+				continue
+			}
+			candidates = append(candidates, &model.RelatedPost{PostID: post.ID, RelatedPostID: relatedPostID, Score: score})
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].Score != candidates[j].Score {
+				return candidates[i].Score > candidates[j].Score
+			}
+			return candidates[i].RelatedPostID > candidates[j].RelatedPostID
+		})
+		if len(candidates) > relatedPostsPerPost {
+			candidates = candidates[:relatedPostsPerPost]
+		}
+		relatedPosts = append(relatedPosts, candidates...)
+	}
+	return relatedPosts
+}
+
+// binaryVectors 投稿ごとの特徴の有無をベクトルにする。同じ特徴が複数ある場合も1とする。
+func binaryVectors(features map[int][]string) map[int]map[string]float64 {
+	vectors := map[int]map[string]float64{}
+	for postID, values := range features {
+		vectors[postID] = map[string]float64{}
+		for _, value := range values {
+			vectors[postID][value] = 1
+		}
+	}
+	return vectors
+}
+
+// tfidfVectors 投稿ごとのタイトル・詳細のTF-IDFベクトル。
+// 日本語は単語に分割できないため、正規化した文字列の2文字ずつの組(bigram)を単語とみなす。
+func tfidfVectors(posts []*model.Post) map[int]map[string]float64 {
+	termCounts := map[int]map[string]float64{}
+	documentFrequencies := map[string]int{}
+	for _, post := range posts {
+		counts := map[string]float64{}
+		for _, text := range []string{post.Title, post.Detail} {
+			runes := []rune(normalizeText(text))
+			for i := 0; i+1 < len(runes); i++ {
+				counts[string(runes[i:i+2])]++
+			}
+		}
+		if len(counts) == 0 {
+			continue
+		}
+		termCounts[post.ID] = counts
+		for term := range counts {
+			documentFrequencies[term]++
+		}
+	}
+
+	vectors := map[int]map[string]float64{}
+	for postID, counts := range termCounts {
+		vectors[postID] = map[string]float64{}
+		for term, count := range counts {
+			idf := math.Log(float64(len(posts)) / float64(documentFrequencies[term]))
+			vectors[postID][term] = count * idf
+		}
+	}
+	return vectors
+}
+
+// cosineSimilarities ベクトルが共通の要素を持つ投稿同士のコサイン類似度(0〜1)。
+func cosineSimilarities(vectors map[int]map[string]float64) map[int]map[int]float64 {
+	norms := map[int]float64{}
+	postings := map[string][]int{}
+	for postID, vector := range vectors {
+		for term, weight := range vector {
+			norms[postID] += weight * weight
+			postings[term] = append(postings[term], postID)
+		}
+	}
+
+	similarities := map[int]map[int]float64{}
+	for postID, vector := range vectors {
+		if norms[postID] == 0 {
+			continue
+		}
+		dots := map[int]float64{}
+		for term, weight := range vector {
+			for _, otherID := range postings[term] {
+				if otherID != postID {
+					dots[otherID] += weight * vectors[otherID][term]
+				}
+			}
+		}
+		similarities[postID] = map[int]float64{}
+		for otherID, dot := range dots {
+			if norms[otherID] == 0 {
+				continue
+			}
+			similarities[postID][otherID] = dot / math.Sqrt(norms[postID]*norms[otherID])
+		}
+	}
+	return similarities
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockRelatedPostRepository struct {
+	mock.Mock
+}
+
+func (repository *mockRelatedPostRepository) FetchRelatedPostSources() ([]*model.Post, error) {
+	args := repository.Called()
+	posts, ok := args.Get(0).([]*model.Post)
+	if ok {
+		return posts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (repository *mockRelatedPostRepository) FetchAllFavorites() ([]*model.Favorite, error) {
+	args := repository.Called()
+	favorites, ok := args.Get(0).([]*model.Favorite)
+	if ok {
+		return favorites, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (repository *mockRelatedPostRepository) ReplaceRelatedPosts(relatedPosts []*model.RelatedPost) error {
+	args := repository.Called(relatedPosts)
+	return args.Error(0)
+}
+
+func (repository *mockRelatedPostRepository) FetchRelatedPostIDs(postID, excludeUserID, limit int) ([]int, error) {
+	args := repository.Called(postID, excludeUserID, limit)
+	ids, ok := args.Get(0).([]int)
+	if ok {
+		return ids, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 関連する投稿一覧取得テスト
+func TestGetRelatedPosts_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	relatedPostRepository := mockRelatedPostRepository{}
+	usecase := NewRelatedPostUseCase(&repository, &relatedPostRepository)
+	relatedPostRepository.On("FetchRelatedPostIDs", 1, 2, 3).Return([]int{5, 4}, nil)
+	repository.On("FetchByIDs", []int{5, 4}, 2).Return([]*model.GetPostResult{makeGetPostResult(5), makeGetPostResult(4)}, nil)
+
+	// 2. Exercise
+	posts, err := usecase.GetRelatedPosts(1, 2, 3)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+	assert.Equal(t, 5, posts[0].ID)
+	assert.NotEmpty(t, posts[0].EmbedMovieURL)
+
+	// 4. Teardown
+}
+
+func TestGetRelatedPosts_success_defaultLimit(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	relatedPostRepository := mockRelatedPostRepository{}
+	usecase := NewRelatedPostUseCase(&repository, &relatedPostRepository)
+	relatedPostRepository.On("FetchRelatedPostIDs", 1, 0, defaultRelatedPostsLimit).Return([]int{}, nil)
+	repository.On("FetchByIDs", []int{}, 0).Return([]*model.GetPostResult{}, nil)
+
+	// 2. Exercise
+	posts, err := usecase.GetRelatedPosts(1, 0, 0)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Empty(t, posts)
+	repository.AssertExpectations(t)
+	relatedPostRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestGetRelatedPosts_error(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	relatedPostRepository := mockRelatedPostRepository{}
+	usecase := NewRelatedPostUseCase(&repository, &relatedPostRepository)
+	relatedPostRepository.On("FetchRelatedPostIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	posts, err := usecase.GetRelatedPosts(1, 0, 5)
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, posts)
+
+	// 4. Teardown
+}
+
+// 関連する投稿の再計算テスト
+func TestComputeRelatedPosts_success(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	relatedPostRepository := mockRelatedPostRepository{}
+	usecase := NewRelatedPostUseCase(&repository, &relatedPostRepository)
+	relatedPostRepository.On("FetchRelatedPostSources").Return([]*model.Post{
+		{ID: 1, Title: "努力は必ず報われる", Speaker: "speaker1"},
+		{ID: 2, Title: "努力は裏切らない", Speaker: "speaker1"},
+	}, nil)
+	relatedPostRepository.On("FetchAllFavorites").Return([]*model.Favorite{}, nil)
+	relatedPostRepository.On("ReplaceRelatedPosts", mock.MatchedBy(func(relatedPosts []*model.RelatedPost) bool {
+		return len(relatedPosts) == 2
+	})).Return(nil)
+
+	// 2. Exercise
+	count, err := usecase.ComputeRelatedPosts()
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// 4. Teardown
+}
+
+func TestComputeRelatedPosts_error(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	relatedPostRepository := mockRelatedPostRepository{}
+	usecase := NewRelatedPostUseCase(&repository, &relatedPostRepository)
+	relatedPostRepository.On("FetchRelatedPostSources").Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	count, err := usecase.ComputeRelatedPosts()
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+	relatedPostRepository.AssertNotCalled(t, "ReplaceRelatedPosts", mock.Anything)
+
+	// 4. Teardown
+}
+
+// 関連度の計算テスト
+func TestComputeRelatedPosts_score(t *testing.T) {
+	// 1. Setup
+	posts := []*model.Post{
+		{ID: 1, Title: "努力は必ず報われる", Speaker: "speaker1"},
+		{ID: 2, Title: "努力は必ず報われる日が来る", Speaker: "Speaker1"},
+		{ID: 3, Title: "継続は力なり", Speaker: "speaker2"},
+		{ID: 4, Title: "明日の天気", Speaker: "speaker3"},
+	}
+	favorites := []*model.Favorite{
+		{UserID: 10, PostID: 1, Tag: "motivation"},
+		{UserID: 10, PostID: 3, Tag: "Motivation"},
+		{UserID: 11, PostID: 4},
+		{UserID: 11, PostID: 99},
+	}
+
+	// 2. Exercise
+	relatedPosts := computeRelatedPosts(posts, favorites)
+
+	// 3. Verify
+	related := map[int][]int{}
+	scores := map[int]map[int]float64{}
+	for _, relatedPost := range relatedPosts {
+		related[relatedPost.PostID] = append(related[relatedPost.PostID], relatedPost.RelatedPostID)
+		if scores[relatedPost.PostID] == nil {
+			scores[relatedPost.PostID] = map[int]float64{}
+		}
+		scores[relatedPost.PostID][relatedPost.RelatedPostID] = relatedPost.Score
+	}
+	// 同じ発言者、タイトルの類似 > お気に入りの理由の分類、同じユーザーによるお気に入り
+	assert.Equal(t, []int{2, 3}, related[1])
+	assert.Equal(t, []int{1}, related[2])
+	assert.Equal(t, []int{1}, related[3])
+	// 共通点のない投稿、存在しない投稿は関連しない
+	assert.Empty(t, related[4])
+	// 関連度は対称
+	assert.InDelta(t, scores[1][2], scores[2][1], 1e-9)
+	assert.InDelta(t, relatedTagWeight+relatedCoFavoriteWeight, scores[1][3], 1e-9)
+
+	// 4. Teardown
+}
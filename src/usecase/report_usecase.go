@@ -0,0 +1,255 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// defaultReportHideThreshold 自動で非表示にする未対応の通報件数のデフォルト値
+const defaultReportHideThreshold = 5
+
+var (
+	// ErrReportTargetNotFound 通報、対応の対象が存在しない場合のエラー
+	ErrReportTargetNotFound = errors.New("対象が存在しません。")
+	// ErrAlreadyReported 同じ対象を既に通報している場合のエラー
+	ErrAlreadyReported = errors.New("既に通報済みです。")
+	// ErrModerationActionNotApplicable 対象に適用できない対応を指定した場合のエラー
+	ErrModerationActionNotApplicable = errors.New("対象に適用できない対応です。")
+)
+
+// ReportUseCase インターフェース
+type ReportUseCase interface {
+	// 通報
+	CreateReport(reporterID int, targetType string, targetID int, reasonCode, detail string) error
+	// 未対応の通報の対象一覧取得
+	GetReportQueue(targetType string, limit, page int) (totalCount int, items []*model.ReportQueueItem, err error)
+	// 通報の対象取得
+	GetReportTarget(targetType string, targetID int) (*model.ReportTarget, error)
+	// モデレーターの対応
+	ModerateTarget(moderatorID int, targetType string, targetID int, action, reason string) error
+}
+
+// reportUseCase 構造体
+type reportUseCase struct {
+	repository.ModerationRepository
+	repository.UserRepository
+	repository.ReportRepository
+	autocompleteIndexes *AutocompleteIndexCache
+	clock               Clock
+}
+
+// NewReportUseCase ReportUseCaseを生成。
+func NewReportUseCase(moderationRepository repository.ModerationRepository, userRepository repository.UserRepository, reportRepository repository.ReportRepository, autocompleteIndexes *AutocompleteIndexCache) ReportUseCase {
+	return &reportUseCase{moderationRepository, userRepository, reportRepository, autocompleteIndexes, time.Now}
+}
+
+// CreateReport 通報。同じ対象を通報できるのは未対応の間は1回のみ。
+// 投稿、コメントは未対応の通報件数がしきい値に達した場合に自動で非表示にする。ユーザーは自動では利用停止にしない。
+func (usecase *reportUseCase) CreateReport(reporterID int, targetType string, targetID int, reasonCode, detail string) error {
+	target, err := usecase.fetchTarget(targetType, targetID)
+	if err != nil {
+		return err
+	}
+
+	report := &model.Report{
+		ReporterID: reporterID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		ReasonCode: reasonCode,
+		Detail:     detail,
+		Status:     model.ReportStatusOpen,
+	}
+	saved, err := usecase.ReportRepository.SaveReport(report)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return ErrAlreadyReported
+	}
+
+	if targetType == model.ReportTargetUser || target.IsHidden {
+		return nil
+	}
+	count, err := usecase.ReportRepository.CountOpenReports(targetType, targetID)
+	if err != nil {
+		return err
+	}
+	if count < reportHideThreshold() {
+		return nil
+	}
+
+	// 自動で非表示にした場合はモデレーターの確認が必要なため、通報は未対応のままとする
+	action := &model.ModerationAction{
+		TargetType:   targetType,
+		TargetID:     targetID,
+		TargetUserID: target.TargetUserID,
+		Action:       model.ModerationActionAutoHide,
+		Reason:       "通報の件数が" + strconv.Itoa(count) + "件に達したため",
+	}
+	hidden := true
+	if err := usecase.ReportRepository.CreateModerationAction(action, &model.ModerationEffect{Hidden: &hidden}, false); err != nil {
+		return err
+	}
+	usecase.invalidateCaches(targetType, targetID)
+	return nil
+}
+
+// reportHideThreshold 自動で非表示にする未対応の通報件数。環境変数REPORT_HIDE_THRESHOLDで変更できる。
+func reportHideThreshold() int {
+	threshold, err := strconv.Atoi(os.Getenv("REPORT_HIDE_THRESHOLD"))
+	if err != nil || threshold <= 0 {
+		return defaultReportHideThreshold
+	}
+	return threshold
+}
+
+// GetReportQueue 未対応の通報の対象一覧取得。通報件数の多い順に返す。
+func (usecase *reportUseCase) GetReportQueue(targetType string, limit, page int) (totalCount int, items []*model.ReportQueueItem, err error) {
+	totalCount, items, err = usecase.ReportRepository.FetchReportQueue(targetType, limit, page)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for _, item := range items {
+		item.ReasonCodes = []string{}
+		if item.ConcatenatedReasonCodes != "" {
+			item.ReasonCodes = strings.Split(item.ConcatenatedReasonCodes, ",")
+		}
+	}
+	return totalCount, items, nil
+}
+
+// GetReportTarget 通報の対象取得。通報と対応の履歴を含む。
+func (usecase *reportUseCase) GetReportTarget(targetType string, targetID int) (*model.ReportTarget, error) {
+	target, err := usecase.fetchTarget(targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Reports, err = usecase.ReportRepository.FetchReports(targetType, targetID); err != nil {
+		return nil, err
+	}
+	if target.Actions, err = usecase.ReportRepository.FetchModerationActions(targetType, targetID); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// ModerateTarget モデレーターの対応。対象への変更、対応の記録、対象の未対応の通報の対応済みへの変更を1つのトランザクションで行う。
+// 警告、利用停止は、投稿、コメントの場合は投稿者に対して行う。
+// 利用停止にしたユーザーはログインできなくなる。発行済みのトークンは有効期限まで使用できる。
+func (usecase *reportUseCase) ModerateTarget(moderatorID int, targetType string, targetID int, action, reason string) error {
+	target, err := usecase.fetchTarget(targetType, targetID)
+	if err != nil {
+		return err
+	}
+
+	effect := &model.ModerationEffect{}
+	switch action {
+	case model.ModerationActionDismiss:
+		if target.IsHidden {
+			hidden := false
+			effect.Hidden = &hidden
+		}
+	case model.ModerationActionHide:
+		if targetType == model.ReportTargetUser {
+			return ErrModerationActionNotApplicable
+		}
+		hidden := true
+		effect.Hidden = &hidden
+	case model.ModerationActionDelete:
+		switch targetType {
+		case model.ReportTargetPost:
+			effect.Events = []*model.DomainEvent{model.NewDomainEvent(model.DomainEventPostDeleted, &model.DomainEventTarget{ID: targetID})}
+		case model.ReportTargetComment:
+			effect.Events = []*model.DomainEvent{model.NewDomainEvent(model.DomainEventCommentDeleted, &model.DomainEventTarget{ID: targetID})}
+		default:
+			// ユーザーは削除できない
+			return ErrModerationActionNotApplicable
+		}
+		effect.Delete = true
+	case model.ModerationActionWarn:
+		// 対応の記録のみ行う
+	case model.ModerationActionSuspend:
+		suspendedAt := usecase.clock()
+		effect.SuspendedAt = &suspendedAt
+	default:
+		return ErrModerationActionNotApplicable
+	}
+
+	moderationAction := &model.ModerationAction{
+		ModeratorID:  moderatorID,
+		TargetType:   targetType,
+		TargetID:     targetID,
+		TargetUserID: target.TargetUserID,
+		Action:       action,
+		Reason:       reason,
+	}
+	if err := usecase.ReportRepository.CreateModerationAction(moderationAction, effect, true); err != nil {
+		return err
+	}
+
+	if len(effect.Events) > 0 {
+		wakeDomainEventDispatcher()
+	}
+	if effect.Hidden != nil || effect.Delete {
+		usecase.invalidateCaches(targetType, targetID)
+	}
+	return nil
+}
+
+// fetchTarget 通報、対応の対象を取得する。非表示の投稿、コメントも含める。
+func (usecase *reportUseCase) fetchTarget(targetType string, targetID int) (*model.ReportTarget, error) {
+	target := &model.ReportTarget{TargetType: targetType, TargetID: targetID}
+	switch targetType {
+	case model.ReportTargetPost:
+		post, err := usecase.ModerationRepository.FetchPostForModeration(targetID)
+		if err != nil {
+			return nil, err
+		}
+		if post == nil {
+			return nil, ErrReportTargetNotFound
+		}
+		target.Post = post
+		target.TargetUserID = post.UserID
+		target.IsHidden = post.IsHidden
+	case model.ReportTargetComment:
+		comment, err := usecase.ModerationRepository.FetchCommentByID(targetID)
+		if err != nil {
+			return nil, err
+		}
+		if comment == nil {
+			return nil, ErrReportTargetNotFound
+		}
+		target.Comment = comment
+		target.TargetUserID = comment.UserID
+		target.IsHidden = comment.IsHidden
+	case model.ReportTargetUser:
+		// 存在しないユーザー、退会済みのユーザーは取得できない
+		user, err := usecase.UserRepository.FetchByID(targetID)
+		if err != nil {
+			return nil, ErrReportTargetNotFound
+		}
+		target.User = user
+		target.TargetUserID = user.ID
+	default:
+		return nil, ErrReportTargetNotFound
+	}
+	return target, nil
+}
+
+// invalidateCaches 投稿の表示状態が変わった場合に、投稿を含むキャッシュを破棄する
+func (usecase *reportUseCase) invalidateCaches(targetType string, targetID int) {
+	if targetType != model.ReportTargetPost {
+		return
+	}
+	usecase.autocompleteIndexes.invalidate()
+	quoteCards.invalidate(targetID)
+}
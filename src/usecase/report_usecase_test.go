@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockReportRepository struct {
+	mock.Mock
+}
+
+// 通報登録
+func (repository *mockReportRepository) SaveReport(report *model.Report) (bool, error) {
+	args := repository.Called(report)
+	return args.Bool(0), args.Error(1)
+}
+
+// 未対応の通報件数取得
+func (repository *mockReportRepository) CountOpenReports(targetType string, targetID int) (int, error) {
+	args := repository.Called(targetType, targetID)
+	return args.Int(0), args.Error(1)
+}
+
+// 未対応の通報の対象一覧取得
+func (repository *mockReportRepository) FetchReportQueue(targetType string, limit, page int) (int, []*model.ReportQueueItem, error) {
+	args := repository.Called(targetType, limit, page)
+	items, ok := args.Get(1).([]*model.ReportQueueItem)
+	if ok {
+		return args.Int(0), items, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+// 通報一覧取得
+func (repository *mockReportRepository) FetchReports(targetType string, targetID int) ([]*model.GetReportResult, error) {
+	args := repository.Called(targetType, targetID)
+	reports, ok := args.Get(0).([]*model.GetReportResult)
+	if ok {
+		return reports, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// モデレーターの対応登録
+func (repository *mockReportRepository) CreateModerationAction(action *model.ModerationAction, effect *model.ModerationEffect, resolveReports bool) error {
+	return repository.Called(action, effect, resolveReports).Error(0)
+}
+
+// モデレーターの対応一覧取得
+func (repository *mockReportRepository) FetchModerationActions(targetType string, targetID int) ([]*model.ModerationAction, error) {
+	args := repository.Called(targetType, targetID)
+	actions, ok := args.Get(0).([]*model.ModerationAction)
+	if ok {
+		return actions, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// 通報テスト
+func TestCreateReport_success(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	reportRepository := mockReportRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewReportUseCase(&postRepository, &userRepository, &reportRepository, NewAutocompleteIndexCache())
+	postRepository.On("FetchPostForModeration", 1).Return(&model.Post{ID: 1, UserID: 2}, nil)
+	reportRepository.On("SaveReport", mock.MatchedBy(func(report *model.Report) bool {
+		return report.ReporterID == 3 && report.TargetType == model.ReportTargetPost && report.TargetID == 1 && report.ReasonCode == model.ReportReasonSpam
+	})).Return(true, nil)
+	reportRepository.On("CountOpenReports", model.ReportTargetPost, 1).Return(1, nil)
+
+	// 2. Exercise
+	err := usecase.CreateReport(3, model.ReportTargetPost, 1, model.ReportReasonSpam, "detail")
+
+	// 3. Verify
+	assert.NoError(t, err)
+	reportRepository.AssertNotCalled(t, "CreateModerationAction", mock.Anything, mock.Anything, mock.Anything)
+
+	// 4. Teardown
+}
+
+func TestCreateReport_success_autoHide(t *testing.T) {
+	// 1. Setup
+	os.Setenv("REPORT_HIDE_THRESHOLD", "3")
+	defer os.Unsetenv("REPORT_HIDE_THRESHOLD")
+	postRepository := mockPostRepository{}
+	reportRepository := mockReportRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewReportUseCase(&postRepository, &userRepository, &reportRepository, NewAutocompleteIndexCache())
+	postRepository.On("FetchCommentByID", 1).Return(&model.Comment{ID: 1, UserID: 2}, nil)
+	reportRepository.On("SaveReport", mock.AnythingOfType("*model.Report")).Return(true, nil)
+	reportRepository.On("CountOpenReports", model.ReportTargetComment, 1).Return(3, nil)
+	reportRepository.On("CreateModerationAction", mock.MatchedBy(func(action *model.ModerationAction) bool {
+		return action.Action == model.ModerationActionAutoHide && action.ModeratorID == 0 && action.TargetUserID == 2
+	}), mock.MatchedBy(func(effect *model.ModerationEffect) bool {
+		return effect.Hidden != nil && *effect.Hidden && !effect.Delete && effect.SuspendedAt == nil
+	}), false).Return(nil)
+
+	// 2. Exercise
+	err := usecase.CreateReport(3, model.ReportTargetComment, 1, model.ReportReasonHarassment, "")
+
+	// 3. Verify
+	assert.NoError(t, err)
+	postRepository.AssertExpectations(t)
+	reportRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestCreateReport_error(t *testing.T) {
+	// 1. Setup
+	cases := []struct {
+		label      string
+		targetType string
+		targetID   int
+		err        error
+	}{
+		{"投稿なし", model.ReportTargetPost, 2, ErrReportTargetNotFound},
+		{"ユーザーなし", model.ReportTargetUser, 2, ErrReportTargetNotFound},
+		{"通報済み", model.ReportTargetUser, 1, ErrAlreadyReported},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			postRepository := mockPostRepository{}
+			reportRepository := mockReportRepository{}
+			userRepository := mockUserRepository{}
+			usecase := NewReportUseCase(&postRepository, &userRepository, &reportRepository, NewAutocompleteIndexCache())
+			postRepository.On("FetchPostForModeration", 2).Return(nil, nil)
+			userRepository.On("FetchByID", 1).Return(makeUserForRead(1), nil)
+			userRepository.On("FetchByID", 2).Return(nil, errors.New("record not found"))
+			reportRepository.On("SaveReport", mock.AnythingOfType("*model.Report")).Return(false, nil)
+
+			// 2. Exercise
+			err := usecase.CreateReport(3, c.targetType, c.targetID, model.ReportReasonSpam, "")
+
+			// 3. Verify
+			assert.Equal(t, c.err, err)
+			reportRepository.AssertNotCalled(t, "CountOpenReports", mock.Anything, mock.Anything)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// 未対応の通報の対象一覧取得テスト
+func TestGetReportQueue(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	reportRepository := mockReportRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewReportUseCase(&postRepository, &userRepository, &reportRepository, NewAutocompleteIndexCache())
+	reportRepository.On("FetchReportQueue", "", 10, 1).Return(2, []*model.ReportQueueItem{
+		{TargetType: model.ReportTargetPost, TargetID: 1, ReportCount: 2, ConcatenatedReasonCodes: "copyright,spam"},
+		{TargetType: model.ReportTargetUser, TargetID: 1, ReportCount: 1},
+	}, nil)
+
+	// 2. Exercise
+	totalCount, items, err := usecase.GetReportQueue("", 10, 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, totalCount)
+	assert.Equal(t, []string{"copyright", "spam"}, items[0].ReasonCodes)
+	assert.Equal(t, []string{}, items[1].ReasonCodes)
+
+	// 4. Teardown
+}
+
+// 通報の対象取得テスト
+func TestGetReportTarget(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	reportRepository := mockReportRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewReportUseCase(&postRepository, &userRepository, &reportRepository, NewAutocompleteIndexCache())
+	postRepository.On("FetchPostForModeration", 1).Return(&model.Post{ID: 1, UserID: 2, IsHidden: true}, nil)
+	reportRepository.On("FetchReports", model.ReportTargetPost, 1).Return([]*model.GetReportResult{{ReporterName: "reporter"}}, nil)
+	reportRepository.On("FetchModerationActions", model.ReportTargetPost, 1).Return([]*model.ModerationAction{{Action: model.ModerationActionAutoHide}}, nil)
+
+	// 2. Exercise
+	target, err := usecase.GetReportTarget(model.ReportTargetPost, 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, target.TargetUserID)
+	assert.True(t, target.IsHidden)
+	assert.Len(t, target.Reports, 1)
+	assert.Len(t, target.Actions, 1)
+
+	// 4. Teardown
+}
+
+// モデレーターの対応テスト
+func TestModerateTarget_success(t *testing.T) {
+	// 1. Setup
+	hidden, shown := true, false
+	cases := []struct {
+		label      string
+		targetType string
+		action     string
+		hidden     *bool
+		delete     bool
+		suspend    bool
+		eventType  string
+	}{
+		{"却下", model.ReportTargetPost, model.ModerationActionDismiss, &shown, false, false, ""},
+		{"非表示", model.ReportTargetComment, model.ModerationActionHide, &hidden, false, false, ""},
+		{"削除", model.ReportTargetPost, model.ModerationActionDelete, nil, true, false, model.DomainEventPostDeleted},
+		{"コメント削除", model.ReportTargetComment, model.ModerationActionDelete, nil, true, false, model.DomainEventCommentDeleted},
+		{"警告", model.ReportTargetComment, model.ModerationActionWarn, nil, false, false, ""},
+		{"利用停止", model.ReportTargetPost, model.ModerationActionSuspend, nil, false, true, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			postRepository := mockPostRepository{}
+			reportRepository := mockReportRepository{}
+			userRepository := mockUserRepository{}
+			usecase := NewReportUseCase(&postRepository, &userRepository, &reportRepository, NewAutocompleteIndexCache())
+			if c.targetType == model.ReportTargetPost {
+				postRepository.On("FetchPostForModeration", 1).Return(&model.Post{ID: 1, UserID: 2, IsHidden: true}, nil)
+			} else {
+				postRepository.On("FetchCommentByID", 1).Return(&model.Comment{ID: 1, UserID: 2}, nil)
+			}
+			var effect *model.ModerationEffect
+			reportRepository.On("CreateModerationAction", mock.MatchedBy(func(action *model.ModerationAction) bool {
+				return action.ModeratorID == 5 && action.Action == c.action && action.TargetUserID == 2 && action.Reason == "reason"
+			}), mock.AnythingOfType("*model.ModerationEffect"), true).Return(nil).Run(func(args mock.Arguments) {
+				effect = args.Get(1).(*model.ModerationEffect)
+			})
+
+			// 2. Exercise
+			err := usecase.ModerateTarget(5, c.targetType, 1, c.action, "reason")
+
+			// 3. Verify
+			assert.NoError(t, err)
+			postRepository.AssertExpectations(t)
+			reportRepository.AssertExpectations(t)
+			assert.Equal(t, c.hidden, effect.Hidden)
+			assert.Equal(t, c.delete, effect.Delete)
+			assert.Equal(t, c.suspend, effect.SuspendedAt != nil)
+			if c.eventType == "" {
+				assert.Empty(t, effect.Events)
+			} else {
+				assert.Len(t, effect.Events, 1)
+				assert.Equal(t, c.eventType, effect.Events[0].Type)
+			}
+		})
+	}
+
+	// 4. Teardown
+}
+
+func TestModerateTarget_error(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	reportRepository := mockReportRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewReportUseCase(&postRepository, &userRepository, &reportRepository, NewAutocompleteIndexCache())
+	postRepository.On("FetchPostForModeration", 1).Return(&model.Post{ID: 1, UserID: 2}, nil)
+	reportRepository.On("CreateModerationAction", mock.AnythingOfType("*model.ModerationAction"), mock.AnythingOfType("*model.ModerationEffect"), true).Return(errors.New("error"))
+
+	// 2. Exercise
+	err := usecase.ModerateTarget(5, model.ReportTargetPost, 1, model.ModerationActionHide, "reason")
+
+	// 3. Verify
+	assert.EqualError(t, err, "error")
+	reportRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestModerateTarget_error_notApplicable(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	reportRepository := mockReportRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewReportUseCase(&postRepository, &userRepository, &reportRepository, NewAutocompleteIndexCache())
+	userRepository.On("FetchByID", 1).Return(makeUserForRead(1), nil)
+
+	for _, action := range []string{model.ModerationActionHide, model.ModerationActionDelete} {
+		// 2. Exercise
+		err := usecase.ModerateTarget(5, model.ReportTargetUser, 1, action, "reason")
+
+		// 3. Verify
+		assert.Equal(t, ErrModerationActionNotApplicable, err, action)
+	}
+	reportRepository.AssertNotCalled(t, "CreateModerationAction", mock.Anything, mock.Anything, mock.Anything)
+
+	// 4. Teardown
+}
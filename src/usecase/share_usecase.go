@@ -0,0 +1,162 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// siteName サイト名
+const siteName = "Power Phrase"
+
+// shareDescriptionLength リンクプレビューの説明文の最大文字数
+const shareDescriptionLength = 120
+
+// ShareUseCase インターフェース
+type ShareUseCase interface {
+	// 投稿のリンクプレビュー用ページ情報取得
+	GetPostSharePage(postID int) (*model.SharePage, error)
+	// ユーザーのリンクプレビュー用ページ情報取得
+	GetUserSharePage(userID int) (*model.SharePage, error)
+	// まとめのリンクプレビュー用ページ情報取得
+	GetCollectionSharePage(collectionID int) (*model.SharePage, error)
+}
+
+// shareUseCase 構造体
+type shareUseCase struct {
+	repository.PostRepository
+	repository.CollectionRepository
+	repository.UserRepository
+}
+
+// NewShareUseCase ShareUseCaseを生成。
+func NewShareUseCase(postRepository repository.PostRepository, collectionRepository repository.CollectionRepository, userRepository repository.UserRepository) ShareUseCase {
+	return &shareUseCase{postRepository, collectionRepository, userRepository}
+}
+
+// GetPostSharePage 投稿のリンクプレビュー用ページ情報取得。画像には引用カードを使用する。
+// 存在しない投稿、非表示の投稿はErrPostNotFoundを返す。
+func (usecase *shareUseCase) GetPostSharePage(postID int) (*model.SharePage, error) {
+	post, err := usecase.PostRepository.FetchByID(postID, 0)
+	if err == repository.ErrNotFound {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	description := post.Detail
+	if description == "" {
+		description = post.Speaker + "の言葉"
+	}
+
+	return &model.SharePage{
+		Title:        fmt.Sprintf("「%s」%s | %s", post.Title, post.Speaker, siteName),
+		Description:  truncateRunes(description, shareDescriptionLength),
+		ImageURL:     apiURL(fmt.Sprintf("/api/v1/posts/%d/card.png", post.ID)),
+		CanonicalURL: PostPageURL(post.ID),
+		Type:         "article",
+		TwitterCard:  model.TwitterCardSummaryLargeImage,
+	}, nil
+}
+
+// GetUserSharePage ユーザーのリンクプレビュー用ページ情報取得。画像にはユーザー画像を使用する。
+// 存在しないユーザー、退会済みのユーザーはErrUserNotFoundを返す。
+func (usecase *shareUseCase) GetUserSharePage(userID int) (*model.SharePage, error) {
+	user, err := usecase.UserRepository.FetchByID(userID)
+	if err == repository.ErrNotFound {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	imageURL := ""
+	if user.ImageFilePath != "" {
+		imageURL = apiURL("/" + user.ImageFilePath)
+	}
+
+	return &model.SharePage{
+		Title:        fmt.Sprintf("%s | %s", user.Name, siteName),
+		Description:  fmt.Sprintf("%sさんが投稿した言葉の一覧です。", user.Name),
+		ImageURL:     imageURL,
+		CanonicalURL: UserPageURL(user.ID),
+		Type:         "profile",
+		TwitterCard:  model.TwitterCardSummary,
+	}, nil
+}
+
+// GetCollectionSharePage まとめのリンクプレビュー用ページ情報取得。
+// 非公開のまとめはErrCollectionNotFoundを返す。画像には表紙の投稿の引用カードを使用する。
+func (usecase *shareUseCase) GetCollectionSharePage(collectionID int) (*model.SharePage, error) {
+	collection, err := usecase.CollectionRepository.FetchCollectionByID(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection == nil || !collection.IsPublic {
+		return nil, ErrCollectionNotFound
+	}
+
+	description := collection.Description
+	if description == "" {
+		description = fmt.Sprintf("%sさんがまとめた%d件の言葉です。", collection.UserName, collection.PostCount)
+	}
+
+	imageURL := ""
+	twitterCard := model.TwitterCardSummary
+	coverPostID := collection.CoverPostID
+	if coverPostID == 0 {
+		coverPostID = collection.FirstPostID
+	}
+	if coverPostID > 0 {
+		imageURL = apiURL(fmt.Sprintf("/api/v1/posts/%d/card.png", coverPostID))
+		twitterCard = model.TwitterCardSummaryLargeImage
+	}
+
+	return &model.SharePage{
+		Title:        fmt.Sprintf("%s | %s", collection.Name, siteName),
+		Description:  truncateRunes(description, shareDescriptionLength),
+		ImageURL:     imageURL,
+		CanonicalURL: CollectionPageURL(collection.ID),
+		Type:         "website",
+		TwitterCard:  twitterCard,
+	}, nil
+}
+
+// PostPageURL フロントエンドの投稿詳細ページのURL
+func PostPageURL(postID int) string {
+	return frontendURL(fmt.Sprintf("/posts/%d", postID))
+}
+
+// UserPageURL フロントエンドのユーザーページのURL
+func UserPageURL(userID int) string {
+	return frontendURL(fmt.Sprintf("/users/%d", userID))
+}
+
+// CollectionPageURL フロントエンドのまとめページのURL
+func CollectionPageURL(collectionID int) string {
+	return frontendURL(fmt.Sprintf("/collections/%d", collectionID))
+}
+
+// frontendURL フロントエンドの絶対URL。環境変数FRONTEND_BASE_URLを基準とする。
+func frontendURL(path string) string {
+	return strings.TrimRight(os.Getenv("FRONTEND_BASE_URL"), "/") + path
+}
+
+// apiURL APIの絶対URL。環境変数API_BASE_URLを基準とする。
+func apiURL(path string) string {
+	return strings.TrimRight(os.Getenv("API_BASE_URL"), "/") + path
+}
+
+// truncateRunes 文字列を最大length文字に切り詰める。切り詰めた場合は末尾に「…」を付ける。
+func truncateRunes(text string, length int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= length {
+		return string(runes)
+	}
+	return string(runes[:length-1]) + "…"
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// 投稿のリンクプレビュー用ページ情報取得テスト
+func TestGetPostSharePage_success(t *testing.T) {
+	// 1. Setup
+	os.Setenv("API_BASE_URL", "https://api.example.com/")
+	os.Setenv("FRONTEND_BASE_URL", "https://www.example.com")
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewShareUseCase(&postRepository, &postRepository, &userRepository)
+	post := makeGetPostResult(1)
+	post.Title = "title1"
+	post.Speaker = "speaker1"
+	post.Detail = strings.Repeat("あ", 200)
+	postRepository.On("FetchByID", 1, 0).Return(post, nil)
+
+	// 2. Exercise
+	page, err := usecase.GetPostSharePage(1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, "「title1」speaker1 | Power Phrase", page.Title)
+	assert.Equal(t, shareDescriptionLength, len([]rune(page.Description)))
+	assert.True(t, strings.HasSuffix(page.Description, "…"))
+	assert.Equal(t, "https://api.example.com/api/v1/posts/1/card.png", page.ImageURL)
+	assert.Equal(t, "https://www.example.com/posts/1", page.CanonicalURL)
+	assert.Equal(t, model.TwitterCardSummaryLargeImage, page.TwitterCard)
+
+	// 4. Teardown
+	os.Unsetenv("API_BASE_URL")
+	os.Unsetenv("FRONTEND_BASE_URL")
+}
+
+func TestGetPostSharePage_success_noDetail(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewShareUseCase(&postRepository, &postRepository, &userRepository)
+	post := makeGetPostResult(1)
+	post.Speaker = "speaker1"
+	post.Detail = ""
+	postRepository.On("FetchByID", 1, 0).Return(post, nil)
+
+	// 2. Exercise
+	page, err := usecase.GetPostSharePage(1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, "speaker1の言葉", page.Description)
+
+	// 4. Teardown
+}
+
+func TestGetPostSharePage_error(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewShareUseCase(&postRepository, &postRepository, &userRepository)
+	postRepository.On("FetchByID", 1, 0).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	page, err := usecase.GetPostSharePage(1)
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, page)
+
+	// 4. Teardown
+}
+
+func TestGetPostSharePage_error_notFound(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewShareUseCase(&postRepository, &postRepository, &userRepository)
+	postRepository.On("FetchByID", 1, 0).Return(nil, repository.ErrNotFound)
+
+	// 2. Exercise
+	page, err := usecase.GetPostSharePage(1)
+
+	// 3. Verify
+	assert.Equal(t, ErrPostNotFound, err)
+	assert.Nil(t, page)
+
+	// 4. Teardown
+}
+
+// ユーザーのリンクプレビュー用ページ情報取得テスト
+func TestGetUserSharePage_success(t *testing.T) {
+	// 1. Setup
+	os.Setenv("API_BASE_URL", "https://api.example.com")
+	os.Setenv("FRONTEND_BASE_URL", "https://www.example.com")
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewShareUseCase(&postRepository, &postRepository, &userRepository)
+	user := makeUserForRead(1)
+	user.ImageFilePath = "images/1.png"
+	userRepository.On("FetchByID", 1).Return(user, nil)
+
+	// 2. Exercise
+	page, err := usecase.GetUserSharePage(1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, user.Name+" | Power Phrase", page.Title)
+	assert.Equal(t, "https://api.example.com/images/1.png", page.ImageURL)
+	assert.Equal(t, "https://www.example.com/users/1", page.CanonicalURL)
+	assert.Equal(t, model.TwitterCardSummary, page.TwitterCard)
+
+	// 4. Teardown
+	os.Unsetenv("API_BASE_URL")
+	os.Unsetenv("FRONTEND_BASE_URL")
+}
+
+func TestGetUserSharePage_error(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewShareUseCase(&postRepository, &postRepository, &userRepository)
+	userRepository.On("FetchByID", 1).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	page, err := usecase.GetUserSharePage(1)
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Nil(t, page)
+
+	// 4. Teardown
+}
+
+func TestGetUserSharePage_error_notFound(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewShareUseCase(&postRepository, &postRepository, &userRepository)
+	userRepository.On("FetchByID", 1).Return(nil, repository.ErrNotFound)
+
+	// 2. Exercise
+	page, err := usecase.GetUserSharePage(1)
+
+	// 3. Verify
+	assert.Equal(t, ErrUserNotFound, err)
+	assert.Nil(t, page)
+
+	// 4. Teardown
+}
+
+// まとめのリンクプレビュー用ページ情報取得テスト
+func TestGetCollectionSharePage_success(t *testing.T) {
+	// 1. Setup
+	os.Setenv("API_BASE_URL", "https://api.example.com")
+	os.Setenv("FRONTEND_BASE_URL", "https://www.example.com")
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewShareUseCase(&postRepository, &postRepository, &userRepository)
+	collection := makeGetCollectionResult(1, 1, true)
+	collection.Name = "朝読む言葉"
+	collection.PostCount = 3
+	collection.FirstPostID = 5
+	postRepository.On("FetchCollectionByID", 1).Return(collection, nil)
+
+	// 2. Exercise
+	page, err := usecase.GetCollectionSharePage(1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, "朝読む言葉 | Power Phrase", page.Title)
+	assert.Equal(t, "username1さんがまとめた3件の言葉です。", page.Description)
+	assert.Equal(t, "https://api.example.com/api/v1/posts/5/card.png", page.ImageURL)
+	assert.Equal(t, "https://www.example.com/collections/1", page.CanonicalURL)
+	assert.Equal(t, model.TwitterCardSummaryLargeImage, page.TwitterCard)
+
+	// 4. Teardown
+	os.Unsetenv("API_BASE_URL")
+	os.Unsetenv("FRONTEND_BASE_URL")
+}
+
+func TestGetCollectionSharePage_error_private(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewShareUseCase(&postRepository, &postRepository, &userRepository)
+	postRepository.On("FetchCollectionByID", 1).Return(makeGetCollectionResult(1, 1, false), nil)
+
+	// 2. Exercise
+	page, err := usecase.GetCollectionSharePage(1)
+
+	// 3. Verify
+	assert.Equal(t, ErrCollectionNotFound, err)
+	assert.Nil(t, page)
+
+	// 4. Teardown
+}
@@ -0,0 +1,168 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeText 比較用に文字列を正規化する。
+// Unicode正規化(NFKC)による全角半角の統一、小文字化、カタカナのひらがな化、ローマ字のひらがな化を行い、空白・記号を除去する。
+func normalizeText(text string) string {
+	return normalize(text, false)
+}
+
+// normalizePrefix 前方一致検索のクエリ用に文字列を正規化する。
+// 入力途中のローマ字(例：「matsus」の「s」)は末尾から取り除く。
+func normalizePrefix(text string) string {
+	normalized := normalize(text, true)
+	trimmed := strings.TrimRightFunc(normalized, func(r rune) bool {
+		return r >= 'a' && r <= 'z'
+	})
+	if trimmed == "" {
+		return normalized
+	}
+	return trimmed
+}
+
+// normalize 正規化処理本体。
+// prefixがfalseの場合は、語末の「n」を「ん」として扱う。
+func normalize(text string, prefix bool) string {
+	text = strings.ToLower(norm.NFKC.String(text))
+	text = katakanaToHiragana(text)
+	text = romajiToHiragana(text)
+	if !prefix {
+		text = finalNToHiragana(text)
+	}
+
+	var builder strings.Builder
+	for _, r := range text {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// finalNToHiragana ひらがなの直後にある語末の「n」を「ん」に変換する。
+func finalNToHiragana(text string) string {
+	runes := []rune(text)
+	for i, r := range runes {
+		if r != 'n' || i == 0 || !unicode.Is(unicode.Hiragana, runes[i-1]) {
+			continue
+		}
+		if i+1 == len(runes) || !unicode.IsLetter(runes[i+1]) {
+			runes[i] = 'ん'
+		}
+	}
+	return string(runes)
+}
+
+// katakanaToHiragana カタカナをひらがなに変換する。
+func katakanaToHiragana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 0x60
+		}
+		return r
+	}, text)
+}
+
+// romajiTable ローマ字とひらがなの対応表。
+var romajiTable = map[string]string{
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"sa": "さ", "si": "し", "su": "す", "se": "せ", "so": "そ",
+	"ta": "た", "ti": "ち", "tu": "つ", "te": "て", "to": "と",
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"ha": "は", "hi": "ひ", "hu": "ふ", "he": "へ", "ho": "ほ",
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"ya": "や", "yu": "ゆ", "yo": "よ",
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"wa": "わ", "wo": "を",
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"za": "ざ", "zi": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"da": "だ", "di": "ぢ", "du": "づ", "de": "で", "do": "ど",
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+	"fu": "ふ", "ji": "じ", "ja": "じゃ", "ju": "じゅ", "jo": "じょ",
+	"shi": "し", "sha": "しゃ", "shu": "しゅ", "sho": "しょ",
+	"chi": "ち", "cha": "ちゃ", "chu": "ちゅ", "cho": "ちょ",
+	"tsu": "つ",
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+}
+
+// romajiToHiragana 文字列中のローマ字をひらがなに変換する。変換できない文字はそのまま残す。
+func romajiToHiragana(text string) string {
+	var builder strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if r < 'a' || r > 'z' {
+			builder.WriteRune(r)
+			i++
+			continue
+		}
+
+		// 促音(例：「kk」→「っk」)
+		if i+1 < len(runes) && runes[i+1] == r && r != 'n' && !isRomajiVowel(r) {
+			builder.WriteString("っ")
+			i++
+			continue
+		}
+
+		// 撥音(例：「nn」→「ん」、「nni」→「んに」)
+		if r == 'n' && i+1 < len(runes) && runes[i+1] == 'n' {
+			builder.WriteString("ん")
+			if i+2 < len(runes) && (isRomajiVowel(runes[i+2]) || runes[i+2] == 'y') {
+				i++
+			} else {
+				i += 2
+			}
+			continue
+		}
+
+		// 長い綴りから優先して一致させる
+		matched := false
+		for length := 3; length >= 1; length-- {
+			if i+length > len(runes) {
+				continue
+			}
+			if kana, ok := romajiTable[string(runes[i:i+length])]; ok {
+				builder.WriteString(kana)
+				i += length
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		// 子音の前の「n」は「ん」とする
+		if r == 'n' && i+1 < len(runes) && !isRomajiVowel(runes[i+1]) && runes[i+1] != 'y' {
+			builder.WriteString("ん")
+			i++
+			continue
+		}
+
+		builder.WriteRune(r)
+		i++
+	}
+	return builder.String()
+}
+
+// isRomajiVowel ローマ字の母音であるかどうか。
+func isRomajiVowel(r rune) bool {
+	return strings.ContainsRune("aiueo", r)
+}
@@ -0,0 +1,175 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+	"golang.org/x/text/language"
+)
+
+// ErrTranslationForbidden 翻訳を変更する権限がない場合のエラー
+var ErrTranslationForbidden = errors.New("翻訳を変更する権限がありません。")
+
+// TranslationUseCase インターフェース
+type TranslationUseCase interface {
+	// 翻訳の登録または更新
+	SaveTranslation(postID, translatorID int, lang, title, detail string) error
+	// 翻訳一覧取得
+	GetTranslations(postID int) ([]*model.GetPostTranslationResult, error)
+	// 翻訳削除
+	DeleteTranslation(postID, loginUserID int, lang string) error
+}
+
+// translationUseCase 構造体
+type translationUseCase struct {
+	repository.PostRepository
+	repository.TranslationRepository
+	repository.UserRepository
+}
+
+// NewTranslationUseCase TranslationUseCaseを生成。
+func NewTranslationUseCase(postRepository repository.PostRepository, translationRepository repository.TranslationRepository, userRepository repository.UserRepository) TranslationUseCase {
+	return &translationUseCase{postRepository, translationRepository, userRepository}
+}
+
+// SaveTranslation 翻訳の登録または更新。同じ言語の翻訳がある場合は上書きする。
+// 既存の翻訳を上書きできるのは投稿者と既存の翻訳の翻訳者のみとし、それ以外はErrTranslationForbiddenを返す。
+// 存在しない投稿、非表示の投稿はErrPostNotFoundを返す。
+func (usecase *translationUseCase) SaveTranslation(postID, translatorID int, lang, title, detail string) error {
+	post, err := usecase.PostRepository.FetchByID(postID, 0)
+	if err == repository.ErrNotFound {
+		return ErrPostNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	lang = canonicalLanguage(lang)
+	if post.UserID != translatorID {
+		translations, err := usecase.TranslationRepository.FetchTranslations([]int{postID})
+		if err != nil {
+			return err
+		}
+		for _, translation := range translations {
+			if translation.Language == lang && translation.TranslatorID != translatorID {
+				return ErrTranslationForbidden
+			}
+		}
+	}
+
+	translation := &model.PostTranslation{
+		PostID:       postID,
+		Language:     lang,
+		Title:        title,
+		Detail:       detail,
+		TranslatorID: translatorID,
+	}
+	return usecase.TranslationRepository.SaveTranslation(translation)
+}
+
+// GetTranslations 翻訳一覧取得
+func (usecase *translationUseCase) GetTranslations(postID int) ([]*model.GetPostTranslationResult, error) {
+	return usecase.TranslationRepository.FetchTranslations([]int{postID})
+}
+
+// DeleteTranslation 翻訳削除。削除できるのは投稿者と管理者のみとし、それ以外はErrTranslationForbiddenを返す。
+// 存在しない投稿、非表示の投稿はErrPostNotFoundを返す。
+func (usecase *translationUseCase) DeleteTranslation(postID, loginUserID int, lang string) error {
+	post, err := usecase.PostRepository.FetchByID(postID, 0)
+	if err == repository.ErrNotFound {
+		return ErrPostNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if post.UserID != loginUserID {
+		user, err := usecase.UserRepository.FetchByID(loginUserID)
+		if err != nil && err != repository.ErrNotFound {
+			return err
+		}
+		if user == nil || user.Role != model.RoleAdmin {
+			return ErrTranslationForbidden
+		}
+	}
+
+	return usecase.TranslationRepository.DeleteTranslation(postID, canonicalLanguage(lang))
+}
+
+// canonicalLanguage 言語タグを正規の表記(例：en-us→en-US)にする。解析できない場合はそのまま返す。
+func canonicalLanguage(lang string) string {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return lang
+	}
+	return tag.String()
+}
+
+// baseLanguage 言語タグから地域などのサブタグを除いた言語(例：en-US→en)を返す。解析できない場合はそのまま返す。
+func baseLanguage(lang string) string {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return lang
+	}
+	base, _ := tag.Base()
+	return base.String()
+}
+
+// applyTranslations 希望言語の順に、各投稿に最も合う翻訳を設定する。
+// 原文の言語が最も合う場合や、希望言語に合う翻訳がない場合は設定しない。
+func applyTranslations(translationRepository repository.TranslationRepository, posts []*model.GetPostResult, preferredLanguages []string) error {
+	if len(posts) == 0 || len(preferredLanguages) == 0 {
+		return nil
+	}
+
+	preferred := []language.Tag{}
+	for _, lang := range preferredLanguages {
+		if tag, err := language.Parse(lang); err == nil {
+			preferred = append(preferred, tag)
+		}
+	}
+	if len(preferred) == 0 {
+		return nil
+	}
+
+	postIDs := make([]int, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	translations, err := translationRepository.FetchTranslations(postIDs)
+	if err != nil {
+		return err
+	}
+	translationsByPostID := map[int][]*model.GetPostTranslationResult{}
+	for _, translation := range translations {
+		translationsByPostID[translation.PostID] = append(translationsByPostID[translation.PostID], translation)
+	}
+
+	for _, post := range posts {
+		post.Translation = selectTranslation(post.Language, translationsByPostID[post.ID], preferred)
+	}
+	return nil
+}
+
+// selectTranslation 希望言語に最も合う翻訳を選ぶ。原文が最も合う場合はnilを返す。
+func selectTranslation(originalLanguage string, translations []*model.GetPostTranslationResult, preferred []language.Tag) *model.GetPostTranslationResult {
+	if len(translations) == 0 {
+		return nil
+	}
+	if originalLanguage == "" {
+		originalLanguage = model.DefaultLanguage
+	}
+
+	// 先頭を原文とし、一致しない場合も原文が選ばれるようにする
+	supported := []language.Tag{language.Make(originalLanguage)}
+	for _, translation := range translations {
+		supported = append(supported, language.Make(translation.Language))
+	}
+	_, index, confidence := language.NewMatcher(supported).Match(preferred...)
+	if confidence == language.No || index == 0 {
+		return nil
+	}
+	return translations[index-1]
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/text/language"
+)
+
+// 翻訳を生成
+func makeGetPostTranslationResult(id, postID int, lang string) *model.GetPostTranslationResult {
+	return &model.GetPostTranslationResult{
+		PostTranslation: model.PostTranslation{
+			ID:           id,
+			PostID:       postID,
+			Language:     lang,
+			Title:        "title_" + lang,
+			TranslatorID: 2,
+		},
+		TranslatorName: "username2",
+	}
+}
+
+// 翻訳登録テスト
+func TestSaveTranslation_success(t *testing.T) {
+	cases := []struct {
+		label        string
+		translatorID int
+		translations []*model.GetPostTranslationResult
+	}{
+		{"新規", 3, []*model.GetPostTranslationResult{makeGetPostTranslationResult(1, 1, "fr")}},
+		{"翻訳者による上書き", 2, []*model.GetPostTranslationResult{makeGetPostTranslationResult(1, 1, "en-US")}},
+		{"投稿者による上書き", 1, nil},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		postRepository := mockPostRepository{}
+		userRepository := mockUserRepository{}
+		usecase := NewTranslationUseCase(&postRepository, &postRepository, &userRepository)
+		postRepository.On("FetchByID", 1, 0).Return(makeGetPostResult(1), nil)
+		postRepository.On("FetchTranslations", []int{1}).Return(test.translations, nil)
+		postRepository.On("SaveTranslation", mock.MatchedBy(func(translation *model.PostTranslation) bool {
+			return translation.PostID == 1 && translation.TranslatorID == test.translatorID && translation.Language == "en-US" && translation.Title == "title"
+		})).Return(nil)
+
+		// 2. Exercise
+		err := usecase.SaveTranslation(1, test.translatorID, "en-us", "title", "detail")
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		postRepository.AssertCalled(t, "SaveTranslation", mock.Anything)
+
+		// 4. Teardown
+	}
+}
+
+func TestSaveTranslation_error_postNotFound(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewTranslationUseCase(&postRepository, &postRepository, &userRepository)
+	postRepository.On("FetchByID", 1, 0).Return(nil, repository.ErrNotFound)
+
+	// 2. Exercise
+	err := usecase.SaveTranslation(1, 2, "en", "title", "detail")
+
+	// 3. Verify
+	assert.Equal(t, ErrPostNotFound, err)
+	postRepository.AssertNotCalled(t, "SaveTranslation", mock.Anything)
+
+	// 4. Teardown
+}
+
+func TestSaveTranslation_error_forbidden(t *testing.T) {
+	// 1. Setup
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := NewTranslationUseCase(&postRepository, &postRepository, &userRepository)
+	postRepository.On("FetchByID", 1, 0).Return(makeGetPostResult(1), nil)
+	postRepository.On("FetchTranslations", []int{1}).Return([]*model.GetPostTranslationResult{makeGetPostTranslationResult(1, 1, "en")}, nil)
+
+	// 2. Exercise
+	err := usecase.SaveTranslation(1, 3, "en", "title", "detail")
+
+	// 3. Verify
+	assert.Equal(t, ErrTranslationForbidden, err)
+	postRepository.AssertNotCalled(t, "SaveTranslation", mock.Anything)
+
+	// 4. Teardown
+}
+
+// 翻訳削除テスト
+func TestDeleteTranslation_success(t *testing.T) {
+	cases := []struct {
+		label       string
+		loginUserID int
+		role        string
+	}{
+		{"投稿者", 1, model.RoleUser},
+		{"管理者", 3, model.RoleAdmin},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		postRepository := mockPostRepository{}
+		userRepository := mockUserRepository{}
+		usecase := NewTranslationUseCase(&postRepository, &postRepository, &userRepository)
+		postRepository.On("FetchByID", 1, 0).Return(makeGetPostResult(1), nil)
+		user := makeUserForRead(test.loginUserID)
+		user.Role = test.role
+		userRepository.On("FetchByID", test.loginUserID).Return(user, nil)
+		postRepository.On("DeleteTranslation", 1, "zh-Hant").Return(nil)
+
+		// 2. Exercise
+		err := usecase.DeleteTranslation(1, test.loginUserID, "zh-hant")
+
+		// 3. Verify
+		assert.NoError(t, err, test.label)
+		postRepository.AssertCalled(t, "DeleteTranslation", 1, "zh-Hant")
+
+		// 4. Teardown
+	}
+}
+
+func TestDeleteTranslation_error(t *testing.T) {
+	cases := []struct {
+		label       string
+		post        *model.GetPostResult
+		postErr     error
+		loginUserID int
+		role        string
+		expected    error
+	}{
+		{"投稿なし", nil, repository.ErrNotFound, 1, model.RoleUser, ErrPostNotFound},
+		{"翻訳者", makeGetPostResult(1), nil, 2, model.RoleUser, ErrTranslationForbidden},
+		{"モデレーター", makeGetPostResult(1), nil, 3, model.RoleModerator, ErrTranslationForbidden},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		postRepository := mockPostRepository{}
+		userRepository := mockUserRepository{}
+		usecase := NewTranslationUseCase(&postRepository, &postRepository, &userRepository)
+		postRepository.On("FetchByID", 1, 0).Return(test.post, test.postErr)
+		user := makeUserForRead(test.loginUserID)
+		user.Role = test.role
+		userRepository.On("FetchByID", test.loginUserID).Return(user, nil)
+
+		// 2. Exercise
+		err := usecase.DeleteTranslation(1, test.loginUserID, "en")
+
+		// 3. Verify
+		assert.Equal(t, test.expected, err, test.label)
+		postRepository.AssertNotCalled(t, "DeleteTranslation", mock.Anything, mock.Anything)
+
+		// 4. Teardown
+	}
+}
+
+// 投稿一覧の翻訳設定テスト
+func TestGetPosts_success_translation(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	reactionRepository := mockReactionRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &mockProhibitedWordRepository{}, &mockReportRepository{}, &reactionRepository, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	posts := []*model.GetPostResult{makeGetPostResult(1), makeGetPostResult(2)}
+	posts[0].Language = "ja"
+	posts[1].Language = "ja"
+	translation := makeGetPostTranslationResult(1, 1, "en")
+	repository.On("Fetch", 3, 1, "", 0, 0, false, "en").Return(2, posts, nil)
+	repository.On("FetchTranslations", []int{1, 2}).Return([]*model.GetPostTranslationResult{translation}, nil)
+	reactionRepository.On("FetchReactionCounts", []int{1, 2}, 0).Return([]*model.PostReactionCount{}, nil)
+
+	// 2. Exercise
+	_, actual, err := usecase.GetPosts(3, 1, "", 0, 0, false, "EN", []string{"en-GB"})
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, translation, actual[0].Translation)
+	assert.Nil(t, actual[1].Translation)
+
+	// 4. Teardown
+}
+
+// 翻訳選択テスト
+func TestSelectTranslation(t *testing.T) {
+	translations := []*model.GetPostTranslationResult{
+		makeGetPostTranslationResult(1, 1, "en"),
+		makeGetPostTranslationResult(2, 1, "zh-Hant"),
+	}
+	cases := []struct {
+		label     string
+		preferred []string
+		expected  *model.GetPostTranslationResult
+	}{
+		{"完全一致", []string{"en"}, translations[0]},
+		{"地域違い", []string{"en-US"}, translations[0]},
+		{"文字体系", []string{"zh-TW"}, translations[1]},
+		{"優先順", []string{"fr", "zh-Hant", "en"}, translations[1]},
+		{"原文の言語", []string{"ja", "en"}, nil},
+		{"該当なし", []string{"fr"}, nil},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		preferred := []language.Tag{}
+		for _, lang := range test.preferred {
+			preferred = append(preferred, language.Make(lang))
+		}
+
+		// 2. Exercise
+		actual := selectTranslation("ja", translations, preferred)
+
+		// 3. Verify
+		assert.Equal(t, test.expected, actual, test.label)
+
+		// 4. Teardown
+	}
+}
@@ -0,0 +1,204 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// defaultTrashRetentionDays 削除した投稿、コメント、ユーザーを保持する日数のデフォルト値
+const defaultTrashRetentionDays = 30
+
+var (
+	// ErrTrashItemNotFound 削除済みの投稿、コメントが存在しない場合のエラー。本人以外が削除したものも含む。
+	ErrTrashItemNotFound = errors.New("削除済みの対象が存在しません。")
+	// ErrRestorePeriodExpired 復元できる期間を過ぎている場合のエラー
+	ErrRestorePeriodExpired = errors.New("復元できる期間を過ぎています。")
+)
+
+// TrashUseCase インターフェース
+type TrashUseCase interface {
+	// ゴミ箱の一覧取得
+	GetTrash(userID int, targetType string, limit, page int) (totalCount int, items []*model.TrashItem, err error)
+	// 投稿の復元
+	RestorePost(id, userID int) error
+	// コメントの復元
+	RestoreComment(id, userID int) error
+	// 保持期間を過ぎたデータの完全削除
+	PurgeExpired() (*model.PurgeResult, error)
+}
+
+// trashUseCase 構造体
+type trashUseCase struct {
+	repository.TrashRepository
+	repository.UserRepository
+	autocompleteIndexes *AutocompleteIndexCache
+	clock               Clock
+}
+
+// NewTrashUseCase TrashUseCaseを生成。
+func NewTrashUseCase(trashRepository repository.TrashRepository, userRepository repository.UserRepository, autocompleteIndexes *AutocompleteIndexCache) TrashUseCase {
+	return &trashUseCase{trashRepository, userRepository, autocompleteIndexes, time.Now}
+}
+
+// trashRetention 削除した投稿、コメント、ユーザーを保持する期間。環境変数TRASH_RETENTION_DAYSで変更できる。
+// この期間内であれば復元でき、過ぎたものは完全削除の対象となる。
+func trashRetention() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil || days <= 0 {
+		days = defaultTrashRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// GetTrash ゴミ箱の一覧取得。復元できる期間内の削除済みの投稿、コメントを削除日時の新しい順に返す。
+// targetTypeにpost、commentを指定した場合はその種類のみ取得する。
+// 両方を取得する場合、件数と取得位置は投稿とコメントの合計で数える。
+func (usecase *trashUseCase) GetTrash(userID int, targetType string, limit, page int) (totalCount int, items []*model.TrashItem, err error) {
+	since := usecase.clock().Add(-trashRetention())
+	items = []*model.TrashItem{}
+
+	// 両方を取得する場合は、指定ページまでの件数をそれぞれ取得してから並べ替える
+	fetchLimit, fetchPage := limit, page
+	if targetType == "" {
+		fetchLimit, fetchPage = limit*page, 1
+	}
+
+	if targetType == "" || targetType == model.ReportTargetPost {
+		count, posts, err := usecase.TrashRepository.FetchDeletedPosts(userID, since, fetchLimit, fetchPage)
+		if err != nil {
+			return 0, nil, err
+		}
+		totalCount += count
+		for _, post := range posts {
+			items = append(items, newTrashItem(model.ReportTargetPost, post.ID, *post.DeletedAt))
+			items[len(items)-1].Post = post
+		}
+	}
+	if targetType == "" || targetType == model.ReportTargetComment {
+		count, comments, err := usecase.TrashRepository.FetchDeletedComments(userID, since, fetchLimit, fetchPage)
+		if err != nil {
+			return 0, nil, err
+		}
+		totalCount += count
+		for _, comment := range comments {
+			items = append(items, newTrashItem(model.ReportTargetComment, comment.ID, *comment.DeletedAt))
+			items[len(items)-1].Comment = comment
+		}
+	}
+
+	if targetType == "" {
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].DeletedAt.After(items[j].DeletedAt)
+		})
+		offset := limit * (page - 1)
+		if offset > len(items) {
+			offset = len(items)
+		}
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		items = items[offset:end]
+	}
+
+	return totalCount, items, nil
+}
+
+// newTrashItem ゴミ箱の項目を生成する
+func newTrashItem(targetType string, targetID int, deletedAt time.Time) *model.TrashItem {
+	return &model.TrashItem{
+		TargetType:      targetType,
+		TargetID:        targetID,
+		DeletedAt:       deletedAt,
+		RestorableUntil: deletedAt.Add(trashRetention()),
+	}
+}
+
+// RestorePost 投稿の復元。本人が削除した投稿のみ、復元できる期間内に限り復元できる。
+func (usecase *trashUseCase) RestorePost(id, userID int) error {
+	post, err := usecase.TrashRepository.FetchDeletedPostByID(id)
+	if err != nil {
+		return err
+	}
+	if post == nil || post.UserID != userID {
+		return ErrTrashItemNotFound
+	}
+	if !usecase.clock().Before(post.DeletedAt.Add(trashRetention())) {
+		return ErrRestorePeriodExpired
+	}
+
+	if err := usecase.TrashRepository.RestorePost(id, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventPostRestored, &model.DomainEventTarget{ID: id})}); err != nil {
+		return err
+	}
+	wakeDomainEventDispatcher()
+	usecase.autocompleteIndexes.invalidate()
+	quoteCards.invalidate(id)
+	return nil
+}
+
+// RestoreComment コメントの復元。本人が削除したコメントのみ、復元できる期間内に限り復元できる。
+// 投稿が削除されている場合も復元できるが、投稿を復元するまで表示されない。
+func (usecase *trashUseCase) RestoreComment(id, userID int) error {
+	comment, err := usecase.TrashRepository.FetchDeletedCommentByID(id)
+	if err != nil {
+		return err
+	}
+	if comment == nil || comment.UserID != userID {
+		return ErrTrashItemNotFound
+	}
+	if !usecase.clock().Before(comment.DeletedAt.Add(trashRetention())) {
+		return ErrRestorePeriodExpired
+	}
+
+	if err := usecase.TrashRepository.RestoreComment(id, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventCommentRestored, &model.DomainEventTarget{ID: id})}); err != nil {
+		return err
+	}
+	wakeDomainEventDispatcher()
+	return nil
+}
+
+// PurgeExpired 保持期間を過ぎた削除済みの投稿、コメント、ユーザーを完全に削除する。
+// 投稿、ユーザーはお気に入りなどの関連データも削除し、ユーザーはプロフィール画像のファイルも削除する。
+// 完全に削除した投稿、コメント、ユーザーごとにドメインイベントを発行する。
+func (usecase *trashUseCase) PurgeExpired() (*model.PurgeResult, error) {
+	before := usecase.clock().Add(-trashRetention())
+	result := &model.PurgeResult{}
+
+	var err error
+	if result.Posts, err = usecase.TrashRepository.PurgePosts(before); err != nil {
+		return nil, err
+	}
+	if result.Comments, err = usecase.TrashRepository.PurgeComments(before); err != nil {
+		return nil, err
+	}
+
+	users, err := usecase.UserRepository.FetchDeletedUsers(before)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if err := usecase.UserRepository.Purge(user.ID, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventUserPurged, &model.DomainEventTarget{ID: user.ID})}); err != nil {
+			return nil, err
+		}
+		if imagePath, ok := uploadedImagePath(user.ImageFilePath); ok {
+			os.Remove(filepath.Join(assetsDir, filepath.FromSlash(imagePath)))
+		}
+		result.Users++
+	}
+	if result.Posts > 0 || result.Comments > 0 || result.Users > 0 {
+		wakeDomainEventDispatcher()
+	}
+	if result.Posts > 0 || result.Users > 0 {
+		usecase.autocompleteIndexes.invalidate()
+	}
+
+	return result, nil
+}
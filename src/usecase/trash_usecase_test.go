@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// ゴミ箱の一覧取得テスト
+func TestGetTrash(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	since := current.Add(-trashRetention())
+	deletedAt := func(days int) *time.Time {
+		deletedAt := current.AddDate(0, 0, -days)
+		return &deletedAt
+	}
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := &trashUseCase{&postRepository, &userRepository, NewAutocompleteIndexCache(), fixedClock(current)}
+	postRepository.On("FetchDeletedPosts", 1, since, 4, 1).Return(3, []*model.Post{
+		{ID: 1, UserID: 1, DeletedAt: deletedAt(1)},
+		{ID: 2, UserID: 1, DeletedAt: deletedAt(3)},
+		{ID: 3, UserID: 1, DeletedAt: deletedAt(5)},
+	}, nil)
+	postRepository.On("FetchDeletedComments", 1, since, 4, 1).Return(2, []*model.Comment{
+		{ID: 1, UserID: 1, DeletedAt: deletedAt(2)},
+		{ID: 2, UserID: 1, DeletedAt: deletedAt(4)},
+	}, nil)
+
+	// 2. Exercise
+	totalCount, items, err := usecase.GetTrash(1, "", 2, 2)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 5, totalCount)
+	assert.Len(t, items, 2)
+	assert.Equal(t, model.ReportTargetPost, items[0].TargetType)
+	assert.Equal(t, 2, items[0].TargetID)
+	assert.Equal(t, model.ReportTargetComment, items[1].TargetType)
+	assert.Equal(t, 2, items[1].TargetID)
+	assert.Equal(t, deletedAt(3).AddDate(0, 0, defaultTrashRetentionDays), items[0].RestorableUntil)
+
+	// 4. Teardown
+}
+
+// 投稿の復元テスト
+func TestRestorePost(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	recent := current.AddDate(0, 0, -1)
+	expired := current.AddDate(0, 0, -defaultTrashRetentionDays)
+
+	cases := []struct {
+		label  string
+		id     int
+		userID int
+		err    error
+	}{
+		{"成功", 1, 1, nil},
+		{"本人以外", 1, 2, ErrTrashItemNotFound},
+		{"削除されていない", 2, 1, ErrTrashItemNotFound},
+		{"期限切れ", 3, 1, ErrRestorePeriodExpired},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			postRepository := mockPostRepository{}
+			userRepository := mockUserRepository{}
+			usecase := &trashUseCase{&postRepository, &userRepository, NewAutocompleteIndexCache(), fixedClock(current)}
+			postRepository.On("FetchDeletedPostByID", 1).Return(&model.Post{ID: 1, UserID: 1, DeletedAt: &recent}, nil)
+			postRepository.On("FetchDeletedPostByID", 2).Return(nil, nil)
+			postRepository.On("FetchDeletedPostByID", 3).Return(&model.Post{ID: 3, UserID: 1, DeletedAt: &expired}, nil)
+			postRepository.On("RestorePost", 1).Return(nil)
+
+			// 2. Exercise
+			err := usecase.RestorePost(c.id, c.userID)
+
+			// 3. Verify
+			assert.Equal(t, c.err, err)
+			if c.err != nil {
+				postRepository.AssertNotCalled(t, "RestorePost", c.id)
+				assert.Empty(t, postRepository.domainEvents)
+			} else {
+				assert.Len(t, postRepository.domainEvents, 1)
+				assert.Equal(t, model.DomainEventPostRestored, postRepository.domainEvents[0].Type)
+			}
+		})
+	}
+
+	// 4. Teardown
+}
+
+// コメントの復元テスト
+func TestRestoreComment(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	deletedAt := current.AddDate(0, 0, -1)
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := &trashUseCase{&postRepository, &userRepository, NewAutocompleteIndexCache(), fixedClock(current)}
+	postRepository.On("FetchDeletedCommentByID", 1).Return(&model.Comment{ID: 1, UserID: 1, DeletedAt: &deletedAt}, nil)
+	postRepository.On("RestoreComment", 1).Return(nil)
+
+	// 2. Exercise
+	err := usecase.RestoreComment(1, 1)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	postRepository.AssertExpectations(t)
+	assert.Len(t, postRepository.domainEvents, 1)
+	assert.Equal(t, model.DomainEventCommentRestored, postRepository.domainEvents[0].Type)
+
+	// 4. Teardown
+}
+
+// 保持期間を過ぎたデータの完全削除テスト
+func TestPurgeExpired(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	before := current.Add(-trashRetention())
+	setAssetsDir(t)
+	os.MkdirAll(filepath.Join(assetsDir, "images"), 0755)
+	imageFile := filepath.Join(assetsDir, "images", "101.png")
+	ioutil.WriteFile(imageFile, []byte("png"), 0644)
+
+	postRepository := mockPostRepository{}
+	userRepository := mockUserRepository{}
+	usecase := &trashUseCase{&postRepository, &userRepository, NewAutocompleteIndexCache(), fixedClock(current)}
+	postRepository.On("PurgePosts", before).Return(3, nil)
+	postRepository.On("PurgeComments", before).Return(2, nil)
+	userRepository.On("FetchDeletedUsers", before).Return([]*model.User{
+		{ID: 101, ImageFilePath: "images/101.png"},
+		// アップロードした画像のディレクトリ外のファイルは削除しない
+		{ID: 102, ImageFilePath: "../test.env"},
+	}, nil)
+	userRepository.On("Purge", 101).Return(nil)
+	userRepository.On("Purge", 102).Return(nil)
+
+	// 2. Exercise
+	result, err := usecase.PurgeExpired()
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, &model.PurgeResult{Posts: 3, Comments: 2, Users: 2}, result)
+	_, err = os.Stat(imageFile)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat("../test.env")
+	assert.NoError(t, err)
+	userRepository.AssertExpectations(t)
+	assert.Len(t, userRepository.domainEvents, 2)
+	assert.Equal(t, model.DomainEventUserPurged, userRepository.domainEvents[0].Type)
+
+	// 4. Teardown
+}
@@ -12,13 +12,18 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrUserSuspended 利用停止中のユーザーがログインしようとした場合のエラー
+var ErrUserSuspended = errors.New("このユーザーは利用停止中です。")
+
 // UserUseCase インターフェース
 type UserUseCase interface {
 	CreateUser(name, email, password, imageFilePath string) (userID int, token string, err error)
 	Login(email, password string) (userID int, token string, err error)
 	GetUser(id int) (*model.User, error)
-	UpdateUser(userID int, name, email, password, imageFilePath string) error
+	UpdateUser(userID int, name, email, password, imageFilePath, timeZone string) error
 	DeleteUser(id int) error
+	// 利用可能なユーザーか確認
+	CheckActiveUser(id int) error
 }
 
 // userUseCase 構造体
@@ -44,11 +49,13 @@ func (usecase *userUseCase) CreateUser(name, email, password, imageFilePath stri
 		Email:         email,
 		Password:      string(passwordHash),
 		ImageFilePath: imageFilePath,
+		Role:          model.RoleUser,
 	}
 
-	if err = usecase.UserRepository.Create(&user); err != nil {
+	if err = usecase.UserRepository.Create(&user, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventUserCreated, (*model.UserEventPayload)(&user))}); err != nil {
 		return 0, "", err
 	}
+	wakeDomainEventDispatcher()
 
 	// JWTトークン生成
 	token, err = createToken(&user)
@@ -56,7 +63,7 @@ func (usecase *userUseCase) CreateUser(name, email, password, imageFilePath stri
 	return user.ID, token, err
 }
 
-// Login ログイン
+// Login ログイン。利用停止中のユーザーはログインできない。
 func (usecase *userUseCase) Login(email, password string) (userID int, token string, err error) {
 	user, err := usecase.UserRepository.FetchByEmail(email)
 	if err != nil {
@@ -66,6 +73,9 @@ func (usecase *userUseCase) Login(email, password string) (userID int, token str
 	if err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
 		return 0, "", errors.New("メールアドレスまたはパスワードに誤りがあります。")
 	}
+	if user.SuspendedAt != nil {
+		return 0, "", ErrUserSuspended
+	}
 
 	// JWTトークン生成
 	token, err = createToken(user)
@@ -85,6 +95,7 @@ func createToken(user *model.User) (string, error) {
 	claims := token.Claims.(jwt.MapClaims)
 	claims["sub"] = user.ID
 	claims["name"] = user.Name
+	claims["role"] = user.Role
 	claims["exp"] = time.Now().Add(time.Hour * 72).Unix()
 
 	// Generate encoded token and send it as response.
@@ -102,8 +113,21 @@ func (usecase *userUseCase) GetUser(id int) (*model.User, error) {
 	return user, nil
 }
 
+// CheckActiveUser ログイン後のリクエストで、ユーザーが利用可能か確認する。
+// 利用停止中の場合はErrUserSuspended、存在しない場合や退会済みの場合はErrUserNotFoundを返す。
+func (usecase *userUseCase) CheckActiveUser(id int) error {
+	user, err := usecase.UserRepository.FetchByID(id)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if user.SuspendedAt != nil {
+		return ErrUserSuspended
+	}
+	return nil
+}
+
 // UpdateUser 更新
-func (usecase *userUseCase) UpdateUser(userID int, name, email, password, imageFilePath string) error {
+func (usecase *userUseCase) UpdateUser(userID int, name, email, password, imageFilePath, timeZone string) error {
 	oldUser, err := usecase.UserRepository.FetchByID(userID)
 	if err != nil {
 		return err
@@ -125,10 +149,12 @@ func (usecase *userUseCase) UpdateUser(userID int, name, email, password, imageF
 		Email:         email,
 		Password:      newPassword,
 		ImageFilePath: imageFilePath,
+		TimeZone:      timeZone,
 	}
-	if err := usecase.UserRepository.Update(&newUser); err != nil {
+	if err := usecase.UserRepository.Update(&newUser, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventUserUpdated, &model.DomainEventTarget{ID: userID})}); err != nil {
 		return err
 	}
+	wakeDomainEventDispatcher()
 
 	if imageFilePath != "" {
 		os.Remove("assets/" + oldUser.ImageFilePath)
@@ -139,8 +165,9 @@ func (usecase *userUseCase) UpdateUser(userID int, name, email, password, imageF
 
 // DeleteUser 削除
 func (usecase *userUseCase) DeleteUser(id int) error {
-	if err := usecase.UserRepository.Delete(id); err != nil {
+	if err := usecase.UserRepository.Delete(id, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventUserDeleted, &model.DomainEventTarget{ID: id})}); err != nil {
 		return err
 	}
+	wakeDomainEventDispatcher()
 	return nil
 }
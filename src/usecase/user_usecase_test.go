@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -17,11 +18,16 @@ import (
 // Mock
 type mockUserRepository struct {
 	mock.Mock
+	// 送信箱に保存されたドメインイベント
+	domainEvents []*model.DomainEvent
 }
 
-func (repository *mockUserRepository) Create(user *model.User) error {
+func (repository *mockUserRepository) Create(user *model.User, events []*model.DomainEvent) error {
 	args := repository.Called(user)
 	user.ID = 1
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
 	return args.Error(0)
 }
 
@@ -44,12 +50,79 @@ func (repository *mockUserRepository) FetchByID(id int) (*model.User, error) {
 	return user.(*model.User), args.Error(1)
 }
 
-func (repository *mockUserRepository) Update(user *model.User) error {
-	return repository.Called(user).Error(0)
+func (repository *mockUserRepository) Update(user *model.User, events []*model.DomainEvent) error {
+	args := repository.Called(user)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
+}
+
+func (repository *mockUserRepository) Delete(id int, events []*model.DomainEvent) error {
+	args := repository.Called(id)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
 }
 
-func (repository *mockUserRepository) Delete(id int) error {
-	return repository.Called(id).Error(0)
+func (repository *mockUserRepository) FetchDeletedUsers(before time.Time) ([]*model.User, error) {
+	args := repository.Called(before)
+	users, ok := args.Get(0).([]*model.User)
+	if ok {
+		return users, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (repository *mockUserRepository) Purge(id int, events []*model.DomainEvent) error {
+	args := repository.Called(id)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
+}
+
+func (repository *mockUserRepository) Follow(follow *model.Follow, events []*model.DomainEvent) error {
+	args := repository.Called(follow)
+	if args.Error(0) == nil {
+		repository.domainEvents = append(repository.domainEvents, events...)
+	}
+	return args.Error(0)
+}
+
+func (repository *mockUserRepository) Unfollow(followerID, followeeID int) error {
+	return repository.Called(followerID, followeeID).Error(0)
+}
+
+func (repository *mockUserRepository) FetchFollowers(userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error) {
+	args := repository.Called(userID, limit, page)
+	users, ok := args.Get(1).([]*model.GetFollowUserResult)
+	if ok {
+		return args.Int(0), users, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+func (repository *mockUserRepository) FetchFollowing(userID, limit, page int) (totalCount int, users []*model.GetFollowUserResult, err error) {
+	args := repository.Called(userID, limit, page)
+	users, ok := args.Get(1).([]*model.GetFollowUserResult)
+	if ok {
+		return args.Int(0), users, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+func (repository *mockUserRepository) FetchFollowCounts(userID, loginUserID int) (*model.FollowCounts, error) {
+	args := repository.Called(userID, loginUserID)
+	counts, ok := args.Get(0).(*model.FollowCounts)
+	if ok {
+		return counts, args.Error(1)
+	}
+
+	return nil, args.Error(1)
 }
 
 // 入力用ユーザー
@@ -94,6 +167,10 @@ func TestCreateUser_success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, id, userID)
 	assert.NotEqual(t, "", token)
+	assert.Len(t, repository.domainEvents, 1)
+	assert.Equal(t, model.DomainEventUserCreated, repository.domainEvents[0].Type)
+	payload, _ := json.Marshal(repository.domainEvents[0].Data)
+	assert.JSONEq(t, `{"id":1,"created_at":"0001-01-01T00:00:00Z","name":"`+user.Name+`","image_file_path":"`+user.ImageFilePath+`"}`, string(payload))
 
 	// 4. Teardown
 }
@@ -177,6 +254,61 @@ func TestLogin_error_repositoryError(t *testing.T) {
 	// 4. Teardown
 }
 
+func TestLogin_error_suspended(t *testing.T) {
+	// 1. Setup
+	repository := mockUserRepository{}
+	usecase := NewUserUseCase(&repository)
+	id := 1
+	userForInput := makeUserForInput(id)
+	userForRead := makeUserForRead(id)
+	suspendedAt := time.Now()
+	userForRead.SuspendedAt = &suspendedAt
+	repository.On("FetchByEmail", userForInput.Email).Return(userForRead, nil)
+
+	// 2. Exercise
+	userID, token, err := usecase.Login(userForInput.Email, userForInput.Password)
+
+	// 3. Verify
+	assert.Equal(t, ErrUserSuspended, err)
+	assert.Equal(t, 0, userID)
+	assert.Equal(t, "", token)
+
+	// 4. Teardown
+}
+
+// 利用可能なユーザーの確認テスト
+func TestCheckActiveUser(t *testing.T) {
+	suspendedAt := time.Now()
+	suspendedUser := makeUserForRead(2)
+	suspendedUser.SuspendedAt = &suspendedAt
+	cases := []struct {
+		label    string
+		id       int
+		user     *model.User
+		err      error
+		expected error
+	}{
+		{"利用可能", 1, makeUserForRead(1), nil, nil},
+		{"利用停止中", 2, suspendedUser, nil, ErrUserSuspended},
+		{"存在しない", 3, nil, errors.New("record not found"), ErrUserNotFound},
+	}
+
+	for _, test := range cases {
+		// 1. Setup
+		repository := mockUserRepository{}
+		usecase := NewUserUseCase(&repository)
+		repository.On("FetchByID", test.id).Return(test.user, test.err)
+
+		// 2. Exercise
+		err := usecase.CheckActiveUser(test.id)
+
+		// 3. Verify
+		assert.Equal(t, test.expected, err, test.label)
+
+		// 4. Teardown
+	}
+}
+
 // ユーザー詳細テスト
 func TestGetUser_success(t *testing.T) {
 	// 1. Setup
@@ -232,7 +364,7 @@ func TestUpdateUser_success(t *testing.T) {
 	repository.On("Update", mock.AnythingOfType("*model.User")).Return(nil)
 
 	// 2. Exercise
-	err := usecase.UpdateUser(id, user.Name, user.Email, user.Password, user.ImageFilePath)
+	err := usecase.UpdateUser(id, user.Name, user.Email, user.Password, user.ImageFilePath, user.TimeZone)
 
 	// 3. Verify
 	assert.NoError(t, err)
@@ -249,7 +381,7 @@ func TestUpdateUser_error(t *testing.T) {
 	repository.On("Update", mock.AnythingOfType("*model.User")).Return(errors.New("error"))
 
 	// 2. Exercise
-	err := usecase.UpdateUser(id, user.Name, user.Email, user.Password, user.ImageFilePath)
+	err := usecase.UpdateUser(id, user.Name, user.Email, user.Password, user.ImageFilePath, user.TimeZone)
 
 	// 3. Verify
 	assert.Error(t, err)
@@ -270,6 +402,7 @@ func TestDeleteUser_success(t *testing.T) {
 
 	// 3. Verify
 	assert.NoError(t, err)
+	assert.Equal(t, []*model.DomainEvent{model.NewDomainEvent(model.DomainEventUserDeleted, &model.DomainEventTarget{ID: id})}, repository.domainEvents)
 
 	// 4. Teardown
 }
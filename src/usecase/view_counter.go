@@ -0,0 +1,145 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+// defaultViewDedupWindowMinutes 同じ閲覧者による閲覧を1回と数える期間(分)のデフォルト値
+const defaultViewDedupWindowMinutes = 30
+
+// viewFlushRetryInterval データベースへの反映に失敗した場合に再度反映するまでの間隔
+const viewFlushRetryInterval = time.Minute
+
+// ViewCounter 投稿の閲覧数を集計する。
+// 閲覧の記録ではメモリ上に閲覧をためるのみ行い、重複の除外と閲覧数の加算はデータベースへの反映時にまとめて行う。
+// 重複の除外はデータベースで行うため、複数のサーバーで閲覧した場合も1回と数える。
+type ViewCounter struct {
+	repository repository.PostViewRepository
+	runJob     JobRunner
+	mutex      sync.Mutex
+	// データベースに反映していない閲覧
+	pending []*model.PostView
+	// データベースへの反映中、または再度の反映待ちの場合はtrue
+	flushing bool
+	// 実行中のデータベースへの反映
+	flushes sync.WaitGroup
+	// 期間を過ぎた閲覧の記録の定期的な削除の開始
+	expiryOnce sync.Once
+}
+
+// NewViewCounter ViewCounterを生成。APIサーバーで1つだけ生成し、全APIリクエストで共有する。
+func NewViewCounter(repository repository.PostViewRepository, runJob JobRunner) *ViewCounter {
+	return &ViewCounter{repository: repository, runJob: runJob}
+}
+
+// viewDedupWindow 同じ閲覧者による閲覧を1回と数える期間。環境変数VIEW_DEDUP_WINDOW_MINUTESで変更できる。
+func viewDedupWindow() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("VIEW_DEDUP_WINDOW_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = defaultViewDedupWindowMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// record 閲覧を記録する。viewerは閲覧者を識別する文字列、userIDはログインユーザーのID(ログインしていない場合は0)、currentは閲覧日時。
+// 同じ閲覧者による同じ投稿の閲覧は、期間(currentを期間の長さで区切ったもの)ごとに1回だけ数える。
+func (counter *ViewCounter) record(postID int, viewer string, userID int, current time.Time) {
+	counter.expiryOnce.Do(func() { go counter.expirePeriodically() })
+
+	counter.mutex.Lock()
+	counter.pending = append(counter.pending, &model.PostView{
+		CreatedAt:   current,
+		PostID:      postID,
+		Viewer:      viewer,
+		WindowStart: current.Truncate(viewDedupWindow()),
+		UserID:      userID,
+		Date:        current.Format(dailyPostDateFormat),
+	})
+	counter.mutex.Unlock()
+
+	counter.startFlush()
+}
+
+// startFlush 反映中でなければ、データベースへの反映を非同期に開始する。
+func (counter *ViewCounter) startFlush() {
+	counter.mutex.Lock()
+	if counter.flushing {
+		counter.mutex.Unlock()
+		return
+	}
+	counter.flushing = true
+	counter.flushes.Add(1)
+	counter.mutex.Unlock()
+
+	counter.runJob(func() {
+		defer counter.flushes.Done()
+		if err := counter.flush(); err != nil {
+			log.Printf("閲覧数の反映に失敗しました：%v", err)
+			// 失敗した閲覧は戻してあるため、時間をおいて再度反映する
+			time.AfterFunc(viewFlushRetryInterval, func() {
+				counter.mutex.Lock()
+				counter.flushing = false
+				counter.mutex.Unlock()
+				counter.startFlush()
+			})
+		}
+	})
+}
+
+// flush 反映していない閲覧をデータベースに反映する。反映中に記録された閲覧もまとめて反映する。
+// 反映に失敗した場合は、失敗した閲覧を反映していない閲覧に戻してエラーを返す。戻した閲覧の反映中はflushingをtrueのままとする。
+// 重複の除外はデータベースで行うため、同じ閲覧を再度反映しても二重には数えない。
+func (counter *ViewCounter) flush() error {
+	for {
+		counter.mutex.Lock()
+		if len(counter.pending) == 0 {
+			counter.flushing = false
+			counter.mutex.Unlock()
+			return nil
+		}
+		views := counter.pending
+		counter.pending = nil
+		counter.mutex.Unlock()
+
+		if err := counter.repository.SavePostViews(views); err != nil {
+			counter.mutex.Lock()
+			counter.pending = append(views, counter.pending...)
+			counter.mutex.Unlock()
+			return err
+		}
+	}
+}
+
+// Flush 反映していない閲覧をデータベースに反映する。実行中の反映の終了を待ってから反映する。
+// APIサーバーの停止時に、新しいリクエストを受け付けなくなってから呼び出す。
+func (counter *ViewCounter) Flush() error {
+	counter.flushes.Wait()
+	return counter.flush()
+}
+
+// expirePeriodically 重複の除外期間ごとに、期間を過ぎた閲覧の記録を削除する。
+func (counter *ViewCounter) expirePeriodically() {
+	ticker := time.NewTicker(viewDedupWindow())
+	defer ticker.Stop()
+
+	for current := range ticker.C {
+		if err := counter.expire(current); err != nil {
+			log.Printf("閲覧の記録の削除に失敗しました：%v", err)
+		}
+	}
+}
+
+// expire current時点で重複の除外期間を過ぎた閲覧の記録を削除する。
+// 反映が遅れた閲覧を数え直さないよう、直前の期間の記録は残す。
+func (counter *ViewCounter) expire(current time.Time) error {
+	window := viewDedupWindow()
+	return counter.repository.DeletePostViews(current.Truncate(window).Add(-window))
+}
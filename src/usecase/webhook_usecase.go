@@ -0,0 +1,368 @@
+// Package usecase Application Service層。
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+)
+
+const (
+	// webhookMaxAttempts 1つの配信を送信する回数の上限(初回を含む)
+	webhookMaxAttempts = 5
+	// webhookRetryInterval 最初の再送までの間隔。以降は再送ごとに2倍にする
+	webhookRetryInterval = time.Minute
+	// webhookRetryBatchSize 1回の再送処理で送信する配信の件数
+	webhookRetryBatchSize = 100
+	// webhookResponseBodyLimit 送信先の応答を読み捨てる上限のバイト数
+	webhookResponseBodyLimit = 64 * 1024
+	// webhookDeliveryLease 送信する配信を確保する時間。送信のタイムアウトより長くする
+	webhookDeliveryLease = time.Minute
+)
+
+var (
+	// ErrWebhookNotFound Webhook、配信が存在しない場合のエラー
+	ErrWebhookNotFound = errors.New("Webhookが存在しません。")
+	// ErrInvalidWebhookURL http、https以外のURLを指定した場合のエラー
+	ErrInvalidWebhookURL = errors.New("URL：http、httpsのURLを入力してください。")
+	// ErrInvalidWebhookEventType 存在しないイベントの種類を指定した場合のエラー
+	ErrInvalidWebhookEventType = errors.New("EventTypes：イベントの種類が正しくありません。")
+	// ErrWebhookAddressNotAllowed 送信先が内部のネットワークのIPアドレスの場合のエラー
+	ErrWebhookAddressNotAllowed = errors.New("送信先のIPアドレスには送信できません。")
+)
+
+// webhookHTTPClient Webhookの送信に使用するHTTPクライアント。
+// 内部のネットワークに送信させられないよう、接続先のIPアドレスを確認する。
+// プロキシを経由すると接続先がプロキシになり確認できないため、環境変数のプロキシの設定は使用しない。
+var webhookHTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		Proxy:               nil,
+		DialContext:         dialWebhookHost,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+}
+
+// WebhookUseCase インターフェース
+type WebhookUseCase interface {
+	// Webhook一覧取得
+	GetWebhooks() ([]*model.Webhook, error)
+	// Webhook登録
+	CreateWebhook(url string, eventTypes []string) (*model.Webhook, error)
+	// Webhook削除
+	DeleteWebhook(id int) error
+	// Webhookの配信履歴取得
+	GetWebhookDeliveries(webhookID, limit, page int) (totalCount int, deliveries []*model.WebhookDelivery, err error)
+	// Webhookの再配信
+	RedeliverWebhook(webhookID, deliveryID int) (*model.WebhookDelivery, error)
+	// 再送待ちのWebhookの配信の再送
+	RetryWebhookDeliveries() (int, error)
+	// ドメインイベントのWebhookへの配信(サブスクライバー)
+	HandleDomainEvent(event *model.OutboxEvent) error
+}
+
+// webhookUseCase 構造体
+type webhookUseCase struct {
+	repository.WebhookRepository
+	runJob JobRunner
+	clock  Clock
+}
+
+// NewWebhookUseCase WebhookUseCaseを生成。
+func NewWebhookUseCase(webhookRepository repository.WebhookRepository, runJob JobRunner) WebhookUseCase {
+	return &webhookUseCase{webhookRepository, runJob, time.Now}
+}
+
+// GetWebhooks Webhook一覧取得。署名の鍵は返さない。
+func (usecase *webhookUseCase) GetWebhooks() ([]*model.Webhook, error) {
+	webhooks, err := usecase.WebhookRepository.FetchWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+	}
+	return webhooks, nil
+}
+
+// CreateWebhook Webhook登録。署名の鍵を生成し、登録したWebhookとともに返す。
+func (usecase *webhookUseCase) CreateWebhook(webhookURL string, eventTypes []string) (*model.Webhook, error) {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, ErrInvalidWebhookURL
+	}
+	for _, eventType := range eventTypes {
+		if !isWebhookEventType(eventType) {
+			return nil, ErrInvalidWebhookEventType
+		}
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &model.Webhook{URL: webhookURL, EventTypes: strings.Join(eventTypes, ","), Secret: secret}
+	if err := usecase.WebhookRepository.CreateWebhook(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// isWebhookEventType Webhookで通知するイベントの種類の場合はtrueを返す
+func isWebhookEventType(eventType string) bool {
+	for _, t := range model.WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// newWebhookSecret 署名の鍵を生成する。
+func newWebhookSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// DeleteWebhook Webhook削除。配信履歴も削除する。
+func (usecase *webhookUseCase) DeleteWebhook(id int) error {
+	webhook, err := usecase.WebhookRepository.FetchWebhook(id)
+	if err != nil {
+		return err
+	}
+	if webhook == nil {
+		return ErrWebhookNotFound
+	}
+	return usecase.WebhookRepository.DeleteWebhook(id)
+}
+
+// GetWebhookDeliveries Webhookの配信履歴取得。新しい順に返す。
+func (usecase *webhookUseCase) GetWebhookDeliveries(webhookID, limit, page int) (totalCount int, deliveries []*model.WebhookDelivery, err error) {
+	webhook, err := usecase.WebhookRepository.FetchWebhook(webhookID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if webhook == nil {
+		return 0, nil, ErrWebhookNotFound
+	}
+	return usecase.WebhookRepository.FetchWebhookDeliveries(webhookID, limit, page)
+}
+
+// RedeliverWebhook Webhookの再配信。配信と同じ内容で新しい配信を登録して送信し、その結果を返す。
+// 送信に失敗した場合は通常の配信と同様に再送する。
+func (usecase *webhookUseCase) RedeliverWebhook(webhookID, deliveryID int) (*model.WebhookDelivery, error) {
+	webhook, err := usecase.WebhookRepository.FetchWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := usecase.WebhookRepository.FetchWebhookDelivery(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil || delivery == nil || delivery.WebhookID != webhookID {
+		return nil, ErrWebhookNotFound
+	}
+
+	redelivery, err := usecase.createWebhookDelivery(webhook, delivery.EventType, delivery.Payload)
+	if err != nil {
+		return nil, err
+	}
+	// 確保できなかった場合は再送の処理が送信するため、配信待ちのまま返す
+	if _, err := usecase.deliverWebhook(webhook, redelivery); err != nil {
+		return nil, err
+	}
+	return redelivery, nil
+}
+
+// RetryWebhookDeliveries 再送する日時を過ぎた配信待ちのWebhookの配信を再送する。送信した件数を返す。
+// 他の処理が確保済みの配信は送信しない。
+func (usecase *webhookUseCase) RetryWebhookDeliveries() (int, error) {
+	deliveries, err := usecase.WebhookRepository.FetchDueWebhookDeliveries(usecase.clock(), webhookRetryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	webhooks := map[int]*model.Webhook{}
+	count := 0
+	for _, delivery := range deliveries {
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			if webhook, err = usecase.WebhookRepository.FetchWebhook(delivery.WebhookID); err != nil {
+				return count, err
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+		if webhook == nil {
+			continue
+		}
+		sent, err := usecase.deliverWebhook(webhook, delivery)
+		if err != nil {
+			return count, err
+		}
+		if sent {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// HandleDomainEvent ドメインイベントを通知するWebhookへの配信を登録し、送信する。
+// イベントの処理を遅らせないよう送信は非同期に実行し、エラーはログに出力する。送信に失敗した配信は再送する。
+// 配信の登録に失敗した場合はエラーを返し、イベントごと再処理させる。
+func (usecase *webhookUseCase) HandleDomainEvent(event *model.OutboxEvent) error {
+	webhooks, err := usecase.WebhookRepository.FetchWebhooksByEventType(event.EventType)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(&model.WebhookPayload{ID: event.ID, Event: event.EventType, CreatedAt: event.CreatedAt, Data: json.RawMessage(event.Payload)})
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		webhook := webhook
+		delivery, err := usecase.createWebhookDelivery(webhook, event.EventType, string(payload))
+		if err != nil {
+			return err
+		}
+		usecase.runJob(func() {
+			if _, err := usecase.deliverWebhook(webhook, delivery); err != nil {
+				log.Printf("Webhookの配信に失敗しました：%v", err)
+			}
+		})
+	}
+	return nil
+}
+
+// createWebhookDelivery 配信待ちの配信を登録する。送信前に処理が中断した場合も再送されるよう、再送する日時を現在日時とする。
+// データベースで秒未満が切り上げられても直後に確保できるよう、秒未満は切り捨てる。
+func (usecase *webhookUseCase) createWebhookDelivery(webhook *model.Webhook, eventType, payload string) (*model.WebhookDelivery, error) {
+	nextAttemptAt := usecase.clock().Truncate(time.Second)
+	delivery := &model.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        model.WebhookDeliveryStatusPending,
+		NextAttemptAt: &nextAttemptAt,
+	}
+	if err := usecase.WebhookRepository.CreateWebhookDelivery(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// deliverWebhook 配信を確保してから送信し、結果を記録する。他の処理が確保済みの場合は送信せずfalseを返す。
+// 2xxの応答の場合は配信済みとし、それ以外は上限の回数に達するまで間隔を2倍ずつ空けて再送する。
+func (usecase *webhookUseCase) deliverWebhook(webhook *model.Webhook, delivery *model.WebhookDelivery) (bool, error) {
+	sentAt := usecase.clock()
+	claimed, err := usecase.WebhookRepository.ClaimWebhookDelivery(delivery.ID, sentAt, sentAt.Add(webhookDeliveryLease))
+	if err != nil || !claimed {
+		return false, err
+	}
+	delivery.Attempts++
+	delivery.ResponseCode, delivery.Error = 0, ""
+
+	request, err := http.NewRequest(http.MethodPost, webhook.URL, strings.NewReader(delivery.Payload))
+	if err == nil {
+		timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("User-Agent", "PowerPhrase-Webhook")
+		request.Header.Set("X-Webhook-Event", delivery.EventType)
+		request.Header.Set("X-Webhook-Delivery", strconv.Itoa(delivery.ID))
+		request.Header.Set("X-Webhook-Timestamp", timestamp)
+		request.Header.Set("X-Webhook-Signature", signWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+		var response *http.Response
+		if response, err = webhookHTTPClient.Do(request); err == nil {
+			io.Copy(ioutil.Discard, io.LimitReader(response.Body, webhookResponseBodyLimit))
+			response.Body.Close()
+			delivery.ResponseCode = response.StatusCode
+		}
+	}
+	if err != nil {
+		delivery.Error = truncateRunes(err.Error(), 256)
+	}
+
+	switch {
+	case delivery.ResponseCode >= 200 && delivery.ResponseCode < 300:
+		delivery.Status = model.WebhookDeliveryStatusSucceeded
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &sentAt
+	case delivery.Attempts >= webhookMaxAttempts:
+		delivery.Status = model.WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+	default:
+		nextAttemptAt := sentAt.Add(webhookRetryInterval << uint(delivery.Attempts-1))
+		delivery.Status = model.WebhookDeliveryStatusPending
+		delivery.NextAttemptAt = &nextAttemptAt
+	}
+	return true, usecase.WebhookRepository.UpdateWebhookDelivery(delivery)
+}
+
+// dialWebhookHost 送信先のホストを名前解決し、全てのIPアドレスが外部のネットワークの場合のみ接続する。
+// 名前解決と接続の間にIPアドレスが変わっても内部のネットワークに接続しないよう、確認したIPアドレスに接続する。
+func dialWebhookHost(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return nil, ErrWebhookAddressNotAllowed
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	for _, addr := range addrs {
+		var conn net.Conn
+		if conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(addr.IP.String(), port)); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// isPublicIP 外部のネットワークのIPアドレスの場合はtrueを返す。
+// ループバック、リンクローカル、プライベート、未指定、マルチキャストのアドレスはfalseを返す。
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast())
+}
+
+// signWebhookPayload 送信する内容の署名。「タイムスタンプ.内容」を署名の鍵でHMAC-SHA256により署名する。
+// 受信側は同じ方法で計算した値と比較し、タイムスタンプが古すぎないことを確認することで、改ざんと再送攻撃を防げる。
+func signWebhookPayload(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s.%s", timestamp, payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,481 @@
+package usecase
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock
+type mockWebhookRepository struct {
+	mock.Mock
+}
+
+func (repository *mockWebhookRepository) FetchWebhooks() ([]*model.Webhook, error) {
+	args := repository.Called()
+	webhooks, ok := args.Get(0).([]*model.Webhook)
+	if ok {
+		return webhooks, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (repository *mockWebhookRepository) FetchWebhook(id int) (*model.Webhook, error) {
+	args := repository.Called(id)
+	webhook, ok := args.Get(0).(*model.Webhook)
+	if ok {
+		return webhook, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (repository *mockWebhookRepository) FetchWebhooksByEventType(eventType string) ([]*model.Webhook, error) {
+	args := repository.Called(eventType)
+	webhooks, ok := args.Get(0).([]*model.Webhook)
+	if ok {
+		return webhooks, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (repository *mockWebhookRepository) CreateWebhook(webhook *model.Webhook) error {
+	return repository.Called(webhook).Error(0)
+}
+
+func (repository *mockWebhookRepository) DeleteWebhook(id int) error {
+	return repository.Called(id).Error(0)
+}
+
+func (repository *mockWebhookRepository) CreateWebhookDelivery(delivery *model.WebhookDelivery) error {
+	return repository.Called(delivery).Error(0)
+}
+
+func (repository *mockWebhookRepository) ClaimWebhookDelivery(id int, now, leaseUntil time.Time) (bool, error) {
+	args := repository.Called(id, now, leaseUntil)
+	return args.Bool(0), args.Error(1)
+}
+
+func (repository *mockWebhookRepository) UpdateWebhookDelivery(delivery *model.WebhookDelivery) error {
+	return repository.Called(delivery).Error(0)
+}
+
+func (repository *mockWebhookRepository) FetchWebhookDeliveries(webhookID, limit, page int) (totalCount int, deliveries []*model.WebhookDelivery, err error) {
+	args := repository.Called(webhookID, limit, page)
+	deliveries, ok := args.Get(1).([]*model.WebhookDelivery)
+	if ok {
+		return args.Int(0), deliveries, args.Error(2)
+	}
+
+	return args.Int(0), nil, args.Error(2)
+}
+
+func (repository *mockWebhookRepository) FetchWebhookDelivery(id int) (*model.WebhookDelivery, error) {
+	args := repository.Called(id)
+	delivery, ok := args.Get(0).(*model.WebhookDelivery)
+	if ok {
+		return delivery, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (repository *mockWebhookRepository) FetchDueWebhookDeliveries(before time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	args := repository.Called(before, limit)
+	deliveries, ok := args.Get(0).([]*model.WebhookDelivery)
+	if ok {
+		return deliveries, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+// webhookReceiver Webhookの受信を記録するテスト用のサーバー
+type webhookReceiver struct {
+	*httptest.Server
+	requests []*http.Request
+	bodies   []string
+}
+
+// newWebhookReceiver statusCodeを応答するテスト用のサーバーを起動する。
+// テスト用のサーバーはループバックアドレスで待ち受けるため、送信先のIPアドレスを確認しないHTTPクライアントに差し替える。
+func newWebhookReceiver(t *testing.T, statusCode int) *webhookReceiver {
+	original := webhookHTTPClient
+	webhookHTTPClient = &http.Client{Timeout: 10 * time.Second}
+	t.Cleanup(func() { webhookHTTPClient = original })
+
+	receiver := &webhookReceiver{}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		receiver.requests = append(receiver.requests, r)
+		receiver.bodies = append(receiver.bodies, string(body))
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver
+}
+
+// timePtr 日時のポインタ
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// Webhook登録テスト
+func TestCreateWebhook(t *testing.T) {
+	// 1. Setup
+	cases := []struct {
+		label      string
+		url        string
+		eventTypes []string
+		err        error
+	}{
+		{"success", "https://example.com/hook", []string{model.WebhookEventPostCreated, model.WebhookEventCommentCreated}, nil},
+		{"http以外", "ftp://example.com/hook", []string{model.WebhookEventPostCreated}, ErrInvalidWebhookURL},
+		{"ホストなし", "https:///hook", []string{model.WebhookEventPostCreated}, ErrInvalidWebhookURL},
+		{"存在しないイベント", "https://example.com/hook", []string{"post.deleted"}, ErrInvalidWebhookEventType},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			repository := mockWebhookRepository{}
+			usecase := NewWebhookUseCase(&repository, runSynchronously)
+			repository.On("CreateWebhook", mock.AnythingOfType("*model.Webhook")).Return(nil)
+
+			// 2. Exercise
+			webhook, err := usecase.CreateWebhook(c.url, c.eventTypes)
+
+			// 3. Verify
+			assert.Equal(t, c.err, err)
+			if c.err != nil {
+				repository.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+				return
+			}
+			assert.Equal(t, "post.created,comment.created", webhook.EventTypes)
+			assert.Len(t, webhook.Secret, 64)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// Webhook一覧取得テスト
+func TestGetWebhooks_success(t *testing.T) {
+	// 1. Setup
+	repository := mockWebhookRepository{}
+	usecase := NewWebhookUseCase(&repository, runSynchronously)
+	repository.On("FetchWebhooks").Return([]*model.Webhook{{ID: 1, URL: "https://example.com/hook", Secret: "secret"}}, nil)
+
+	// 2. Exercise
+	webhooks, err := usecase.GetWebhooks()
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+	assert.Empty(t, webhooks[0].Secret)
+
+	// 4. Teardown
+}
+
+// ドメインイベントのWebhookへの配信テスト
+func TestHandleDomainEvent_success(t *testing.T) {
+	// 1. Setup
+	sentAt := time.Date(2020, 12, 31, 16, 0, 0, 0, time.UTC)
+	receiver := newWebhookReceiver(t, http.StatusNoContent)
+
+	repository := mockWebhookRepository{}
+	usecase := &webhookUseCase{&repository, runSynchronously, fixedClock(sentAt)}
+	webhook := &model.Webhook{ID: 1, URL: receiver.URL, EventTypes: model.WebhookEventCommentCreated, Secret: "secret"}
+	repository.On("FetchWebhooksByEventType", model.WebhookEventCommentCreated).Return([]*model.Webhook{webhook}, nil)
+	repository.On("CreateWebhookDelivery", mock.AnythingOfType("*model.WebhookDelivery")).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.WebhookDelivery).ID = 7
+	}).Return(nil)
+	repository.On("ClaimWebhookDelivery", 7, sentAt, sentAt.Add(webhookDeliveryLease)).Return(true, nil)
+	var delivery *model.WebhookDelivery
+	repository.On("UpdateWebhookDelivery", mock.AnythingOfType("*model.WebhookDelivery")).Run(func(args mock.Arguments) {
+		delivery = args.Get(0).(*model.WebhookDelivery)
+	}).Return(nil)
+
+	event := &model.OutboxEvent{ID: 9, CreatedAt: sentAt, EventType: model.DomainEventCommentCreated, Payload: `{"id":3,"post_id":2,"body":"body"}`}
+
+	// 2. Exercise
+	err := usecase.HandleDomainEvent(event)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Len(t, receiver.requests, 1)
+	request, body := receiver.requests[0], receiver.bodies[0]
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	assert.Equal(t, model.WebhookEventCommentCreated, request.Header.Get("X-Webhook-Event"))
+	assert.Equal(t, "7", request.Header.Get("X-Webhook-Delivery"))
+	assert.Equal(t, timestamp, request.Header.Get("X-Webhook-Timestamp"))
+	assert.Equal(t, signWebhookPayload("secret", timestamp, body), request.Header.Get("X-Webhook-Signature"))
+	assert.JSONEq(t, `{"id":9,"event":"comment.created","created_at":"2020-12-31T16:00:00Z","data":{"id":3,"post_id":2,"body":"body"}}`, body)
+
+	assert.Equal(t, model.WebhookDeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, delivery.ResponseCode)
+	assert.Equal(t, sentAt, *delivery.DeliveredAt)
+	assert.Nil(t, delivery.NextAttemptAt)
+
+	// 4. Teardown
+}
+
+func TestHandleDomainEvent_error(t *testing.T) {
+	// 1. Setup
+	repository := mockWebhookRepository{}
+	usecase := NewWebhookUseCase(&repository, runSynchronously)
+	repository.On("FetchWebhooksByEventType", model.DomainEventPostCreated).Return([]*model.Webhook{{ID: 1, URL: "https://example.com/hook"}}, nil)
+	repository.On("CreateWebhookDelivery", mock.AnythingOfType("*model.WebhookDelivery")).Return(errors.New("error"))
+
+	// 2. Exercise
+	err := usecase.HandleDomainEvent(&model.OutboxEvent{ID: 1, EventType: model.DomainEventPostCreated, Payload: "{}"})
+
+	// 3. Verify
+	assert.Error(t, err)
+	repository.AssertNotCalled(t, "UpdateWebhookDelivery", mock.Anything)
+
+	// 4. Teardown
+}
+
+// 署名テスト
+func TestSignWebhookPayload(t *testing.T) {
+	// 2. Exercise
+	signature := signWebhookPayload("secret", "1609430400", `{"event":"post.created"}`)
+
+	// 3. Verify
+	assert.Equal(t, "sha256=40b50d87e1c4b94e55e646aaa025b92d342806d2058c9337c983d96a9ab0b927", signature)
+}
+
+// 送信失敗時の再送テスト
+func TestDeliverWebhook_retry(t *testing.T) {
+	// 1. Setup
+	sentAt := time.Date(2020, 12, 31, 16, 0, 0, 0, time.UTC)
+	receiver := newWebhookReceiver(t, http.StatusInternalServerError)
+
+	cases := []struct {
+		label         string
+		attempts      int
+		status        string
+		nextAttemptAt *time.Time
+	}{
+		{"初回", 0, model.WebhookDeliveryStatusPending, timePtr(sentAt.Add(time.Minute))},
+		{"3回目", 2, model.WebhookDeliveryStatusPending, timePtr(sentAt.Add(4 * time.Minute))},
+		{"上限", webhookMaxAttempts - 1, model.WebhookDeliveryStatusFailed, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			repository := mockWebhookRepository{}
+			usecase := &webhookUseCase{&repository, runSynchronously, fixedClock(sentAt)}
+			repository.On("ClaimWebhookDelivery", 1, sentAt, sentAt.Add(webhookDeliveryLease)).Return(true, nil)
+			repository.On("UpdateWebhookDelivery", mock.AnythingOfType("*model.WebhookDelivery")).Return(nil)
+			webhook := &model.Webhook{ID: 1, URL: receiver.URL, Secret: "secret"}
+			delivery := &model.WebhookDelivery{ID: 1, WebhookID: 1, Payload: "{}", Attempts: c.attempts}
+
+			// 2. Exercise
+			sent, err := usecase.deliverWebhook(webhook, delivery)
+
+			// 3. Verify
+			assert.NoError(t, err)
+			assert.True(t, sent)
+			assert.Equal(t, c.status, delivery.Status)
+			assert.Equal(t, c.attempts+1, delivery.Attempts)
+			assert.Equal(t, http.StatusInternalServerError, delivery.ResponseCode)
+			assert.Equal(t, c.nextAttemptAt, delivery.NextAttemptAt)
+			assert.Nil(t, delivery.DeliveredAt)
+		})
+	}
+
+	// 4. Teardown
+}
+
+func TestDeliverWebhook_error_connection(t *testing.T) {
+	// 1. Setup
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	receiver.Close()
+	repository := mockWebhookRepository{}
+	usecase := &webhookUseCase{&repository, runSynchronously, time.Now}
+	repository.On("ClaimWebhookDelivery", 1, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(true, nil)
+	repository.On("UpdateWebhookDelivery", mock.AnythingOfType("*model.WebhookDelivery")).Return(nil)
+	webhook := &model.Webhook{ID: 1, URL: receiver.URL, Secret: "secret"}
+	delivery := &model.WebhookDelivery{ID: 1, WebhookID: 1, Payload: "{}"}
+
+	// 2. Exercise
+	_, err := usecase.deliverWebhook(webhook, delivery)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, model.WebhookDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 0, delivery.ResponseCode)
+	assert.NotEmpty(t, delivery.Error)
+	assert.NotNil(t, delivery.NextAttemptAt)
+
+	// 4. Teardown
+}
+
+func TestDeliverWebhook_error_privateAddress(t *testing.T) {
+	// 1. Setup
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+	repository := mockWebhookRepository{}
+	usecase := &webhookUseCase{&repository, runSynchronously, time.Now}
+	repository.On("ClaimWebhookDelivery", 1, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(true, nil)
+	repository.On("UpdateWebhookDelivery", mock.AnythingOfType("*model.WebhookDelivery")).Return(nil)
+	webhook := &model.Webhook{ID: 1, URL: receiver.URL, Secret: "secret"}
+	delivery := &model.WebhookDelivery{ID: 1, WebhookID: 1, Payload: "{}"}
+
+	// 2. Exercise
+	_, err := usecase.deliverWebhook(webhook, delivery)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivery.ResponseCode)
+	assert.Contains(t, delivery.Error, ErrWebhookAddressNotAllowed.Error())
+
+	// 4. Teardown
+}
+
+// 送信先のIPアドレスの確認テスト
+func TestIsPublicIP(t *testing.T) {
+	cases := []struct {
+		ip       string
+		expected bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.1", false},
+		{"172.16.0.1", false},
+		{"192.168.0.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, c := range cases {
+		// 2. Exercise
+		actual := isPublicIP(net.ParseIP(c.ip))
+
+		// 3. Verify
+		assert.Equal(t, c.expected, actual, c.ip)
+	}
+}
+
+// 再配信テスト
+func TestRedeliverWebhook(t *testing.T) {
+	// 1. Setup
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	webhook := &model.Webhook{ID: 1, URL: receiver.URL, Secret: "secret"}
+	delivery := &model.WebhookDelivery{ID: 5, WebhookID: 1, EventType: model.WebhookEventPostCreated, Payload: `{"event":"post.created"}`, Status: model.WebhookDeliveryStatusFailed}
+
+	cases := []struct {
+		label      string
+		webhookID  int
+		deliveryID int
+		err        error
+	}{
+		{"success", 1, 5, nil},
+		{"webhookNotFound", 2, 5, ErrWebhookNotFound},
+		{"deliveryNotFound", 1, 6, ErrWebhookNotFound},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			repository := mockWebhookRepository{}
+			usecase := NewWebhookUseCase(&repository, runSynchronously)
+			repository.On("FetchWebhook", 1).Return(webhook, nil)
+			repository.On("FetchWebhook", 2).Return(nil, nil)
+			repository.On("FetchWebhookDelivery", 5).Return(delivery, nil)
+			repository.On("FetchWebhookDelivery", 6).Return(nil, nil)
+			repository.On("CreateWebhookDelivery", mock.MatchedBy(func(redelivery *model.WebhookDelivery) bool {
+				return redelivery.WebhookID == 1 && redelivery.Payload == delivery.Payload
+			})).Return(nil)
+			repository.On("ClaimWebhookDelivery", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(true, nil)
+			repository.On("UpdateWebhookDelivery", mock.AnythingOfType("*model.WebhookDelivery")).Return(nil)
+
+			// 2. Exercise
+			redelivery, err := usecase.RedeliverWebhook(c.webhookID, c.deliveryID)
+
+			// 3. Verify
+			assert.Equal(t, c.err, err)
+			if c.err != nil {
+				assert.Nil(t, redelivery)
+				repository.AssertNotCalled(t, "CreateWebhookDelivery", mock.Anything)
+				return
+			}
+			assert.Equal(t, model.WebhookDeliveryStatusSucceeded, redelivery.Status)
+			assert.Equal(t, model.WebhookEventPostCreated, redelivery.EventType)
+			assert.Equal(t, http.StatusOK, redelivery.ResponseCode)
+			assert.Equal(t, []string{delivery.Payload}, receiver.bodies[len(receiver.bodies)-1:])
+		})
+	}
+
+	// 4. Teardown
+}
+
+// 再送テスト
+func TestRetryWebhookDeliveries_success(t *testing.T) {
+	// 1. Setup
+	current := time.Date(2020, 12, 31, 16, 0, 0, 0, time.UTC)
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	repository := mockWebhookRepository{}
+	usecase := &webhookUseCase{&repository, runSynchronously, fixedClock(current)}
+	deliveries := []*model.WebhookDelivery{
+		{ID: 1, WebhookID: 1, Payload: "{}", Attempts: 1},
+		{ID: 2, WebhookID: 1, Payload: "{}", Attempts: 2},
+		{ID: 3, WebhookID: 1, Payload: "{}", Attempts: 1},
+	}
+	repository.On("FetchDueWebhookDeliveries", current, webhookRetryBatchSize).Return(deliveries, nil)
+	repository.On("ClaimWebhookDelivery", 1, current, current.Add(webhookDeliveryLease)).Return(true, nil)
+	repository.On("ClaimWebhookDelivery", 2, current, current.Add(webhookDeliveryLease)).Return(true, nil)
+	// 他の処理が確保済みの配信は送信しない
+	repository.On("ClaimWebhookDelivery", 3, current, current.Add(webhookDeliveryLease)).Return(false, nil)
+	repository.On("FetchWebhook", 1).Return(&model.Webhook{ID: 1, URL: receiver.URL, Secret: "secret"}, nil).Once()
+	repository.On("UpdateWebhookDelivery", mock.AnythingOfType("*model.WebhookDelivery")).Return(nil)
+
+	// 2. Exercise
+	count, err := usecase.RetryWebhookDeliveries()
+
+	// 3. Verify
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, receiver.requests, 2)
+	assert.Equal(t, 3, deliveries[1].Attempts)
+	assert.Equal(t, 1, deliveries[2].Attempts)
+	repository.AssertExpectations(t)
+	repository.AssertNumberOfCalls(t, "UpdateWebhookDelivery", 2)
+
+	// 4. Teardown
+}
+
+func TestRetryWebhookDeliveries_error(t *testing.T) {
+	// 1. Setup
+	repository := mockWebhookRepository{}
+	usecase := NewWebhookUseCase(&repository, runSynchronously)
+	repository.On("FetchDueWebhookDeliveries", mock.Anything, webhookRetryBatchSize).Return(nil, errors.New("error"))
+
+	// 2. Exercise
+	count, err := usecase.RetryWebhookDeliveries()
+
+	// 3. Verify
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+
+	// 4. Teardown
+}
@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/k-kazuya0926/power-phrase2-api/domain/repository"
+	"golang.org/x/text/unicode/norm"
+)
+
+// wordFilterTTL 禁止語フィルターのキャッシュの有効期間。他のサーバーで更新された禁止語一覧もこの期間内に反映される。
+const wordFilterTTL = 5 * time.Minute
+
+// wordMaskRune 伏せ字に使用する文字
+const wordMaskRune = '*'
+
+// ProhibitedWordError 登録を拒否する禁止語を含む場合のエラー
+type ProhibitedWordError struct {
+	// 禁止語を含む項目名
+	Fields []string
+}
+
+// Error エラーメッセージ。項目ごとに改行で区切る。
+func (err *ProhibitedWordError) Error() string {
+	messages := make([]string, 0, len(err.Fields))
+	for _, field := range err.Fields {
+		messages = append(messages, fmt.Sprintf("%s：使用できない語句が含まれています。", field))
+	}
+	return strings.Join(messages, "\n")
+}
+
+// filterRune 正規化後の1文字と、元の文字列での位置(ルーン単位、endは含まない)。
+type filterRune struct {
+	r          rune
+	start, end int
+}
+
+// normalizeForFilter 禁止語の検出用に文字列を正規化する。
+// normalizeTextと同様に全角半角の統一、小文字化、カタカナのひらがな化、空白・記号の除去を行う。
+// 伏せ字にする位置を特定するため、正規化後の各文字に元の文字列での位置を持たせる。
+// ローマ字は英単語と区別できないため変換しない。
+func normalizeForFilter(text string) []filterRune {
+	var normalized []filterRune
+	for i, r := range []rune(text) {
+		converted := katakanaToHiragana(strings.ToLower(norm.NFKC.String(string(r))))
+		for _, c := range converted {
+			// 半角カタカナの濁点・半濁点は直前の文字と合成する(例：「ｶﾞ」→「が」)
+			if c == '\u3099' || c == '\u309a' {
+				if len(normalized) > 0 {
+					last := &normalized[len(normalized)-1]
+					if composed := []rune(norm.NFC.String(string([]rune{last.r, c}))); len(composed) == 1 {
+						last.r = composed[0]
+						last.end = i + 1
+					}
+				}
+				continue
+			}
+			if unicode.IsSpace(c) || unicode.IsPunct(c) || unicode.IsSymbol(c) {
+				continue
+			}
+			normalized = append(normalized, filterRune{r: c, start: i, end: i + 1})
+		}
+	}
+	return normalized
+}
+
+// normalizeProhibitedWord 禁止語を検出用に正規化する
+func normalizeProhibitedWord(word string) string {
+	var builder strings.Builder
+	for _, r := range normalizeForFilter(word) {
+		builder.WriteRune(r.r)
+	}
+	return builder.String()
+}
+
+// wordFilterNode Aho-Corasickオートマトンのノード。
+type wordFilterNode struct {
+	next map[rune]int
+	// 一致しなかった場合の遷移先
+	fail int
+	// このノードで検出される禁止語(失敗遷移先で検出されるものも含む)
+	outputs []*model.ProhibitedWord
+}
+
+// wordFilter 禁止語の検出に使用するAho-Corasickオートマトン。
+// 禁止語の数によらず、文字列の長さに比例する時間で全ての禁止語を検出する。
+type wordFilter struct {
+	nodes   []*wordFilterNode
+	builtAt time.Time
+}
+
+// wordMatch 検出した禁止語と、元の文字列での位置(ルーン単位、endは含まない)。
+type wordMatch struct {
+	word       *model.ProhibitedWord
+	start, end int
+}
+
+// newWordFilter 禁止語一覧からフィルターを構築する。
+func newWordFilter(words []*model.ProhibitedWord) *wordFilter {
+	filter := &wordFilter{
+		nodes:   []*wordFilterNode{{next: map[rune]int{}}},
+		builtAt: time.Now(),
+	}
+
+	// トライ木を構築する
+	for _, word := range words {
+		if word.NormalizedWord == "" {
+			continue
+		}
+		current := 0
+		for _, r := range word.NormalizedWord {
+			next, ok := filter.nodes[current].next[r]
+			if !ok {
+				filter.nodes = append(filter.nodes, &wordFilterNode{next: map[rune]int{}})
+				next = len(filter.nodes) - 1
+				filter.nodes[current].next[r] = next
+			}
+			current = next
+		}
+		filter.nodes[current].outputs = append(filter.nodes[current].outputs, word)
+	}
+
+	// 幅優先で失敗遷移を設定する
+	queue := []int{}
+	for _, child := range filter.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for r, child := range filter.nodes[current].next {
+			fail := filter.nodes[current].fail
+			for fail > 0 {
+				if _, ok := filter.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = filter.nodes[fail].fail
+			}
+			if next, ok := filter.nodes[fail].next[r]; ok && next != child {
+				filter.nodes[child].fail = next
+			}
+			failNode := filter.nodes[filter.nodes[child].fail]
+			filter.nodes[child].outputs = append(filter.nodes[child].outputs, failNode.outputs...)
+			queue = append(queue, child)
+		}
+	}
+
+	return filter
+}
+
+// match 文字列に含まれる禁止語を全て検出する。
+func (filter *wordFilter) match(text string) []*wordMatch {
+	normalized := normalizeForFilter(text)
+
+	var matches []*wordMatch
+	current := 0
+	for i, r := range normalized {
+		for current > 0 {
+			if _, ok := filter.nodes[current].next[r.r]; ok {
+				break
+			}
+			current = filter.nodes[current].fail
+		}
+		current = filter.nodes[current].next[r.r]
+
+		for _, word := range filter.nodes[current].outputs {
+			length := len([]rune(word.NormalizedWord))
+			matches = append(matches, &wordMatch{
+				word:  word,
+				start: normalized[i-length+1].start,
+				end:   r.end,
+			})
+		}
+	}
+	return matches
+}
+
+// wordFilterCache 禁止語フィルターのキャッシュ。
+type wordFilterCache struct {
+	mutex  sync.Mutex
+	filter *wordFilter
+}
+
+// wordFilters 全APIリクエストで共有する禁止語フィルターのキャッシュ。
+var wordFilters = &wordFilterCache{}
+
+// get フィルターを取得する。未構築または期限切れの場合はfetchで取得した禁止語一覧から構築する。
+func (cache *wordFilterCache) get(fetch func() ([]*model.ProhibitedWord, error)) (*wordFilter, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if cache.filter != nil && time.Since(cache.filter.builtAt) < wordFilterTTL {
+		return cache.filter, nil
+	}
+
+	words, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	cache.filter = newWordFilter(words)
+
+	return cache.filter, nil
+}
+
+// invalidate キャッシュを破棄する。禁止語の登録・削除時に呼び出す。
+func (cache *wordFilterCache) invalidate() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.filter = nil
+}
+
+// filteredField 禁止語をチェックする項目。
+type filteredField struct {
+	// エラーメッセージに使用する項目名
+	name string
+	text *string
+}
+
+// applyWordFilter 項目に含まれる禁止語をチェックする。
+// 登録を拒否する禁止語を含む項目がある場合はProhibitedWordErrorを返す。
+// 伏せ字にする禁止語は項目の値を書き換える。
+// モデレーターの確認待ちにする禁止語を含む場合は、検出した禁止語を返す。
+func applyWordFilter(repository repository.ProhibitedWordRepository, fields ...*filteredField) (reviewWords []string, err error) {
+	filter, err := wordFilters.get(repository.FetchProhibitedWords)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedFields := []string{}
+	for _, field := range fields {
+		matches := filter.match(*field.text)
+		if len(matches) == 0 {
+			continue
+		}
+
+		runes := []rune(*field.text)
+		blocked, masked := false, false
+		for _, match := range matches {
+			switch match.word.Action {
+			case model.ProhibitedWordActionBlock:
+				blocked = true
+			case model.ProhibitedWordActionReview:
+				reviewWords = appendUnique(reviewWords, match.word.Word)
+			case model.ProhibitedWordActionMask:
+				for i := match.start; i < match.end; i++ {
+					// 空白・記号はそのまま残す
+					if !unicode.IsSpace(runes[i]) && !unicode.IsPunct(runes[i]) && !unicode.IsSymbol(runes[i]) {
+						runes[i] = wordMaskRune
+					}
+				}
+				masked = true
+			}
+		}
+		if blocked {
+			blockedFields = appendUnique(blockedFields, field.name)
+			continue
+		}
+		if masked {
+			*field.text = string(runes)
+		}
+	}
+
+	if len(blockedFields) > 0 {
+		return nil, &ProhibitedWordError{Fields: blockedFields}
+	}
+	return reviewWords, nil
+}
+
+// appendUnique 重複しない場合のみ追加する
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// holdForReview 禁止語を含むため非表示にした投稿、コメントをモデレーターの確認待ちにする。
+// システムによる通報として登録し、モデレーター向けの通報の対象一覧に表示する。
+func holdForReview(repository repository.ReportRepository, targetType string, targetID int, reviewWords []string) error {
+	report := &model.Report{
+		TargetType: targetType,
+		TargetID:   targetID,
+		ReasonCode: model.ReportReasonProhibitedWord,
+		Detail:     "禁止語：" + strings.Join(reviewWords, "、"),
+		Status:     model.ReportStatusOpen,
+	}
+	_, err := repository.SaveReport(report)
+	return err
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/k-kazuya0926/power-phrase2-api/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setProhibitedWords 禁止語フィルターのキャッシュを破棄し、リポジトリのモックに禁止語一覧を設定する
+func setProhibitedWords(t *testing.T, repository *mockProhibitedWordRepository, words ...*model.ProhibitedWord) {
+	wordFilters.invalidate()
+	t.Cleanup(wordFilters.invalidate)
+	for _, word := range words {
+		word.NormalizedWord = normalizeProhibitedWord(word.Word)
+	}
+	repository.On("FetchProhibitedWords").Return(words, nil)
+}
+
+// 禁止語検出用の正規化テスト
+func TestNormalizeProhibitedWord(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"バカ", "ばか"},
+		{"ﾊﾞｶ", "ばか"},
+		{"バ カ", "ばか"},
+		{"ば・か！", "ばか"},
+		{"ＳＰＡＭ", "spam"},
+		{"spam", "spam"},
+		{"　！？", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			// 2. Exercise
+			actual := normalizeProhibitedWord(c.input)
+
+			// 3. Verify
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+// 禁止語検出テスト
+func TestWordFilter_match(t *testing.T) {
+	// 1. Setup
+	words := []*model.ProhibitedWord{
+		{Word: "he", NormalizedWord: "he"},
+		{Word: "she", NormalizedWord: "she"},
+		{Word: "his", NormalizedWord: "his"},
+		{Word: "hers", NormalizedWord: "hers"},
+		{Word: "バカ", NormalizedWord: "ばか"},
+	}
+	filter := newWordFilter(words)
+
+	cases := []struct {
+		label    string
+		text     string
+		expected []string
+	}{
+		{"一致なし", "good morning", nil},
+		{"重なり", "ushers", []string{"she", "he", "hers"}},
+		{"全角半角", "ＵＳＨＥＲＳ", []string{"she", "he", "hers"}},
+		{"空白挿入", "ﾊﾞ カ", []string{"バカ"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			// 2. Exercise
+			matches := filter.match(c.text)
+
+			// 3. Verify
+			var actual []string
+			for _, match := range matches {
+				actual = append(actual, match.word.Word)
+			}
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// 禁止語チェックテスト
+func TestApplyWordFilter(t *testing.T) {
+	// 1. Setup
+	repository := mockProhibitedWordRepository{}
+	setProhibitedWords(t, &repository,
+		&model.ProhibitedWord{Word: "禁止", Action: model.ProhibitedWordActionBlock},
+		&model.ProhibitedWord{Word: "バカ", Action: model.ProhibitedWordActionMask},
+		&model.ProhibitedWord{Word: "要確認", Action: model.ProhibitedWordActionReview},
+	)
+
+	cases := []struct {
+		label         string
+		title         string
+		detail        string
+		expectedTitle string
+		reviewWords   []string
+		blocked       []string
+	}{
+		{"一致なし", "title", "detail", "title", nil, nil},
+		{"伏せ字", "ﾊﾞ カ!な話", "detail", "** *!な話", nil, nil},
+		{"確認待ち", "title", "要 確認", "title", []string{"要確認"}, nil},
+		{"拒否", "禁 止", "禁止です", "禁 止", nil, []string{"Title", "Detail"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			title, detail := c.title, c.detail
+
+			// 2. Exercise
+			reviewWords, err := applyWordFilter(&repository, &filteredField{"Title", &title}, &filteredField{"Detail", &detail})
+
+			// 3. Verify
+			if c.blocked != nil {
+				prohibitedErr, ok := err.(*ProhibitedWordError)
+				assert.True(t, ok)
+				assert.Equal(t, c.blocked, prohibitedErr.Fields)
+				assert.Equal(t, "Title：使用できない語句が含まれています。\nDetail：使用できない語句が含まれています。", err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedTitle, title)
+			assert.Equal(t, c.reviewWords, reviewWords)
+		})
+	}
+
+	// 4. Teardown
+}
+
+// 禁止語を含む投稿の登録テスト
+func TestCreatePost_success_review(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	reportRepository := mockReportRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &reportRepository, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository, &model.ProhibitedWord{Word: "要確認", Action: model.ProhibitedWordActionReview})
+	repository.On("Create", mock.MatchedBy(func(post *model.Post) bool {
+		return post.IsHidden
+	})).Return(nil)
+	reportRepository.On("SaveReport", mock.MatchedBy(func(report *model.Report) bool {
+		return report.ReporterID == 0 && report.TargetType == model.ReportTargetPost && report.ReasonCode == model.ReportReasonProhibitedWord && report.Detail == "禁止語：要確認"
+	})).Return(true, nil)
+
+	// 2. Exercise
+	err := usecase.CreatePost(1, "要確認の言葉", "speaker", "", "", model.PostSource{}, "", "", nil, true)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+	reportRepository.AssertExpectations(t)
+	prohibitedWordRepository.AssertExpectations(t)
+	assert.Empty(t, repository.domainEvents)
+
+	// 4. Teardown
+}
+
+// 禁止語を含む投稿の更新テスト
+func TestUpdatePost_success_review(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	reportRepository := mockReportRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &reportRepository, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository, &model.ProhibitedWord{Word: "要確認", Action: model.ProhibitedWordActionReview})
+	// 更新内容が公開されないよう、更新と同時に非表示にする
+	repository.On("Update", mock.MatchedBy(func(post *model.Post) bool {
+		return post.ID == 1 && post.IsHidden
+	})).Return(nil)
+	reportRepository.On("SaveReport", mock.MatchedBy(func(report *model.Report) bool {
+		return report.TargetType == model.ReportTargetPost && report.TargetID == 1 && report.ReasonCode == model.ReportReasonProhibitedWord
+	})).Return(true, nil)
+
+	// 2. Exercise
+	err := usecase.UpdatePost(1, "要確認の言葉", "speaker", "", "", model.PostSource{}, "", "", nil)
+
+	// 3. Verify
+	assert.NoError(t, err)
+	repository.AssertExpectations(t)
+	reportRepository.AssertExpectations(t)
+
+	// 4. Teardown
+}
+
+func TestCreatePost_error_blockedTag(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewPostUseCase(&repository, &repository, &repository, &repository, &prohibitedWordRepository, &mockReportRepository{}, &mockReactionRepository{}, &mockPostViewRepository{}, NewAutocompleteIndexCache(), nil, runSynchronously)
+	setProhibitedWords(t, &prohibitedWordRepository, &model.ProhibitedWord{Word: "禁止", Action: model.ProhibitedWordActionBlock})
+
+	// 2. Exercise
+	err := usecase.CreatePost(1, "title", "speaker", "", "", model.PostSource{}, "", "", []string{"禁止", "禁止タグ"}, true)
+
+	// 3. Verify
+	prohibitedErr, ok := err.(*ProhibitedWordError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Tags"}, prohibitedErr.Fields)
+	repository.AssertNotCalled(t, "Create", mock.Anything)
+
+	// 4. Teardown
+}
+
+func TestCreateComment_error_blocked(t *testing.T) {
+	// 1. Setup
+	repository := mockPostRepository{}
+	prohibitedWordRepository := mockProhibitedWordRepository{}
+	usecase := NewCommentUseCase(&repository, &prohibitedWordRepository, &mockReportRepository{})
+	setProhibitedWords(t, &prohibitedWordRepository, &model.ProhibitedWord{Word: "禁止", Action: model.ProhibitedWordActionBlock})
+
+	// 2. Exercise
+	err := usecase.CreateComment(1, 1, "キン止ではなく禁止")
+
+	// 3. Verify
+	prohibitedErr, ok := err.(*ProhibitedWordError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Body"}, prohibitedErr.Fields)
+	repository.AssertNotCalled(t, "CreateComment", mock.Anything)
+
+	// 4. Teardown
+}
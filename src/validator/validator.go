@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/k-kazuya0926/power-phrase2-api/ui/http/request"
 	"github.com/labstack/echo"
+	"golang.org/x/text/language"
 	"gopkg.in/go-playground/validator.v9"
 )
 
@@ -17,7 +20,12 @@ type customValidator struct {
 
 // NewValidator バリデーターを生成
 func NewValidator() echo.Validator {
-	return &customValidator{validator.New()}
+	v := validator.New()
+	v.RegisterStructValidation(request.ValidatePostSource, request.PostSourceRequest{})
+	v.RegisterValidation("timezone", validateTimeZone)
+	v.RegisterValidation("date", validateDate)
+	v.RegisterValidation("language", validateLanguage)
+	return &customValidator{v}
 }
 
 // Validate バリデーション実行
@@ -39,6 +47,18 @@ func (cv *customValidator) Validate(i interface{}) error {
 			errorMessage = fmt.Sprintf("%s：正しい形式で入力してください。", err.Field())
 		case "min":
 			errorMessage = fmt.Sprintf("%s：%s以上の値を入力してください。", err.Field(), err.Param())
+		case "excluded":
+			errorMessage = fmt.Sprintf("%s：SourceTypeを指定してください。", err.Field())
+		case "timezone":
+			errorMessage = fmt.Sprintf("%s：タイムゾーン名(例：Asia/Tokyo)を入力してください。", err.Field())
+		case "date":
+			errorMessage = fmt.Sprintf("%s：日付(例：2006-01-02)の形式で入力してください。", err.Field())
+		case "language":
+			errorMessage = fmt.Sprintf("%s：言語タグ(例：ja、en-US)を入力してください。", err.Field())
+		case "page":
+			errorMessage = fmt.Sprintf("%s：ページ番号(例：p.12、12-15)の形式で入力してください。", err.Field())
+		case "timestamp":
+			errorMessage = fmt.Sprintf("%s：再生位置(例：1:23、01:02:03)の形式で入力してください。", err.Field())
 		default:
 			errorMessage = fmt.Sprintf("%s：正しい値を入力してください。", err.Field())
 		}
@@ -47,3 +67,21 @@ func (cv *customValidator) Validate(i interface{}) error {
 	}
 	return errors.New(strings.Join(errorMessages, "\n"))
 }
+
+// validateTimeZone IANAタイムゾーン名であることをチェックする。
+func validateTimeZone(fl validator.FieldLevel) bool {
+	_, err := time.LoadLocation(fl.Field().String())
+	return err == nil
+}
+
+// validateDate 日付(例：2006-01-02)の形式であることをチェックする。
+func validateDate(fl validator.FieldLevel) bool {
+	_, err := time.Parse("2006-01-02", fl.Field().String())
+	return err == nil
+}
+
+// validateLanguage BCP 47の言語タグであることをチェックする。
+func validateLanguage(fl validator.FieldLevel) bool {
+	_, err := language.Parse(fl.Field().String())
+	return err == nil
+}